@@ -1,21 +1,11 @@
 package main
 
-import "github.com/rsmrtk/mybox/internal/app"
+import (
+	"os"
+
+	"github.com/rsmrtk/mybox/internal/cli"
+)
 
 func main() {
-	app.Run()
+	os.Exit(cli.Run(os.Args[1:]))
 }
-
-//func main() {
-//	r := gin.Default()
-//
-//	r.GET("/", func(c *gin.Context) {
-//		c.JSON(http.StatusOK, gin.H{
-//			"message": "Congratulations!",
-//		})
-//	})
-//
-//	if err := r.Run(`:9595`); err != nil {
-//		panic(err)
-//	}
-//}
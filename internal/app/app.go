@@ -1,10 +1,6 @@
 package app
 
 import (
-	"context"
-	"os/signal"
-	"syscall"
-
 	restservices "github.com/rsmrtk/mybox/internal/rest/services"
 	"github.com/rsmrtk/mybox/pkg"
 )
@@ -16,21 +12,11 @@ type App struct {
 	servicesREST *restservices.Services
 }
 
-func Run() {
-	ctx, cancelFunc := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT, syscall.SIGKILL)
-	defer cancelFunc()
-
+// New wires the services on top of an already initialised facade.
+func New(pkg *pkg.Facade) *App {
 	app := new(App)
-
-	pkg, err := pkg.New(ctx)
-	if err != nil {
-		panic(err)
-	}
-
 	app.pkg = pkg
 	//app.servicesGRPC = grpcservices.NewService(grpcservices.Options{Pkg: pkg})
 	app.servicesREST = restservices.NewService(restservices.Options{Pkg: pkg})
-
-	pkg.Log.Infof("Starting REST server...")
-	app.Listen()
+	return app
 }
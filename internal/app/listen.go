@@ -2,17 +2,23 @@ package app
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
-	lg "github.com/rsmrtk/smartlg/logger"
-
+	"github.com/rsmrtk/mybox/internal/jobs"
 	"github.com/rsmrtk/mybox/internal/rest"
 )
 
-func (app *App) Listen() {
-	ctx, cancel := signal.NotifyContext(context.Background(), os.Kill, os.Interrupt, syscall.SIGTERM)
+// shutdownTimeout bounds how long in-flight requests may take to finish.
+const shutdownTimeout = 15 * time.Second
+
+// Listen runs the REST server until ctx is cancelled or a termination signal
+// is received, then shuts it down.
+func (app *App) Listen(ctx context.Context) error {
+	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
 	defer cancel()
 
 	//serverGRPC, err := grpc.NewServer(grpc.ServerOptions{
@@ -28,7 +34,7 @@ func (app *App) Listen() {
 		Services: app.servicesREST,
 	})
 	if err != nil {
-		app.pkg.Log.Fatal("REST server error", lg.H{"error": err})
+		return fmt.Errorf("failed to create REST server: %w", err)
 	}
 
 	//go func() {
@@ -38,12 +44,15 @@ func (app *App) Listen() {
 	//		app.pkg.Log.Fatal("gRPC server error", log.H{"error": err})
 	//	}
 	//}()
+	go jobs.TrashPurge(ctx, app.pkg)
+	go jobs.IdempotencyExpire(ctx, app.pkg)
+	go jobs.Recurring(ctx, app.pkg)
+
+	serveErr := make(chan error, 1)
 	go func() {
 		defer cancel()
 		app.pkg.Log.Infof("REST server started")
-		if err := serverREST.Serve(); err != nil {
-			app.pkg.Log.Fatal("REST server error", lg.H{"error": err})
-		}
+		serveErr <- serverREST.Serve()
 	}()
 
 	<-ctx.Done() // Server is stopped.
@@ -52,8 +61,19 @@ func (app *App) Listen() {
 	//	app.pkg.Log.Fatal("gRPC server shutdown error", map[string]any{"error": err})
 	//}
 
+	select {
+	case err := <-serveErr:
+		if err != nil {
+			return fmt.Errorf("REST server error: %w", err)
+		}
+	default:
+	}
+
 	app.pkg.Log.Infof("REST server stopped")
-	if err := serverREST.Shutdown(context.Background()); err != nil {
-		app.pkg.Log.Fatal("REST server shutdown error", map[string]any{"error": err})
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancelShutdown()
+	if err := serverREST.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("REST server shutdown error: %w", err)
 	}
+	return nil
 }
@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_api_key"
+)
+
+func init() {
+	register(&command{
+		name:  "apikey",
+		usage: "apikey create|revoke|list   manage API keys",
+		run:   apiKey,
+	})
+}
+
+func apiKey(ctx context.Context, f *pkg.Facade, args []string) error {
+	if len(args) == 0 {
+		return usageErrorf("missing apikey subcommand")
+	}
+
+	switch args[0] {
+	case "create":
+		return apiKeyCreate(ctx, f, args[1:])
+	case "revoke":
+		return apiKeyRevoke(ctx, f, args[1:])
+	case "list":
+		return apiKeyList(ctx, f, args[1:])
+	default:
+		return usageErrorf("unknown apikey subcommand %q", args[0])
+	}
+}
+
+func apiKeyCreate(ctx context.Context, f *pkg.Facade, args []string) error {
+	fs := newFlagSet("apikey create")
+	name := fs.String("name", "", "human readable key name")
+	customerID := fs.String("customer", "", "customer ID the key acts as")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *name == "" {
+		return usageErrorf("-name is required")
+	}
+	if _, err := uuid.Parse(*customerID); err != nil {
+		return usageErrorf("-customer must be a UUID")
+	}
+
+	key, prefix, hash, err := m_api_key.Generate()
+	if err != nil {
+		return err
+	}
+
+	data := &m_api_key.Data{
+		KeyID:      uuid.New().String(),
+		KeyName:    *name,
+		KeyPrefix:  prefix,
+		KeyHash:    hash,
+		CustomerID: *customerID,
+		CreatedAt:  time.Now().UTC(),
+	}
+	if err := f.M.APIKey.Create(ctx, data); err != nil {
+		return err
+	}
+
+	// The plain key is never stored, so this is the only time it is shown.
+	fmt.Fprintf(os.Stderr, "created key %s; store it now, it cannot be shown again\n", data.KeyID)
+	fmt.Fprintln(os.Stdout, key)
+	return nil
+}
+
+func apiKeyRevoke(ctx context.Context, f *pkg.Facade, args []string) error {
+	fs := newFlagSet("apikey revoke")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageErrorf("apikey revoke takes exactly one key ID")
+	}
+	keyID := fs.Arg(0)
+	if _, err := uuid.Parse(keyID); err != nil {
+		return usageErrorf("key ID must be a UUID")
+	}
+
+	if err := f.M.APIKey.Revoke(ctx, keyID); err != nil {
+		if errors.Is(err, m_api_key.ErrNotFound) {
+			return fmt.Errorf("key %s does not exist", keyID)
+		}
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "revoked %s\n", keyID)
+	return nil
+}
+
+func apiKeyList(ctx context.Context, f *pkg.Facade, args []string) error {
+	fs := newFlagSet("apikey list")
+	all := fs.Bool("all", false, "include revoked keys")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	items, err := f.M.APIKey.List(ctx, *all)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KEY ID\tPREFIX\tNAME\tCUSTOMER\tCREATED\tREVOKED")
+	for _, d := range items {
+		revoked := "-"
+		if d.RevokedAt != nil {
+			revoked = d.RevokedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			d.KeyID, d.KeyPrefix, d.KeyName, d.CustomerID, d.CreatedAt.Format(time.RFC3339), revoked)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,33 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+func init() {
+	register(&command{
+		name:  "check-config",
+		usage: "check-config                validate configuration and database connectivity",
+		run:   checkConfig,
+	})
+}
+
+// checkConfig relies on pkg.New having already loaded and validated the
+// configuration in Run; what is left is to prove the database is reachable.
+func checkConfig(ctx context.Context, f *pkg.Facade, args []string) error {
+	if err := parseFlags(newFlagSet("check-config"), args); err != nil {
+		return err
+	}
+
+	if err := f.M.DB.PingContext(ctx); err != nil {
+		return fmt.Errorf("database unreachable: %w", err)
+	}
+
+	fmt.Fprintln(os.Stdout, f.Config.Redacted())
+	fmt.Fprintln(os.Stdout, "configuration OK")
+	return nil
+}
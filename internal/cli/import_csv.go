@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/imports"
+	restservices "github.com/rsmrtk/mybox/internal/rest/services"
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_import"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+func init() {
+	register(&command{
+		name:  "import-csv",
+		usage: "import-csv [flags] <file>   preview or import a bank CSV file with a mapping profile",
+		run:   importCSV,
+	})
+	register(&command{
+		name:  "import-rollback",
+		usage: "import-rollback <batch_id>  move the records of an import to the trash",
+		run:   importRollback,
+	})
+}
+
+func importCSV(ctx context.Context, f *pkg.Facade, args []string) error {
+	fs := newFlagSet("import-csv")
+	customerID := fs.String("customer", "", "customer ID to import for")
+	profile := fs.String("profile", "", "name or ID of a saved mapping profile")
+	mappingFile := fs.String("mapping", "", "JSON file with a mapping, instead of -profile")
+	accountID := fs.String("account", "", "account to link every record to")
+	dryRun := fs.Bool("dry-run", false, "only show what would be imported")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageErrorf("import-csv takes exactly one file")
+	}
+	if _, err := uuid.Parse(*customerID); err != nil {
+		return usageErrorf("-customer must be a UUID")
+	}
+	if (*profile == "") == (*mappingFile == "") {
+		return usageErrorf("exactly one of -profile and -mapping is required")
+	}
+	ctx = utils.AuthSetCtx(ctx, *customerID)
+
+	content, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	req := &imports.CSVRequest{
+		Content:   string(content),
+		FileName:  filepath.Base(fs.Arg(0)),
+		AccountID: *accountID,
+		DryRun:    *dryRun,
+	}
+
+	switch {
+	case *mappingFile != "":
+		body, err := os.ReadFile(*mappingFile)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(body, &req.Mapping); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", *mappingFile, err)
+		}
+	case uuid.Validate(*profile) == nil:
+		req.ProfileID = *profile
+	default:
+		p, err := f.M.Import.FindProfileByName(ctx, *customerID, *profile)
+		if errors.Is(err, m_import.ErrNotFound) {
+			return fmt.Errorf("profile %q does not exist", *profile)
+		}
+		if err != nil {
+			return err
+		}
+		req.ProfileID = p.ProfileID
+	}
+
+	s := restservices.NewService(restservices.Options{Pkg: f})
+	res, err := s.Import.CSV.Handle(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return printImport(res)
+}
+
+// printImport lists the rows of an import and sums them up.
+func printImport(res *imports.ImportResponse) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "LINE\tSTATUS\tTYPE\tDATE\tAMOUNT\tNAME\tERROR")
+	for _, r := range res.Rows {
+		date, amount := "-", "-"
+		if r.Date != nil {
+			date = r.Date.String()
+		}
+		if len(r.Amount) > 0 {
+			amount = fmt.Sprintf("%.2f", r.Amount[0].Amount)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			r.Line, r.Status, r.Direction, date, amount, r.Name, r.Error)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	switch {
+	case res.DryRun:
+		fmt.Fprintf(os.Stderr, "dry run: %d of %d rows would be imported\n", res.Valid, res.Total)
+	case res.Committed:
+		fmt.Fprintf(os.Stderr, "imported %d of %d rows as batch %s\n", res.Valid, res.Total, res.BatchID)
+	default:
+		fmt.Fprintf(os.Stderr, "imported none of %d rows\n", res.Total)
+	}
+	if res.Duplicate > 0 {
+		fmt.Fprintf(os.Stderr, "skipped %d rows imported before\n", res.Duplicate)
+	}
+	if failed := res.Invalid + res.Failed; failed > 0 {
+		return fmt.Errorf("%d rows failed to import", failed)
+	}
+	return nil
+}
+
+func importRollback(ctx context.Context, f *pkg.Facade, args []string) error {
+	fs := newFlagSet("import-rollback")
+	customerID := fs.String("customer", "", "customer ID the import belongs to")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageErrorf("import-rollback takes exactly one batch ID")
+	}
+	if _, err := uuid.Parse(*customerID); err != nil {
+		return usageErrorf("-customer must be a UUID")
+	}
+	ctx = utils.AuthSetCtx(ctx, *customerID)
+
+	s := restservices.NewService(restservices.Options{Pkg: f})
+	res, err := s.Import.Rollback.Handle(ctx, &imports.RollbackRequest{BatchID: fs.Arg(0)})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "rolled back %s: %d records moved to the trash, %d already deleted\n",
+		res.BatchID, res.Deleted, res.Skipped)
+	return nil
+}
@@ -0,0 +1,221 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/imports"
+	restservices "github.com/rsmrtk/mybox/internal/rest/services"
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+func init() {
+	register(&command{
+		name:  "import-ofx",
+		usage: "import-ofx [flags] <file>   preview or import an OFX/QFX statement, skipping known transactions",
+		run:   importOFX,
+	})
+	register(&command{
+		name:  "import-qif",
+		usage: "import-qif [flags] <file>   preview or import a QIF file",
+		run:   importQIF,
+	})
+	register(&command{
+		name:  "import-camt",
+		usage: "import-camt [flags] <file>  preview or import a camt.053 statement, skipping known bank references",
+		run:   importCAMT,
+	})
+	register(&command{
+		name:  "import-mt940",
+		usage: "import-mt940 [flags] <file> preview or import an MT940 statement, skipping known bank references",
+		run:   importMT940,
+	})
+}
+
+func importOFX(ctx context.Context, f *pkg.Facade, args []string) error {
+	fs := newFlagSet("import-ofx")
+	customerID := fs.String("customer", "", "customer ID to import for")
+	accountID := fs.String("account", "", "account to link every record to")
+	defaultType := fs.String("type", "", "type of every record instead of the transaction type")
+	dryRun := fs.Bool("dry-run", false, "only show what would be imported")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageErrorf("import-ofx takes exactly one file")
+	}
+	if _, err := uuid.Parse(*customerID); err != nil {
+		return usageErrorf("-customer must be a UUID")
+	}
+	ctx = utils.AuthSetCtx(ctx, *customerID)
+
+	content, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	s := restservices.NewService(restservices.Options{Pkg: f})
+	res, err := s.Import.OFX.Handle(ctx, &imports.OFXRequest{
+		Content:     string(content),
+		FileName:    filepath.Base(fs.Arg(0)),
+		AccountID:   *accountID,
+		DefaultType: *defaultType,
+		DryRun:      *dryRun,
+	})
+	if err != nil {
+		return err
+	}
+	return printImport(res)
+}
+
+func importQIF(ctx context.Context, f *pkg.Facade, args []string) error {
+	fs := newFlagSet("import-qif")
+	customerID := fs.String("customer", "", "customer ID to import for")
+	accountID := fs.String("account", "", "account to link every record to")
+	defaultType := fs.String("type", "", "type of records without a category")
+	dateFormat := fs.String("date-format", "", "date format such as DD.MM.YYYY (default month first)")
+	decimal := fs.String("decimal", "", `decimal separator, "." (default) or ","`)
+	dryRun := fs.Bool("dry-run", false, "only show what would be imported")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageErrorf("import-qif takes exactly one file")
+	}
+	if _, err := uuid.Parse(*customerID); err != nil {
+		return usageErrorf("-customer must be a UUID")
+	}
+	ctx = utils.AuthSetCtx(ctx, *customerID)
+
+	content, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	s := restservices.NewService(restservices.Options{Pkg: f})
+	res, err := s.Import.QIF.Handle(ctx, &imports.QIFRequest{
+		Content:          string(content),
+		FileName:         filepath.Base(fs.Arg(0)),
+		AccountID:        *accountID,
+		DefaultType:      *defaultType,
+		DateFormat:       *dateFormat,
+		DecimalSeparator: *decimal,
+		DryRun:           *dryRun,
+	})
+	if err != nil {
+		return err
+	}
+	return printImport(res)
+}
+
+func importCAMT(ctx context.Context, f *pkg.Facade, args []string) error {
+	fs := newFlagSet("import-camt")
+	customerID := fs.String("customer", "", "customer ID to import for")
+	accountID := fs.String("account", "", "account to link records to")
+	accounts := fs.String("accounts", "", "accounts per bank account, as IBAN=ID,IBAN=ID")
+	defaultType := fs.String("type", "", "type of every record instead of credit or debit")
+	dryRun := fs.Bool("dry-run", false, "only show what would be imported")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageErrorf("import-camt takes exactly one file")
+	}
+	if _, err := uuid.Parse(*customerID); err != nil {
+		return usageErrorf("-customer must be a UUID")
+	}
+	byBank, err := parseAccounts(*accounts)
+	if err != nil {
+		return err
+	}
+	ctx = utils.AuthSetCtx(ctx, *customerID)
+
+	content, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	s := restservices.NewService(restservices.Options{Pkg: f})
+	res, err := s.Import.CAMT.Handle(ctx, &imports.CAMTRequest{
+		Content:     string(content),
+		FileName:    filepath.Base(fs.Arg(0)),
+		AccountID:   *accountID,
+		Accounts:    byBank,
+		DefaultType: *defaultType,
+		DryRun:      *dryRun,
+	})
+	if err != nil {
+		return err
+	}
+	return printImport(res)
+}
+
+func importMT940(ctx context.Context, f *pkg.Facade, args []string) error {
+	fs := newFlagSet("import-mt940")
+	customerID := fs.String("customer", "", "customer ID to import for")
+	accountID := fs.String("account", "", "account to link records to")
+	accounts := fs.String("accounts", "", "accounts per bank account, as IBAN=ID,IBAN=ID")
+	defaultType := fs.String("type", "", "type of every record instead of credit or debit")
+	dryRun := fs.Bool("dry-run", false, "only show what would be imported")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageErrorf("import-mt940 takes exactly one file")
+	}
+	if _, err := uuid.Parse(*customerID); err != nil {
+		return usageErrorf("-customer must be a UUID")
+	}
+	byBank, err := parseAccounts(*accounts)
+	if err != nil {
+		return err
+	}
+	ctx = utils.AuthSetCtx(ctx, *customerID)
+
+	content, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	s := restservices.NewService(restservices.Options{Pkg: f})
+	res, err := s.Import.MT940.Handle(ctx, &imports.MT940Request{
+		Content:     string(content),
+		FileName:    filepath.Base(fs.Arg(0)),
+		AccountID:   *accountID,
+		Accounts:    byBank,
+		DefaultType: *defaultType,
+		DryRun:      *dryRun,
+	})
+	if err != nil {
+		return err
+	}
+	return printImport(res)
+}
+
+// parseAccounts reads the -accounts flag, a list of bank account=account ID
+// pairs.
+func parseAccounts(s string) (map[string]string, error) {
+	return parsePairs("accounts", "IBAN=ID", s)
+}
+
+// parsePairs reads a flag holding a list of key=value pairs; form shows
+// them in the usage error.
+func parsePairs(flag, form, s string) (map[string]string, error) {
+	if s == "" {
+		return nil, nil
+	}
+	pairs := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !ok || key == "" || value == "" {
+			return nil, usageErrorf("-%s takes %s pairs, not %q", flag, form, pair)
+		}
+		pairs[key] = value
+	}
+	return pairs, nil
+}
@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/imports"
+	"github.com/rsmrtk/mybox/internal/rest/domain/transaction"
+	restservices "github.com/rsmrtk/mybox/internal/rest/services"
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+func init() {
+	register(&command{
+		name:  "export-journal",
+		usage: "export-journal [flags] <file> write incomes and expenses as a Beancount or Ledger journal (- for stdout)",
+		run:   exportJournal,
+	})
+	register(&command{
+		name:  "import-journal",
+		usage: "import-journal [flags] <file> preview or import a Beancount or Ledger journal",
+		run:   importJournal,
+	})
+}
+
+func exportJournal(ctx context.Context, f *pkg.Facade, args []string) error {
+	fs := newFlagSet("export-journal")
+	customerID := fs.String("customer", "", "customer ID whose accounts and tags to use")
+	format := fs.String("format", transaction.FormatBeancount, "beancount or ledger")
+	mappingFile := fs.String("mapping", "", "JSON file naming the journal accounts, as the mapping of POST /transactions/export")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageErrorf("export-journal takes exactly one file")
+	}
+	if *format != transaction.FormatBeancount && *format != transaction.FormatLedger {
+		return usageErrorf("-format must be beancount or ledger")
+	}
+	if *customerID != "" {
+		if _, err := uuid.Parse(*customerID); err != nil {
+			return usageErrorf("-customer must be a UUID")
+		}
+		ctx = utils.AuthSetCtx(ctx, *customerID)
+	}
+
+	req := &transaction.ExportRequest{Format: *format}
+	if *mappingFile != "" {
+		content, err := os.ReadFile(*mappingFile)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(content, &req.Mapping); err != nil {
+			return fmt.Errorf("failed to read %s: %w", *mappingFile, err)
+		}
+	}
+
+	s := restservices.NewService(restservices.Options{Pkg: f})
+	res, err := s.Transaction.Export.Handle(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	out := os.Stdout
+	if name := fs.Arg(0); name != "-" {
+		file, err := os.Create(name)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+	if err := res.Write(out); err != nil {
+		return err
+	}
+	if out != os.Stdout {
+		return out.Close()
+	}
+	return nil
+}
+
+func importJournal(ctx context.Context, f *pkg.Facade, args []string) error {
+	fs := newFlagSet("import-journal")
+	customerID := fs.String("customer", "", "customer ID to import for")
+	accountID := fs.String("account", "", "account of records paid into or from unmapped journal accounts")
+	accounts := fs.String("accounts", "", "accounts per journal account, as Assets:Bank=ID,Liabilities:Visa=ID")
+	types := fs.String("types", "", "types per journal account, as Expenses:Food=groceries,...")
+	categories := fs.String("categories", "", "categories per journal account, as Expenses:Food=ID,...")
+	defaultType := fs.String("type", "", "type of records of unmapped journal accounts")
+	dryRun := fs.Bool("dry-run", false, "only show what would be imported")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageErrorf("import-journal takes exactly one file")
+	}
+	if _, err := uuid.Parse(*customerID); err != nil {
+		return usageErrorf("-customer must be a UUID")
+	}
+	req := &imports.JournalRequest{
+		AccountID:   *accountID,
+		DefaultType: *defaultType,
+		DryRun:      *dryRun,
+	}
+	var err error
+	if req.Accounts, err = parsePairs("accounts", "account=ID", *accounts); err != nil {
+		return err
+	}
+	if req.Types, err = parsePairs("types", "account=type", *types); err != nil {
+		return err
+	}
+	if req.Categories, err = parsePairs("categories", "account=ID", *categories); err != nil {
+		return err
+	}
+	ctx = utils.AuthSetCtx(ctx, *customerID)
+
+	content, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	req.Content, req.FileName = string(content), filepath.Base(fs.Arg(0))
+
+	s := restservices.NewService(restservices.Options{Pkg: f})
+	res, err := s.Import.Journal.Handle(ctx, req)
+	if err != nil {
+		return err
+	}
+	return printImport(res)
+}
@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/migrations"
+)
+
+func init() {
+	register(&command{
+		name:  "migrate",
+		usage: "migrate [-dry-run]          apply pending database migrations",
+		run:   migrate,
+	})
+}
+
+func migrate(ctx context.Context, f *pkg.Facade, args []string) error {
+	fs := newFlagSet("migrate")
+	dryRun := fs.Bool("dry-run", false, "only list pending migrations")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if *dryRun {
+		pending, err := migrations.Pending(ctx, f.M.DB)
+		if err != nil {
+			return err
+		}
+		for _, m := range pending {
+			fmt.Fprintln(os.Stdout, m.Version)
+		}
+		return nil
+	}
+
+	applied, err := migrations.Up(ctx, f.M.DB)
+	for _, v := range applied {
+		fmt.Fprintf(os.Stdout, "applied %s\n", v)
+	}
+	if err != nil {
+		return err
+	}
+	if len(applied) == 0 {
+		fmt.Fprintln(os.Stdout, "database is up to date")
+	}
+	return nil
+}
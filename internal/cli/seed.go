@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"os"
+	"time"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/expense"
+	"github.com/rsmrtk/mybox/internal/rest/domain/income"
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+	restservices "github.com/rsmrtk/mybox/internal/rest/services"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+func init() {
+	register(&command{
+		name:  "seed",
+		usage: "seed [-months N] [-seed S]  generate demo incomes and expenses",
+		run:   seed,
+	})
+}
+
+// seedExpense describes a kind of spending the demo household has.
+type seedExpense struct {
+	name     string
+	typ      string
+	perMonth int // how many times it happens in a month
+	min, max float64
+}
+
+var seedExpenses = []seedExpense{
+	{"Rent", "Housing", 1, 1200, 1200},
+	{"Electricity bill", "Utilities", 1, 45, 120},
+	{"Internet", "Utilities", 1, 59.99, 59.99},
+	{"Mobile plan", "Utilities", 1, 25, 25},
+	{"Groceries", "Food", 4, 60, 180},
+	{"Restaurant", "Food", 2, 25, 90},
+	{"Coffee", "Food", 6, 3.5, 6},
+	{"Fuel", "Transportation", 2, 40, 75},
+	{"Public transport", "Transportation", 1, 30, 30},
+	{"Streaming subscription", "Entertainment", 1, 15.99, 15.99},
+	{"Cinema", "Entertainment", 1, 12, 30},
+	{"Pharmacy", "Health", 1, 8, 45},
+	{"Clothing", "Shopping", 1, 20, 150},
+}
+
+func seed(ctx context.Context, f *pkg.Facade, args []string) error {
+	fs := newFlagSet("seed")
+	months := fs.Int("months", 6, "number of months to generate, ending with the current one")
+	seedValue := fs.Uint64("seed", uint64(time.Now().UnixNano()), "random seed, for reproducible data")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *months <= 0 {
+		return usageErrorf("-months must be positive")
+	}
+
+	rnd := rand.New(rand.NewPCG(*seedValue, *seedValue))
+	s := restservices.NewService(restservices.Options{Pkg: f})
+
+	now := time.Now().UTC()
+	first := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -(*months - 1), 0)
+
+	var incomes, expenses int
+	for m := 0; m < *months; m++ {
+		start := first.AddDate(0, m, 0)
+		days := start.AddDate(0, 1, -1).Day()
+
+		salary := &income.CreateRequest{
+			IncomeName:   "Salary",
+			IncomeAmount: seedAmount(4800 + rnd.Float64()*400),
+			IncomeType:   "Employment",
+			IncomeDate:   models.NewDate(start.AddDate(0, 0, 24)),
+		}
+		if _, err := s.Income.Create.Handle(ctx, salary); err != nil {
+			return fmt.Errorf("failed to create income: %w", err)
+		}
+		incomes++
+
+		if rnd.IntN(3) == 0 {
+			freelance := &income.CreateRequest{
+				IncomeName:   "Freelance project",
+				IncomeAmount: seedAmount(300 + rnd.Float64()*1200),
+				IncomeType:   "Freelance",
+				IncomeDate:   models.NewDate(start.AddDate(0, 0, rnd.IntN(days))),
+			}
+			if _, err := s.Income.Create.Handle(ctx, freelance); err != nil {
+				return fmt.Errorf("failed to create income: %w", err)
+			}
+			incomes++
+		}
+
+		for _, e := range seedExpenses {
+			for i := 0; i < e.perMonth; i++ {
+				day := 0
+				if e.perMonth > 1 || e.min != e.max {
+					day = rnd.IntN(days)
+				}
+				req := &expense.CreateRequest{
+					ExpenseName:   e.name,
+					ExpenseAmount: seedAmount(e.min + rnd.Float64()*(e.max-e.min)),
+					ExpenseType:   e.typ,
+					ExpenseDate:   models.NewDate(start.AddDate(0, 0, day)),
+				}
+				if _, err := s.Expense.Create.Handle(ctx, req); err != nil {
+					return fmt.Errorf("failed to create expense: %w", err)
+				}
+				expenses++
+			}
+		}
+	}
+
+	fmt.Fprintf(os.Stdout, "seeded %d incomes and %d expenses over %d months\n", incomes, expenses, *months)
+	return nil
+}
+
+// seedAmount rounds v to cents and wraps it the way the create requests expect.
+func seedAmount(v float64) []*models.Amount {
+	return []*models.Amount{{
+		Amount:         math.Round(v*100) / 100,
+		CurrencyCode:   "USD",
+		CurrencySymbol: "$",
+	}}
+}
@@ -0,0 +1,26 @@
+package cli
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/app"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+func init() {
+	register(&command{
+		name:  "serve",
+		usage: "serve                       start the REST server (default)",
+		run:   serve,
+	})
+}
+
+func serve(ctx context.Context, f *pkg.Facade, args []string) error {
+	if err := parseFlags(newFlagSet("serve"), args); err != nil {
+		return err
+	}
+
+	f.Log.Infof("Effective config: %s", f.Config.Redacted())
+	f.Log.Infof("Starting REST server...")
+	return app.New(f).Listen(ctx)
+}
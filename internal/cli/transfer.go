@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/expense"
+	"github.com/rsmrtk/mybox/internal/rest/domain/income"
+	restservices "github.com/rsmrtk/mybox/internal/rest/services"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+func init() {
+	register(&command{
+		name:  "import",
+		usage: "import <file>               create incomes and expenses from a JSON export",
+		run:   importFile,
+	})
+	register(&command{
+		name:  "export",
+		usage: "export <file>               write all incomes and expenses as JSON (- for stdout)",
+		run:   exportFile,
+	})
+}
+
+// dump is the file format shared by import and export. Records use the
+// create request shape so an export can be imported as is; category and
+// account IDs are kept, so they must exist where the file is imported.
+type dump struct {
+	Incomes  []*income.CreateRequest  `json:"incomes"`
+	Expenses []*expense.CreateRequest `json:"expenses"`
+}
+
+func importFile(ctx context.Context, f *pkg.Facade, args []string) error {
+	fs := newFlagSet("import")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageErrorf("import takes exactly one file")
+	}
+
+	body, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	var d dump
+	if err := json.Unmarshal(body, &d); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", fs.Arg(0), err)
+	}
+
+	s := restservices.NewService(restservices.Options{Pkg: f})
+
+	var failed int
+	for i, req := range d.Incomes {
+		if _, err := s.Income.Create.Handle(ctx, req); err != nil {
+			fmt.Fprintf(os.Stderr, "income #%d (%s): %v\n", i+1, req.IncomeName, err)
+			failed++
+		}
+	}
+	for i, req := range d.Expenses {
+		if _, err := s.Expense.Create.Handle(ctx, req); err != nil {
+			fmt.Fprintf(os.Stderr, "expense #%d (%s): %v\n", i+1, req.ExpenseName, err)
+			failed++
+		}
+	}
+
+	total := len(d.Incomes) + len(d.Expenses)
+	fmt.Fprintf(os.Stdout, "imported %d of %d records\n", total-failed, total)
+	if failed > 0 {
+		return fmt.Errorf("%d records failed to import", failed)
+	}
+	return nil
+}
+
+func exportFile(ctx context.Context, f *pkg.Facade, args []string) error {
+	fs := newFlagSet("export")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageErrorf("export takes exactly one file")
+	}
+
+	s := restservices.NewService(restservices.Options{Pkg: f})
+
+	incomes, err := s.Income.List.Handle(ctx, &income.ListRequest{Limit: math.MaxInt32})
+	if err != nil {
+		return err
+	}
+	expenses, err := s.Expense.List.Handle(ctx, &expense.ListRequest{Limit: math.MaxInt32})
+	if err != nil {
+		return err
+	}
+
+	d := dump{
+		Incomes:  make([]*income.CreateRequest, 0, len(incomes.Items)),
+		Expenses: make([]*expense.CreateRequest, 0, len(expenses.Items)),
+	}
+	for _, it := range incomes.Items {
+		d.Incomes = append(d.Incomes, exportIncome(it))
+	}
+	for _, it := range expenses.Items {
+		d.Expenses = append(d.Expenses, exportExpense(it))
+	}
+
+	out := os.Stdout
+	if name := fs.Arg(0); name != "-" {
+		file, err := os.Create(name)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(d); err != nil {
+		return err
+	}
+	if out != os.Stdout {
+		if err := out.Close(); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "exported %d incomes and %d expenses\n", len(d.Incomes), len(d.Expenses))
+	}
+	return nil
+}
+
+// exportIncome returns the request that creates the income again.
+func exportIncome(it *income.ListItem) *income.CreateRequest {
+	return &income.CreateRequest{
+		IncomeName:   it.IncomeName,
+		IncomeAmount: it.IncomeAmount,
+		IncomeType:   it.IncomeType,
+		IncomeDate:   it.IncomeDate,
+		CategoryID:   it.CategoryID,
+		AccountID:    it.AccountID,
+		Tags:         it.Tags,
+	}
+}
+
+// exportExpense returns the request that creates the expense again, split
+// lines included.
+func exportExpense(it *expense.ListItem) *expense.CreateRequest {
+	return &expense.CreateRequest{
+		ExpenseName:   it.ExpenseName,
+		ExpenseAmount: it.ExpenseAmount,
+		ExpenseType:   it.ExpenseType,
+		ExpenseDate:   it.ExpenseDate,
+		CategoryID:    it.CategoryID,
+		AccountID:     it.AccountID,
+		Tags:          it.Tags,
+		Splits:        it.Splits,
+	}
+}
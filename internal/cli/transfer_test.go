@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/expense"
+	"github.com/rsmrtk/mybox/internal/rest/domain/income"
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+)
+
+func TestExportRoundTrip(t *testing.T) {
+	date := models.NewDate(time.Date(2024, time.January, 5, 0, 0, 0, 0, time.UTC))
+	eur := func(amount float64) []*models.Amount {
+		return []*models.Amount{{Amount: amount, CurrencyCode: "EUR", CurrencySymbol: "€"}}
+	}
+
+	in := &income.ListItem{
+		IncomeID:     "income-1",
+		IncomeName:   "Salary",
+		IncomeAmount: eur(1500),
+		IncomeType:   "salary",
+		IncomeDate:   date,
+		CreatedAt:    date,
+		CategoryID:   "category-1",
+		AccountID:    "account-1",
+		Tags:         []string{"work"},
+		Version:      3,
+	}
+	ex := &expense.ListItem{
+		ExpenseID:     "expense-1",
+		ExpenseName:   "Groceries",
+		ExpenseAmount: eur(45.1),
+		ExpenseType:   "food",
+		ExpenseDate:   date,
+		CreatedAt:     date,
+		CategoryID:    "category-2",
+		AccountID:     "account-1",
+		Tags:          []string{"home", "weekly"},
+		Splits: []*expense.Split{
+			{Amount: eur(20), CategoryID: "category-3", Note: "household"},
+			{Amount: eur(25.1)},
+		},
+		Version: 2,
+	}
+
+	body, err := json.Marshal(dump{
+		Incomes:  []*income.CreateRequest{exportIncome(in)},
+		Expenses: []*expense.CreateRequest{exportExpense(ex)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got dump
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	wantIncome := &income.CreateRequest{
+		IncomeName:   in.IncomeName,
+		IncomeAmount: in.IncomeAmount,
+		IncomeType:   in.IncomeType,
+		IncomeDate:   in.IncomeDate,
+		CategoryID:   in.CategoryID,
+		AccountID:    in.AccountID,
+		Tags:         in.Tags,
+	}
+	wantExpense := &expense.CreateRequest{
+		ExpenseName:   ex.ExpenseName,
+		ExpenseAmount: ex.ExpenseAmount,
+		ExpenseType:   ex.ExpenseType,
+		ExpenseDate:   ex.ExpenseDate,
+		CategoryID:    ex.CategoryID,
+		AccountID:     ex.AccountID,
+		Tags:          ex.Tags,
+		Splits:        ex.Splits,
+	}
+	if len(got.Incomes) != 1 || !reflect.DeepEqual(got.Incomes[0], wantIncome) {
+		t.Errorf("incomes = %s, want %+v", body, wantIncome)
+	}
+	if len(got.Expenses) != 1 || !reflect.DeepEqual(got.Expenses[0], wantExpense) {
+		t.Errorf("expenses = %s, want %+v", body, wantExpense)
+	}
+}
@@ -0,0 +1,35 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/rsmrtk/mybox/pkg"
+	lg "github.com/rsmrtk/smartlg/logger"
+)
+
+// idempotencyExpireInterval is how often expired idempotency keys are removed.
+// Expired keys are already ignored when reserving, so this only bounds the
+// size of the table.
+const idempotencyExpireInterval = time.Hour
+
+// IdempotencyExpire deletes expired idempotency keys until ctx is cancelled.
+func IdempotencyExpire(ctx context.Context, f *pkg.Facade) {
+	ticker := time.NewTicker(idempotencyExpireInterval)
+	defer ticker.Stop()
+
+	for {
+		n, err := f.M.Idempotency.DeleteExpired(ctx, time.Now())
+		if err != nil {
+			f.Log.Error("failed to delete expired idempotency keys", lg.H{"error": err.Error()})
+		} else if n > 0 {
+			f.Log.Infof("Deleted %d expired idempotency key(s)", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/expense"
+	"github.com/rsmrtk/mybox/internal/rest/domain/income"
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+	expensecreate "github.com/rsmrtk/mybox/internal/rest/services/expense/create"
+	incomecreate "github.com/rsmrtk/mybox/internal/rest/services/income/create"
+	"github.com/rsmrtk/mybox/internal/rest/services/recurring/form"
+	"github.com/rsmrtk/mybox/internal/rest/services/recurring/rrule"
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/dbtx"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_recurring"
+	"github.com/rsmrtk/mybox/pkg/utils"
+	lg "github.com/rsmrtk/smartlg/logger"
+)
+
+const (
+	// recurringBatch is the number of templates locked per transaction.
+	recurringBatch = 50
+	// recurringCatchUp bounds the occurrences of one template materialized per
+	// run, so that a template started long ago catches up over several runs.
+	recurringCatchUp = 100
+	// recurringRetry is how long a template that failed is left alone.
+	recurringRetry = time.Hour
+)
+
+// Recurring materializes the due occurrences of recurring templates. It runs
+// once at start and then every Config.Recurring.Interval until ctx is
+// cancelled.
+func Recurring(ctx context.Context, f *pkg.Facade) {
+	ticker := time.NewTicker(f.Config.Recurring.Interval)
+	defer ticker.Stop()
+
+	for {
+		MaterializeRecurring(ctx, f, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// MaterializeRecurring creates the income or expense of every occurrence up
+// to today that has been neither materialized nor skipped. Records are made
+// through the create services, so they are validated, tagged and audited like
+// any other. Each template is locked while it is handled and its occurrence
+// rows are written in the same transaction as the records, so an occurrence
+// is materialized once even with several servers running this job. A
+// template that fails keeps its error and is retried after recurringRetry.
+func MaterializeRecurring(ctx context.Context, f *pkg.Facade, now time.Time) {
+	today := form.Day(now)
+	m := &materializer{
+		f:       f,
+		income:  incomecreate.New(f),
+		expense: expensecreate.New(f),
+	}
+
+	for {
+		var due int
+		err := dbtx.InTx(ctx, f.M.DB, func(ctx context.Context) error {
+			templates, err := f.M.Recurring.Due(ctx, today, now, recurringBatch)
+			if err != nil {
+				return err
+			}
+			due = len(templates)
+			for _, d := range templates {
+				// A failing template only rolls back its own occurrences
+				err := dbtx.Savepoint(ctx, "recurring", func(ctx context.Context) error {
+					return m.materialize(ctx, d, today)
+				})
+				if err != nil {
+					f.Log.Error("failed to materialize recurring template", lg.H{"error": err.Error(), "recurring_id": d.RecurringID})
+					if err := f.M.Recurring.Fail(ctx, d.RecurringID, err.Error(), now.Add(recurringRetry)); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			f.Log.Error("failed to materialize recurring templates", lg.H{"error": err.Error()})
+			return
+		}
+		if m.created > 0 {
+			f.Log.Infof("Materialized %d recurring occurrence(s)", m.created)
+			m.created = 0
+		}
+		// Failed templates are not due again until their retry, so the next
+		// batch holds other templates
+		if due < recurringBatch {
+			return
+		}
+	}
+}
+
+type materializer struct {
+	f       *pkg.Facade
+	income  *incomecreate.Facade
+	expense *expensecreate.Facade
+	created int
+}
+
+// materialize handles the occurrences of d from its next_on up to today and
+// moves next_on past them.
+func (m *materializer) materialize(ctx context.Context, d *m_recurring.Data, today time.Time) error {
+	rule, err := rrule.Parse(d.Rule)
+	if err != nil {
+		return err
+	}
+	handled, err := m.f.M.Recurring.Occurrences(ctx, d.RecurringID, *d.NextOn)
+	if err != nil {
+		return err
+	}
+
+	// The create services act for the template's workspace
+	ctx = utils.AuthSetCtx(ctx, d.WorkspaceID)
+
+	dates := rule.Between(d.StartsOn, *d.NextOn, today, recurringCatchUp)
+	created := 0
+	for _, day := range dates {
+		if _, ok := handled[day.Format(time.DateOnly)]; ok {
+			continue
+		}
+		recordID, err := m.create(ctx, d, day)
+		if err != nil {
+			return fmt.Errorf("occurrence %s: %w", day.Format(time.DateOnly), err)
+		}
+		err = m.f.M.Recurring.AddOccurrence(ctx, &m_recurring.Occurrence{
+			RecurringID: d.RecurringID,
+			OccursOn:    day,
+			Status:      m_recurring.StatusMaterialized,
+			RecordID:    &recordID,
+			CreatedAt:   time.Now().UTC(),
+		})
+		if errors.Is(err, m_recurring.ErrOccurrenceHandled) {
+			// Cannot happen while the template is locked; undo the record anyway
+			return fmt.Errorf("occurrence %s: %w", day.Format(time.DateOnly), err)
+		}
+		if err != nil {
+			return err
+		}
+		created++
+	}
+
+	from := today.AddDate(0, 0, 1)
+	if len(dates) == recurringCatchUp {
+		from = dates[len(dates)-1].AddDate(0, 0, 1)
+	}
+	if err := form.Schedule(d, from); err != nil {
+		return err
+	}
+	if err := m.f.M.Recurring.Advance(ctx, d.RecurringID, d.NextOn); err != nil {
+		return err
+	}
+	m.created += created
+	return nil
+}
+
+// create makes the record of one occurrence and returns its ID.
+func (m *materializer) create(ctx context.Context, d *m_recurring.Data, day time.Time) (string, error) {
+	typ := deref(d.Type)
+	categoryID := deref(d.CategoryID)
+	accountID := deref(d.AccountID)
+
+	switch d.Direction {
+	case m_recurring.DirectionIncome:
+		res, err := m.income.Handle(ctx, &income.CreateRequest{
+			IncomeName:   d.Name,
+			IncomeAmount: amounts(d.Amount),
+			IncomeType:   typ,
+			IncomeDate:   models.NewDate(day),
+			CategoryID:   categoryID,
+			AccountID:    accountID,
+			Tags:         d.Tags,
+		})
+		if err != nil {
+			return "", err
+		}
+		return res.IncomeID, nil
+
+	case m_recurring.DirectionExpense:
+		res, err := m.expense.Handle(ctx, &expense.CreateRequest{
+			ExpenseName:   d.Name,
+			ExpenseAmount: amounts(d.Amount),
+			ExpenseType:   typ,
+			ExpenseDate:   models.NewDate(day),
+			CategoryID:    categoryID,
+			AccountID:     accountID,
+			Tags:          d.Tags,
+		})
+		if err != nil {
+			return "", err
+		}
+		return res.ExpenseID, nil
+	}
+	return "", fmt.Errorf("unknown direction %q", d.Direction)
+}
+
+// amounts passes the template amount as is; the create services only read
+// the first amount and do not look at its currency.
+func amounts(amount float64) []*models.Amount {
+	return []*models.Amount{{Amount: amount}}
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,63 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/rsmrtk/mybox/internal/rest/services/audit"
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/dbtx"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_audit"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_trash"
+	lg "github.com/rsmrtk/smartlg/logger"
+)
+
+// TrashPurge permanently deletes incomes and expenses that have been in the
+// trash for longer than Config.Trash.Retention. It runs once at start and
+// then every Config.Trash.PurgeInterval until ctx is cancelled.
+func TrashPurge(ctx context.Context, f *pkg.Facade) {
+	ticker := time.NewTicker(f.Config.Trash.PurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		PurgeTrash(ctx, f, time.Now().Add(-f.Config.Trash.Retention))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PurgeTrash deletes everything trashed before cutoff and records a purge
+// audit entry per removed row. Rows whose entry cannot be written stay in
+// the trash until the next run.
+func PurgeTrash(ctx context.Context, f *pkg.Facade, cutoff time.Time) {
+	kinds := map[m_trash.Kind]string{
+		m_trash.Income:  audit.EntityIncome,
+		m_trash.Expense: audit.EntityExpense,
+	}
+	for kind, entityType := range kinds {
+		var ids []string
+		err := dbtx.InTx(ctx, f.M.DB, func(ctx context.Context) error {
+			var err error
+			if ids, err = f.M.Trash.Purge(ctx, kind, cutoff); err != nil {
+				return err
+			}
+			for _, id := range ids {
+				if err := audit.Record(ctx, f, entityType, id, m_audit.ActionPurge, nil, nil); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			f.Log.Error("failed to purge trash", lg.H{"error": err.Error(), "entity_type": entityType})
+			continue
+		}
+		if len(ids) > 0 {
+			f.Log.Infof("Purged %d %s record(s) from trash", len(ids), entityType)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	er "github.com/rsmrtk/fd-er"
+	da "github.com/rsmrtk/mybox/internal/rest/domain/account"
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+	"github.com/rsmrtk/mybox/internal/rest/services/account"
+)
+
+// AccountController handles account HTTP requests
+type AccountController struct {
+	service *account.Service
+}
+
+// NewAccountController creates a new account controller
+func NewAccountController(service *account.Service) *AccountController {
+	return &AccountController{service: service}
+}
+
+// List handles GET request for listing the accounts of the workspace
+func (c *AccountController) List(ctx *gin.Context) {
+	res, err := c.service.List.Handle(ctx, &da.ListRequest{})
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Get handles GET request for fetching an account
+func (c *AccountController) Get(ctx *gin.Context) {
+	var req da.GetRequest
+	if !bindAccount(ctx, &req) {
+		return
+	}
+
+	res, err := c.service.Get.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Create handles POST request for creating an account
+func (c *AccountController) Create(ctx *gin.Context) {
+	var req da.CreateRequest
+	if !bindAccount(ctx, &req) {
+		return
+	}
+
+	res, err := c.service.Create.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, res)
+}
+
+// Update handles PUT request for updating an account
+func (c *AccountController) Update(ctx *gin.Context) {
+	var req da.UpdateRequest
+	if !bindAccount(ctx, &req) {
+		return
+	}
+
+	res, err := c.service.Update.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Delete handles DELETE request for deleting an unused account
+func (c *AccountController) Delete(ctx *gin.Context) {
+	var req da.DeleteRequest
+	if !bindAccount(ctx, &req) {
+		return
+	}
+
+	res, err := c.service.Delete.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Balance handles GET request for the balance and balance history of an account
+func (c *AccountController) Balance(ctx *gin.Context) {
+	req := da.BalanceRequest{
+		AccountID: ctx.Query("account_id"),
+		Interval:  ctx.Query("interval"),
+	}
+
+	if !queryDates(ctx, map[string]**models.Date{"from": &req.From, "to": &req.To}) {
+		return
+	}
+
+	res, err := c.service.Balance.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+func bindAccount(ctx *gin.Context, req any) bool {
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		err = er.NewHTTPError(http.StatusBadRequest).SetInternal(fmt.Errorf("failed to bind request: %w", err))
+		_ = ctx.Error(err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	er "github.com/rsmrtk/fd-er"
+	da "github.com/rsmrtk/mybox/internal/rest/domain/audit"
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+	"github.com/rsmrtk/mybox/internal/rest/services/audit"
+)
+
+// AuditController handles audit log HTTP requests
+type AuditController struct {
+	service *audit.Service
+}
+
+// NewAuditController creates a new audit log controller
+func NewAuditController(service *audit.Service) *AuditController {
+	return &AuditController{service: service}
+}
+
+// List handles GET request for querying the audit log
+func (c *AuditController) List(ctx *gin.Context) {
+	req := da.ListRequest{
+		EntityType: ctx.Query("entity_type"),
+		EntityID:   ctx.Query("entity_id"),
+		ActorID:    ctx.Query("actor_id"),
+	}
+
+	// Parse query parameters
+	if limit := ctx.Query("limit"); limit != "" {
+		fmt.Sscanf(limit, "%d", &req.Limit)
+	}
+	if offset := ctx.Query("offset"); offset != "" {
+		fmt.Sscanf(offset, "%d", &req.Offset)
+	}
+	for param, dst := range map[string]**models.Date{"from": &req.From, "to": &req.To} {
+		v := ctx.Query(param)
+		if v == "" {
+			continue
+		}
+		d, err := models.ParseDate(v)
+		if err != nil {
+			err := er.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid %s date, expected YYYY-MM-DD.", param))
+			_ = ctx.Error(err)
+			return
+		}
+		*dst = &d
+	}
+
+	res, err := c.service.List.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,19 @@
+package controllers
+
+import (
+	"net/http"
+
+	bt "github.com/rsmrtk/mybox/internal/rest/domain/batch"
+)
+
+// batchStatus is 200 when every operation succeeded, 207 when a partial
+// batch had failures and 422 when an atomic batch was rolled back.
+func batchStatus(res *bt.Response) int {
+	switch {
+	case !res.Committed:
+		return http.StatusUnprocessableEntity
+	case res.Failed > 0:
+		return http.StatusMultiStatus
+	}
+	return http.StatusOK
+}
@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	er "github.com/rsmrtk/fd-er"
+	db "github.com/rsmrtk/mybox/internal/rest/domain/budget"
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+	"github.com/rsmrtk/mybox/internal/rest/services/budget"
+)
+
+// BudgetController handles budget HTTP requests
+type BudgetController struct {
+	service *budget.Service
+}
+
+// NewBudgetController creates a new budget controller
+func NewBudgetController(service *budget.Service) *BudgetController {
+	return &BudgetController{service: service}
+}
+
+// List handles GET request for listing budgets
+func (c *BudgetController) List(ctx *gin.Context) {
+	res, err := c.service.List.Handle(ctx, &db.ListRequest{})
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Get handles GET request for fetching a budget
+func (c *BudgetController) Get(ctx *gin.Context) {
+	var req db.GetRequest
+	if !bindBudget(ctx, &req) {
+		return
+	}
+
+	res, err := c.service.Get.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Create handles POST request for creating a budget
+func (c *BudgetController) Create(ctx *gin.Context) {
+	var req db.CreateRequest
+	if !bindBudget(ctx, &req) {
+		return
+	}
+
+	res, err := c.service.Create.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, res)
+}
+
+// Update handles PUT request for updating a budget
+func (c *BudgetController) Update(ctx *gin.Context) {
+	var req db.UpdateRequest
+	if !bindBudget(ctx, &req) {
+		return
+	}
+
+	res, err := c.service.Update.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Delete handles DELETE request for deleting a budget
+func (c *BudgetController) Delete(ctx *gin.Context) {
+	var req db.DeleteRequest
+	if !bindBudget(ctx, &req) {
+		return
+	}
+
+	res, err := c.service.Delete.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Status handles GET request for comparing budgets with actual spending
+func (c *BudgetController) Status(ctx *gin.Context) {
+	req := db.StatusRequest{BudgetID: ctx.Query("budget_id")}
+	if !queryDates(ctx, map[string]**models.Date{"date": &req.Date}) {
+		return
+	}
+
+	res, err := c.service.Status.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+func bindBudget(ctx *gin.Context, req any) bool {
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		err = er.NewHTTPError(http.StatusBadRequest).SetInternal(fmt.Errorf("failed to bind request: %w", err))
+		_ = ctx.Error(err)
+		return false
+	}
+	return true
+}
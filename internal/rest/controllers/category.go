@@ -0,0 +1,121 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	er "github.com/rsmrtk/fd-er"
+	dc "github.com/rsmrtk/mybox/internal/rest/domain/category"
+	"github.com/rsmrtk/mybox/internal/rest/services/category"
+)
+
+// CategoryController handles category catalogue HTTP requests
+type CategoryController struct {
+	service *category.Service
+}
+
+// NewCategoryController creates a new category controller
+func NewCategoryController(service *category.Service) *CategoryController {
+	return &CategoryController{service: service}
+}
+
+// List handles GET request for listing the categories of the workspace
+func (c *CategoryController) List(ctx *gin.Context) {
+	res, err := c.service.List.Handle(ctx, &dc.ListRequest{})
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Get handles GET request for fetching a category
+func (c *CategoryController) Get(ctx *gin.Context) {
+	var req dc.GetRequest
+	if !bindCategory(ctx, &req) {
+		return
+	}
+
+	res, err := c.service.Get.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Create handles POST request for creating a category
+func (c *CategoryController) Create(ctx *gin.Context) {
+	var req dc.CreateRequest
+	if !bindCategory(ctx, &req) {
+		return
+	}
+
+	res, err := c.service.Create.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, res)
+}
+
+// Update handles PUT request for updating a category
+func (c *CategoryController) Update(ctx *gin.Context) {
+	var req dc.UpdateRequest
+	if !bindCategory(ctx, &req) {
+		return
+	}
+
+	res, err := c.service.Update.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Delete handles DELETE request for deleting an unused category
+func (c *CategoryController) Delete(ctx *gin.Context) {
+	var req dc.DeleteRequest
+	if !bindCategory(ctx, &req) {
+		return
+	}
+
+	res, err := c.service.Delete.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Merge handles POST request for merging one category into another
+func (c *CategoryController) Merge(ctx *gin.Context) {
+	var req dc.MergeRequest
+	if !bindCategory(ctx, &req) {
+		return
+	}
+
+	res, err := c.service.Merge.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+func bindCategory(ctx *gin.Context, req any) bool {
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		err = er.NewHTTPError(http.StatusBadRequest).SetInternal(fmt.Errorf("failed to bind request: %w", err))
+		_ = ctx.Error(err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	er "github.com/rsmrtk/fd-er"
+	dd "github.com/rsmrtk/mybox/internal/rest/domain/duplicate"
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+	"github.com/rsmrtk/mybox/internal/rest/services/duplicate"
+)
+
+// DuplicateController handles requests for incomes and expenses entered twice
+type DuplicateController struct {
+	service *duplicate.Service
+}
+
+// NewDuplicateController creates a new duplicate controller
+func NewDuplicateController(service *duplicate.Service) *DuplicateController {
+	return &DuplicateController{service: service}
+}
+
+// List handles GET request for listing suspected duplicate pairs
+func (c *DuplicateController) List(ctx *gin.Context) {
+	req := dd.ListRequest{
+		Direction: ctx.Query("direction"),
+		Account:   ctx.Query("account_id"),
+	}
+
+	// Parse query parameters
+	if limit := ctx.Query("limit"); limit != "" {
+		fmt.Sscanf(limit, "%d", &req.Limit)
+	}
+	if offset := ctx.Query("offset"); offset != "" {
+		fmt.Sscanf(offset, "%d", &req.Offset)
+	}
+	if days := ctx.Query("days"); days != "" {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			_ = ctx.Error(er.NewHTTPError(http.StatusBadRequest, "Invalid days, expected a whole number."))
+			return
+		}
+		req.Days = n
+	}
+	if v := ctx.Query("min_score"); v != "" {
+		minScore, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			_ = ctx.Error(er.NewHTTPError(http.StatusBadRequest, "Invalid min_score, expected a number."))
+			return
+		}
+		req.MinScore = &minScore
+	}
+	for param, dst := range map[string]**models.Date{"from": &req.From, "to": &req.To} {
+		v := ctx.Query(param)
+		if v == "" {
+			continue
+		}
+		d, err := models.ParseDate(v)
+		if err != nil {
+			err := er.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid %s date, expected YYYY-MM-DD.", param))
+			_ = ctx.Error(err)
+			return
+		}
+		*dst = &d
+	}
+
+	res, err := c.service.List.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Merge handles POST request for merging a duplicate into the record it repeats
+func (c *DuplicateController) Merge(ctx *gin.Context) {
+	var req dd.MergeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		err = er.NewHTTPError(http.StatusBadRequest).SetInternal(fmt.Errorf("failed to bind request: %w", err))
+		_ = ctx.Error(err)
+		return
+	}
+
+	res, err := c.service.Merge.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
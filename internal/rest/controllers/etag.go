@@ -0,0 +1,28 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+)
+
+const (
+	headerETag        = "ETag"
+	headerIfMatch     = "If-Match"
+	headerIfNoneMatch = "If-None-Match"
+)
+
+// setETag exposes the version of the returned record.
+func setETag(ctx *gin.Context, version int64) {
+	ctx.Header(headerETag, models.ETag(version))
+}
+
+// notModified answers 304 when If-None-Match still matches the version.
+func notModified(ctx *gin.Context, version int64) bool {
+	if inm := ctx.GetHeader(headerIfNoneMatch); inm != "" && models.MatchWeakETag(inm, version) {
+		ctx.Status(http.StatusNotModified)
+		return true
+	}
+	return false
+}
@@ -5,8 +5,11 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	er "github.com/rsmrtk/fd-er"
+	bt "github.com/rsmrtk/mybox/internal/rest/domain/batch"
 	"github.com/rsmrtk/mybox/internal/rest/domain/expense"
 	expenseService "github.com/rsmrtk/mybox/internal/rest/services/expense"
+	"github.com/rsmrtk/mybox/internal/rest/services/tag"
 )
 
 // ExpenseController handles expense-related HTTP requests
@@ -25,7 +28,8 @@ func NewExpenseController(service *expenseService.Service) *ExpenseController {
 func (c *ExpenseController) Get(ctx *gin.Context) {
 	var req expense.GetRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		err = er.NewHTTPError(http.StatusBadRequest).SetInternal(fmt.Errorf("failed to bind request: %w", err))
+		_ = ctx.Error(err)
 		return
 	}
 
@@ -35,6 +39,11 @@ func (c *ExpenseController) Get(ctx *gin.Context) {
 		return
 	}
 
+	setETag(ctx, resp.Version)
+	if notModified(ctx, resp.Version) {
+		return
+	}
+
 	ctx.JSON(http.StatusOK, resp)
 }
 
@@ -42,7 +51,9 @@ func (c *ExpenseController) Get(ctx *gin.Context) {
 func (c *ExpenseController) Create(ctx *gin.Context) {
 	var req expense.CreateRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		err = er.NewHTTPError(http.StatusBadRequest).SetInternal(fmt.Errorf("failed to bind request: %w", err))
+		ctx.Set("failed_request", req)
+		_ = ctx.Error(err)
 		return
 	}
 
@@ -52,6 +63,7 @@ func (c *ExpenseController) Create(ctx *gin.Context) {
 		return
 	}
 
+	setETag(ctx, resp.Version)
 	ctx.JSON(http.StatusCreated, resp)
 }
 
@@ -59,9 +71,12 @@ func (c *ExpenseController) Create(ctx *gin.Context) {
 func (c *ExpenseController) Update(ctx *gin.Context) {
 	var req expense.UpdateRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		err = er.NewHTTPError(http.StatusBadRequest).SetInternal(fmt.Errorf("failed to bind request: %w", err))
+		ctx.Set("failed_request", req)
+		_ = ctx.Error(err)
 		return
 	}
+	req.IfMatch = ctx.GetHeader(headerIfMatch)
 
 	resp, err := c.service.Update.Handle(ctx.Request.Context(), &req)
 	if err != nil {
@@ -69,6 +84,7 @@ func (c *ExpenseController) Update(ctx *gin.Context) {
 		return
 	}
 
+	setETag(ctx, resp.Version)
 	ctx.JSON(http.StatusOK, resp)
 }
 
@@ -93,6 +109,8 @@ func (c *ExpenseController) List(ctx *gin.Context) {
 	if order := ctx.Query("order"); order != "" {
 		req.Order = order
 	}
+	req.Tags = tag.Split(ctx.Query("tags"))
+	req.TagMode = ctx.Query("tag_mode")
 
 	resp, err := c.service.List.Handle(ctx.Request.Context(), &req)
 	if err != nil {
@@ -107,9 +125,12 @@ func (c *ExpenseController) List(ctx *gin.Context) {
 func (c *ExpenseController) Delete(ctx *gin.Context) {
 	var req expense.DeleteRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		err = er.NewHTTPError(http.StatusBadRequest).SetInternal(fmt.Errorf("failed to bind request: %w", err))
+		ctx.Set("failed_request", req)
+		_ = ctx.Error(err)
 		return
 	}
+	req.IfMatch = ctx.GetHeader(headerIfMatch)
 
 	resp, err := c.service.Delete.Handle(ctx.Request.Context(), &req)
 	if err != nil {
@@ -119,3 +140,42 @@ func (c *ExpenseController) Delete(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, resp)
 }
+
+// Restore handles POST request for restoring an expense from the trash
+func (c *ExpenseController) Restore(ctx *gin.Context) {
+	var req expense.RestoreRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		err = er.NewHTTPError(http.StatusBadRequest).SetInternal(fmt.Errorf("failed to bind request: %w", err))
+		ctx.Set("failed_request", req)
+		_ = ctx.Error(err)
+		return
+	}
+
+	resp, err := c.service.Restore.Handle(ctx.Request.Context(), &req)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	setETag(ctx, resp.Version)
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// Batch handles POST request for creating, updating and deleting expenses in bulk
+func (c *ExpenseController) Batch(ctx *gin.Context) {
+	var req bt.Request
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		err = er.NewHTTPError(http.StatusBadRequest).SetInternal(fmt.Errorf("failed to bind request: %w", err))
+		ctx.Set("failed_request", req)
+		_ = ctx.Error(err)
+		return
+	}
+
+	resp, err := c.service.Batch.Handle(ctx.Request.Context(), &req)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(batchStatus(resp), resp)
+}
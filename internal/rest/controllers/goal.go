@@ -0,0 +1,105 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	er "github.com/rsmrtk/fd-er"
+	dg "github.com/rsmrtk/mybox/internal/rest/domain/goal"
+	"github.com/rsmrtk/mybox/internal/rest/services/goal"
+)
+
+// GoalController handles savings goal HTTP requests
+type GoalController struct {
+	service *goal.Service
+}
+
+// NewGoalController creates a new goal controller
+func NewGoalController(service *goal.Service) *GoalController {
+	return &GoalController{service: service}
+}
+
+// List handles GET request for listing goals
+func (c *GoalController) List(ctx *gin.Context) {
+	res, err := c.service.List.Handle(ctx, &dg.ListRequest{})
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Get handles GET request for fetching a goal
+func (c *GoalController) Get(ctx *gin.Context) {
+	var req dg.GetRequest
+	if !bindGoal(ctx, &req) {
+		return
+	}
+
+	res, err := c.service.Get.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Create handles POST request for creating a goal
+func (c *GoalController) Create(ctx *gin.Context) {
+	var req dg.CreateRequest
+	if !bindGoal(ctx, &req) {
+		return
+	}
+
+	res, err := c.service.Create.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, res)
+}
+
+// Update handles PUT request for updating a goal
+func (c *GoalController) Update(ctx *gin.Context) {
+	var req dg.UpdateRequest
+	if !bindGoal(ctx, &req) {
+		return
+	}
+
+	res, err := c.service.Update.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Delete handles DELETE request for deleting a goal
+func (c *GoalController) Delete(ctx *gin.Context) {
+	var req dg.DeleteRequest
+	if !bindGoal(ctx, &req) {
+		return
+	}
+
+	res, err := c.service.Delete.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+func bindGoal(ctx *gin.Context, req any) bool {
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		err = er.NewHTTPError(http.StatusBadRequest).SetInternal(fmt.Errorf("failed to bind request: %w", err))
+		_ = ctx.Error(err)
+		return false
+	}
+	return true
+}
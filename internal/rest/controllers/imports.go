@@ -0,0 +1,212 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	er "github.com/rsmrtk/fd-er"
+	di "github.com/rsmrtk/mybox/internal/rest/domain/imports"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports"
+)
+
+// ImportController handles file import HTTP requests
+type ImportController struct {
+	service *imports.Service
+}
+
+// NewImportController creates a new import controller
+func NewImportController(service *imports.Service) *ImportController {
+	return &ImportController{service: service}
+}
+
+// Profiles handles GET request for listing import profiles
+func (c *ImportController) Profiles(ctx *gin.Context) {
+	res, err := c.service.Profiles.Handle(ctx, &di.ListProfilesRequest{})
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// CreateProfile handles POST request for creating an import profile
+func (c *ImportController) CreateProfile(ctx *gin.Context) {
+	var req di.CreateProfileRequest
+	if !bindImport(ctx, &req) {
+		return
+	}
+
+	res, err := c.service.CreateProfile.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, res)
+}
+
+// UpdateProfile handles PUT request for updating an import profile
+func (c *ImportController) UpdateProfile(ctx *gin.Context) {
+	var req di.UpdateProfileRequest
+	if !bindImport(ctx, &req) {
+		return
+	}
+
+	res, err := c.service.UpdateProfile.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// DeleteProfile handles DELETE request for deleting an import profile
+func (c *ImportController) DeleteProfile(ctx *gin.Context) {
+	var req di.DeleteProfileRequest
+	if !bindImport(ctx, &req) {
+		return
+	}
+
+	res, err := c.service.DeleteProfile.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// CSV handles POST request for importing a CSV file
+func (c *ImportController) CSV(ctx *gin.Context) {
+	var req di.CSVRequest
+	if !bindImport(ctx, &req) {
+		return
+	}
+
+	res, err := c.service.CSV.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// OFX handles POST request for importing an OFX or QFX statement
+func (c *ImportController) OFX(ctx *gin.Context) {
+	var req di.OFXRequest
+	if !bindImport(ctx, &req) {
+		return
+	}
+
+	res, err := c.service.OFX.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// QIF handles POST request for importing a QIF file
+func (c *ImportController) QIF(ctx *gin.Context) {
+	var req di.QIFRequest
+	if !bindImport(ctx, &req) {
+		return
+	}
+
+	res, err := c.service.QIF.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// CAMT handles POST request for importing a camt.053 statement
+func (c *ImportController) CAMT(ctx *gin.Context) {
+	var req di.CAMTRequest
+	if !bindImport(ctx, &req) {
+		return
+	}
+
+	res, err := c.service.CAMT.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// MT940 handles POST request for importing an MT940 statement
+func (c *ImportController) MT940(ctx *gin.Context) {
+	var req di.MT940Request
+	if !bindImport(ctx, &req) {
+		return
+	}
+
+	res, err := c.service.MT940.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Journal handles POST request for importing a Beancount or Ledger journal
+func (c *ImportController) Journal(ctx *gin.Context) {
+	var req di.JournalRequest
+	if !bindImport(ctx, &req) {
+		return
+	}
+
+	res, err := c.service.Journal.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Batches handles GET request for listing import batches
+func (c *ImportController) Batches(ctx *gin.Context) {
+	res, err := c.service.Batches.Handle(ctx, &di.BatchesRequest{})
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Rollback handles POST request for rolling an import batch back
+func (c *ImportController) Rollback(ctx *gin.Context) {
+	var req di.RollbackRequest
+	if !bindImport(ctx, &req) {
+		return
+	}
+
+	res, err := c.service.Rollback.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+func bindImport(ctx *gin.Context, req any) bool {
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		err = er.NewHTTPError(http.StatusBadRequest).SetInternal(fmt.Errorf("failed to bind request: %w", err))
+		_ = ctx.Error(err)
+		return false
+	}
+	return true
+}
@@ -6,8 +6,10 @@ import (
 
 	"github.com/gin-gonic/gin"
 	er "github.com/rsmrtk/fd-er"
+	bt "github.com/rsmrtk/mybox/internal/rest/domain/batch"
 	di "github.com/rsmrtk/mybox/internal/rest/domain/income"
 	"github.com/rsmrtk/mybox/internal/rest/services/income"
+	"github.com/rsmrtk/mybox/internal/rest/services/tag"
 )
 
 type IncomeController struct {
@@ -28,11 +30,15 @@ func (c *IncomeController) Get(ctx *gin.Context) {
 
 	res, err := c.service.Get.Handle(ctx, &req)
 	if err != nil {
-		err = er.NewHTTPError(http.StatusInternalServerError).SetInternal(fmt.Errorf("failed to handle request: %w", err))
 		_ = ctx.Error(err)
 		return
 	}
 
+	setETag(ctx, res.Version)
+	if notModified(ctx, res.Version) {
+		return
+	}
+
 	ctx.JSON(http.StatusOK, res)
 }
 
@@ -47,12 +53,12 @@ func (c *IncomeController) Create(ctx *gin.Context) {
 
 	res, err := c.service.Create.Handle(ctx, &req)
 	if err != nil {
-		err = er.NewHTTPError(http.StatusInternalServerError).SetInternal(fmt.Errorf("failed to handle request: %w", err))
 		ctx.Set("failed_request", req)
 		_ = ctx.Error(err)
 		return
 	}
 
+	setETag(ctx, res.Version)
 	ctx.JSON(http.StatusOK, res)
 }
 
@@ -64,15 +70,16 @@ func (c *IncomeController) Update(ctx *gin.Context) {
 		_ = ctx.Error(err)
 		return
 	}
+	req.IfMatch = ctx.GetHeader(headerIfMatch)
 
 	res, err := c.service.Update.Handle(ctx, &req)
 	if err != nil {
-		err = er.NewHTTPError(http.StatusInternalServerError).SetInternal(fmt.Errorf("failed to handle request: %w", err))
 		ctx.Set("failed_request", req)
 		_ = ctx.Error(err)
 		return
 	}
 
+	setETag(ctx, res.Version)
 	ctx.JSON(http.StatusOK, res)
 }
 
@@ -99,10 +106,11 @@ func (c *IncomeController) List(ctx *gin.Context) {
 	if order := ctx.Query("order"); order != "" {
 		req.Order = order
 	}
+	req.Tags = tag.Split(ctx.Query("tags"))
+	req.TagMode = ctx.Query("tag_mode")
 
 	res, err := c.service.List.Handle(ctx, &req)
 	if err != nil {
-		err = er.NewHTTPError(http.StatusInternalServerError).SetInternal(fmt.Errorf("failed to handle request: %w", err))
 		_ = ctx.Error(err)
 		return
 	}
@@ -118,10 +126,10 @@ func (c *IncomeController) Delete(ctx *gin.Context) {
 		_ = ctx.Error(err)
 		return
 	}
+	req.IfMatch = ctx.GetHeader(headerIfMatch)
 
 	res, err := c.service.Delete.Handle(ctx, &req)
 	if err != nil {
-		err = er.NewHTTPError(http.StatusInternalServerError).SetInternal(fmt.Errorf("failed to handle request: %w", err))
 		ctx.Set("failed_request", req)
 		_ = ctx.Error(err)
 		return
@@ -129,3 +137,42 @@ func (c *IncomeController) Delete(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, res)
 }
+
+func (c *IncomeController) Restore(ctx *gin.Context) {
+	var req di.RestoreRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		err = er.NewHTTPError(http.StatusBadRequest).SetInternal(fmt.Errorf("failed to bind request: %w", err))
+		ctx.Set("failed_request", req)
+		_ = ctx.Error(err)
+		return
+	}
+
+	res, err := c.service.Restore.Handle(ctx, &req)
+	if err != nil {
+		ctx.Set("failed_request", req)
+		_ = ctx.Error(err)
+		return
+	}
+
+	setETag(ctx, res.Version)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *IncomeController) Batch(ctx *gin.Context) {
+	var req bt.Request
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		err = er.NewHTTPError(http.StatusBadRequest).SetInternal(fmt.Errorf("failed to bind request: %w", err))
+		ctx.Set("failed_request", req)
+		_ = ctx.Error(err)
+		return
+	}
+
+	res, err := c.service.Batch.Handle(ctx, &req)
+	if err != nil {
+		ctx.Set("failed_request", req)
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(batchStatus(res), res)
+}
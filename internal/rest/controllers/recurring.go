@@ -0,0 +1,161 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	er "github.com/rsmrtk/fd-er"
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+	dr "github.com/rsmrtk/mybox/internal/rest/domain/recurring"
+	"github.com/rsmrtk/mybox/internal/rest/services/recurring"
+)
+
+// RecurringController handles recurring template HTTP requests
+type RecurringController struct {
+	service *recurring.Service
+}
+
+// NewRecurringController creates a new recurring template controller
+func NewRecurringController(service *recurring.Service) *RecurringController {
+	return &RecurringController{service: service}
+}
+
+// List handles GET request for listing recurring templates
+func (c *RecurringController) List(ctx *gin.Context) {
+	res, err := c.service.List.Handle(ctx, &dr.ListRequest{})
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Get handles GET request for fetching a recurring template
+func (c *RecurringController) Get(ctx *gin.Context) {
+	var req dr.GetRequest
+	if !bindRecurring(ctx, &req) {
+		return
+	}
+
+	res, err := c.service.Get.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Create handles POST request for creating a recurring template
+func (c *RecurringController) Create(ctx *gin.Context) {
+	var req dr.CreateRequest
+	if !bindRecurring(ctx, &req) {
+		return
+	}
+
+	res, err := c.service.Create.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, res)
+}
+
+// Update handles PUT request for editing a whole series
+func (c *RecurringController) Update(ctx *gin.Context) {
+	var req dr.UpdateRequest
+	if !bindRecurring(ctx, &req) {
+		return
+	}
+
+	res, err := c.service.Update.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Delete handles DELETE request for deleting a recurring template
+func (c *RecurringController) Delete(ctx *gin.Context) {
+	var req dr.DeleteRequest
+	if !bindRecurring(ctx, &req) {
+		return
+	}
+
+	res, err := c.service.Delete.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Preview handles GET request for listing upcoming occurrences
+func (c *RecurringController) Preview(ctx *gin.Context) {
+	req := dr.PreviewRequest{
+		RecurringID: ctx.Query("recurring_id"),
+		Rule:        ctx.Query("rule"),
+	}
+
+	if count := ctx.Query("count"); count != "" {
+		fmt.Sscanf(count, "%d", &req.Count)
+	}
+	if !queryDates(ctx, map[string]**models.Date{"starts_on": &req.StartsOn}) {
+		return
+	}
+
+	res, err := c.service.Preview.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Skip handles POST request for skipping one occurrence
+func (c *RecurringController) Skip(ctx *gin.Context) {
+	var req dr.SkipRequest
+	if !bindRecurring(ctx, &req) {
+		return
+	}
+
+	res, err := c.service.Skip.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Split handles PUT request for editing an occurrence and the ones after it
+func (c *RecurringController) Split(ctx *gin.Context) {
+	var req dr.SplitRequest
+	if !bindRecurring(ctx, &req) {
+		return
+	}
+
+	res, err := c.service.Split.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+func bindRecurring(ctx *gin.Context, req any) bool {
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		err = er.NewHTTPError(http.StatusBadRequest).SetInternal(fmt.Errorf("failed to bind request: %w", err))
+		_ = ctx.Error(err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	er "github.com/rsmrtk/fd-er"
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+	dr "github.com/rsmrtk/mybox/internal/rest/domain/report"
+	"github.com/rsmrtk/mybox/internal/rest/services/report"
+)
+
+// ReportController handles report HTTP requests
+type ReportController struct {
+	service *report.Service
+}
+
+// NewReportController creates a new report controller
+func NewReportController(service *report.Service) *ReportController {
+	return &ReportController{service: service}
+}
+
+// Categories handles GET request for totals per category
+func (c *ReportController) Categories(ctx *gin.Context) {
+	req := dr.CategoriesRequest{
+		Direction: ctx.Query("direction"),
+		TopLevel:  ctx.Query("top_level") == "true",
+	}
+
+	if !queryDates(ctx, map[string]**models.Date{"from": &req.From, "to": &req.To}) {
+		return
+	}
+
+	res, err := c.service.Categories.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Tags handles GET request for totals per tag
+func (c *ReportController) Tags(ctx *gin.Context) {
+	req := dr.TagsRequest{
+		Direction: ctx.Query("direction"),
+	}
+
+	if !queryDates(ctx, map[string]**models.Date{"from": &req.From, "to": &req.To}) {
+		return
+	}
+
+	res, err := c.service.Tags.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// NetWorth handles GET request for the balances of all accounts
+func (c *ReportController) NetWorth(ctx *gin.Context) {
+	var req dr.NetWorthRequest
+
+	if !queryDates(ctx, map[string]**models.Date{"as_of": &req.AsOf}) {
+		return
+	}
+
+	res, err := c.service.NetWorth.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// queryDates parses the given date query parameters into dst. It reports
+// false after answering 400 for a malformed date.
+func queryDates(ctx *gin.Context, dst map[string]**models.Date) bool {
+	for param, d := range dst {
+		v := ctx.Query(param)
+		if v == "" {
+			continue
+		}
+		parsed, err := models.ParseDate(v)
+		if err != nil {
+			err := er.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid %s date, expected YYYY-MM-DD.", param))
+			_ = ctx.Error(err)
+			return false
+		}
+		*d = &parsed
+	}
+	return true
+}
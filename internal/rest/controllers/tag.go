@@ -0,0 +1,86 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	er "github.com/rsmrtk/fd-er"
+	dt "github.com/rsmrtk/mybox/internal/rest/domain/tag"
+	"github.com/rsmrtk/mybox/internal/rest/services/tag"
+)
+
+// TagController handles tag management HTTP requests
+type TagController struct {
+	service *tag.Service
+}
+
+// NewTagController creates a new tag controller
+func NewTagController(service *tag.Service) *TagController {
+	return &TagController{service: service}
+}
+
+// List handles GET request for listing the tags of the workspace
+func (c *TagController) List(ctx *gin.Context) {
+	res, err := c.service.List.Handle(ctx, &dt.ListRequest{})
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Rename handles PUT request for renaming a tag
+func (c *TagController) Rename(ctx *gin.Context) {
+	var req dt.RenameRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		err = er.NewHTTPError(http.StatusBadRequest).SetInternal(fmt.Errorf("failed to bind request: %w", err))
+		_ = ctx.Error(err)
+		return
+	}
+
+	res, err := c.service.Rename.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Delete handles DELETE request for removing a tag from every record
+func (c *TagController) Delete(ctx *gin.Context) {
+	var req dt.DeleteRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		err = er.NewHTTPError(http.StatusBadRequest).SetInternal(fmt.Errorf("failed to bind request: %w", err))
+		_ = ctx.Error(err)
+		return
+	}
+
+	res, err := c.service.Delete.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Merge handles POST request for merging one tag into another
+func (c *TagController) Merge(ctx *gin.Context) {
+	var req dt.MergeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		err = er.NewHTTPError(http.StatusBadRequest).SetInternal(fmt.Errorf("failed to bind request: %w", err))
+		_ = ctx.Error(err)
+		return
+	}
+
+	res, err := c.service.Merge.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
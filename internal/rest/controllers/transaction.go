@@ -0,0 +1,139 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	er "github.com/rsmrtk/fd-er"
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+	dt "github.com/rsmrtk/mybox/internal/rest/domain/transaction"
+	"github.com/rsmrtk/mybox/internal/rest/services/tag"
+	"github.com/rsmrtk/mybox/internal/rest/services/transaction"
+)
+
+// TransactionController handles requests for incomes and expenses as one list
+type TransactionController struct {
+	service *transaction.Service
+}
+
+// NewTransactionController creates a new transaction controller
+func NewTransactionController(service *transaction.Service) *TransactionController {
+	return &TransactionController{service: service}
+}
+
+// List handles GET request for listing incomes and expenses together
+func (c *TransactionController) List(ctx *gin.Context) {
+	req := dt.ListRequest{
+		SortBy: ctx.Query("sort_by"),
+		Order:  ctx.Query("order"),
+	}
+	if !bindFilter(ctx, &req.Filter) {
+		return
+	}
+
+	// Parse query parameters
+	if limit := ctx.Query("limit"); limit != "" {
+		fmt.Sscanf(limit, "%d", &req.Limit)
+	}
+	if offset := ctx.Query("offset"); offset != "" {
+		fmt.Sscanf(offset, "%d", &req.Offset)
+	}
+
+	res, err := c.service.List.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Export handles GET request for downloading incomes and expenses as a file
+func (c *TransactionController) Export(ctx *gin.Context) {
+	req := dt.ExportRequest{
+		Format: ctx.Query("format"),
+		SortBy: ctx.Query("sort_by"),
+		Order:  ctx.Query("order"),
+	}
+	if !bindFilter(ctx, &req.Filter) {
+		return
+	}
+
+	c.export(ctx, &req)
+}
+
+// ExportMapped handles POST request for downloading incomes and expenses as
+// a file, taking the filters, the format and the journal mapping as JSON
+func (c *TransactionController) ExportMapped(ctx *gin.Context) {
+	var req dt.ExportRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		err = er.NewHTTPError(http.StatusBadRequest).SetInternal(fmt.Errorf("failed to bind request: %w", err))
+		_ = ctx.Error(err)
+		return
+	}
+
+	c.export(ctx, &req)
+}
+
+func (c *TransactionController) export(ctx *gin.Context, req *dt.ExportRequest) {
+	res, err := c.service.Export.Handle(ctx, req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Content-Type", res.ContentType)
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, res.FileName))
+	ctx.Status(http.StatusOK)
+	if err := res.Write(ctx.Writer); err != nil {
+		if !ctx.Writer.Written() {
+			// Nothing was sent, so the error can still go out as JSON
+			ctx.Writer.Header().Del("Content-Type")
+			ctx.Writer.Header().Del("Content-Disposition")
+		}
+		_ = ctx.Error(err)
+	}
+}
+
+// bindFilter reads the filter query parameters shared by List and Export. It
+// reports a bad parameter and returns false when one cannot be parsed.
+func bindFilter(ctx *gin.Context, f *dt.Filter) bool {
+	*f = dt.Filter{
+		Direction: ctx.Query("direction"),
+		Type:      ctx.Query("type"),
+		Search:    ctx.Query("q"),
+		Category:  ctx.Query("category_id"),
+		Account:   ctx.Query("account_id"),
+		Tags:      tag.Split(ctx.Query("tags")),
+		TagMode:   ctx.Query("tag_mode"),
+	}
+	for param, dst := range map[string]**models.Date{"from": &f.From, "to": &f.To} {
+		v := ctx.Query(param)
+		if v == "" {
+			continue
+		}
+		d, err := models.ParseDate(v)
+		if err != nil {
+			err := er.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid %s date, expected YYYY-MM-DD.", param))
+			_ = ctx.Error(err)
+			return false
+		}
+		*dst = &d
+	}
+	for param, dst := range map[string]**float64{"min_amount": &f.MinAmount, "max_amount": &f.MaxAmount} {
+		v := ctx.Query(param)
+		if v == "" {
+			continue
+		}
+		amount, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			err := er.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid %s, expected a number.", param))
+			_ = ctx.Error(err)
+			return false
+		}
+		*dst = &amount
+	}
+	return true
+}
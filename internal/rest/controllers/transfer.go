@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	er "github.com/rsmrtk/fd-er"
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+	dt "github.com/rsmrtk/mybox/internal/rest/domain/transfer"
+	"github.com/rsmrtk/mybox/internal/rest/services/transfer"
+)
+
+// TransferController handles transfer HTTP requests
+type TransferController struct {
+	service *transfer.Service
+}
+
+// NewTransferController creates a new transfer controller
+func NewTransferController(service *transfer.Service) *TransferController {
+	return &TransferController{service: service}
+}
+
+// List handles GET request for listing transfers
+func (c *TransferController) List(ctx *gin.Context) {
+	req := dt.ListRequest{
+		AccountID: ctx.Query("account_id"),
+	}
+
+	if limit := ctx.Query("limit"); limit != "" {
+		fmt.Sscanf(limit, "%d", &req.Limit)
+	}
+	if offset := ctx.Query("offset"); offset != "" {
+		fmt.Sscanf(offset, "%d", &req.Offset)
+	}
+	if !queryDates(ctx, map[string]**models.Date{"from": &req.From, "to": &req.To}) {
+		return
+	}
+
+	res, err := c.service.List.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Get handles GET request for fetching a transfer
+func (c *TransferController) Get(ctx *gin.Context) {
+	var req dt.GetRequest
+	if !bindTransfer(ctx, &req) {
+		return
+	}
+
+	res, err := c.service.Get.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Create handles POST request for creating a transfer
+func (c *TransferController) Create(ctx *gin.Context) {
+	var req dt.CreateRequest
+	if !bindTransfer(ctx, &req) {
+		return
+	}
+
+	res, err := c.service.Create.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, res)
+}
+
+// Update handles PUT request for updating a transfer
+func (c *TransferController) Update(ctx *gin.Context) {
+	var req dt.UpdateRequest
+	if !bindTransfer(ctx, &req) {
+		return
+	}
+
+	res, err := c.service.Update.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Delete handles DELETE request for deleting a transfer
+func (c *TransferController) Delete(ctx *gin.Context) {
+	var req dt.DeleteRequest
+	if !bindTransfer(ctx, &req) {
+		return
+	}
+
+	res, err := c.service.Delete.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+func bindTransfer(ctx *gin.Context, req any) bool {
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		err = er.NewHTTPError(http.StatusBadRequest).SetInternal(fmt.Errorf("failed to bind request: %w", err))
+		_ = ctx.Error(err)
+		return false
+	}
+	return true
+}
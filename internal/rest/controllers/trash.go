@@ -0,0 +1,43 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	dt "github.com/rsmrtk/mybox/internal/rest/domain/trash"
+	"github.com/rsmrtk/mybox/internal/rest/services/trash"
+)
+
+// TrashController handles requests for soft deleted incomes and expenses
+type TrashController struct {
+	service *trash.Service
+}
+
+// NewTrashController creates a new trash controller
+func NewTrashController(service *trash.Service) *TrashController {
+	return &TrashController{service: service}
+}
+
+// List handles GET request for listing the trash
+func (c *TrashController) List(ctx *gin.Context) {
+	req := dt.ListRequest{
+		EntityType: ctx.Query("entity_type"),
+	}
+
+	// Parse query parameters
+	if limit := ctx.Query("limit"); limit != "" {
+		fmt.Sscanf(limit, "%d", &req.Limit)
+	}
+	if offset := ctx.Query("offset"); offset != "" {
+		fmt.Sscanf(offset, "%d", &req.Offset)
+	}
+
+	res, err := c.service.List.Handle(ctx, &req)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
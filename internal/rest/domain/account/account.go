@@ -0,0 +1,20 @@
+package account
+
+import (
+	"time"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+)
+
+// Account represents a place money is kept in
+type Account struct {
+	AccountID      string           `json:"account_id"`
+	Name           string           `json:"name"`
+	Kind           string           `json:"kind"` // cash, checking, credit_card or savings
+	Currency       string           `json:"currency"`
+	OpeningBalance []*models.Amount `json:"opening_balance"`
+	OpenedOn       models.Date      `json:"opened_on"`
+	Balance        []*models.Amount `json:"balance"` // Opening balance plus live incomes and incoming transfers, minus live expenses and outgoing transfers with their fees
+	CreatedAt      time.Time        `json:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+}
@@ -0,0 +1,31 @@
+package account
+
+import (
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+)
+
+// BalanceRequest represents the request structure for the balance of an account
+type BalanceRequest struct {
+	AccountID string       `json:"account_id"`
+	From      *models.Date `json:"from,omitempty"`     // Optional: first day of the history, inclusive
+	To        *models.Date `json:"to,omitempty"`       // Optional: last day of the history, inclusive
+	Interval  string       `json:"interval,omitempty"` // Optional: day, week, month (default) or year
+}
+
+// BalancePoint represents the movements of one period and the balance at its end
+type BalancePoint struct {
+	Period  models.Date      `json:"period"` // First day of the period
+	Inflow  []*models.Amount `json:"inflow"`
+	Outflow []*models.Amount `json:"outflow"`
+	Count   int              `json:"count"`
+	Balance []*models.Amount `json:"balance"`
+}
+
+// BalanceResponse represents the response structure for the balance of an
+// account. History only lists periods with movements.
+type BalanceResponse struct {
+	Account
+	Interval string           `json:"interval"`
+	Opening  []*models.Amount `json:"opening"` // Balance before the first listed period
+	History  []*BalancePoint  `json:"history"`
+}
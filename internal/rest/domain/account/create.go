@@ -0,0 +1,19 @@
+package account
+
+import (
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+)
+
+// CreateRequest represents the request structure for creating an account
+type CreateRequest struct {
+	Name           string       `json:"name" binding:"required,max=100"`
+	Kind           string       `json:"kind" binding:"required"`     // cash, checking, credit_card or savings
+	Currency       string       `json:"currency" binding:"required"` // ISO 4217 code, e.g. EUR
+	OpeningBalance float64      `json:"opening_balance,omitempty"`
+	OpenedOn       *models.Date `json:"opened_on,omitempty"` // Optional: defaults to today
+}
+
+// CreateResponse represents the response structure for creating an account
+type CreateResponse struct {
+	Account
+}
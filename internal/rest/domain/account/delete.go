@@ -0,0 +1,14 @@
+package account
+
+// DeleteRequest represents the request structure for deleting an account.
+// Accounts that incomes, expenses, transfers, recurring templates or savings
+// goals still point at cannot be deleted.
+type DeleteRequest struct {
+	AccountID string `json:"account_id" binding:"required"`
+}
+
+// DeleteResponse represents the response structure for deleting an account
+type DeleteResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,11 @@
+package account
+
+// GetRequest represents the request structure for fetching an account
+type GetRequest struct {
+	AccountID string `json:"account_id" binding:"required"`
+}
+
+// GetResponse represents the response structure for fetching an account
+type GetResponse struct {
+	Account
+}
@@ -0,0 +1,9 @@
+package account
+
+// ListRequest represents the request structure for listing accounts
+type ListRequest struct{}
+
+// ListResponse represents the response structure for listing accounts
+type ListResponse struct {
+	Items []*Account `json:"items"`
+}
@@ -0,0 +1,21 @@
+package account
+
+import (
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+)
+
+// UpdateRequest represents the request structure for updating an account.
+// Omitted fields keep their value.
+type UpdateRequest struct {
+	AccountID      string       `json:"account_id" binding:"required"`
+	Name           string       `json:"name,omitempty" binding:"max=100"`
+	Kind           string       `json:"kind,omitempty"`
+	Currency       string       `json:"currency,omitempty"` // Fixed once the account has records or transfers
+	OpeningBalance *float64     `json:"opening_balance,omitempty"`
+	OpenedOn       *models.Date `json:"opened_on,omitempty"`
+}
+
+// UpdateResponse represents the response structure for updating an account
+type UpdateResponse struct {
+	Account
+}
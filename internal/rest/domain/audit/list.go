@@ -0,0 +1,40 @@
+package audit
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+)
+
+// ListRequest represents the request structure for querying the audit log
+type ListRequest struct {
+	EntityType string       `json:"entity_type,omitempty"` // Optional: income, expense or transfer
+	EntityID   string       `json:"entity_id,omitempty"`   // Optional: ID of the income, expense or transfer
+	ActorID    string       `json:"actor_id,omitempty"`    // Optional: customer or API key ID
+	From       *models.Date `json:"from,omitempty"`        // Optional: first day, inclusive
+	To         *models.Date `json:"to,omitempty"`          // Optional: last day, inclusive
+	Limit      int          `json:"limit,omitempty"`
+	Offset     int          `json:"offset,omitempty"`
+}
+
+// ListItem represents a single audit log entry
+type ListItem struct {
+	AuditID         string          `json:"audit_id"`
+	OccurredAt      time.Time       `json:"occurred_at"`
+	ActorCustomerID *string         `json:"actor_customer_id"`
+	ActorAPIKeyID   *string         `json:"actor_api_key_id"`
+	RequestID       *string         `json:"request_id"`
+	EntityType      string          `json:"entity_type"`
+	EntityID        string          `json:"entity_id"`
+	Action          string          `json:"action"`
+	Diff            json.RawMessage `json:"diff"`
+}
+
+// ListResponse represents the response structure for querying the audit log
+type ListResponse struct {
+	Items      []*ListItem `json:"items"`
+	TotalCount int         `json:"total_count"`
+	Limit      int         `json:"limit"`
+	Offset     int         `json:"offset"`
+}
@@ -0,0 +1,54 @@
+package batch
+
+import "encoding/json"
+
+// Modes of executing a batch.
+const (
+	ModeAtomic  = "atomic"  // all operations commit together or none do
+	ModePartial = "partial" // every operation commits on its own
+)
+
+// Operations accepted in a batch.
+const (
+	OpCreate = "create"
+	OpUpdate = "update"
+	OpDelete = "delete"
+)
+
+// Request represents the request structure for a batch of operations
+type Request struct {
+	Mode       string       `json:"mode,omitempty"` // Optional: atomic (default) or partial
+	Operations []*Operation `json:"operations" binding:"required"`
+}
+
+// Operation is one create, update or delete. Data has the same shape as the
+// body of the corresponding single-record endpoint.
+type Operation struct {
+	Op      string          `json:"op"`
+	IfMatch string          `json:"if_match,omitempty"` // Optional: as the If-Match header of update and delete
+	Data    json.RawMessage `json:"data"`
+}
+
+// Result is the outcome of one operation, at the index it had in the request
+type Result struct {
+	Index  int    `json:"index"`
+	Op     string `json:"op"`
+	Status int    `json:"status"`
+	Result any    `json:"result,omitempty"`
+	Error  *Error `json:"error,omitempty"`
+}
+
+// Error describes why an operation failed
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Response represents the response structure for a batch of operations
+type Response struct {
+	Mode      string    `json:"mode"`
+	Committed bool      `json:"committed"`
+	Succeeded int       `json:"succeeded"`
+	Failed    int       `json:"failed"`
+	Results   []*Result `json:"results"`
+}
@@ -0,0 +1,22 @@
+package budget
+
+import (
+	"time"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+)
+
+// Budget represents a spending limit per month or year, either in a
+// category (with its subcategories) or on an expense type
+type Budget struct {
+	BudgetID    string           `json:"budget_id"`
+	CategoryID  string           `json:"category_id,omitempty"`
+	ExpenseType string           `json:"expense_type,omitempty"`
+	Period      string           `json:"period"` // month or year
+	Amount      []*models.Amount `json:"amount"`
+	Currency    string           `json:"currency"` // Only expenses in this currency count against the budget
+	Rollover    bool             `json:"rollover"` // What is left or overspent carries over to the next period
+	StartsOn    models.Date      `json:"starts_on"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
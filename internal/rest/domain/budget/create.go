@@ -0,0 +1,22 @@
+package budget
+
+import (
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+)
+
+// CreateRequest represents the request structure for creating a budget.
+// Exactly one of CategoryID and ExpenseType is required.
+type CreateRequest struct {
+	CategoryID  string       `json:"category_id,omitempty"`
+	ExpenseType string       `json:"expense_type,omitempty" binding:"max=255"`
+	Period      string       `json:"period" binding:"required"` // month or year
+	Amount      float64      `json:"amount" binding:"required"`
+	Currency    string       `json:"currency,omitempty"` // Optional: ISO 4217 code; defaults to USD
+	Rollover    bool         `json:"rollover,omitempty"`
+	StartsOn    *models.Date `json:"starts_on,omitempty"` // Optional: any day of the first period; defaults to the current one
+}
+
+// CreateResponse represents the response structure for creating a budget
+type CreateResponse struct {
+	Budget
+}
@@ -0,0 +1,12 @@
+package budget
+
+// DeleteRequest represents the request structure for deleting a budget
+type DeleteRequest struct {
+	BudgetID string `json:"budget_id" binding:"required"`
+}
+
+// DeleteResponse represents the response structure for deleting a budget
+type DeleteResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,11 @@
+package budget
+
+// GetRequest represents the request structure for fetching a budget
+type GetRequest struct {
+	BudgetID string `json:"budget_id" binding:"required"`
+}
+
+// GetResponse represents the response structure for fetching a budget
+type GetResponse struct {
+	Budget
+}
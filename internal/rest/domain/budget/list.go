@@ -0,0 +1,9 @@
+package budget
+
+// ListRequest represents the request structure for listing budgets
+type ListRequest struct{}
+
+// ListResponse represents the response structure for listing budgets
+type ListResponse struct {
+	Items []*Budget `json:"items"`
+}
@@ -0,0 +1,32 @@
+package budget
+
+import (
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+)
+
+// StatusRequest represents the request structure for comparing budgets with
+// actual spending
+type StatusRequest struct {
+	Date     *models.Date `json:"date,omitempty"`      // Optional: any day of the period; defaults to today
+	BudgetID string       `json:"budget_id,omitempty"` // Optional: a single budget
+}
+
+// BudgetStatus is one budget in the period that holds the requested date
+type BudgetStatus struct {
+	Budget
+	PeriodStart models.Date      `json:"period_start"`
+	PeriodEnd   models.Date      `json:"period_end"`   // Last day, inclusive
+	Carried     []*models.Amount `json:"carried"`      // Brought over from earlier periods with rollover; negative when overspent
+	Planned     []*models.Amount `json:"planned"`      // Amount plus carried
+	Actual      []*models.Amount `json:"actual"`       // Spent in the period so far
+	Remaining   []*models.Amount `json:"remaining"`    // Planned minus actual; negative when overspent
+	PercentUsed float64          `json:"percent_used"` // Actual as a percentage of planned
+	Projected   []*models.Amount `json:"projected"`    // Spend at the end of the period at the current daily run rate
+}
+
+// StatusResponse represents the response structure for budget status.
+// Budgets that start after the requested date are left out.
+type StatusResponse struct {
+	Date  models.Date     `json:"date"`
+	Items []*BudgetStatus `json:"items"`
+}
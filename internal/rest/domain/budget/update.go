@@ -0,0 +1,20 @@
+package budget
+
+import (
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+)
+
+// UpdateRequest represents the request structure for updating a budget.
+// Omitted fields keep their value; what the budget covers, its period and
+// its currency cannot change.
+type UpdateRequest struct {
+	BudgetID string       `json:"budget_id" binding:"required"`
+	Amount   *float64     `json:"amount,omitempty"`
+	Rollover *bool        `json:"rollover,omitempty"`
+	StartsOn *models.Date `json:"starts_on,omitempty"` // Any day of the first period
+}
+
+// UpdateResponse represents the response structure for updating a budget
+type UpdateResponse struct {
+	Budget
+}
@@ -0,0 +1,15 @@
+package category
+
+import "time"
+
+// Category represents a catalogue category
+type Category struct {
+	CategoryID string    `json:"category_id"`
+	ParentID   string    `json:"parent_id,omitempty"`
+	Name       string    `json:"name"`
+	Path       []string  `json:"path"` // Names from the root down to this category
+	Icon       string    `json:"icon,omitempty"`
+	Color      string    `json:"color,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
@@ -0,0 +1,14 @@
+package category
+
+// CreateRequest represents the request structure for creating a category
+type CreateRequest struct {
+	ParentID string `json:"parent_id,omitempty"` // Optional: empty for a top level category
+	Name     string `json:"name" binding:"required,max=100"`
+	Icon     string `json:"icon,omitempty" binding:"max=64"`
+	Color    string `json:"color,omitempty"` // #rrggbb
+}
+
+// CreateResponse represents the response structure for creating a category
+type CreateResponse struct {
+	Category
+}
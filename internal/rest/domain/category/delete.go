@@ -0,0 +1,13 @@
+package category
+
+// DeleteRequest represents the request structure for deleting a category.
+// Categories with subcategories or records cannot be deleted; merge them instead.
+type DeleteRequest struct {
+	CategoryID string `json:"category_id" binding:"required"`
+}
+
+// DeleteResponse represents the response structure for deleting a category
+type DeleteResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,11 @@
+package category
+
+// GetRequest represents the request structure for fetching a category
+type GetRequest struct {
+	CategoryID string `json:"category_id" binding:"required"`
+}
+
+// GetResponse represents the response structure for fetching a category
+type GetResponse struct {
+	Category
+}
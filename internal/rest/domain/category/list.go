@@ -0,0 +1,10 @@
+package category
+
+// ListRequest represents the request structure for listing categories
+type ListRequest struct{}
+
+// ListResponse represents the response structure for listing categories.
+// Parents come before their children.
+type ListResponse struct {
+	Items []*Category `json:"items"`
+}
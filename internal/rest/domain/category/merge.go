@@ -0,0 +1,14 @@
+package category
+
+// MergeRequest represents the request structure for merging one category into another
+type MergeRequest struct {
+	SourceID string `json:"source_id" binding:"required"` // Deleted once its records are moved
+	TargetID string `json:"target_id" binding:"required"`
+}
+
+// MergeResponse represents the response structure for merging categories
+type MergeResponse struct {
+	Target        Category `json:"target"`
+	MovedIncomes  int      `json:"moved_incomes"`
+	MovedExpenses int      `json:"moved_expenses"`
+}
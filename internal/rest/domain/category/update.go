@@ -0,0 +1,16 @@
+package category
+
+// UpdateRequest represents the request structure for updating a category.
+// Omitted fields keep their value.
+type UpdateRequest struct {
+	CategoryID string  `json:"category_id" binding:"required"`
+	ParentID   *string `json:"parent_id,omitempty"` // Empty string moves the category to the top level
+	Name       string  `json:"name,omitempty" binding:"max=100"`
+	Icon       *string `json:"icon,omitempty" binding:"omitempty,max=64"`
+	Color      *string `json:"color,omitempty"` // #rrggbb, empty string clears it
+}
+
+// UpdateResponse represents the response structure for updating a category
+type UpdateResponse struct {
+	Category
+}
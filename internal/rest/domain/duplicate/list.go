@@ -0,0 +1,51 @@
+package duplicate
+
+import (
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+)
+
+// ListRequest represents the request structure for listing suspected duplicates
+type ListRequest struct {
+	Direction string       `json:"direction,omitempty"`  // Optional: income or expense
+	From      *models.Date `json:"from,omitempty"`       // Optional: first day, inclusive
+	To        *models.Date `json:"to,omitempty"`         // Optional: last day, inclusive
+	Account   string       `json:"account_id,omitempty"` // Optional: account
+	Days      int          `json:"days,omitempty"`       // Optional: most days between the two dates; defaults to 3
+	MinScore  *float64     `json:"min_score,omitempty"`  // Optional: lowest score, 0 to 1; defaults to 0.7
+	Limit     int          `json:"limit,omitempty"`
+	Offset    int          `json:"offset,omitempty"`
+}
+
+// Record represents one record of a suspected pair
+type Record struct {
+	ID         string           `json:"id"`
+	Name       string           `json:"name"`
+	Amount     []*models.Amount `json:"amount"`
+	Type       string           `json:"type"`
+	Date       models.Date      `json:"date"`
+	CreatedAt  models.Date      `json:"created_at"`
+	CategoryID string           `json:"category_id,omitempty"`
+	AccountID  string           `json:"account_id,omitempty"`
+	Tags       []string         `json:"tags,omitempty"`
+	Version    int64            `json:"version"`
+}
+
+// Pair represents two incomes or two expenses that may be one money
+// movement entered twice. Score weighs how close the amounts and dates are
+// and how alike the names; each part is scored from 0 to 1 as well.
+type Pair struct {
+	Direction   string    `json:"direction"`
+	Score       float64   `json:"score"`
+	AmountScore float64   `json:"amount_score"`
+	DateScore   float64   `json:"date_score"`
+	NameScore   float64   `json:"name_score"`
+	Records     []*Record `json:"records"` // The later record first
+}
+
+// ListResponse represents the response structure for listing suspected duplicates
+type ListResponse struct {
+	Items      []*Pair `json:"items"`
+	TotalCount int     `json:"total_count"`
+	Limit      int     `json:"limit"`
+	Offset     int     `json:"offset"`
+}
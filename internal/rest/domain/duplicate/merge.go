@@ -0,0 +1,17 @@
+package duplicate
+
+// MergeRequest represents the request structure for merging two duplicates
+type MergeRequest struct {
+	Direction string `json:"direction" binding:"required"` // income or expense
+	KeepID    string `json:"keep_id" binding:"required"`
+	RemoveID  string `json:"remove_id" binding:"required"` // Moved to the trash once the kept record carries its tags
+}
+
+// MergeResponse represents the response structure for merging two duplicates
+type MergeResponse struct {
+	Direction string   `json:"direction"`
+	KeepID    string   `json:"keep_id"`
+	RemovedID string   `json:"removed_id"`
+	Tags      []string `json:"tags,omitempty"` // Tags of the kept record after the merge
+	Version   int64    `json:"version"`        // Version of the kept record after the merge
+}
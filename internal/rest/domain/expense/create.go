@@ -8,8 +8,24 @@ import (
 type CreateRequest struct {
 	ExpenseName   string           `json:"expense_name" binding:"required"`
 	ExpenseAmount []*models.Amount `json:"expense_amount" binding:"required"`
-	ExpenseType   string           `json:"expense_type" binding:"required"`
+	ExpenseType   string           `json:"expense_type" binding:"required_without=CategoryID"`
 	ExpenseDate   models.Date      `json:"expense_date" binding:"required"`
+
+	// CategoryID picks a catalogue category; the type then becomes its name.
+	CategoryID string `json:"category_id,omitempty"`
+
+	// AccountID links the record to an account of the caller's workspace.
+	AccountID string `json:"account_id,omitempty"`
+
+	// Tags are created in the caller's workspace on first use.
+	Tags []string `json:"tags,omitempty"`
+
+	// CheckDuplicates looks for existing records the new one may repeat and
+	// reports them in the reply; the record is created either way.
+	CheckDuplicates bool `json:"check_duplicates,omitempty"`
+
+	// Splits spreads the amount over several categories.
+	Splits []*Split `json:"splits,omitempty"`
 }
 
 // CreateResponse represents the response structure for creating an expense
@@ -20,4 +36,12 @@ type CreateResponse struct {
 	ExpenseType   string           `json:"expense_type"`
 	ExpenseDate   models.Date      `json:"expense_date"`
 	CreatedAt     models.Date      `json:"created_at"`
+	CategoryID    string           `json:"category_id,omitempty"`
+	AccountID     string           `json:"account_id,omitempty"`
+	Tags          []string         `json:"tags,omitempty"`
+	Splits        []*Split         `json:"splits,omitempty"`
+	Version       int64            `json:"version"`
+
+	// Duplicates lists likely duplicates when the request asked for them.
+	Duplicates []*models.Duplicate `json:"duplicates,omitempty"`
 }
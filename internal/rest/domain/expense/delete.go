@@ -3,6 +3,9 @@ package expense
 // DeleteRequest represents the request structure for deleting an expense
 type DeleteRequest struct {
 	ExpenseID string `json:"expense_id" binding:"required"`
+
+	// IfMatch carries the If-Match header; a stale version fails the request with 412.
+	IfMatch string `json:"-"`
 }
 
 // DeleteResponse represents the response structure for deleting an expense
@@ -17,4 +17,9 @@ type GetResponse struct {
 	ExpenseType   string           `json:"expense_type"`
 	ExpenseDate   models.Date      `json:"expense_date"`
 	CreatedAt     models.Date      `json:"created_at"`
+	CategoryID    string           `json:"category_id,omitempty"`
+	AccountID     string           `json:"account_id,omitempty"`
+	Tags          []string         `json:"tags,omitempty"`
+	Splits        []*Split         `json:"splits,omitempty"`
+	Version       int64            `json:"version"`
 }
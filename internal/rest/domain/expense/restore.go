@@ -0,0 +1,13 @@
+package expense
+
+// RestoreRequest represents the request structure for restoring an expense from the trash
+type RestoreRequest struct {
+	ExpenseID string `json:"expense_id" binding:"required"`
+}
+
+// RestoreResponse represents the response structure for restoring an expense
+type RestoreResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	Version int64  `json:"version"`
+}
@@ -0,0 +1,13 @@
+package expense
+
+import (
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+)
+
+// Split represents one split line of an expense. The lines of an expense
+// add up to its amount.
+type Split struct {
+	Amount     []*models.Amount `json:"amount"`
+	CategoryID string           `json:"category_id,omitempty"` // Optional: defaults to the category of the expense
+	Note       string           `json:"note,omitempty"`
+}
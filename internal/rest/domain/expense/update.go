@@ -11,6 +11,22 @@ type UpdateRequest struct {
 	ExpenseAmount []*models.Amount `json:"expense_amount,omitempty"`
 	ExpenseType   string           `json:"expense_type,omitempty"`
 	ExpenseDate   *models.Date     `json:"expense_date,omitempty"`
+
+	// CategoryID picks a catalogue category; the type then becomes its name.
+	// Setting a type without a category detaches the record from the catalogue.
+	CategoryID string `json:"category_id,omitempty"`
+
+	// AccountID moves the record to another account when present; "" unlinks it.
+	AccountID *string `json:"account_id,omitempty"`
+
+	// Tags replaces every tag of the record when present; [] removes them all.
+	Tags []string `json:"tags"`
+
+	// Splits replaces every split line when present; [] removes the split.
+	Splits []*Split `json:"splits"`
+
+	// IfMatch carries the If-Match header; a stale version fails the request with 412.
+	IfMatch string `json:"-"`
 }
 
 // UpdateResponse represents the response structure for updating an expense
@@ -21,4 +37,9 @@ type UpdateResponse struct {
 	ExpenseType   string           `json:"expense_type"`
 	ExpenseDate   models.Date      `json:"expense_date"`
 	UpdatedAt     models.Date      `json:"updated_at"`
+	CategoryID    string           `json:"category_id,omitempty"`
+	AccountID     string           `json:"account_id,omitempty"`
+	Tags          []string         `json:"tags,omitempty"`
+	Splits        []*Split         `json:"splits,omitempty"`
+	Version       int64            `json:"version"`
 }
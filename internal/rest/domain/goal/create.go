@@ -0,0 +1,22 @@
+package goal
+
+import (
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+)
+
+// CreateRequest represents the request structure for creating a goal.
+// Exactly one of AccountID and TagID is required.
+type CreateRequest struct {
+	Name          string       `json:"name" binding:"required,max=255"`
+	TargetAmount  float64      `json:"target_amount" binding:"required"`
+	TargetDate    *models.Date `json:"target_date,omitempty"`
+	AccountID     string       `json:"account_id,omitempty"`
+	TagID         string       `json:"tag_id,omitempty"`
+	InitialAmount float64      `json:"initial_amount,omitempty"`
+	StartsOn      *models.Date `json:"starts_on,omitempty"` // Optional: defaults to today
+}
+
+// CreateResponse represents the response structure for creating a goal
+type CreateResponse struct {
+	Goal
+}
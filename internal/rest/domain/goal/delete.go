@@ -0,0 +1,13 @@
+package goal
+
+// DeleteRequest represents the request structure for deleting a goal.
+// Records counted towards it are kept.
+type DeleteRequest struct {
+	GoalID string `json:"goal_id" binding:"required"`
+}
+
+// DeleteResponse represents the response structure for deleting a goal
+type DeleteResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,11 @@
+package goal
+
+// GetRequest represents the request structure for fetching a goal
+type GetRequest struct {
+	GoalID string `json:"goal_id" binding:"required"`
+}
+
+// GetResponse represents the response structure for fetching a goal
+type GetResponse struct {
+	Goal
+}
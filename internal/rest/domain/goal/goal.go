@@ -0,0 +1,34 @@
+package goal
+
+import (
+	"time"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+)
+
+// Goal represents money put aside for a target, following an account or a tag
+type Goal struct {
+	GoalID        string           `json:"goal_id"`
+	Name          string           `json:"name"`
+	TargetAmount  []*models.Amount `json:"target_amount"`
+	TargetDate    *models.Date     `json:"target_date,omitempty"`
+	AccountID     string           `json:"account_id,omitempty"` // Incomes and transfers in count, transfers out are taken off
+	TagID         string           `json:"tag_id,omitempty"`     // Incomes carrying the tag count
+	InitialAmount []*models.Amount `json:"initial_amount"`       // Saved before starts_on
+	StartsOn      models.Date      `json:"starts_on"`
+	Progress      Progress         `json:"progress"`
+	CreatedAt     time.Time        `json:"created_at"`
+	UpdatedAt     time.Time        `json:"updated_at"`
+}
+
+// Progress is how far a goal has come as of today
+type Progress struct {
+	Saved             []*models.Amount `json:"saved"`     // Initial amount plus contributions since starts_on
+	Remaining         []*models.Amount `json:"remaining"` // Zero once reached
+	PercentComplete   float64          `json:"percent_complete"`
+	Reached           bool             `json:"reached"`
+	RecentMonthlyRate []*models.Amount `json:"recent_monthly_rate"`                 // Average monthly contribution over the last 90 days
+	MonthlyNeeded     []*models.Amount `json:"monthly_needed,omitempty"`            // To reach the target by the target date; omitted without one or once reached
+	ProjectedOn       *models.Date     `json:"projected_completion_date,omitempty"` // At the recent rate; omitted once reached or when the rate is not positive
+	OnTrack           *bool            `json:"on_track,omitempty"`                  // Whether the projected date is on or before the target date
+}
@@ -0,0 +1,9 @@
+package goal
+
+// ListRequest represents the request structure for listing goals
+type ListRequest struct{}
+
+// ListResponse represents the response structure for listing goals
+type ListResponse struct {
+	Items []*Goal `json:"items"`
+}
@@ -0,0 +1,24 @@
+package goal
+
+import (
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+)
+
+// UpdateRequest represents the request structure for updating a goal.
+// Omitted fields keep their value. Setting AccountID or TagID switches the
+// goal over to it.
+type UpdateRequest struct {
+	GoalID        string       `json:"goal_id" binding:"required"`
+	Name          *string      `json:"name,omitempty" binding:"omitempty,max=255"`
+	TargetAmount  *float64     `json:"target_amount,omitempty"`
+	TargetDate    *models.Date `json:"target_date,omitempty"` // Empty string clears it
+	AccountID     *string      `json:"account_id,omitempty"`
+	TagID         *string      `json:"tag_id,omitempty"`
+	InitialAmount *float64     `json:"initial_amount,omitempty"`
+	StartsOn      *models.Date `json:"starts_on,omitempty"`
+}
+
+// UpdateResponse represents the response structure for updating a goal
+type UpdateResponse struct {
+	Goal
+}
@@ -0,0 +1,23 @@
+package imports
+
+import "time"
+
+// Batch is a committed import
+type Batch struct {
+	BatchID      string     `json:"batch_id"`
+	Source       string     `json:"source"` // csv, ofx, qif, camt053, mt940, beancount or ledger
+	FileName     string     `json:"file_name,omitempty"`
+	ProfileID    string     `json:"profile_id,omitempty"`
+	TotalRows    int        `json:"total_rows"`
+	CreatedRows  int        `json:"created_rows"`
+	CreatedAt    time.Time  `json:"created_at"`
+	RolledBackAt *time.Time `json:"rolled_back_at,omitempty"`
+}
+
+// BatchesRequest represents the request structure for listing import batches
+type BatchesRequest struct{}
+
+// BatchesResponse represents the response structure for listing import batches
+type BatchesResponse struct {
+	Items []*Batch `json:"items"`
+}
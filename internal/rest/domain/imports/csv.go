@@ -0,0 +1,12 @@
+package imports
+
+// CSVRequest represents the request structure for importing a CSV file.
+// Exactly one of ProfileID and Mapping is required.
+type CSVRequest struct {
+	ProfileID string   `json:"profile_id,omitempty"`
+	Mapping   *Mapping `json:"mapping,omitempty"` // Optional: used as is instead of a saved profile
+	Content   string   `json:"content" binding:"required"`
+	FileName  string   `json:"file_name,omitempty" binding:"max=255"`
+	AccountID string   `json:"account_id,omitempty"` // Optional: account every record is linked to
+	DryRun    bool     `json:"dry_run,omitempty"`    // Preview only; nothing is saved
+}
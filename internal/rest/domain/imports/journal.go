@@ -0,0 +1,17 @@
+package imports
+
+// JournalRequest represents the request structure for importing a Beancount
+// or Ledger (hledger) journal. Postings out of income accounts become
+// incomes and postings into expense accounts expenses; transactions whose
+// id metadata was imported before are skipped. The maps are keyed by journal
+// account name, such as Expenses:Food or Assets:Checking.
+type JournalRequest struct {
+	Content     string            `json:"content" binding:"required"`
+	FileName    string            `json:"file_name,omitempty" binding:"max=255"`
+	AccountID   string            `json:"account_id,omitempty"`                     // Optional: account of records paid into or from unmapped accounts
+	Accounts    map[string]string `json:"accounts,omitempty"`                       // Optional: asset or liability account to account ID
+	Types       map[string]string `json:"types,omitempty"`                          // Optional: income or expense account to record type
+	Categories  map[string]string `json:"categories,omitempty"`                     // Optional: income or expense account to category ID
+	DefaultType string            `json:"default_type,omitempty" binding:"max=255"` // Optional: type of unmapped accounts; defaults to the exported type or the account's last part
+	DryRun      bool              `json:"dry_run,omitempty"`                        // Preview only; nothing is saved
+}
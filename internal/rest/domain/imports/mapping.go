@@ -0,0 +1,35 @@
+package imports
+
+// Sign conventions, telling incomes from expenses.
+const (
+	SignNegativeExpense = "negative_expense" // negative amounts are expenses, positive ones incomes
+	SignNegativeIncome  = "negative_income"  // negative amounts are incomes, positive ones expenses, as on card statements
+	SignDebitCredit     = "debit_credit"     // separate debit (expense) and credit (income) columns
+	SignExpense         = "expense"          // every row is an expense
+	SignIncome          = "income"           // every row is an income
+)
+
+// Mapping describes how the rows of a bank's CSV files map onto incomes and
+// expenses. Columns are named by their header, ignoring case, or by their
+// 1-based position.
+type Mapping struct {
+	Delimiter        string  `json:"delimiter,omitempty"`         // Optional: one character; defaults to a comma
+	NoHeader         bool    `json:"no_header,omitempty"`         // The file has no header row; columns are positions
+	SkipRows         int     `json:"skip_rows,omitempty"`         // Lines to skip before the header, such as account details
+	Columns          Columns `json:"columns"`                     // Which column holds what
+	DateFormat       string  `json:"date_format"`                 // Made of YYYY, YY, MM, M, MMM, DD and D, for example DD.MM.YYYY
+	DecimalSeparator string  `json:"decimal_separator,omitempty"` // Optional: "." (default) or ","
+	Sign             string  `json:"sign,omitempty"`              // Optional: one of the Sign values; defaults to negative_expense
+	DefaultType      string  `json:"default_type,omitempty"`      // Optional: type of rows without one
+}
+
+// Columns names the columns read from the file. Amount is required unless
+// Sign is debit_credit, which reads Debit and Credit instead.
+type Columns struct {
+	Name   string `json:"name"`
+	Amount string `json:"amount,omitempty"`
+	Debit  string `json:"debit,omitempty"`
+	Credit string `json:"credit,omitempty"`
+	Date   string `json:"date"`
+	Type   string `json:"type,omitempty"`
+}
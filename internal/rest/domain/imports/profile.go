@@ -0,0 +1,12 @@
+package imports
+
+import "time"
+
+// Profile is a saved mapping, usually one per bank
+type Profile struct {
+	ProfileID string    `json:"profile_id"`
+	Name      string    `json:"name"`
+	Mapping   Mapping   `json:"mapping"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,46 @@
+package imports
+
+// ListProfilesRequest represents the request structure for listing import profiles
+type ListProfilesRequest struct{}
+
+// ListProfilesResponse represents the response structure for listing import profiles
+type ListProfilesResponse struct {
+	Items []*Profile `json:"items"`
+}
+
+// CreateProfileRequest represents the request structure for creating an import profile
+type CreateProfileRequest struct {
+	Name    string  `json:"name" binding:"required,max=255"`
+	Mapping Mapping `json:"mapping"`
+}
+
+// CreateProfileResponse represents the response structure for creating an import profile
+type CreateProfileResponse struct {
+	Profile
+}
+
+// UpdateProfileRequest represents the request structure for updating an
+// import profile. Omitted fields keep their value; a mapping replaces the
+// whole mapping.
+type UpdateProfileRequest struct {
+	ProfileID string   `json:"profile_id" binding:"required"`
+	Name      *string  `json:"name,omitempty" binding:"omitempty,max=255"`
+	Mapping   *Mapping `json:"mapping,omitempty"`
+}
+
+// UpdateProfileResponse represents the response structure for updating an import profile
+type UpdateProfileResponse struct {
+	Profile
+}
+
+// DeleteProfileRequest represents the request structure for deleting an
+// import profile. Batches imported with it are kept.
+type DeleteProfileRequest struct {
+	ProfileID string `json:"profile_id" binding:"required"`
+}
+
+// DeleteProfileResponse represents the response structure for deleting an import profile
+type DeleteProfileResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,43 @@
+package imports
+
+import (
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+)
+
+// Row statuses.
+const (
+	StatusValid     = "valid"     // would be created; dry runs only
+	StatusCreated   = "created"   // created by the import
+	StatusInvalid   = "invalid"   // could not be read from the file
+	StatusFailed    = "failed"    // read but rejected by the create service
+	StatusDuplicate = "duplicate" // already imported, going by the bank's transaction ID
+)
+
+// Row is one line of the file as it was read and what became of it
+type Row struct {
+	Line       int              `json:"line"` // 1-based line in the file
+	Status     string           `json:"status"`
+	Direction  string           `json:"direction,omitempty"` // income or expense
+	Name       string           `json:"name,omitempty"`
+	Amount     []*models.Amount `json:"amount,omitempty"`
+	Date       *models.Date     `json:"date,omitempty"`
+	Type       string           `json:"type,omitempty"`
+	ExternalID string           `json:"external_id,omitempty"` // The bank's ID of the transaction, such as an OFX FITID
+	RecordID   string           `json:"record_id,omitempty"`
+	Error      string           `json:"error,omitempty"`
+}
+
+// ImportResponse represents the response structure for an import. Valid
+// rows are created together in one transaction; invalid, failed and
+// duplicate rows are left out. A dry run reports the same without saving anything.
+type ImportResponse struct {
+	BatchID   string `json:"batch_id,omitempty"` // Set once committed; rolls the import back
+	DryRun    bool   `json:"dry_run"`
+	Committed bool   `json:"committed"`
+	Total     int    `json:"total"`
+	Valid     int    `json:"valid"` // Rows created, or that a commit would create
+	Invalid   int    `json:"invalid"`
+	Failed    int    `json:"failed"`
+	Duplicate int    `json:"duplicate"`
+	Rows      []*Row `json:"rows"`
+}
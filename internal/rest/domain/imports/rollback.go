@@ -0,0 +1,15 @@
+package imports
+
+// RollbackRequest represents the request structure for rolling an import
+// back. Its incomes and expenses are deleted like single records, so they
+// go to the trash; records deleted since the import are skipped.
+type RollbackRequest struct {
+	BatchID string `json:"batch_id" binding:"required"`
+}
+
+// RollbackResponse represents the response structure for rolling an import back
+type RollbackResponse struct {
+	Batch
+	Deleted int `json:"deleted"`
+	Skipped int `json:"skipped"`
+}
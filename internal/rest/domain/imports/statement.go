@@ -0,0 +1,53 @@
+package imports
+
+// OFXRequest represents the request structure for importing an OFX or QFX
+// statement, version 1.x or 2.x. Credits become incomes and debits
+// expenses; transactions whose FITID was imported before are skipped.
+type OFXRequest struct {
+	Content     string `json:"content" binding:"required"`
+	FileName    string `json:"file_name,omitempty" binding:"max=255"`
+	AccountID   string `json:"account_id,omitempty"`                     // Optional: account every record is linked to
+	DefaultType string `json:"default_type,omitempty" binding:"max=255"` // Optional: type of every record; defaults to the transaction type
+	DryRun      bool   `json:"dry_run,omitempty"`                        // Preview only; nothing is saved
+}
+
+// QIFRequest represents the request structure for importing a QIF file.
+// Positive amounts become incomes and negative ones expenses. QIF has no
+// transaction IDs, so duplicates are not detected.
+type QIFRequest struct {
+	Content          string `json:"content" binding:"required"`
+	FileName         string `json:"file_name,omitempty" binding:"max=255"`
+	AccountID        string `json:"account_id,omitempty"`                     // Optional: account every record is linked to
+	DefaultType      string `json:"default_type,omitempty" binding:"max=255"` // Optional: type of records without a category
+	DateFormat       string `json:"date_format,omitempty"`                    // Optional: as in Mapping; defaults to Quicken's month-first dates
+	DecimalSeparator string `json:"decimal_separator,omitempty"`              // Optional: "." (default) or ","
+	DryRun           bool   `json:"dry_run,omitempty"`                        // Preview only; nothing is saved
+}
+
+// CAMTRequest represents the request structure for importing an ISO 20022
+// camt.053 bank statement. Booked credits become incomes and debits
+// expenses in the currency of the entry; entries whose bank reference was
+// imported before are skipped. Statements with entries in another currency
+// than their account are refused.
+type CAMTRequest struct {
+	Content     string            `json:"content" binding:"required"`
+	FileName    string            `json:"file_name,omitempty" binding:"max=255"`
+	AccountID   string            `json:"account_id,omitempty"`                     // Optional: account records are linked to
+	Accounts    map[string]string `json:"accounts,omitempty"`                       // Optional: IBAN or account number to account ID, for files of several accounts
+	DefaultType string            `json:"default_type,omitempty" binding:"max=255"` // Optional: type of every record; defaults to credit or debit
+	DryRun      bool              `json:"dry_run,omitempty"`                        // Preview only; nothing is saved
+}
+
+// MT940Request represents the request structure for importing a SWIFT MT940
+// bank statement. Credits become incomes and debits expenses in the
+// currency of the statement; lines whose bank reference was imported before
+// are skipped. The statement is refused if its currency is not that of the
+// account it goes to.
+type MT940Request struct {
+	Content     string            `json:"content" binding:"required"`
+	FileName    string            `json:"file_name,omitempty" binding:"max=255"`
+	AccountID   string            `json:"account_id,omitempty"`                     // Optional: account records are linked to
+	Accounts    map[string]string `json:"accounts,omitempty"`                       // Optional: IBAN or account number to account ID, for files of several accounts
+	DefaultType string            `json:"default_type,omitempty" binding:"max=255"` // Optional: type of every record; defaults to credit or debit
+	DryRun      bool              `json:"dry_run,omitempty"`                        // Preview only; nothing is saved
+}
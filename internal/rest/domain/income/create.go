@@ -9,6 +9,19 @@ type CreateRequest struct {
 	IncomeAmount []*models.Amount `json:"income_amount"`
 	IncomeType   string           `json:"income_type"`
 	IncomeDate   models.Date      `json:"income_date"`
+
+	// CategoryID picks a catalogue category; the type then becomes its name.
+	CategoryID string `json:"category_id,omitempty"`
+
+	// AccountID links the record to an account of the caller's workspace.
+	AccountID string `json:"account_id,omitempty"`
+
+	// Tags are created in the caller's workspace on first use.
+	Tags []string `json:"tags,omitempty"`
+
+	// CheckDuplicates looks for existing records the new one may repeat and
+	// reports them in the reply; the record is created either way.
+	CheckDuplicates bool `json:"check_duplicates,omitempty"`
 }
 
 type CreateResponse struct {
@@ -18,4 +31,11 @@ type CreateResponse struct {
 	IncomeType   string           `json:"income_type"`
 	IncomeDate   models.Date      `json:"income_date"`
 	CreatedAt    models.Date      `json:"created_at"`
+	CategoryID   string           `json:"category_id,omitempty"`
+	AccountID    string           `json:"account_id,omitempty"`
+	Tags         []string         `json:"tags,omitempty"`
+	Version      int64            `json:"version"`
+
+	// Duplicates lists likely duplicates when the request asked for them.
+	Duplicates []*models.Duplicate `json:"duplicates,omitempty"`
 }
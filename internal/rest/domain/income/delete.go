@@ -3,6 +3,9 @@ package income
 // DeleteRequest represents the request structure for deleting an income
 type DeleteRequest struct {
 	IncomeID string `json:"income_id" binding:"required"`
+
+	// IfMatch carries the If-Match header; a stale version fails the request with 412.
+	IfMatch string `json:"-"`
 }
 
 // DeleteResponse represents the response structure for deleting an income
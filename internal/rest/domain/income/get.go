@@ -15,4 +15,8 @@ type GetResponse struct {
 	IncomeType   string           `json:"income_type"`
 	IncomeDate   models.Date      `json:"income_date"`
 	CreatedAt    models.Date      `json:"created_at"`
+	CategoryID   string           `json:"category_id,omitempty"`
+	AccountID    string           `json:"account_id,omitempty"`
+	Tags         []string         `json:"tags,omitempty"`
+	Version      int64            `json:"version"`
 }
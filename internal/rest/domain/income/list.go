@@ -6,10 +6,12 @@ import (
 
 // ListRequest represents the request structure for listing incomes
 type ListRequest struct {
-	Limit  int    `json:"limit,omitempty"`   // Optional: limit number of results
-	Offset int    `json:"offset,omitempty"`  // Optional: offset for pagination
-	SortBy string `json:"sort_by,omitempty"` // Optional: field to sort by
-	Order  string `json:"order,omitempty"`   // Optional: asc or desc
+	Limit   int      `json:"limit,omitempty"`    // Optional: limit number of results
+	Offset  int      `json:"offset,omitempty"`   // Optional: offset for pagination
+	SortBy  string   `json:"sort_by,omitempty"`  // Optional: field to sort by
+	Order   string   `json:"order,omitempty"`    // Optional: asc or desc
+	Tags    []string `json:"tags,omitempty"`     // Optional: tag names
+	TagMode string   `json:"tag_mode,omitempty"` // Optional: any (default) or all of the tags
 }
 
 // ListItem represents a single income item in the list
@@ -20,6 +22,10 @@ type ListItem struct {
 	IncomeType   string           `json:"income_type"`
 	IncomeDate   models.Date      `json:"income_date"`
 	CreatedAt    models.Date      `json:"created_at"`
+	CategoryID   string           `json:"category_id,omitempty"`
+	AccountID    string           `json:"account_id,omitempty"`
+	Tags         []string         `json:"tags,omitempty"`
+	Version      int64            `json:"version"`
 }
 
 // ListResponse represents the response structure for listing incomes
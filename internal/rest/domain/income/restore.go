@@ -0,0 +1,13 @@
+package income
+
+// RestoreRequest represents the request structure for restoring an income from the trash
+type RestoreRequest struct {
+	IncomeID string `json:"income_id" binding:"required"`
+}
+
+// RestoreResponse represents the response structure for restoring an income
+type RestoreResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	Version int64  `json:"version"`
+}
@@ -11,6 +11,19 @@ type UpdateRequest struct {
 	IncomeAmount []*models.Amount `json:"income_amount,omitempty"`
 	IncomeType   string           `json:"income_type,omitempty"`
 	IncomeDate   *models.Date     `json:"income_date,omitempty"`
+
+	// CategoryID picks a catalogue category; the type then becomes its name.
+	// Setting a type without a category detaches the record from the catalogue.
+	CategoryID string `json:"category_id,omitempty"`
+
+	// AccountID moves the record to another account when present; "" unlinks it.
+	AccountID *string `json:"account_id,omitempty"`
+
+	// Tags replaces every tag of the record when present; [] removes them all.
+	Tags []string `json:"tags"`
+
+	// IfMatch carries the If-Match header; a stale version fails the request with 412.
+	IfMatch string `json:"-"`
 }
 
 // UpdateResponse represents the response structure for updating an income
@@ -21,4 +34,8 @@ type UpdateResponse struct {
 	IncomeType   string           `json:"income_type"`
 	IncomeDate   models.Date      `json:"income_date"`
 	UpdatedAt    models.Date      `json:"updated_at"`
+	CategoryID   string           `json:"category_id,omitempty"`
+	AccountID    string           `json:"account_id,omitempty"`
+	Tags         []string         `json:"tags,omitempty"`
+	Version      int64            `json:"version"`
 }
@@ -0,0 +1,11 @@
+package models
+
+// Duplicate is an existing income or expense that a new one may repeat.
+// Score is from 0 to 1.
+type Duplicate struct {
+	ID     string    `json:"id"`
+	Name   string    `json:"name"`
+	Amount []*Amount `json:"amount"`
+	Date   Date      `json:"date"`
+	Score  float64   `json:"score"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ETag formats a row version as a strong entity tag.
+func ETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// MatchETag reports whether an If-Match header value matches the given
+// version. The header may be "*" or a comma-separated list of tags. If-Match
+// uses the strong comparison, so weak tags (W/"...") never match.
+func MatchETag(header string, version int64) bool {
+	return matchETag(header, version, false)
+}
+
+// MatchWeakETag is MatchETag for If-None-Match, which uses the weak
+// comparison: weak tags compare by their opaque value.
+func MatchWeakETag(header string, version int64) bool {
+	return matchETag(header, version, true)
+}
+
+// IfMatch returns the check of an If-Match header value against a version,
+// or nil when the header is empty and any version will do.
+func IfMatch(header string) func(version int64) bool {
+	if header == "" {
+		return nil
+	}
+	return func(version int64) bool { return MatchETag(header, version) }
+}
+
+func matchETag(header string, version int64, weak bool) bool {
+	want := ETag(version)
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if weak {
+			tag = strings.TrimPrefix(tag, "W/")
+		}
+		if tag == "*" || tag == want {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,25 @@
+package recurring
+
+import (
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+)
+
+// CreateRequest represents the request structure for creating a recurring
+// template. Occurrences from StartsOn up to today are materialized by the
+// next scheduler run.
+type CreateRequest struct {
+	Direction  string      `json:"direction" binding:"required"` // income or expense
+	Name       string      `json:"name" binding:"required,max=255"`
+	Amount     float64     `json:"amount" binding:"required"`
+	Type       string      `json:"type,omitempty" binding:"required_without=CategoryID,max=255"`
+	CategoryID string      `json:"category_id,omitempty"` // Optional: the type then becomes the category name
+	AccountID  string      `json:"account_id,omitempty"`
+	Tags       []string    `json:"tags,omitempty"`
+	Rule       string      `json:"rule" binding:"required"`
+	StartsOn   models.Date `json:"starts_on" binding:"required"`
+}
+
+// CreateResponse represents the response structure for creating a recurring template
+type CreateResponse struct {
+	Recurring
+}
@@ -0,0 +1,13 @@
+package recurring
+
+// DeleteRequest represents the request structure for deleting a recurring
+// template. Records already created from it are kept.
+type DeleteRequest struct {
+	RecurringID string `json:"recurring_id" binding:"required"`
+}
+
+// DeleteResponse represents the response structure for deleting a recurring template
+type DeleteResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
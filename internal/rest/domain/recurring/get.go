@@ -0,0 +1,11 @@
+package recurring
+
+// GetRequest represents the request structure for fetching a recurring template
+type GetRequest struct {
+	RecurringID string `json:"recurring_id" binding:"required"`
+}
+
+// GetResponse represents the response structure for fetching a recurring template
+type GetResponse struct {
+	Recurring
+}
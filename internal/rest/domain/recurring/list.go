@@ -0,0 +1,9 @@
+package recurring
+
+// ListRequest represents the request structure for listing recurring templates
+type ListRequest struct{}
+
+// ListResponse represents the response structure for listing recurring templates
+type ListResponse struct {
+	Items []*Recurring `json:"items"`
+}
@@ -0,0 +1,32 @@
+package recurring
+
+import (
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+)
+
+// Occurrence statuses in a preview.
+const (
+	StatusScheduled = "scheduled"
+	StatusSkipped   = "skipped"
+)
+
+// PreviewRequest represents the request structure for previewing upcoming
+// occurrences, either of a saved template or of a rule that is not saved yet
+type PreviewRequest struct {
+	RecurringID string       `json:"recurring_id,omitempty"`
+	Rule        string       `json:"rule,omitempty"`      // Used without RecurringID
+	StartsOn    *models.Date `json:"starts_on,omitempty"` // Used without RecurringID
+	Count       int          `json:"count,omitempty"`     // Default 10, at most 100
+}
+
+// Occurrence is one upcoming date of a rule
+type Occurrence struct {
+	OccursOn models.Date `json:"occurs_on"`
+	Status   string      `json:"status"` // scheduled or skipped
+}
+
+// PreviewResponse represents the response structure for previewing upcoming occurrences
+type PreviewResponse struct {
+	Rule        string        `json:"rule"`
+	Occurrences []*Occurrence `json:"occurrences"`
+}
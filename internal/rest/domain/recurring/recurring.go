@@ -0,0 +1,27 @@
+package recurring
+
+import (
+	"time"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+)
+
+// Recurring represents a template that creates an income or an expense on
+// every occurrence of its rule
+type Recurring struct {
+	RecurringID string           `json:"recurring_id"`
+	Direction   string           `json:"direction"` // income or expense
+	Name        string           `json:"name"`
+	Amount      []*models.Amount `json:"amount"`
+	Type        string           `json:"type,omitempty"`
+	CategoryID  string           `json:"category_id,omitempty"`
+	AccountID   string           `json:"account_id,omitempty"`
+	Tags        []string         `json:"tags,omitempty"`
+	Rule        string           `json:"rule"` // RFC 5545 RRULE, for example FREQ=MONTHLY;BYDAY=-1FR
+	StartsOn    models.Date      `json:"starts_on"`
+	NextOn      *models.Date     `json:"next_on,omitempty"`    // Next occurrence to materialize; omitted once the rule has ended
+	LastError   string           `json:"last_error,omitempty"` // Why the next occurrence could not be materialized
+	RetryAt     *time.Time       `json:"retry_at,omitempty"`   // When materializing is tried again after an error
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
@@ -0,0 +1,18 @@
+package recurring
+
+import (
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+)
+
+// SkipRequest represents the request structure for skipping one occurrence
+// that has not been materialized yet
+type SkipRequest struct {
+	RecurringID string      `json:"recurring_id" binding:"required"`
+	OccursOn    models.Date `json:"occurs_on" binding:"required"`
+}
+
+// SkipResponse represents the response structure for skipping an occurrence
+type SkipResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,30 @@
+package recurring
+
+import (
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+)
+
+// SplitRequest represents the request structure for editing an occurrence
+// and every one after it. The template is ended the day before OccursOn and
+// a new template with the changes takes over from there; omitted fields keep
+// their value, and a COUNT carries over what is left of it.
+type SplitRequest struct {
+	RecurringID string       `json:"recurring_id" binding:"required"`
+	OccursOn    models.Date  `json:"occurs_on" binding:"required"`
+	Name        *string      `json:"name,omitempty" binding:"omitempty,max=255"`
+	Amount      *float64     `json:"amount,omitempty"`
+	Type        *string      `json:"type,omitempty" binding:"omitempty,max=255"`
+	CategoryID  *string      `json:"category_id,omitempty"` // Empty string clears it
+	AccountID   *string      `json:"account_id,omitempty"`  // Empty string clears it
+	Tags        []string     `json:"tags"`                  // Replaces every tag when present; [] removes them all
+	Rule        *string      `json:"rule,omitempty"`
+	StartsOn    *models.Date `json:"starts_on,omitempty"` // Optional: first day of the new template, not before OccursOn
+}
+
+// SplitResponse represents the response structure for a "this and
+// following" edit. Previous is omitted when OccursOn is the first
+// occurrence, in which case the template itself was edited.
+type SplitResponse struct {
+	Previous *Recurring `json:"previous,omitempty"`
+	Next     Recurring  `json:"next"`
+}
@@ -0,0 +1,25 @@
+package recurring
+
+import (
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+)
+
+// UpdateRequest represents the request structure for editing a whole
+// series. Omitted fields keep their value. Records already created from the
+// template are not changed.
+type UpdateRequest struct {
+	RecurringID string       `json:"recurring_id" binding:"required"`
+	Name        *string      `json:"name,omitempty" binding:"omitempty,max=255"`
+	Amount      *float64     `json:"amount,omitempty"`
+	Type        *string      `json:"type,omitempty" binding:"omitempty,max=255"`
+	CategoryID  *string      `json:"category_id,omitempty"` // Empty string clears it
+	AccountID   *string      `json:"account_id,omitempty"`  // Empty string clears it
+	Tags        []string     `json:"tags"`                  // Replaces every tag when present; [] removes them all
+	Rule        *string      `json:"rule,omitempty"`
+	StartsOn    *models.Date `json:"starts_on,omitempty"`
+}
+
+// UpdateResponse represents the response structure for editing a whole series
+type UpdateResponse struct {
+	Recurring
+}
@@ -0,0 +1,45 @@
+package report
+
+import (
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+)
+
+// CategoriesRequest represents the request structure for the category report
+type CategoriesRequest struct {
+	From      *models.Date `json:"from,omitempty"`      // Optional: first day, inclusive
+	To        *models.Date `json:"to,omitempty"`        // Optional: last day, inclusive
+	Direction string       `json:"direction,omitempty"` // Optional: income or expense
+	TopLevel  bool         `json:"top_level,omitempty"` // Optional: only top level categories
+}
+
+// CategoryTotals represents the totals of one category. The own amounts
+// count records filed directly under the category; the rolled up amounts
+// add every subcategory. Amounts in different currencies are not converted,
+// so each holds one amount per currency.
+type CategoryTotals struct {
+	CategoryID    string           `json:"category_id"`
+	ParentID      string           `json:"parent_id,omitempty"`
+	Name          string           `json:"name"`
+	Path          []string         `json:"path"`
+	Income        []*models.Amount `json:"income"`
+	Expense       []*models.Amount `json:"expense"`
+	Count         int              `json:"count"`
+	RollupIncome  []*models.Amount `json:"rollup_income"`
+	RollupExpense []*models.Amount `json:"rollup_expense"`
+	RollupCount   int              `json:"rollup_count"`
+}
+
+// UncategorizedTotals represents the totals of records outside the catalogue
+type UncategorizedTotals struct {
+	Income  []*models.Amount `json:"income"`
+	Expense []*models.Amount `json:"expense"`
+	Count   int              `json:"count"`
+}
+
+// CategoriesResponse represents the response structure for the category report
+type CategoriesResponse struct {
+	From          *models.Date         `json:"from,omitempty"`
+	To            *models.Date         `json:"to,omitempty"`
+	Items         []*CategoryTotals    `json:"items"`
+	Uncategorized *UncategorizedTotals `json:"uncategorized"`
+}
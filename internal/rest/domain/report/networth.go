@@ -0,0 +1,29 @@
+package report
+
+import (
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+)
+
+// NetWorthRequest represents the request structure for the net worth report
+type NetWorthRequest struct {
+	AsOf *models.Date `json:"as_of,omitempty"` // Optional: last day counted, inclusive; defaults to every record
+}
+
+// AccountBalance represents the balance of one account
+type AccountBalance struct {
+	AccountID string           `json:"account_id"`
+	Name      string           `json:"name"`
+	Kind      string           `json:"kind"`
+	Balance   []*models.Amount `json:"balance"`
+}
+
+// NetWorthResponse represents the response structure for the net worth
+// report. Balances in different currencies are not converted, so net worth,
+// assets and liabilities hold one amount per currency.
+type NetWorthResponse struct {
+	AsOf        *models.Date      `json:"as_of,omitempty"`
+	Accounts    []*AccountBalance `json:"accounts"`
+	Assets      []*models.Amount  `json:"assets"`      // Sum of the positive balances
+	Liabilities []*models.Amount  `json:"liabilities"` // Sum of the negative balances, as a positive amount
+	NetWorth    []*models.Amount  `json:"net_worth"`
+}
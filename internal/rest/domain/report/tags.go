@@ -0,0 +1,30 @@
+package report
+
+import (
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+)
+
+// TagsRequest represents the request structure for the tag report
+type TagsRequest struct {
+	From      *models.Date `json:"from,omitempty"`      // Optional: first day, inclusive
+	To        *models.Date `json:"to,omitempty"`        // Optional: last day, inclusive
+	Direction string       `json:"direction,omitempty"` // Optional: income or expense
+}
+
+// TagTotals represents the totals of the records carrying one tag. Records
+// with several tags count towards each of them. Amounts hold one amount per
+// currency.
+type TagTotals struct {
+	TagID   string           `json:"tag_id"`
+	Name    string           `json:"name"`
+	Income  []*models.Amount `json:"income"`
+	Expense []*models.Amount `json:"expense"`
+	Count   int              `json:"count"`
+}
+
+// TagsResponse represents the response structure for the tag report
+type TagsResponse struct {
+	From  *models.Date `json:"from,omitempty"`
+	To    *models.Date `json:"to,omitempty"`
+	Items []*TagTotals `json:"items"`
+}
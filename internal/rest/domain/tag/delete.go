@@ -0,0 +1,14 @@
+package tag
+
+// DeleteRequest represents the request structure for deleting a tag.
+// The tag is removed from every record carrying it. Tags that savings goals
+// follow cannot be deleted.
+type DeleteRequest struct {
+	TagID string `json:"tag_id" binding:"required"`
+}
+
+// DeleteResponse represents the response structure for deleting a tag
+type DeleteResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
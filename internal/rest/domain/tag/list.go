@@ -0,0 +1,9 @@
+package tag
+
+// ListRequest represents the request structure for listing tags
+type ListRequest struct{}
+
+// ListResponse represents the response structure for listing tags
+type ListResponse struct {
+	Items []*Tag `json:"items"`
+}
@@ -0,0 +1,13 @@
+package tag
+
+// MergeRequest represents the request structure for merging one tag into another
+type MergeRequest struct {
+	SourceID string `json:"source_id" binding:"required"` // Deleted once its records and goals carry the target
+	TargetID string `json:"target_id" binding:"required"`
+}
+
+// MergeResponse represents the response structure for merging tags
+type MergeResponse struct {
+	Target       Tag `json:"target"`
+	MovedRecords int `json:"moved_records"`
+}
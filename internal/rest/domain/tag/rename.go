@@ -0,0 +1,12 @@
+package tag
+
+// RenameRequest represents the request structure for renaming a tag
+type RenameRequest struct {
+	TagID string `json:"tag_id" binding:"required"`
+	Name  string `json:"name" binding:"required"`
+}
+
+// RenameResponse represents the response structure for renaming a tag
+type RenameResponse struct {
+	Tag
+}
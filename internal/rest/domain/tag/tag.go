@@ -0,0 +1,11 @@
+package tag
+
+import "time"
+
+// Tag represents a tag of the workspace
+type Tag struct {
+	TagID     string    `json:"tag_id"`
+	Name      string    `json:"name"`
+	Uses      int       `json:"uses"` // Live incomes and expenses carrying the tag
+	CreatedAt time.Time `json:"created_at"`
+}
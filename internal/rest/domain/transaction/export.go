@@ -0,0 +1,43 @@
+package transaction
+
+import "io"
+
+// Export formats
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+	FormatXLSX  = "xlsx"
+
+	FormatBeancount = "beancount"
+	FormatLedger    = "ledger" // also read by hledger
+)
+
+// ExportRequest represents the request structure for exporting incomes and expenses
+type ExportRequest struct {
+	Filter
+	Format  string         `json:"format,omitempty"`  // Optional: csv (default), jsonl, xlsx, beancount or ledger
+	SortBy  string         `json:"sort_by,omitempty"` // Optional: date, amount, name, type or created_at; journals are by date
+	Order   string         `json:"order,omitempty"`   // Optional: asc or desc; journals are oldest first
+	Mapping *LedgerMapping `json:"mapping,omitempty"` // Optional: accounts of the beancount and ledger formats
+}
+
+// LedgerMapping names the accounts a journal export posts records to. A
+// record is a balanced transaction between an income or expense account and
+// the account the money went into or came out of. Records left out of the
+// mapping go to accounts named after their type and account, such as
+// Expenses:Groceries and Assets:Checking, or Liabilities:Visa for a credit card.
+// The split lines of an expense post to the accounts of their categories.
+type LedgerMapping struct {
+	Categories map[string]string `json:"categories,omitempty"` // category ID of a record or split line to income or expense account; comes before Types
+	Types      map[string]string `json:"types,omitempty"`      // record type to income or expense account
+	Accounts   map[string]string `json:"accounts,omitempty"`   // account ID to asset or liability account
+	Cash       string            `json:"cash,omitempty"`       // account of records outside an account; defaults to Assets:Cash
+}
+
+// ExportResponse represents the file an export produces. Write streams the
+// rows to w as they are read, so it is called once, after the headers are sent.
+type ExportResponse struct {
+	ContentType string
+	FileName    string
+	Write       func(w io.Writer) error
+}
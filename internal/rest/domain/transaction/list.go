@@ -0,0 +1,52 @@
+package transaction
+
+import (
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+)
+
+// Filter holds the filters shared by listing and exporting incomes and expenses
+type Filter struct {
+	Direction string       `json:"direction,omitempty"`   // Optional: income or expense
+	From      *models.Date `json:"from,omitempty"`        // Optional: first day, inclusive
+	To        *models.Date `json:"to,omitempty"`          // Optional: last day, inclusive
+	Type      string       `json:"type,omitempty"`        // Optional: exact income or expense type
+	Search    string       `json:"q,omitempty"`           // Optional: part of the name, case-insensitive
+	MinAmount *float64     `json:"min_amount,omitempty"`  // Optional: smallest amount, inclusive
+	MaxAmount *float64     `json:"max_amount,omitempty"`  // Optional: largest amount, inclusive
+	Category  string       `json:"category_id,omitempty"` // Optional: category, including its subcategories
+	Account   string       `json:"account_id,omitempty"`  // Optional: account
+	Tags      []string     `json:"tags,omitempty"`        // Optional: tag names
+	TagMode   string       `json:"tag_mode,omitempty"`    // Optional: any (default) or all of the tags
+}
+
+// ListRequest represents the request structure for listing incomes and expenses together
+type ListRequest struct {
+	Filter
+	Limit  int    `json:"limit,omitempty"`
+	Offset int    `json:"offset,omitempty"`
+	SortBy string `json:"sort_by,omitempty"` // Optional: date, amount, name, type or created_at
+	Order  string `json:"order,omitempty"`   // Optional: asc or desc
+}
+
+// ListItem represents a single income or expense
+type ListItem struct {
+	TransactionID string           `json:"transaction_id"`
+	Direction     string           `json:"direction"`
+	Name          string           `json:"name"`
+	Amount        []*models.Amount `json:"amount"`
+	Type          string           `json:"type"`
+	Date          models.Date      `json:"date"`
+	CreatedAt     models.Date      `json:"created_at"`
+	CategoryID    string           `json:"category_id,omitempty"`
+	AccountID     string           `json:"account_id,omitempty"`
+	Tags          []string         `json:"tags,omitempty"`
+	Version       int64            `json:"version"`
+}
+
+// ListResponse represents the response structure for listing incomes and expenses together
+type ListResponse struct {
+	Items      []*ListItem `json:"items"`
+	TotalCount int         `json:"total_count"`
+	Limit      int         `json:"limit"`
+	Offset     int         `json:"offset"`
+}
@@ -0,0 +1,21 @@
+package transfer
+
+import (
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+)
+
+// CreateRequest represents the request structure for creating a transfer
+type CreateRequest struct {
+	FromAccountID string      `json:"from_account_id" binding:"required"`
+	ToAccountID   string      `json:"to_account_id" binding:"required"`
+	Amount        float64     `json:"amount" binding:"required"` // In the currency of the source account
+	Rate          float64     `json:"rate,omitempty"`            // Required when the accounts have different currencies
+	Fee           float64     `json:"fee,omitempty"`             // Optional: in the currency of the source account
+	TransferDate  models.Date `json:"transfer_date" binding:"required"`
+	Note          string      `json:"note,omitempty" binding:"max=255"`
+}
+
+// CreateResponse represents the response structure for creating a transfer
+type CreateResponse struct {
+	Transfer
+}
@@ -0,0 +1,12 @@
+package transfer
+
+// DeleteRequest represents the request structure for deleting a transfer
+type DeleteRequest struct {
+	TransferID string `json:"transfer_id" binding:"required"`
+}
+
+// DeleteResponse represents the response structure for deleting a transfer
+type DeleteResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
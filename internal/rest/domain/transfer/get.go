@@ -0,0 +1,11 @@
+package transfer
+
+// GetRequest represents the request structure for fetching a transfer
+type GetRequest struct {
+	TransferID string `json:"transfer_id" binding:"required"`
+}
+
+// GetResponse represents the response structure for fetching a transfer
+type GetResponse struct {
+	Transfer
+}
@@ -0,0 +1,22 @@
+package transfer
+
+import (
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+)
+
+// ListRequest represents the request structure for listing transfers
+type ListRequest struct {
+	AccountID string       `json:"account_id,omitempty"` // Optional: transfers from or to the account
+	From      *models.Date `json:"from,omitempty"`       // Optional: first day, inclusive
+	To        *models.Date `json:"to,omitempty"`         // Optional: last day, inclusive
+	Limit     int          `json:"limit,omitempty"`
+	Offset    int          `json:"offset,omitempty"`
+}
+
+// ListResponse represents the response structure for listing transfers, newest first
+type ListResponse struct {
+	Items      []*Transfer `json:"items"`
+	TotalCount int         `json:"total_count"`
+	Limit      int         `json:"limit"`
+	Offset     int         `json:"offset"`
+}
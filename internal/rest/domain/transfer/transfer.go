@@ -0,0 +1,23 @@
+package transfer
+
+import (
+	"time"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+)
+
+// Transfer represents money moved between two accounts. Transfers change
+// account balances but are not counted as income or expense.
+type Transfer struct {
+	TransferID    string           `json:"transfer_id"`
+	FromAccountID string           `json:"from_account_id"`
+	ToAccountID   string           `json:"to_account_id"`
+	Amount        []*models.Amount `json:"amount"`   // Leaves the source account, in its currency
+	Rate          float64          `json:"rate"`     // Destination units per source unit
+	Received      []*models.Amount `json:"received"` // Arrives at the destination account, in its currency
+	Fee           []*models.Amount `json:"fee"`      // Charged to the source account on top of the amount
+	TransferDate  models.Date      `json:"transfer_date"`
+	Note          string           `json:"note,omitempty"`
+	CreatedAt     time.Time        `json:"created_at"`
+	UpdatedAt     time.Time        `json:"updated_at"`
+}
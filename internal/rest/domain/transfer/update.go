@@ -0,0 +1,24 @@
+package transfer
+
+import (
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+)
+
+// UpdateRequest represents the request structure for updating a transfer.
+// Omitted fields keep their value; moving the transfer between accounts of
+// different currencies needs the rate again.
+type UpdateRequest struct {
+	TransferID    string       `json:"transfer_id" binding:"required"`
+	FromAccountID string       `json:"from_account_id,omitempty"`
+	ToAccountID   string       `json:"to_account_id,omitempty"`
+	Amount        *float64     `json:"amount,omitempty"`
+	Rate          *float64     `json:"rate,omitempty"`
+	Fee           *float64     `json:"fee,omitempty"`
+	TransferDate  *models.Date `json:"transfer_date,omitempty"`
+	Note          *string      `json:"note,omitempty" binding:"omitempty,max=255"` // Empty string clears it
+}
+
+// UpdateResponse represents the response structure for updating a transfer
+type UpdateResponse struct {
+	Transfer
+}
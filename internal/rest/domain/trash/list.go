@@ -0,0 +1,34 @@
+package trash
+
+import (
+	"time"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+)
+
+// ListRequest represents the request structure for listing the trash
+type ListRequest struct {
+	EntityType string `json:"entity_type,omitempty"` // Optional: income or expense
+	Limit      int    `json:"limit,omitempty"`
+	Offset     int    `json:"offset,omitempty"`
+}
+
+// ListItem represents a single trashed income or expense
+type ListItem struct {
+	EntityType string           `json:"entity_type"`
+	EntityID   string           `json:"entity_id"`
+	Name       string           `json:"name"`
+	Amount     []*models.Amount `json:"amount"`
+	Type       string           `json:"type"`
+	Date       models.Date      `json:"date"`
+	DeletedAt  time.Time        `json:"deleted_at"`
+	PurgeAt    time.Time        `json:"purge_at"`
+}
+
+// ListResponse represents the response structure for listing the trash
+type ListResponse struct {
+	Items      []*ListItem `json:"items"`
+	TotalCount int         `json:"total_count"`
+	Limit      int         `json:"limit"`
+	Offset     int         `json:"offset"`
+}
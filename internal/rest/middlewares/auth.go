@@ -1,31 +1,65 @@
 package middlewares
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	er "github.com/rsmrtk/fd-er"
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_api_key"
 	"github.com/rsmrtk/mybox/pkg/utils"
 )
 
 const headerAPIKey = "X-API-Key"
 
-var apiKeys = map[string]*string{
-	"Q9mTf4BxeJ7pK2vZsR1uML8aG0cYhWdXn3oCbPVtF6qUwEiHr5jSkDgNOPYBvL": utils.ValueToPtr("e89462b1-cf26-4dc4-8ee4-ffa9194443e8"),
+// AuthMiddleware resolves the X-API-Key header against the api_key table.
+// Keys are managed with the `apikey` subcommand of cmd/server.
+func AuthMiddleware(pkg *pkg.Facade) gin.HandlerFunc {
+	return authMiddleware(pkg, true)
 }
 
-func AuthMiddleware() gin.HandlerFunc {
+// OptionalAuthMiddleware is AuthMiddleware for routes that also serve
+// anonymous callers: a missing key is allowed, an invalid one is not.
+func OptionalAuthMiddleware(pkg *pkg.Facade) gin.HandlerFunc {
+	return authMiddleware(pkg, false)
+}
+
+func authMiddleware(pkg *pkg.Facade, required bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		// Already resolved by OptionalAuthMiddleware on the engine
+		if utils.AuthAPIKeyCtx(c.Request.Context()) != "" {
+			c.Next()
+			return
+		}
+
 		key := c.GetHeader(headerAPIKey)
-		customerID, exists := apiKeys[key]
-		if !exists {
-			_ = c.Error(er.NewHTTPError(http.StatusUnauthorized, "invalid API key"))
-			c.Abort()
+		if key == "" {
+			if required {
+				_ = c.Error(er.NewHTTPError(http.StatusUnauthorized, "invalid API key"))
+				c.Abort()
+				return
+			}
+			c.Next()
 			return
 		}
-		if customerID != nil {
-			utils.GinAuthSetCtx(c, *customerID)
+
+		data, err := pkg.M.APIKey.FindActiveByHash(c.Request.Context(), m_api_key.Hash(key))
+		if err != nil {
+			if errors.Is(err, m_api_key.ErrNotFound) {
+				_ = c.Error(er.NewHTTPError(http.StatusUnauthorized, "invalid API key"))
+			} else {
+				_ = c.Error(er.NewHTTPError(http.StatusInternalServerError).SetInternal(err))
+			}
+			c.Abort()
+			return
 		}
+
+		// Services receive either the gin context or the request context,
+		// so the identity is stored in both.
+		utils.GinAuthSetCtx(c, data.CustomerID)
+		ctx := utils.AuthSetCtx(c.Request.Context(), data.CustomerID)
+		c.Request = c.Request.WithContext(utils.AuthSetAPIKeyCtx(ctx, data.KeyID))
 		c.Next()
 	}
 }
@@ -25,7 +25,21 @@ func ErrorMiddleware(pkg *pkg.Facade) gin.HandlerFunc {
 			logData["path"] = c.Request.URL.Path
 			logData["method"] = c.Request.Method
 
+			// A streamed response that fails halfway can only be cut short
+			if c.Writer.Written() {
+				logData["error"] = err.Err.Error()
+				pkg.Log.Error("HTTP error after the response started", logData)
+				c.Abort()
+				return
+			}
+
 			switch {
+			case isBodyTooLarge(err.Err):
+				m := gin.H{"code": http.StatusRequestEntityTooLarge, "message": "Request body too large."}
+				c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": m})
+				logData["error"] = m
+				pkg.Log.Error("HTTP error", logData)
+				return
 			case errors.As(err.Err, &herr):
 				m := gin.H{"code": herr.Code, "message": herr.Message}
 				if herr.Internal != nil {
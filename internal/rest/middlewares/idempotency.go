@@ -0,0 +1,144 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	er "github.com/rsmrtk/fd-er"
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_idempotency"
+	"github.com/rsmrtk/mybox/pkg/utils"
+	lg "github.com/rsmrtk/smartlg/logger"
+)
+
+const (
+	headerIdempotencyKey     = "Idempotency-Key"
+	headerIdempotentReplayed = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+)
+
+// replayedHeaders are the response headers kept with the body, so that a
+// replayed create still tells the client where the record is and which
+// version it has.
+var replayedHeaders = []string{"ETag", "Location"}
+
+// IdempotencyMiddleware makes a route safe to retry. The first request with
+// a given Idempotency-Key is processed and, if it succeeds, its response is
+// kept for Config.Idempotency.TTL. Retries with the same key and body get
+// that response back; the same key with a different body is rejected with
+// 422. Keys are scoped to the client and the route. A retry while the first
+// request is still in progress gets 409, until Config.Idempotency.Lease has
+// passed. Requests without the header are not affected.
+func IdempotencyMiddleware(pkg *pkg.Facade) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(headerIdempotencyKey)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			_ = c.Error(er.NewHTTPError(http.StatusBadRequest, "Idempotency-Key is too long."))
+			c.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			_ = c.Error(err)
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		sum := sha256.Sum256(body)
+		now := time.Now()
+		entry := &m_idempotency.Data{
+			Scope:       idempotencyClient(c) + " " + c.Request.Method + " " + c.FullPath(),
+			Key:         key,
+			Fingerprint: hex.EncodeToString(sum[:]),
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(pkg.Config.Idempotency.TTL),
+		}
+
+		existing, err := pkg.M.Idempotency.Reserve(c.Request.Context(), entry, pkg.Config.Idempotency.Lease)
+		if err != nil {
+			_ = c.Error(er.NewHTTPError(http.StatusInternalServerError).SetInternal(err))
+			c.Abort()
+			return
+		}
+		if existing != nil {
+			switch {
+			case existing.Fingerprint != entry.Fingerprint:
+				_ = c.Error(er.NewHTTPError(http.StatusUnprocessableEntity, "Idempotency-Key was already used with a different request."))
+			case existing.StatusCode == 0:
+				_ = c.Error(er.NewHTTPError(http.StatusConflict, "A request with this Idempotency-Key is still being processed."))
+			default:
+				for name, value := range existing.Headers {
+					c.Header(name, value)
+				}
+				c.Header(headerIdempotentReplayed, "true")
+				c.Data(existing.StatusCode, existing.ContentType, existing.Response)
+			}
+			c.Abort()
+			return
+		}
+
+		rec := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = rec
+		c.Next()
+
+		// The outcome is stored even if the client has gone away or the
+		// request timed out, otherwise the key would stay in progress.
+		ctx := context.WithoutCancel(c.Request.Context())
+		status := rec.Status()
+		if len(c.Errors) == 0 && status >= 200 && status < 300 {
+			headers := map[string]string{}
+			for _, name := range replayedHeaders {
+				if value := rec.Header().Get(name); value != "" {
+					headers[name] = value
+				}
+			}
+			err = pkg.M.Idempotency.Complete(ctx, entry.Scope, entry.Key, status, rec.Header().Get("Content-Type"), rec.body.Bytes(), headers)
+		} else {
+			// Failed requests are not replayed; the client may retry with the same key.
+			err = pkg.M.Idempotency.Release(ctx, entry.Scope, entry.Key)
+		}
+		if err != nil {
+			pkg.Log.Error("failed to store idempotency key", lg.H{"error": err.Error(), "path": c.Request.URL.Path})
+		}
+	}
+}
+
+// idempotencyClient is the client part of the scope of a key. Anonymous
+// callers are scoped by the key alone, since their address may change
+// between retries.
+func idempotencyClient(c *gin.Context) string {
+	if c.GetHeader(headerAPIKey) == "" {
+		if _, ok := utils.GinAuthLookup(c); !ok {
+			return "anonymous"
+		}
+	}
+	return clientKey(c)
+}
+
+// responseRecorder keeps a copy of the response body for replay.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *responseRecorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
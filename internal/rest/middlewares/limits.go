@@ -0,0 +1,85 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	er "github.com/rsmrtk/fd-er"
+)
+
+// BodyLimitMiddleware rejects requests whose body is larger than max bytes.
+// Declared lengths are checked up front; chunked bodies are cut off while
+// being read and reported by ErrorMiddleware.
+func BodyLimitMiddleware(max int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > max {
+			_ = c.Error(er.NewHTTPError(http.StatusRequestEntityTooLarge, "Request body too large."))
+			c.Abort()
+			return
+		}
+		if c.Request.Body != nil {
+			c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, max)
+		}
+		c.Next()
+	}
+}
+
+// TimeoutMiddleware puts a deadline on the request context so database calls
+// made on behalf of a slow request are cancelled. Routes in exempt, such as
+// downloads streamed under StreamMiddleware, run without one.
+func TimeoutMiddleware(timeout time.Duration, exempt ...string) gin.HandlerFunc {
+	skip := make(map[string]bool, len(exempt))
+	for _, path := range exempt {
+		skip[path] = true
+	}
+	return func(c *gin.Context) {
+		if skip[c.FullPath()] {
+			c.Next()
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && !c.Writer.Written() {
+			_ = c.Error(er.NewHTTPError(http.StatusGatewayTimeout, "Request timed out."))
+		}
+	}
+}
+
+// StreamMiddleware keeps a long response going past the server write
+// timeout: each write to the client pushes the write deadline timeout
+// further, so only a client that stops reading for that long is cut off.
+func StreamMiddleware(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer = &streamWriter{ResponseWriter: c.Writer, rc: http.NewResponseController(c.Writer), timeout: timeout}
+		c.Next()
+	}
+}
+
+type streamWriter struct {
+	gin.ResponseWriter
+	rc      *http.ResponseController
+	timeout time.Duration
+}
+
+func (w *streamWriter) Write(p []byte) (int, error) {
+	// Not every writer supports deadlines; those are left as they are
+	_ = w.rc.SetWriteDeadline(time.Now().Add(w.timeout))
+	return w.ResponseWriter.Write(p)
+}
+
+// isBodyTooLarge reports whether err was caused by BodyLimitMiddleware.
+func isBodyTooLarge(err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return true
+	}
+	var herr *er.HTTPError
+	return errors.As(err, &herr) && herr.Internal != nil && errors.As(herr.Internal, &maxBytesErr)
+}
@@ -0,0 +1,65 @@
+package middlewares
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	er "github.com/rsmrtk/fd-er"
+	"github.com/rsmrtk/mybox/pkg"
+	lg "github.com/rsmrtk/smartlg/logger"
+)
+
+func TestBodyLimitMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	f := &pkg.Facade{Log: lg.New(io.Discard).With().Logger().Level(lg.InfoLevel)}
+
+	engine := gin.New()
+	engine.Use(ErrorMiddleware(f), BodyLimitMiddleware(32))
+	// Binds the body the way the controllers do
+	engine.POST("/", func(ctx *gin.Context) {
+		var req struct {
+			Name string `json:"name"`
+		}
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			err = er.NewHTTPError(http.StatusBadRequest).SetInternal(fmt.Errorf("failed to bind request: %w", err))
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.Status(http.StatusNoContent)
+	})
+
+	large := `{"name": "` + strings.Repeat("x", 64) + `"}`
+	tests := []struct {
+		name    string
+		body    string
+		chunked bool
+		want    int
+	}{
+		{name: "small", body: `{"name": "x"}`, want: http.StatusNoContent},
+		{name: "malformed", body: `{"name":`, want: http.StatusBadRequest},
+		{name: "declared length too large", body: large, want: http.StatusRequestEntityTooLarge},
+		{name: "chunked small", body: `{"name": "x"}`, chunked: true, want: http.StatusNoContent},
+		{name: "chunked too large", body: large, chunked: true, want: http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.chunked {
+				// A chunked body has no declared length
+				req.ContentLength = -1
+				req.TransferEncoding = []string{"chunked"}
+			}
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package middlewares
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	er "github.com/rsmrtk/fd-er"
+	"github.com/rsmrtk/mybox/internal/rest/ratelimit"
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_api_key"
+	"github.com/rsmrtk/mybox/pkg/utils"
+	lg "github.com/rsmrtk/smartlg/logger"
+)
+
+// RateLimitMiddleware limits requests per client. An empty class picks read
+// or write from the HTTP method. Clients are identified by API key, then by
+// authenticated customer, then by IP address.
+func RateLimitMiddleware(pkg *pkg.Facade, limiter *ratelimit.Limiter, class ratelimit.Class) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cls := class
+		if cls == "" {
+			cls = ratelimit.ClassWrite
+			if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+				cls = ratelimit.ClassRead
+			}
+		}
+
+		res, limited, err := limiter.Allow(c.Request.Context(), cls, clientKey(c))
+		if err != nil {
+			// A broken store must not take the API down with it.
+			pkg.Log.Error("rate limiter error", lg.H{"error": err.Error()})
+			c.Next()
+			return
+		}
+		if !limited {
+			c.Next()
+			return
+		}
+
+		h := c.Writer.Header()
+		h.Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+		h.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+		h.Set("RateLimit-Reset", ceilSeconds(res.Reset))
+
+		if !res.Allowed {
+			h.Set("Retry-After", ceilSeconds(res.RetryAfter))
+			_ = c.Error(er.NewHTTPError(http.StatusTooManyRequests, "Too many requests."))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+func clientKey(c *gin.Context) string {
+	if key := c.GetHeader(headerAPIKey); key != "" {
+		return "key:" + m_api_key.Hash(key)
+	}
+	if customerID, ok := utils.GinAuthLookup(c); ok {
+		return "customer:" + customerID
+	}
+	return "ip:" + c.ClientIP()
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
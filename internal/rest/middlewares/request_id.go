@@ -0,0 +1,24 @@
+package middlewares
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+const headerRequestID = "X-Request-ID"
+
+// RequestIDMiddleware propagates the caller's X-Request-ID, or assigns one,
+// and echoes it on the response.
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(headerRequestID)
+		if id == "" || len(id) > 128 {
+			id = uuid.New().String()
+		}
+
+		c.Request = c.Request.WithContext(utils.RequestIDSetCtx(c.Request.Context(), id))
+		c.Writer.Header().Set(headerRequestID, id)
+		c.Next()
+	}
+}
@@ -0,0 +1,58 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Class groups routes that share a limit.
+type Class string
+
+const (
+	ClassRead   Class = "read"
+	ClassWrite  Class = "write"
+	ClassExport Class = "export"
+)
+
+// Limit allows Requests per Per, with bursts up to Requests.
+type Limit struct {
+	Requests int
+	Per      time.Duration
+}
+
+// Result is the outcome of taking one token from a bucket.
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration // until the bucket is full again
+	RetryAfter time.Duration // until the next token, when not allowed
+}
+
+// Store keeps token buckets. MemoryStore is enough for a single replica;
+// multiple replicas need an implementation backed by a shared store so that
+// a client cannot multiply its limit by the number of instances.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit, now time.Time) (Result, error)
+}
+
+// Limiter applies a Limit per Class on top of a Store.
+type Limiter struct {
+	store  Store
+	limits map[Class]Limit
+}
+
+func New(store Store, limits map[Class]Limit) *Limiter {
+	return &Limiter{store: store, limits: limits}
+}
+
+// Allow takes a token for key from the bucket of class. Classes without a
+// configured limit are always allowed.
+func (l *Limiter) Allow(ctx context.Context, class Class, key string) (Result, bool, error) {
+	limit, ok := l.limits[class]
+	if !ok || limit.Requests <= 0 || limit.Per <= 0 {
+		return Result{Allowed: true}, false, nil
+	}
+	res, err := l.store.Take(ctx, string(class)+":"+key, limit, time.Now())
+	return res, true, err
+}
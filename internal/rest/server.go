@@ -2,6 +2,7 @@ package rest
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -10,16 +11,17 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/rsmrtk/mybox/internal/rest/controllers"
 	"github.com/rsmrtk/mybox/internal/rest/middlewares"
+	"github.com/rsmrtk/mybox/internal/rest/ratelimit"
 	"github.com/rsmrtk/mybox/internal/rest/services"
 	"github.com/rsmrtk/mybox/pkg"
 	log "github.com/rsmrtk/smartlg"
 )
 
 type Server struct {
-	addr   string
-	cert   string
-	key    string
-	server *gin.Engine
+	cert    string
+	key     string
+	server  *gin.Engine
+	httpSrv *http.Server
 }
 
 type ServerOptions struct {
@@ -45,58 +47,228 @@ func (w *tlsErrorFilter) Write(p []byte) (n int, err error) {
 }
 
 func NewServer(o ServerOptions) (*Server, error) {
+	cfg := o.Facade.Config.HTTP
+
 	engine := gin.New()
-	engine.Use(middlewares.CORSMiddleware())
+	// Let services use *gin.Context as a context.Context that carries the
+	// request deadline set by TimeoutMiddleware.
+	engine.ContextWithFallback = true
+	engine.Use(middlewares.RequestIDMiddleware())
+	engine.Use(middlewares.CORSMiddleware(o.Facade.Config.CORS))
 	engine.Use(middlewares.ErrorMiddleware(o.Facade))
+	engine.Use(middlewares.BodyLimitMiddleware(cfg.MaxBodyBytes))
+	// Exports stream for as long as they take; StreamMiddleware keeps them alive
+	engine.Use(middlewares.TimeoutMiddleware(cfg.RequestTimeout, "/transactions/export"))
+	engine.Use(middlewares.OptionalAuthMiddleware(o.Facade))
+
+	limiter := newLimiter(o.Facade.Config.RateLimit)
+	rateLimit := middlewares.RateLimitMiddleware(o.Facade, limiter, "")
+	idempotent := middlewares.IdempotencyMiddleware(o.Facade)
 
 	engine.GET("/", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"status": "ok"}) })
 	engine.GET("/health", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"status": "ok"}) })
 
-	incomes := engine.Group("/income", middlewares.CORSMiddleware())
+	incomes := engine.Group("/income", rateLimit)
 	{
 		c := controllers.NewEstimateController(o.Services.Income)
 		incomes.GET("/list", c.List) // List all incomes
 		incomes.GET("", c.Get)       // Get single income
-		incomes.POST("", c.Create)
+		incomes.POST("", idempotent, c.Create)
 		incomes.PUT("", c.Update)
 		incomes.DELETE("", c.Delete)
+		incomes.POST("/restore", c.Restore)         // Restore from trash
+		incomes.POST("/batch", idempotent, c.Batch) // Bulk create, update and delete
 	}
 
-	expenses := engine.Group("/expense", middlewares.CORSMiddleware())
+	expenses := engine.Group("/expense", rateLimit)
 	{
 		c := controllers.NewExpenseController(o.Services.Expense)
 		expenses.GET("/list", c.List) // List all expenses
 		expenses.GET("", c.Get)       // Get single expense
-		expenses.POST("", c.Create)
+		expenses.POST("", idempotent, c.Create)
 		expenses.PUT("", c.Update)
 		expenses.DELETE("", c.Delete)
+		expenses.POST("/restore", c.Restore)         // Restore from trash
+		expenses.POST("/batch", idempotent, c.Batch) // Bulk create, update and delete
 	}
 
-	return &Server{addr: ":9595", cert: o.Facade.Config.TLSCertFile, key: o.Facade.Config.TLSKeyFile, server: engine}, nil
-}
+	transactions := engine.Group("/transactions", rateLimit)
+	{
+		c := controllers.NewTransactionController(o.Services.Transaction)
+		transactions.GET("", c.List) // List incomes and expenses together
+	}
+
+	exports := engine.Group("/transactions/export", middlewares.RateLimitMiddleware(o.Facade, limiter, ratelimit.ClassExport),
+		middlewares.StreamMiddleware(cfg.WriteTimeout))
+	{
+		c := controllers.NewTransactionController(o.Services.Transaction)
+		exports.GET("", c.Export)        // Download incomes and expenses as CSV, JSON Lines, XLSX, Beancount or Ledger
+		exports.POST("", c.ExportMapped) // The same with the filters and a journal mapping as JSON
+	}
+
+	duplicates := engine.Group("/duplicates", rateLimit)
+	{
+		c := controllers.NewDuplicateController(o.Services.Duplicate)
+		duplicates.GET("", c.List)         // List suspected duplicate pairs with their scores
+		duplicates.POST("/merge", c.Merge) // Keep one record, move its duplicate to the trash
+	}
+
+	trashed := engine.Group("/trash", rateLimit)
+	{
+		c := controllers.NewTrashController(o.Services.Trash)
+		trashed.GET("", c.List) // List soft deleted incomes and expenses
+	}
+
+	categories := engine.Group("/category", middlewares.AuthMiddleware(o.Facade), rateLimit)
+	{
+		c := controllers.NewCategoryController(o.Services.Category)
+		categories.GET("/list", c.List) // List the categories of the workspace
+		categories.GET("", c.Get)       // Get single category
+		categories.POST("", c.Create)
+		categories.PUT("", c.Update)
+		categories.DELETE("", c.Delete)
+		categories.POST("/merge", c.Merge) // Move records and subcategories into another category
+	}
+
+	tags := engine.Group("/tag", middlewares.AuthMiddleware(o.Facade), rateLimit)
+	{
+		c := controllers.NewTagController(o.Services.Tag)
+		tags.GET("/list", c.List) // List the tags of the workspace with their use counts
+		tags.PUT("", c.Rename)
+		tags.DELETE("", c.Delete)    // Remove from every record
+		tags.POST("/merge", c.Merge) // Retag records and delete the source tag
+	}
+
+	accounts := engine.Group("/account", middlewares.AuthMiddleware(o.Facade), rateLimit)
+	{
+		c := controllers.NewAccountController(o.Services.Account)
+		accounts.GET("/list", c.List) // List the accounts of the workspace with their balances
+		accounts.GET("", c.Get)       // Get single account
+		accounts.POST("", c.Create)
+		accounts.PUT("", c.Update)
+		accounts.DELETE("", c.Delete)
+		accounts.GET("/balance", c.Balance) // Current balance and balance history
+	}
+
+	transfers := engine.Group("/transfer", middlewares.AuthMiddleware(o.Facade), rateLimit)
+	{
+		c := controllers.NewTransferController(o.Services.Transfer)
+		transfers.GET("/list", c.List) // List transfers, optionally of one account
+		transfers.GET("", c.Get)       // Get single transfer
+		transfers.POST("", c.Create)
+		transfers.PUT("", c.Update)
+		transfers.DELETE("", c.Delete)
+	}
+
+	recurrings := engine.Group("/recurring", middlewares.AuthMiddleware(o.Facade), rateLimit)
+	{
+		c := controllers.NewRecurringController(o.Services.Recurring)
+		recurrings.GET("/list", c.List) // List the recurring templates of the workspace
+		recurrings.GET("", c.Get)       // Get single template
+		recurrings.POST("", c.Create)
+		recurrings.PUT("", c.Update)          // Edit the whole series
+		recurrings.DELETE("", c.Delete)       // Records already created are kept
+		recurrings.GET("/preview", c.Preview) // Upcoming occurrences of a template or an unsaved rule
+		recurrings.POST("/skip", c.Skip)      // Skip one occurrence
+		recurrings.PUT("/following", c.Split) // Edit an occurrence and every one after it
+	}
+
+	budgets := engine.Group("/budget", middlewares.AuthMiddleware(o.Facade), rateLimit)
+	{
+		c := controllers.NewBudgetController(o.Services.Budget)
+		budgets.GET("/list", c.List) // List the budgets of the workspace
+		budgets.GET("", c.Get)       // Get single budget
+		budgets.POST("", c.Create)
+		budgets.PUT("", c.Update)
+		budgets.DELETE("", c.Delete)
+		budgets.GET("/status", c.Status) // Planned vs actual for the period holding a date
+	}
+
+	goals := engine.Group("/goal", middlewares.AuthMiddleware(o.Facade), rateLimit)
+	{
+		c := controllers.NewGoalController(o.Services.Goal)
+		goals.GET("/list", c.List) // List the savings goals of the workspace with their progress
+		goals.GET("", c.Get)       // Get single goal with its progress
+		goals.POST("", c.Create)
+		goals.PUT("", c.Update)
+		goals.DELETE("", c.Delete)
+	}
+
+	imports := engine.Group("/import", middlewares.AuthMiddleware(o.Facade), rateLimit)
+	{
+		c := controllers.NewImportController(o.Services.Import)
+		imports.GET("/profiles", c.Profiles) // List the saved CSV mappings
+		imports.POST("/profiles", c.CreateProfile)
+		imports.PUT("/profiles", c.UpdateProfile)
+		imports.DELETE("/profiles", c.DeleteProfile)
+		imports.POST("/csv", c.CSV)           // Preview (dry_run) or import a CSV file
+		imports.POST("/ofx", c.OFX)           // Preview or import an OFX/QFX statement, skipping known FITIDs
+		imports.POST("/qif", c.QIF)           // Preview or import a QIF file
+		imports.POST("/camt053", c.CAMT)      // Preview or import a camt.053 statement, skipping known bank references
+		imports.POST("/mt940", c.MT940)       // Preview or import an MT940 statement, skipping known bank references
+		imports.POST("/journal", c.Journal)   // Preview or import a Beancount or Ledger journal, skipping records imported before
+		imports.GET("/batches", c.Batches)    // List the committed imports
+		imports.POST("/rollback", c.Rollback) // Move the records of an import to the trash
+	}
+
+	reports := engine.Group("/reports", middlewares.AuthMiddleware(o.Facade), rateLimit)
+	{
+		c := controllers.NewReportController(o.Services.Report)
+		reports.GET("/categories", c.Categories) // Totals per category with roll-up to parents
+		reports.GET("/tags", c.Tags)             // Totals per tag
+		reports.GET("/net-worth", c.NetWorth)    // Balances of all accounts per currency
+	}
+
+	audits := engine.Group("/audit", middlewares.AuthMiddleware(o.Facade), rateLimit)
+	{
+		c := controllers.NewAuditController(o.Services.Audit)
+		audits.GET("", c.List) // Query the audit log
+	}
 
-func (s *Server) Serve() error {
 	errorFilter := &tlsErrorFilter{facade: nil}
 
 	httpSrv := &http.Server{
-		Addr:     s.addr,
-		Handler:  s.server,
-		ErrorLog: log.New(errorFilter, "", log.LstdFlags),
+		Addr:              cfg.Addr,
+		Handler:           engine,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		ReadTimeout:       cfg.ReadTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		MaxHeaderBytes:    cfg.MaxHeaderBytes,
+		ErrorLog:          log.New(errorFilter, "", log.LstdFlags),
 	}
 
+	return &Server{cert: o.Facade.Config.TLSCertFile, key: o.Facade.Config.TLSKeyFile, server: engine, httpSrv: httpSrv}, nil
+}
+
+// newLimiter builds the in-memory rate limiter. With rate limiting disabled
+// the limiter has no limits and lets every request through.
+func newLimiter(cfg pkg.RateLimitConfig) *ratelimit.Limiter {
+	limits := map[ratelimit.Class]ratelimit.Limit{}
+	if cfg.Enabled {
+		limits[ratelimit.ClassRead] = ratelimit.Limit{Requests: cfg.Read.Requests, Per: cfg.Read.Per}
+		limits[ratelimit.ClassWrite] = ratelimit.Limit{Requests: cfg.Write.Requests, Per: cfg.Write.Per}
+		limits[ratelimit.ClassExport] = ratelimit.Limit{Requests: cfg.Export.Requests, Per: cfg.Export.Per}
+	}
+	return ratelimit.New(ratelimit.NewMemoryStore(), limits)
+}
+
+func (s *Server) Serve() error {
+	var err error
 	// Use HTTP for local development when certificates are not provided
 	if s.cert == "" || s.key == "" {
-		if err := httpSrv.ListenAndServe(); err != nil {
-			return fmt.Errorf("internal error: %w", err)
-		}
+		err = s.httpSrv.ListenAndServe()
 	} else {
-		if err := httpSrv.ListenAndServeTLS(s.cert, s.key); err != nil {
-			return fmt.Errorf("internal error: %w", err)
-		}
+		err = s.httpSrv.ListenAndServeTLS(s.cert, s.key)
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("internal error: %w", err)
 	}
 	return nil
 }
 
-func (s *Server) Shutdown(_ context.Context) error {
-	return nil
+// Shutdown stops accepting connections and waits for in-flight requests
+// until ctx is done.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.httpSrv.Shutdown(ctx)
 }
@@ -0,0 +1,35 @@
+package balance
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/account"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Facade is the account balance facade
+type Facade struct {
+	pkg *pkg.Facade
+}
+
+// New creates a new account balance facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg: pkg,
+	}
+}
+
+// Handle handles the account balance request
+func (f *Facade) Handle(ctx context.Context, req *account.BalanceRequest) (*account.BalanceResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	if err := s.balance(); err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
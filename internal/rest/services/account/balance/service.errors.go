@@ -0,0 +1,21 @@
+package balance
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	AccountNotFound    *err.HTTPError
+	InvalidAccountID   *err.HTTPError
+	InvalidInterval    *err.HTTPError
+	InvalidDateRange   *err.HTTPError
+	FailedToGetBalance *err.HTTPError
+}{
+	AccountNotFound:    err.NewHTTPError(http.StatusNotFound, "Account not found."),
+	InvalidAccountID:   err.NewHTTPError(http.StatusBadRequest, "Invalid account ID format."),
+	InvalidInterval:    err.NewHTTPError(http.StatusBadRequest, "Interval must be day, week, month or year."),
+	InvalidDateRange:   err.NewHTTPError(http.StatusBadRequest, "From must not be after to."),
+	FailedToGetBalance: err.NewHTTPError(http.StatusInternalServerError, "Failed to get account balance."),
+}
@@ -0,0 +1,104 @@
+package balance
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/account"
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+	"github.com/rsmrtk/mybox/internal/rest/services/account/form"
+	"github.com/rsmrtk/mybox/internal/rest/services/record"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_account"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx  context.Context
+	req  *account.BalanceRequest
+	f    *Facade
+	data *m_account.Data
+
+	opening float64
+	history []*account.BalancePoint
+}
+
+func (s *service) balance() error {
+	if s.req.Interval == "" {
+		s.req.Interval = "month"
+	}
+	if !slices.Contains(m_account.Intervals, s.req.Interval) {
+		return errs.InvalidInterval
+	}
+	if s.req.From != nil && s.req.To != nil && s.req.From.After(s.req.To.Time) {
+		return errs.InvalidDateRange
+	}
+
+	id, err := uuid.Parse(s.req.AccountID)
+	if err != nil {
+		return errs.InvalidAccountID
+	}
+	s.data, err = s.f.pkg.M.Account.Find(s.ctx, utils.AuthCtx(s.ctx), id.String())
+	if errors.Is(err, m_account.ErrNotFound) {
+		return errs.AccountNotFound
+	}
+	if err != nil {
+		return errs.FailedToGetBalance
+	}
+
+	movements, err := s.f.pkg.M.Account.Movements(s.ctx, s.data.AccountID, s.req.Interval)
+	if err != nil {
+		return errs.FailedToGetBalance
+	}
+
+	// Periods before the range only move the opening balance of the history
+	s.opening = s.data.OpeningBalance
+	running := s.opening
+	for _, mv := range movements {
+		running += mv.Inflow - mv.Outflow
+		if s.req.From != nil && !next(mv.Period, s.req.Interval).After(s.req.From.Time) {
+			s.opening = running
+			continue
+		}
+		if s.req.To != nil && mv.Period.After(s.req.To.Time) {
+			break
+		}
+		s.history = append(s.history, &account.BalancePoint{
+			Period:  models.NewDate(mv.Period),
+			Inflow:  record.AmountsIn(mv.Inflow, s.data.Currency),
+			Outflow: record.AmountsIn(mv.Outflow, s.data.Currency),
+			Count:   mv.Count,
+			Balance: record.AmountsIn(running, s.data.Currency),
+		})
+	}
+
+	return nil
+}
+
+func (s *service) reply() *account.BalanceResponse {
+	history := s.history
+	if history == nil {
+		history = []*account.BalancePoint{}
+	}
+	return &account.BalanceResponse{
+		Account:  form.Convert(s.data),
+		Interval: s.req.Interval,
+		Opening:  record.AmountsIn(s.opening, s.data.Currency),
+		History:  history,
+	}
+}
+
+// next returns the start of the period after the one starting at t.
+func next(t time.Time, interval string) time.Time {
+	switch interval {
+	case "day":
+		return t.AddDate(0, 0, 1)
+	case "week":
+		return t.AddDate(0, 0, 7)
+	case "year":
+		return t.AddDate(1, 0, 0)
+	}
+	return t.AddDate(0, 1, 0)
+}
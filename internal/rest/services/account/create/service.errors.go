@@ -0,0 +1,21 @@
+package create
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	InvalidName           *err.HTTPError
+	InvalidKind           *err.HTTPError
+	InvalidCurrency       *err.HTTPError
+	DuplicateName         *err.HTTPError
+	FailedToCreateAccount *err.HTTPError
+}{
+	InvalidName:           err.NewHTTPError(http.StatusBadRequest, "Name must not be blank."),
+	InvalidKind:           err.NewHTTPError(http.StatusBadRequest, "Kind must be cash, checking, credit_card or savings."),
+	InvalidCurrency:       err.NewHTTPError(http.StatusBadRequest, "Currency must be a three-letter ISO 4217 code."),
+	DuplicateName:         err.NewHTTPError(http.StatusConflict, "An account with this name already exists."),
+	FailedToCreateAccount: err.NewHTTPError(http.StatusInternalServerError, "Failed to create account."),
+}
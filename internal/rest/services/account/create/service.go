@@ -0,0 +1,63 @@
+package create
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/account"
+	"github.com/rsmrtk/mybox/internal/rest/services/account/form"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_account"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx  context.Context
+	req  *account.CreateRequest
+	f    *Facade
+	data *m_account.Data
+}
+
+func (s *service) create() error {
+	name := form.Name(s.req.Name)
+	if name == "" {
+		return errs.InvalidName
+	}
+	if !form.ValidKind(s.req.Kind) {
+		return errs.InvalidKind
+	}
+	currency, ok := form.Currency(s.req.Currency)
+	if !ok {
+		return errs.InvalidCurrency
+	}
+
+	now := time.Now().UTC()
+	s.data = &m_account.Data{
+		AccountID:      uuid.New().String(),
+		WorkspaceID:    utils.AuthCtx(s.ctx),
+		Name:           name,
+		Kind:           s.req.Kind,
+		Currency:       currency,
+		OpeningBalance: s.req.OpeningBalance,
+		OpenedOn:       now.Truncate(24 * time.Hour),
+		CreatedAt:      now,
+	}
+	if s.req.OpenedOn != nil && !s.req.OpenedOn.IsZero() {
+		s.data.OpenedOn = s.req.OpenedOn.Time
+	}
+
+	err := s.f.pkg.M.Account.Create(s.ctx, s.data)
+	if errors.Is(err, m_account.ErrDuplicateName) {
+		return errs.DuplicateName
+	}
+	if err != nil {
+		return errs.FailedToCreateAccount
+	}
+
+	return nil
+}
+
+func (s *service) reply() *account.CreateResponse {
+	return &account.CreateResponse{Account: form.Convert(s.data)}
+}
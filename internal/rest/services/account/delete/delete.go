@@ -0,0 +1,35 @@
+package delete
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/account"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Facade is the delete account facade
+type Facade struct {
+	pkg *pkg.Facade
+}
+
+// New creates a new delete account facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg: pkg,
+	}
+}
+
+// Handle handles the delete account request
+func (f *Facade) Handle(ctx context.Context, req *account.DeleteRequest) (*account.DeleteResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	if err := s.delete(); err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
@@ -0,0 +1,19 @@
+package delete
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	AccountNotFound       *err.HTTPError
+	InvalidAccountID      *err.HTTPError
+	AccountInUse          *err.HTTPError
+	FailedToDeleteAccount *err.HTTPError
+}{
+	AccountNotFound:       err.NewHTTPError(http.StatusNotFound, "Account not found."),
+	InvalidAccountID:      err.NewHTTPError(http.StatusBadRequest, "Invalid account ID format."),
+	AccountInUse:          err.NewHTTPError(http.StatusConflict, "Account still has incomes, expenses, transfers, recurring templates or savings goals; move them to another account first."),
+	FailedToDeleteAccount: err.NewHTTPError(http.StatusInternalServerError, "Failed to delete account."),
+}
@@ -0,0 +1,44 @@
+package delete
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/account"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_account"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx context.Context
+	req *account.DeleteRequest
+	f   *Facade
+}
+
+func (s *service) delete() error {
+	id, err := uuid.Parse(s.req.AccountID)
+	if err != nil {
+		return errs.InvalidAccountID
+	}
+
+	// Records in the trash still reference the account and block it too
+	err = s.f.pkg.M.Account.Delete(s.ctx, utils.AuthCtx(s.ctx), id.String())
+	switch {
+	case errors.Is(err, m_account.ErrNotFound):
+		return errs.AccountNotFound
+	case errors.Is(err, m_account.ErrInUse):
+		return errs.AccountInUse
+	case err != nil:
+		return errs.FailedToDeleteAccount
+	}
+
+	return nil
+}
+
+func (s *service) reply() *account.DeleteResponse {
+	return &account.DeleteResponse{
+		Success: true,
+		Message: "Account deleted successfully",
+	}
+}
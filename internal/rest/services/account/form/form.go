@@ -0,0 +1,47 @@
+// Package form validates account fields and converts accounts for the API.
+// The account services and the net worth report share it.
+package form
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	da "github.com/rsmrtk/mybox/internal/rest/domain/account"
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+	"github.com/rsmrtk/mybox/internal/rest/services/record"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_account"
+)
+
+var currency = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// Convert returns the API form of an account.
+func Convert(d *m_account.Data) da.Account {
+	return da.Account{
+		AccountID:      d.AccountID,
+		Name:           d.Name,
+		Kind:           d.Kind,
+		Currency:       d.Currency,
+		OpeningBalance: record.AmountsIn(d.OpeningBalance, d.Currency),
+		OpenedOn:       models.NewDate(d.OpenedOn),
+		Balance:        record.AmountsIn(d.Balance, d.Currency),
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+	}
+}
+
+// ValidKind reports whether s is one of m_account.Kinds.
+func ValidKind(s string) bool {
+	return slices.Contains(m_account.Kinds, s)
+}
+
+// Currency upper-cases an ISO 4217 code and reports whether it is well formed.
+func Currency(s string) (string, bool) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	return s, currency.MatchString(s)
+}
+
+// Name trims an account name as the unique index compares it.
+func Name(s string) string {
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,35 @@
+package get
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/account"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Facade is the get account facade
+type Facade struct {
+	pkg *pkg.Facade
+}
+
+// New creates a new get account facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg: pkg,
+	}
+}
+
+// Handle handles the get account request
+func (f *Facade) Handle(ctx context.Context, req *account.GetRequest) (*account.GetResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	if err := s.find(); err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
@@ -0,0 +1,17 @@
+package get
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	AccountNotFound    *err.HTTPError
+	InvalidAccountID   *err.HTTPError
+	FailedToGetAccount *err.HTTPError
+}{
+	AccountNotFound:    err.NewHTTPError(http.StatusNotFound, "Account not found."),
+	InvalidAccountID:   err.NewHTTPError(http.StatusBadRequest, "Invalid account ID format."),
+	FailedToGetAccount: err.NewHTTPError(http.StatusInternalServerError, "Failed to get account."),
+}
@@ -0,0 +1,40 @@
+package get
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/account"
+	"github.com/rsmrtk/mybox/internal/rest/services/account/form"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_account"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx  context.Context
+	req  *account.GetRequest
+	f    *Facade
+	data *m_account.Data
+}
+
+func (s *service) find() error {
+	id, err := uuid.Parse(s.req.AccountID)
+	if err != nil {
+		return errs.InvalidAccountID
+	}
+
+	s.data, err = s.f.pkg.M.Account.Find(s.ctx, utils.AuthCtx(s.ctx), id.String())
+	if errors.Is(err, m_account.ErrNotFound) {
+		return errs.AccountNotFound
+	}
+	if err != nil {
+		return errs.FailedToGetAccount
+	}
+
+	return nil
+}
+
+func (s *service) reply() *account.GetResponse {
+	return &account.GetResponse{Account: form.Convert(s.data)}
+}
@@ -0,0 +1,13 @@
+package list
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	FailedToListAccounts *err.HTTPError
+}{
+	FailedToListAccounts: err.NewHTTPError(http.StatusInternalServerError, "Failed to list accounts."),
+}
@@ -0,0 +1,36 @@
+package list
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/account"
+	"github.com/rsmrtk/mybox/internal/rest/services/account/form"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_account"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx   context.Context
+	req   *account.ListRequest
+	f     *Facade
+	items []*m_account.Data
+}
+
+func (s *service) list() error {
+	var err error
+	s.items, err = s.f.pkg.M.Account.List(s.ctx, utils.AuthCtx(s.ctx))
+	if err != nil {
+		return errs.FailedToListAccounts
+	}
+
+	return nil
+}
+
+func (s *service) reply() *account.ListResponse {
+	items := make([]*account.Account, 0, len(s.items))
+	for _, d := range s.items {
+		a := form.Convert(d)
+		items = append(items, &a)
+	}
+	return &account.ListResponse{Items: items}
+}
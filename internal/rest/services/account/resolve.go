@@ -0,0 +1,45 @@
+package account
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_account"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+// Resolve looks up an account referenced by an income or expense. Malformed
+// IDs, unauthenticated callers and accounts of other workspaces all yield
+// m_account.ErrNotFound.
+func Resolve(ctx context.Context, f *pkg.Facade, id string) (*m_account.Data, error) {
+	workspaceID, ok := utils.AuthLookup(ctx)
+	if !ok {
+		return nil, m_account.ErrNotFound
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, m_account.ErrNotFound
+	}
+	return f.M.Account.Find(ctx, workspaceID, id)
+}
+
+// Currencies returns the currencies of the records linked to the accounts,
+// given as account IDs keyed by record ID, keyed by record ID in turn.
+// Records outside an account are left out and stay in record.DefaultCurrency.
+func Currencies(ctx context.Context, f *pkg.Facade, accounts map[string]string) (map[string]string, error) {
+	ids := make([]string, 0, len(accounts))
+	for _, id := range accounts {
+		ids = append(ids, id)
+	}
+	byAccount, err := f.M.Account.Currencies(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	currencies := make(map[string]string, len(accounts))
+	for recordID, accountID := range accounts {
+		if currency, ok := byAccount[accountID]; ok {
+			currencies[recordID] = currency
+		}
+	}
+	return currencies, nil
+}
@@ -0,0 +1,33 @@
+package account
+
+import (
+	"github.com/rsmrtk/mybox/internal/rest/services/account/balance"
+	"github.com/rsmrtk/mybox/internal/rest/services/account/create"
+	"github.com/rsmrtk/mybox/internal/rest/services/account/delete"
+	"github.com/rsmrtk/mybox/internal/rest/services/account/get"
+	"github.com/rsmrtk/mybox/internal/rest/services/account/list"
+	"github.com/rsmrtk/mybox/internal/rest/services/account/update"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Service is the account service facade
+type Service struct {
+	Get     *get.Facade
+	List    *list.Facade
+	Create  *create.Facade
+	Update  *update.Facade
+	Delete  *delete.Facade
+	Balance *balance.Facade
+}
+
+// New creates a new account service
+func New(f *pkg.Facade) *Service {
+	return &Service{
+		Get:     get.New(f),
+		List:    list.New(f),
+		Create:  create.New(f),
+		Update:  update.New(f),
+		Delete:  delete.New(f),
+		Balance: balance.New(f),
+	}
+}
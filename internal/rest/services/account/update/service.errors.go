@@ -0,0 +1,27 @@
+package update
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	AccountNotFound       *err.HTTPError
+	InvalidAccountID      *err.HTTPError
+	InvalidName           *err.HTTPError
+	InvalidKind           *err.HTTPError
+	InvalidCurrency       *err.HTTPError
+	DuplicateName         *err.HTTPError
+	CurrencyInUse         *err.HTTPError
+	FailedToUpdateAccount *err.HTTPError
+}{
+	AccountNotFound:       err.NewHTTPError(http.StatusNotFound, "Account not found."),
+	InvalidAccountID:      err.NewHTTPError(http.StatusBadRequest, "Invalid account ID format."),
+	InvalidName:           err.NewHTTPError(http.StatusBadRequest, "Name must not be blank."),
+	InvalidKind:           err.NewHTTPError(http.StatusBadRequest, "Kind must be cash, checking, credit_card or savings."),
+	InvalidCurrency:       err.NewHTTPError(http.StatusBadRequest, "Currency must be a three-letter ISO 4217 code."),
+	DuplicateName:         err.NewHTTPError(http.StatusConflict, "An account with this name already exists."),
+	CurrencyInUse:         err.NewHTTPError(http.StatusConflict, "The currency of an account with records or transfers cannot be changed."),
+	FailedToUpdateAccount: err.NewHTTPError(http.StatusInternalServerError, "Failed to update account."),
+}
@@ -0,0 +1,87 @@
+package update
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/account"
+	"github.com/rsmrtk/mybox/internal/rest/services/account/form"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_account"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx  context.Context
+	req  *account.UpdateRequest
+	f    *Facade
+	data *m_account.Data
+}
+
+func (s *service) update() error {
+	id, err := uuid.Parse(s.req.AccountID)
+	if err != nil {
+		return errs.InvalidAccountID
+	}
+
+	s.data, err = s.f.pkg.M.Account.Find(s.ctx, utils.AuthCtx(s.ctx), id.String())
+	if errors.Is(err, m_account.ErrNotFound) {
+		return errs.AccountNotFound
+	}
+	if err != nil {
+		return errs.FailedToUpdateAccount
+	}
+
+	if s.req.Name != "" {
+		if s.data.Name = form.Name(s.req.Name); s.data.Name == "" {
+			return errs.InvalidName
+		}
+	}
+	if s.req.Kind != "" {
+		if !form.ValidKind(s.req.Kind) {
+			return errs.InvalidKind
+		}
+		s.data.Kind = s.req.Kind
+	}
+	if s.req.Currency != "" {
+		currency, ok := form.Currency(s.req.Currency)
+		if !ok {
+			return errs.InvalidCurrency
+		}
+		if currency != s.data.Currency {
+			// Amounts already booked are in the old currency
+			moved, err := s.f.pkg.M.Account.Moved(s.ctx, s.data.AccountID)
+			if err != nil {
+				return errs.FailedToUpdateAccount
+			}
+			if moved {
+				return errs.CurrencyInUse
+			}
+		}
+		s.data.Currency = currency
+	}
+	if s.req.OpeningBalance != nil {
+		// The balance moves with the opening balance
+		s.data.Balance += *s.req.OpeningBalance - s.data.OpeningBalance
+		s.data.OpeningBalance = *s.req.OpeningBalance
+	}
+	if s.req.OpenedOn != nil && !s.req.OpenedOn.IsZero() {
+		s.data.OpenedOn = s.req.OpenedOn.Time
+	}
+
+	err = s.f.pkg.M.Account.Update(s.ctx, s.data)
+	switch {
+	case errors.Is(err, m_account.ErrNotFound):
+		return errs.AccountNotFound
+	case errors.Is(err, m_account.ErrDuplicateName):
+		return errs.DuplicateName
+	case err != nil:
+		return errs.FailedToUpdateAccount
+	}
+
+	return nil
+}
+
+func (s *service) reply() *account.UpdateResponse {
+	return &account.UpdateResponse{Account: form.Convert(s.data)}
+}
@@ -0,0 +1,41 @@
+package update
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/account"
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/dbtx"
+)
+
+// Facade is the update account facade
+type Facade struct {
+	pkg *pkg.Facade
+}
+
+// New creates a new update account facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg: pkg,
+	}
+}
+
+// Handle handles the update account request
+func (f *Facade) Handle(ctx context.Context, req *account.UpdateRequest) (*account.UpdateResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	// The movements are checked in the transaction that changes the currency
+	err := dbtx.InTx(ctx, f.pkg.M.DB, func(ctx context.Context) error {
+		s.ctx = ctx
+		return s.update()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
@@ -0,0 +1,21 @@
+package list
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	InvalidEntityType  *err.HTTPError
+	InvalidEntityID    *err.HTTPError
+	InvalidActorID     *err.HTTPError
+	InvalidDateRange   *err.HTTPError
+	FailedToListAudits *err.HTTPError
+}{
+	InvalidEntityType:  err.NewHTTPError(http.StatusBadRequest, "Entity type must be income, expense or transfer."),
+	InvalidEntityID:    err.NewHTTPError(http.StatusBadRequest, "Invalid entity ID format."),
+	InvalidActorID:     err.NewHTTPError(http.StatusBadRequest, "Invalid actor ID format."),
+	InvalidDateRange:   err.NewHTTPError(http.StatusBadRequest, "From must not be after to."),
+	FailedToListAudits: err.NewHTTPError(http.StatusInternalServerError, "Failed to list audit log."),
+}
@@ -0,0 +1,97 @@
+package list
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/audit"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_audit"
+)
+
+type service struct {
+	ctx   context.Context
+	req   *audit.ListRequest
+	f     *Facade
+	items []*m_audit.Data
+	total int
+}
+
+func (s *service) list() error {
+	// Set default values if not provided
+	if s.req.Limit <= 0 || s.req.Limit > 500 {
+		s.req.Limit = 100 // Default limit
+	}
+	if s.req.Offset < 0 {
+		s.req.Offset = 0
+	}
+
+	filter := m_audit.Filter{
+		EntityType: s.req.EntityType,
+		EntityID:   s.req.EntityID,
+		ActorID:    s.req.ActorID,
+		Limit:      s.req.Limit,
+		Offset:     s.req.Offset,
+	}
+
+	switch s.req.EntityType {
+	case "", "income", "expense", "transfer":
+	default:
+		return errs.InvalidEntityType
+	}
+	if s.req.EntityID != "" {
+		if _, err := uuid.Parse(s.req.EntityID); err != nil {
+			return errs.InvalidEntityID
+		}
+	}
+	if s.req.ActorID != "" {
+		if _, err := uuid.Parse(s.req.ActorID); err != nil {
+			return errs.InvalidActorID
+		}
+	}
+
+	if s.req.From != nil {
+		filter.From = &s.req.From.Time
+	}
+	if s.req.To != nil {
+		// To is inclusive, so the range ends at the start of the next day
+		to := s.req.To.Time.AddDate(0, 0, 1)
+		filter.To = &to
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return errs.InvalidDateRange
+	}
+
+	var err error
+	s.items, s.total, err = s.f.pkg.M.Audit.List(s.ctx, filter)
+	if err != nil {
+		return errs.FailedToListAudits
+	}
+
+	return nil
+}
+
+func (s *service) reply() *audit.ListResponse {
+	items := make([]*audit.ListItem, 0, len(s.items))
+
+	for _, data := range s.items {
+		items = append(items, &audit.ListItem{
+			AuditID:         data.AuditID,
+			OccurredAt:      data.OccurredAt.UTC().Truncate(time.Millisecond),
+			ActorCustomerID: data.ActorCustomerID,
+			ActorAPIKeyID:   data.ActorAPIKeyID,
+			RequestID:       data.RequestID,
+			EntityType:      data.EntityType,
+			EntityID:        data.EntityID,
+			Action:          data.Action,
+			Diff:            data.Diff,
+		})
+	}
+
+	return &audit.ListResponse{
+		Items:      items,
+		TotalCount: s.total,
+		Limit:      s.req.Limit,
+		Offset:     s.req.Offset,
+	}
+}
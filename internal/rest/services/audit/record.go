@@ -0,0 +1,183 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/db-fd-model/m_expense"
+	"github.com/rsmrtk/db-fd-model/m_income"
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_audit"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_split"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_transfer"
+	"github.com/rsmrtk/mybox/pkg/utils"
+	lg "github.com/rsmrtk/smartlg/logger"
+)
+
+// Entity types recorded in the log.
+const (
+	EntityIncome   = "income"
+	EntityExpense  = "expense"
+	EntityTransfer = "transfer"
+)
+
+// Snapshot is the audited state of a record, keyed by API field name.
+type Snapshot map[string]any
+
+// Change is one field of a diff.
+type Change struct {
+	Old any `json:"old"`
+	New any `json:"new"`
+}
+
+// Record appends an entry for a mutation. before is nil for creates and
+// after is nil for deletes. Callers run it in the transaction of the
+// mutation, so that an entry that cannot be written rolls the mutation back.
+func Record(ctx context.Context, f *pkg.Facade, entityType, entityID, action string, before, after Snapshot) error {
+	diff, err := json.Marshal(Diff(before, after))
+	if err != nil {
+		return fmt.Errorf("failed to encode audit diff: %w", err)
+	}
+
+	d := &m_audit.Data{
+		AuditID:    uuid.New().String(),
+		OccurredAt: time.Now().UTC(),
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+		Diff:       diff,
+	}
+	if customerID, ok := utils.AuthLookup(ctx); ok {
+		d.ActorCustomerID = &customerID
+	}
+	if keyID := utils.AuthAPIKeyCtx(ctx); keyID != "" {
+		d.ActorAPIKeyID = &keyID
+	}
+	if requestID := utils.RequestIDCtx(ctx); requestID != "" {
+		d.RequestID = &requestID
+	}
+
+	if err := f.M.Audit.Create(ctx, d); err != nil {
+		f.Log.Error("failed to write audit log", lg.H{"error": err.Error(), "entity_id": entityID, "action": action})
+		return err
+	}
+	return nil
+}
+
+// Diff returns the fields whose value differs between before and after.
+func Diff(before, after Snapshot) map[string]Change {
+	diff := map[string]Change{}
+	for k, v := range before {
+		if nv, ok := after[k]; !ok || !reflect.DeepEqual(v, nv) {
+			diff[k] = Change{Old: v, New: after[k]}
+		}
+	}
+	for k, v := range after {
+		if _, ok := before[k]; !ok {
+			diff[k] = Change{New: v}
+		}
+	}
+	return diff
+}
+
+// ExpenseSnapshot captures the audited fields of an expense row.
+func ExpenseSnapshot(d *m_expense.Data) Snapshot {
+	s := Snapshot{}
+	if name, ok := d.ExpenseName.(string); ok {
+		s["expense_name"] = name
+	}
+	if d.ExpenseAmount.Valid {
+		s["expense_amount"] = d.ExpenseAmount.Float64
+	}
+	if typ, ok := d.ExpenseType.(string); ok {
+		s["expense_type"] = typ
+	}
+	if d.ExpenseDate.Valid {
+		s["expense_date"] = models.NewDate(d.ExpenseDate.Time).String()
+	}
+	return s
+}
+
+// IncomeSnapshot captures the audited fields of an income row.
+func IncomeSnapshot(d *m_income.Data) Snapshot {
+	s := Snapshot{}
+	if d.IncomeName != nil {
+		s["income_name"] = *d.IncomeName
+	}
+	if d.IncomeAmount != nil {
+		s["income_amount"] = *d.IncomeAmount
+	}
+	if d.IncomeType != nil {
+		s["income_type"] = *d.IncomeType
+	}
+	if d.IncomeDate != nil {
+		s["income_date"] = models.NewDate(*d.IncomeDate).String()
+	}
+	return s
+}
+
+// TransferSnapshot captures the audited fields of a transfer row.
+func TransferSnapshot(d *m_transfer.Data) Snapshot {
+	s := Snapshot{
+		"from_account_id": d.FromAccountID,
+		"to_account_id":   d.ToAccountID,
+		"amount":          d.Amount,
+		"rate":            d.Rate,
+		"received":        d.Received,
+		"fee":             d.Fee,
+		"transfer_date":   models.NewDate(d.TransferDate).String(),
+	}
+	if d.Note != nil {
+		s["note"] = *d.Note
+	}
+	return s
+}
+
+// WithCategory adds the category of a record to its snapshot.
+func (s Snapshot) WithCategory(categoryID string) Snapshot {
+	if categoryID != "" {
+		s["category_id"] = categoryID
+	}
+	return s
+}
+
+// WithAccount adds the account of a record to its snapshot.
+func (s Snapshot) WithAccount(accountID string) Snapshot {
+	if accountID != "" {
+		s["account_id"] = accountID
+	}
+	return s
+}
+
+// WithTags adds the tags of a record to its snapshot.
+func (s Snapshot) WithTags(tags []string) Snapshot {
+	if len(tags) > 0 {
+		s["tags"] = tags
+	}
+	return s
+}
+
+// WithSplits adds the split lines of an expense to its snapshot.
+func (s Snapshot) WithSplits(lines []*m_split.Data) Snapshot {
+	if len(lines) == 0 {
+		return s
+	}
+	splits := make([]map[string]any, 0, len(lines))
+	for _, d := range lines {
+		line := map[string]any{"amount": d.Amount}
+		if d.CategoryID != nil {
+			line["category_id"] = *d.CategoryID
+		}
+		if d.Note != nil {
+			line["note"] = *d.Note
+		}
+		splits = append(splits, line)
+	}
+	s["splits"] = splits
+	return s
+}
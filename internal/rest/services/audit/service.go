@@ -0,0 +1,18 @@
+package audit
+
+import (
+	"github.com/rsmrtk/mybox/internal/rest/services/audit/list"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Service is the audit log service facade
+type Service struct {
+	List *list.Facade
+}
+
+// New creates a new audit log service
+func New(f *pkg.Facade) *Service {
+	return &Service{
+		List: list.New(f),
+	}
+}
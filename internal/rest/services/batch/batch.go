@@ -0,0 +1,144 @@
+// Package batch runs a list of operations through the regular single-record
+// services, either in one transaction or each in its own.
+package batch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin/binding"
+	er "github.com/rsmrtk/fd-er"
+	"github.com/rsmrtk/mybox/internal/rest/domain/batch"
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/dbtx"
+)
+
+// Handler runs one operation and returns the response of the service it
+// was dispatched to.
+type Handler func(ctx context.Context, op *batch.Operation) (any, error)
+
+var errs = struct {
+	InvalidMode  *er.HTTPError
+	NoOperations *er.HTTPError
+	RolledBack   *er.HTTPError
+}{
+	InvalidMode:  er.NewHTTPError(http.StatusBadRequest, "Mode must be atomic or partial."),
+	NoOperations: er.NewHTTPError(http.StatusBadRequest, "Batch has no operations."),
+	RolledBack:   er.NewHTTPError(http.StatusFailedDependency, "Rolled back because another operation in the batch failed."),
+}
+
+// errFailed rolls back an atomic batch once every operation has been tried.
+var errFailed = errors.New("batch operation failed")
+
+// Run validates the request and executes its operations in order. In atomic
+// mode every operation runs in a savepoint of a single transaction, so that
+// all of them are tried and reported before the transaction is rolled back.
+func Run(ctx context.Context, f *pkg.Facade, req *batch.Request, handle Handler) (*batch.Response, error) {
+	if req.Mode == "" {
+		req.Mode = batch.ModeAtomic
+	}
+	if req.Mode != batch.ModeAtomic && req.Mode != batch.ModePartial {
+		return nil, errs.InvalidMode
+	}
+	if len(req.Operations) == 0 {
+		return nil, errs.NoOperations
+	}
+	if max := f.Config.Batch.MaxOperations; len(req.Operations) > max {
+		return nil, er.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Batch has %d operations; at most %d are allowed.", len(req.Operations), max))
+	}
+
+	res := &batch.Response{
+		Mode:    req.Mode,
+		Results: make([]*batch.Result, len(req.Operations)),
+	}
+
+	if req.Mode == batch.ModePartial {
+		for i, op := range req.Operations {
+			res.Results[i] = runOne(ctx, i, op, func(ctx context.Context, fn func(context.Context) error) error {
+				return dbtx.InTx(ctx, f.M.DB, fn)
+			}, handle)
+		}
+		res.Committed = true
+		return count(res), nil
+	}
+
+	err := dbtx.InTx(ctx, f.M.DB, func(ctx context.Context) error {
+		failed := false
+		for i, op := range req.Operations {
+			res.Results[i] = runOne(ctx, i, op, func(ctx context.Context, fn func(context.Context) error) error {
+				return dbtx.Savepoint(ctx, fmt.Sprintf("batch_op_%d", i), fn)
+			}, handle)
+			failed = failed || res.Results[i].Error != nil
+		}
+		if failed {
+			return errFailed
+		}
+		return nil
+	})
+	switch {
+	case err == nil:
+		res.Committed = true
+	case errors.Is(err, errFailed):
+		for _, r := range res.Results {
+			if r.Error == nil {
+				r.Status, r.Result, r.Error = errs.RolledBack.Code, nil, &batch.Error{Code: errs.RolledBack.Code, Message: fmt.Sprint(errs.RolledBack.Message)}
+			}
+		}
+	default:
+		return nil, er.NewHTTPError(http.StatusInternalServerError, "Failed to run batch.").SetInternal(err)
+	}
+	return count(res), nil
+}
+
+func runOne(ctx context.Context, i int, op *batch.Operation, wrap func(context.Context, func(context.Context) error) error, handle Handler) *batch.Result {
+	r := &batch.Result{Index: i, Op: op.Op, Status: http.StatusOK}
+	if op.Op == batch.OpCreate {
+		r.Status = http.StatusCreated
+	}
+
+	err := wrap(ctx, func(ctx context.Context) error {
+		var err error
+		r.Result, err = handle(ctx, op)
+		return err
+	})
+	if err != nil {
+		code, message := http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError)
+		var herr *er.HTTPError
+		if errors.As(err, &herr) {
+			code, message = herr.Code, fmt.Sprint(herr.Message)
+		}
+		r.Status, r.Result, r.Error = code, nil, &batch.Error{Code: code, Message: message}
+	}
+	return r
+}
+
+func count(res *batch.Response) *batch.Response {
+	for _, r := range res.Results {
+		if r.Error == nil {
+			res.Succeeded++
+		} else {
+			res.Failed++
+		}
+	}
+	return res
+}
+
+// Decode reads the data of an operation into the request type of the single
+// record endpoint and applies the same binding rules as its controller.
+func Decode(op *batch.Operation, req any) error {
+	if err := json.Unmarshal(op.Data, req); err != nil {
+		return er.NewHTTPError(http.StatusBadRequest, "Invalid operation data.").SetInternal(err)
+	}
+	if err := binding.Validator.ValidateStruct(req); err != nil {
+		return er.NewHTTPError(http.StatusBadRequest, "Invalid operation data.").SetInternal(err)
+	}
+	return nil
+}
+
+// UnknownOperation is returned by handlers for an op they do not support.
+func UnknownOperation(op *batch.Operation) error {
+	return er.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unknown operation %q.", op.Op))
+}
@@ -0,0 +1,25 @@
+package create
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	InvalidPeriod        *err.HTTPError
+	InvalidAmount        *err.HTTPError
+	InvalidCurrency      *err.HTTPError
+	TargetRequired       *err.HTTPError
+	UnknownCategory      *err.HTTPError
+	BudgetExists         *err.HTTPError
+	FailedToCreateBudget *err.HTTPError
+}{
+	InvalidPeriod:        err.NewHTTPError(http.StatusBadRequest, "Period must be month or year."),
+	InvalidAmount:        err.NewHTTPError(http.StatusBadRequest, "Amount must be positive."),
+	InvalidCurrency:      err.NewHTTPError(http.StatusBadRequest, "Currency must be a three-letter ISO 4217 code."),
+	TargetRequired:       err.NewHTTPError(http.StatusBadRequest, "Exactly one of category_id and expense_type is required."),
+	UnknownCategory:      err.NewHTTPError(http.StatusBadRequest, "Category not found."),
+	BudgetExists:         err.NewHTTPError(http.StatusConflict, "A budget for this category or expense type and period already exists."),
+	FailedToCreateBudget: err.NewHTTPError(http.StatusInternalServerError, "Failed to create budget."),
+}
@@ -0,0 +1,87 @@
+package create
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/budget"
+	accountform "github.com/rsmrtk/mybox/internal/rest/services/account/form"
+	"github.com/rsmrtk/mybox/internal/rest/services/budget/form"
+	"github.com/rsmrtk/mybox/internal/rest/services/category"
+	"github.com/rsmrtk/mybox/internal/rest/services/record"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_budget"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_category"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx  context.Context
+	req  *budget.CreateRequest
+	f    *Facade
+	data *m_budget.Data
+}
+
+func (s *service) create() error {
+	if !form.ValidPeriod(s.req.Period) {
+		return errs.InvalidPeriod
+	}
+	if s.req.Amount <= 0 {
+		return errs.InvalidAmount
+	}
+	cur := record.DefaultCurrency
+	if s.req.Currency != "" {
+		var ok bool
+		if cur, ok = accountform.Currency(s.req.Currency); !ok {
+			return errs.InvalidCurrency
+		}
+	}
+
+	now := time.Now().UTC()
+	s.data = &m_budget.Data{
+		BudgetID:    uuid.New().String(),
+		WorkspaceID: utils.AuthCtx(s.ctx),
+		Period:      s.req.Period,
+		Amount:      s.req.Amount,
+		Currency:    cur,
+		Rollover:    s.req.Rollover,
+		StartsOn:    form.PeriodStart(now, s.req.Period),
+		CreatedAt:   now,
+	}
+	if s.req.StartsOn != nil && !s.req.StartsOn.IsZero() {
+		s.data.StartsOn = form.PeriodStart(s.req.StartsOn.Time, s.req.Period)
+	}
+
+	expType := strings.TrimSpace(s.req.ExpenseType)
+	if (s.req.CategoryID == "") == (expType == "") {
+		return errs.TargetRequired
+	}
+	if expType != "" {
+		s.data.ExpenseType = &expType
+	} else {
+		c, err := category.Resolve(s.ctx, s.f.pkg, s.req.CategoryID)
+		if errors.Is(err, m_category.ErrNotFound) {
+			return errs.UnknownCategory
+		}
+		if err != nil {
+			return errs.FailedToCreateBudget
+		}
+		s.data.CategoryID = &c.CategoryID
+	}
+
+	err := s.f.pkg.M.Budget.Create(s.ctx, s.data)
+	if errors.Is(err, m_budget.ErrDuplicate) {
+		return errs.BudgetExists
+	}
+	if err != nil {
+		return errs.FailedToCreateBudget
+	}
+
+	return nil
+}
+
+func (s *service) reply() *budget.CreateResponse {
+	return &budget.CreateResponse{Budget: form.Convert(s.data)}
+}
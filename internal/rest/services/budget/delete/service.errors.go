@@ -0,0 +1,17 @@
+package delete
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	BudgetNotFound       *err.HTTPError
+	InvalidBudgetID      *err.HTTPError
+	FailedToDeleteBudget *err.HTTPError
+}{
+	BudgetNotFound:       err.NewHTTPError(http.StatusNotFound, "Budget not found."),
+	InvalidBudgetID:      err.NewHTTPError(http.StatusBadRequest, "Invalid budget ID format."),
+	FailedToDeleteBudget: err.NewHTTPError(http.StatusInternalServerError, "Failed to delete budget."),
+}
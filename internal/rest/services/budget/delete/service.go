@@ -0,0 +1,41 @@
+package delete
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/budget"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_budget"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx context.Context
+	req *budget.DeleteRequest
+	f   *Facade
+}
+
+func (s *service) delete() error {
+	id, err := uuid.Parse(s.req.BudgetID)
+	if err != nil {
+		return errs.InvalidBudgetID
+	}
+
+	err = s.f.pkg.M.Budget.Delete(s.ctx, utils.AuthCtx(s.ctx), id.String())
+	if errors.Is(err, m_budget.ErrNotFound) {
+		return errs.BudgetNotFound
+	}
+	if err != nil {
+		return errs.FailedToDeleteBudget
+	}
+
+	return nil
+}
+
+func (s *service) reply() *budget.DeleteResponse {
+	return &budget.DeleteResponse{
+		Success: true,
+		Message: "Budget deleted successfully",
+	}
+}
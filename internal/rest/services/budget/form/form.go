@@ -0,0 +1,55 @@
+// Package form holds the period arithmetic of budgets and converts budgets
+// for the API. The budget services share it.
+package form
+
+import (
+	"slices"
+	"time"
+
+	db "github.com/rsmrtk/mybox/internal/rest/domain/budget"
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+	"github.com/rsmrtk/mybox/internal/rest/services/record"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_budget"
+)
+
+// ValidPeriod reports whether s is one of m_budget.Periods.
+func ValidPeriod(s string) bool {
+	return slices.Contains(m_budget.Periods, s)
+}
+
+// PeriodStart returns the first day of the period that holds t.
+func PeriodStart(t time.Time, period string) time.Time {
+	if period == m_budget.PeriodYear {
+		return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// NextPeriod returns the first day of the period after the one starting on start.
+func NextPeriod(start time.Time, period string) time.Time {
+	if period == m_budget.PeriodYear {
+		return start.AddDate(1, 0, 0)
+	}
+	return start.AddDate(0, 1, 0)
+}
+
+// Convert returns the API form of a budget.
+func Convert(d *m_budget.Data) db.Budget {
+	b := db.Budget{
+		BudgetID:  d.BudgetID,
+		Period:    d.Period,
+		Amount:    record.AmountsIn(d.Amount, d.Currency),
+		Currency:  d.Currency,
+		Rollover:  d.Rollover,
+		StartsOn:  models.NewDate(d.StartsOn),
+		CreatedAt: d.CreatedAt,
+		UpdatedAt: d.UpdatedAt,
+	}
+	if d.CategoryID != nil {
+		b.CategoryID = *d.CategoryID
+	}
+	if d.ExpenseType != nil {
+		b.ExpenseType = *d.ExpenseType
+	}
+	return b
+}
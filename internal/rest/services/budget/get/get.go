@@ -0,0 +1,35 @@
+package get
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/budget"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Facade is the get budget facade
+type Facade struct {
+	pkg *pkg.Facade
+}
+
+// New creates a new get budget facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg: pkg,
+	}
+}
+
+// Handle handles the get budget request
+func (f *Facade) Handle(ctx context.Context, req *budget.GetRequest) (*budget.GetResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	if err := s.find(); err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
@@ -0,0 +1,40 @@
+package get
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/budget"
+	"github.com/rsmrtk/mybox/internal/rest/services/budget/form"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_budget"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx  context.Context
+	req  *budget.GetRequest
+	f    *Facade
+	data *m_budget.Data
+}
+
+func (s *service) find() error {
+	id, err := uuid.Parse(s.req.BudgetID)
+	if err != nil {
+		return errs.InvalidBudgetID
+	}
+
+	s.data, err = s.f.pkg.M.Budget.Find(s.ctx, utils.AuthCtx(s.ctx), id.String())
+	if errors.Is(err, m_budget.ErrNotFound) {
+		return errs.BudgetNotFound
+	}
+	if err != nil {
+		return errs.FailedToGetBudget
+	}
+
+	return nil
+}
+
+func (s *service) reply() *budget.GetResponse {
+	return &budget.GetResponse{Budget: form.Convert(s.data)}
+}
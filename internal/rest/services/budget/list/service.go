@@ -0,0 +1,36 @@
+package list
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/budget"
+	"github.com/rsmrtk/mybox/internal/rest/services/budget/form"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_budget"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx   context.Context
+	req   *budget.ListRequest
+	f     *Facade
+	items []*m_budget.Data
+}
+
+func (s *service) list() error {
+	var err error
+	s.items, err = s.f.pkg.M.Budget.List(s.ctx, utils.AuthCtx(s.ctx))
+	if err != nil {
+		return errs.FailedToListBudgets
+	}
+
+	return nil
+}
+
+func (s *service) reply() *budget.ListResponse {
+	items := make([]*budget.Budget, 0, len(s.items))
+	for _, d := range s.items {
+		b := form.Convert(d)
+		items = append(items, &b)
+	}
+	return &budget.ListResponse{Items: items}
+}
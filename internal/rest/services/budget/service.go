@@ -0,0 +1,33 @@
+package budget
+
+import (
+	"github.com/rsmrtk/mybox/internal/rest/services/budget/create"
+	"github.com/rsmrtk/mybox/internal/rest/services/budget/delete"
+	"github.com/rsmrtk/mybox/internal/rest/services/budget/get"
+	"github.com/rsmrtk/mybox/internal/rest/services/budget/list"
+	"github.com/rsmrtk/mybox/internal/rest/services/budget/status"
+	"github.com/rsmrtk/mybox/internal/rest/services/budget/update"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Service is the budget service facade
+type Service struct {
+	Get    *get.Facade
+	List   *list.Facade
+	Create *create.Facade
+	Update *update.Facade
+	Delete *delete.Facade
+	Status *status.Facade
+}
+
+// New creates a new budget service
+func New(f *pkg.Facade) *Service {
+	return &Service{
+		Get:    get.New(f),
+		List:   list.New(f),
+		Create: create.New(f),
+		Update: update.New(f),
+		Delete: delete.New(f),
+		Status: status.New(f),
+	}
+}
@@ -0,0 +1,17 @@
+package status
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	BudgetNotFound          *err.HTTPError
+	InvalidBudgetID         *err.HTTPError
+	FailedToGetBudgetStatus *err.HTTPError
+}{
+	BudgetNotFound:          err.NewHTTPError(http.StatusNotFound, "Budget not found."),
+	InvalidBudgetID:         err.NewHTTPError(http.StatusBadRequest, "Invalid budget ID format."),
+	FailedToGetBudgetStatus: err.NewHTTPError(http.StatusInternalServerError, "Failed to get budget status."),
+}
@@ -0,0 +1,187 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/budget"
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+	"github.com/rsmrtk/mybox/internal/rest/services/budget/form"
+	"github.com/rsmrtk/mybox/internal/rest/services/category/tree"
+	"github.com/rsmrtk/mybox/internal/rest/services/record"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_budget"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_transaction"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx     context.Context
+	req     *budget.StatusRequest
+	f       *Facade
+	date    time.Time
+	today   time.Time
+	budgets []*m_budget.Data
+	tree    *tree.Tree
+	totals  []*m_transaction.PeriodTotal
+	items   []*budget.BudgetStatus
+}
+
+func (s *service) status() error {
+	s.today = day(time.Now().UTC())
+	s.date = s.today
+	if s.req.Date != nil && !s.req.Date.IsZero() {
+		s.date = day(s.req.Date.Time)
+	}
+
+	if err := s.load(); err != nil {
+		return err
+	}
+	if len(s.budgets) == 0 {
+		return nil
+	}
+
+	items, err := s.f.pkg.M.Category.List(s.ctx, utils.AuthCtx(s.ctx))
+	if err != nil {
+		return errs.FailedToGetBudgetStatus
+	}
+	s.tree = tree.New(items)
+
+	// Spending is read once, by month, from the first budgeted period to the
+	// end of the latest period asked for; year budgets add up their months
+	from, to := s.budgets[0].StartsOn, time.Time{}
+	for _, b := range s.budgets {
+		if b.StartsOn.Before(from) {
+			from = b.StartsOn
+		}
+		if end := form.NextPeriod(form.PeriodStart(s.date, b.Period), b.Period); end.After(to) {
+			to = end
+		}
+	}
+	s.totals, err = s.f.pkg.M.Transaction.ExpensesByPeriod(s.ctx, m_transaction.Filter{From: &from, To: &to}, m_budget.PeriodMonth)
+	if err != nil {
+		return errs.FailedToGetBudgetStatus
+	}
+
+	for _, b := range s.budgets {
+		s.items = append(s.items, s.compute(b))
+	}
+
+	return nil
+}
+
+// load reads the budget asked for or all of them, keeping those that have
+// started by the requested date.
+func (s *service) load() error {
+	workspaceID := utils.AuthCtx(s.ctx)
+	var budgets []*m_budget.Data
+	if s.req.BudgetID != "" {
+		id, err := uuid.Parse(s.req.BudgetID)
+		if err != nil {
+			return errs.InvalidBudgetID
+		}
+		d, err := s.f.pkg.M.Budget.Find(s.ctx, workspaceID, id.String())
+		if errors.Is(err, m_budget.ErrNotFound) {
+			return errs.BudgetNotFound
+		}
+		if err != nil {
+			return errs.FailedToGetBudgetStatus
+		}
+		budgets = []*m_budget.Data{d}
+	} else {
+		var err error
+		budgets, err = s.f.pkg.M.Budget.List(s.ctx, workspaceID)
+		if err != nil {
+			return errs.FailedToGetBudgetStatus
+		}
+	}
+
+	for _, b := range budgets {
+		if !b.StartsOn.After(s.date) {
+			s.budgets = append(s.budgets, b)
+		}
+	}
+	return nil
+}
+
+// compute compares a budget with the spending of the period holding the
+// requested date. With rollover, every earlier period since the budget
+// started carries what was left of it, or what was overspent, forward.
+func (s *service) compute(b *m_budget.Data) *budget.BudgetStatus {
+	start := form.PeriodStart(s.date, b.Period)
+	end := form.NextPeriod(start, b.Period)
+
+	spent := map[time.Time]float64{}
+	for _, t := range s.totals {
+		if s.matches(b, t) {
+			spent[form.PeriodStart(t.Period, b.Period)] += t.Amount
+		}
+	}
+
+	carried := 0.0
+	if b.Rollover {
+		for p := b.StartsOn; p.Before(start); p = form.NextPeriod(p, b.Period) {
+			carried += b.Amount - spent[p]
+		}
+	}
+	planned := b.Amount + carried
+	actual := spent[start]
+
+	st := &budget.BudgetStatus{
+		Budget:      form.Convert(b),
+		PeriodStart: models.NewDate(start),
+		PeriodEnd:   models.NewDate(end.AddDate(0, 0, -1)),
+		Carried:     record.AmountsIn(carried, b.Currency),
+		Planned:     record.AmountsIn(planned, b.Currency),
+		Actual:      record.AmountsIn(actual, b.Currency),
+		Remaining:   record.AmountsIn(planned-actual, b.Currency),
+		Projected:   record.AmountsIn(project(actual, start, end, s.today), b.Currency),
+	}
+	if planned > 0 {
+		st.PercentUsed = math.Round(actual/planned*10000) / 100
+	}
+	return st
+}
+
+// matches reports whether spending counts against a budget: a category
+// budget covers the category and its subcategories, a type budget the type
+// regardless of case. Spending in other currencies than the budget's is
+// left out, as the amounts cannot be added up.
+func (s *service) matches(b *m_budget.Data, t *m_transaction.PeriodTotal) bool {
+	if record.Currency(t.Currency) != b.Currency {
+		return false
+	}
+	if b.CategoryID != nil {
+		return t.CategoryID != nil && s.tree.IsDescendant(*t.CategoryID, *b.CategoryID)
+	}
+	return t.Type == strings.ToLower(*b.ExpenseType)
+}
+
+// project extrapolates actual to the whole of [start, end) at the daily rate
+// seen up to today. Periods that are over, or not started yet, keep actual.
+func project(actual float64, start, end, today time.Time) float64 {
+	if today.Before(start) || !today.Before(end) {
+		return actual
+	}
+	elapsed := today.Sub(start).Hours()/24 + 1
+	total := end.Sub(start).Hours() / 24
+	return actual / elapsed * total
+}
+
+func (s *service) reply() *budget.StatusResponse {
+	items := s.items
+	if items == nil {
+		items = []*budget.BudgetStatus{}
+	}
+	return &budget.StatusResponse{
+		Date:  models.NewDate(s.date),
+		Items: items,
+	}
+}
+
+func day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,35 @@
+package status
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/budget"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Facade is the budget status facade
+type Facade struct {
+	pkg *pkg.Facade
+}
+
+// New creates a new budget status facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg: pkg,
+	}
+}
+
+// Handle handles the budget status request
+func (f *Facade) Handle(ctx context.Context, req *budget.StatusRequest) (*budget.StatusResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	if err := s.status(); err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
@@ -0,0 +1,19 @@
+package update
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	BudgetNotFound       *err.HTTPError
+	InvalidBudgetID      *err.HTTPError
+	InvalidAmount        *err.HTTPError
+	FailedToUpdateBudget *err.HTTPError
+}{
+	BudgetNotFound:       err.NewHTTPError(http.StatusNotFound, "Budget not found."),
+	InvalidBudgetID:      err.NewHTTPError(http.StatusBadRequest, "Invalid budget ID format."),
+	InvalidAmount:        err.NewHTTPError(http.StatusBadRequest, "Amount must be positive."),
+	FailedToUpdateBudget: err.NewHTTPError(http.StatusInternalServerError, "Failed to update budget."),
+}
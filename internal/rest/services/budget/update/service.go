@@ -0,0 +1,61 @@
+package update
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/budget"
+	"github.com/rsmrtk/mybox/internal/rest/services/budget/form"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_budget"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx  context.Context
+	req  *budget.UpdateRequest
+	f    *Facade
+	data *m_budget.Data
+}
+
+func (s *service) update() error {
+	id, err := uuid.Parse(s.req.BudgetID)
+	if err != nil {
+		return errs.InvalidBudgetID
+	}
+
+	s.data, err = s.f.pkg.M.Budget.Find(s.ctx, utils.AuthCtx(s.ctx), id.String())
+	if errors.Is(err, m_budget.ErrNotFound) {
+		return errs.BudgetNotFound
+	}
+	if err != nil {
+		return errs.FailedToUpdateBudget
+	}
+
+	if s.req.Amount != nil {
+		if *s.req.Amount <= 0 {
+			return errs.InvalidAmount
+		}
+		s.data.Amount = *s.req.Amount
+	}
+	if s.req.Rollover != nil {
+		s.data.Rollover = *s.req.Rollover
+	}
+	if s.req.StartsOn != nil && !s.req.StartsOn.IsZero() {
+		s.data.StartsOn = form.PeriodStart(s.req.StartsOn.Time, s.data.Period)
+	}
+
+	err = s.f.pkg.M.Budget.Update(s.ctx, s.data)
+	if errors.Is(err, m_budget.ErrNotFound) {
+		return errs.BudgetNotFound
+	}
+	if err != nil {
+		return errs.FailedToUpdateBudget
+	}
+
+	return nil
+}
+
+func (s *service) reply() *budget.UpdateResponse {
+	return &budget.UpdateResponse{Budget: form.Convert(s.data)}
+}
@@ -0,0 +1,35 @@
+package update
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/budget"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Facade is the update budget facade
+type Facade struct {
+	pkg *pkg.Facade
+}
+
+// New creates a new update budget facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg: pkg,
+	}
+}
+
+// Handle handles the update budget request
+func (f *Facade) Handle(ctx context.Context, req *budget.UpdateRequest) (*budget.UpdateResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	if err := s.update(); err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
@@ -0,0 +1,41 @@
+package create
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/category"
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/dbtx"
+)
+
+// Facade is the create category facade
+type Facade struct {
+	pkg *pkg.Facade
+}
+
+// New creates a new create category facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg: pkg,
+	}
+}
+
+// Handle handles the create category request
+func (f *Facade) Handle(ctx context.Context, req *category.CreateRequest) (*category.CreateResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	// The parent is checked in the same transaction that writes the category
+	err := dbtx.InTx(ctx, f.pkg.M.DB, func(ctx context.Context) error {
+		s.ctx = ctx
+		return s.create()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
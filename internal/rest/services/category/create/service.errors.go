@@ -0,0 +1,21 @@
+package create
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	InvalidName            *err.HTTPError
+	InvalidColor           *err.HTTPError
+	ParentNotFound         *err.HTTPError
+	DuplicateName          *err.HTTPError
+	FailedToCreateCategory *err.HTTPError
+}{
+	InvalidName:            err.NewHTTPError(http.StatusBadRequest, "Name must not be blank."),
+	InvalidColor:           err.NewHTTPError(http.StatusBadRequest, "Color must be a #rrggbb hex value."),
+	ParentNotFound:         err.NewHTTPError(http.StatusBadRequest, "Parent category not found."),
+	DuplicateName:          err.NewHTTPError(http.StatusConflict, "A category with this name already exists under the same parent."),
+	FailedToCreateCategory: err.NewHTTPError(http.StatusInternalServerError, "Failed to create category."),
+}
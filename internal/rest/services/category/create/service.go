@@ -0,0 +1,76 @@
+package create
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/category"
+	"github.com/rsmrtk/mybox/internal/rest/services/category/tree"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_category"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx  context.Context
+	req  *category.CreateRequest
+	f    *Facade
+	data *m_category.Data
+	tree *tree.Tree
+}
+
+func (s *service) create() error {
+	name := tree.Name(s.req.Name)
+	if name == "" {
+		return errs.InvalidName
+	}
+	if !tree.ValidColor(s.req.Color) {
+		return errs.InvalidColor
+	}
+
+	workspaceID := utils.AuthCtx(s.ctx)
+	items, err := s.f.pkg.M.Category.List(s.ctx, workspaceID)
+	if err != nil {
+		return errs.FailedToCreateCategory
+	}
+	s.tree = tree.New(items)
+
+	s.data = &m_category.Data{
+		CategoryID:  uuid.New().String(),
+		WorkspaceID: workspaceID,
+		Name:        name,
+		CreatedAt:   time.Now().UTC(),
+	}
+	if s.req.ParentID != "" {
+		id, err := uuid.Parse(s.req.ParentID)
+		if err != nil || s.tree.Get(id.String()) == nil {
+			return errs.ParentNotFound
+		}
+		parentID := id.String()
+		s.data.ParentID = &parentID
+	}
+	if s.req.Icon != "" {
+		s.data.Icon = &s.req.Icon
+	}
+	if s.req.Color != "" {
+		s.data.Color = &s.req.Color
+	}
+
+	err = s.f.pkg.M.Category.Create(s.ctx, s.data)
+	if errors.Is(err, m_category.ErrDuplicateName) {
+		return errs.DuplicateName
+	}
+	if err != nil {
+		return errs.FailedToCreateCategory
+	}
+
+	return nil
+}
+
+func (s *service) reply() *category.CreateResponse {
+	c := s.tree.Convert(s.data)
+	// The new category is not part of the tree yet
+	c.Path = append(s.tree.Path(c.ParentID), c.Name)
+	return &category.CreateResponse{Category: c}
+}
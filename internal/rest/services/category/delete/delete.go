@@ -0,0 +1,35 @@
+package delete
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/category"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Facade is the delete category facade
+type Facade struct {
+	pkg *pkg.Facade
+}
+
+// New creates a new delete category facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg: pkg,
+	}
+}
+
+// Handle handles the delete category request
+func (f *Facade) Handle(ctx context.Context, req *category.DeleteRequest) (*category.DeleteResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	if err := s.delete(); err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
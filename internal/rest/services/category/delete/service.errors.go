@@ -0,0 +1,19 @@
+package delete
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	CategoryNotFound       *err.HTTPError
+	InvalidCategoryID      *err.HTTPError
+	CategoryInUse          *err.HTTPError
+	FailedToDeleteCategory *err.HTTPError
+}{
+	CategoryNotFound:       err.NewHTTPError(http.StatusNotFound, "Category not found."),
+	InvalidCategoryID:      err.NewHTTPError(http.StatusBadRequest, "Invalid category ID format."),
+	CategoryInUse:          err.NewHTTPError(http.StatusConflict, "Category has subcategories or records; merge it into another category instead."),
+	FailedToDeleteCategory: err.NewHTTPError(http.StatusInternalServerError, "Failed to delete category."),
+}
@@ -0,0 +1,44 @@
+package delete
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/category"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_category"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx context.Context
+	req *category.DeleteRequest
+	f   *Facade
+}
+
+func (s *service) delete() error {
+	id, err := uuid.Parse(s.req.CategoryID)
+	if err != nil {
+		return errs.InvalidCategoryID
+	}
+
+	// Records in the trash still reference the category and block it too
+	err = s.f.pkg.M.Category.Delete(s.ctx, utils.AuthCtx(s.ctx), id.String())
+	switch {
+	case errors.Is(err, m_category.ErrNotFound):
+		return errs.CategoryNotFound
+	case errors.Is(err, m_category.ErrInUse):
+		return errs.CategoryInUse
+	case err != nil:
+		return errs.FailedToDeleteCategory
+	}
+
+	return nil
+}
+
+func (s *service) reply() *category.DeleteResponse {
+	return &category.DeleteResponse{
+		Success: true,
+		Message: "Category deleted successfully",
+	}
+}
@@ -0,0 +1,17 @@
+package get
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	CategoryNotFound    *err.HTTPError
+	InvalidCategoryID   *err.HTTPError
+	FailedToGetCategory *err.HTTPError
+}{
+	CategoryNotFound:    err.NewHTTPError(http.StatusNotFound, "Category not found."),
+	InvalidCategoryID:   err.NewHTTPError(http.StatusBadRequest, "Invalid category ID format."),
+	FailedToGetCategory: err.NewHTTPError(http.StatusInternalServerError, "Failed to get category."),
+}
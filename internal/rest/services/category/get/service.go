@@ -0,0 +1,41 @@
+package get
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/category"
+	"github.com/rsmrtk/mybox/internal/rest/services/category/tree"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx  context.Context
+	req  *category.GetRequest
+	f    *Facade
+	tree *tree.Tree
+}
+
+func (s *service) find() error {
+	id, err := uuid.Parse(s.req.CategoryID)
+	if err != nil {
+		return errs.InvalidCategoryID
+	}
+	s.req.CategoryID = id.String()
+
+	// The whole catalogue is loaded to resolve the path
+	items, err := s.f.pkg.M.Category.List(s.ctx, utils.AuthCtx(s.ctx))
+	if err != nil {
+		return errs.FailedToGetCategory
+	}
+	s.tree = tree.New(items)
+	if s.tree.Get(s.req.CategoryID) == nil {
+		return errs.CategoryNotFound
+	}
+
+	return nil
+}
+
+func (s *service) reply() *category.GetResponse {
+	return &category.GetResponse{Category: s.tree.Convert(s.tree.Get(s.req.CategoryID))}
+}
@@ -0,0 +1,13 @@
+package list
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	FailedToListCategories *err.HTTPError
+}{
+	FailedToListCategories: err.NewHTTPError(http.StatusInternalServerError, "Failed to list categories."),
+}
@@ -0,0 +1,38 @@
+package list
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/category"
+	"github.com/rsmrtk/mybox/internal/rest/services/category/tree"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx  context.Context
+	req  *category.ListRequest
+	f    *Facade
+	tree *tree.Tree
+}
+
+func (s *service) list() error {
+	items, err := s.f.pkg.M.Category.List(s.ctx, utils.AuthCtx(s.ctx))
+	if err != nil {
+		return errs.FailedToListCategories
+	}
+	s.tree = tree.New(items)
+
+	return nil
+}
+
+func (s *service) reply() *category.ListResponse {
+	walk := s.tree.Walk()
+	items := make([]*category.Category, 0, len(walk))
+
+	for _, d := range walk {
+		c := s.tree.Convert(d)
+		items = append(items, &c)
+	}
+
+	return &category.ListResponse{Items: items}
+}
@@ -0,0 +1,41 @@
+package merge
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/category"
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/dbtx"
+)
+
+// Facade is the merge category facade
+type Facade struct {
+	pkg *pkg.Facade
+}
+
+// New creates a new merge category facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg: pkg,
+	}
+}
+
+// Handle handles the merge category request
+func (f *Facade) Handle(ctx context.Context, req *category.MergeRequest) (*category.MergeResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	// Categories and the records they move change together
+	err := dbtx.InTx(ctx, f.pkg.M.DB, func(ctx context.Context) error {
+		s.ctx = ctx
+		return s.merge()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
@@ -0,0 +1,23 @@
+package merge
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	InvalidCategoryID       *err.HTTPError
+	CategoryNotFound        *err.HTTPError
+	SameCategory            *err.HTTPError
+	TargetBelowSource       *err.HTTPError
+	DuplicateName           *err.HTTPError
+	FailedToMergeCategories *err.HTTPError
+}{
+	InvalidCategoryID:       err.NewHTTPError(http.StatusBadRequest, "Invalid category ID format."),
+	CategoryNotFound:        err.NewHTTPError(http.StatusNotFound, "Category not found."),
+	SameCategory:            err.NewHTTPError(http.StatusBadRequest, "Source and target must be different categories."),
+	TargetBelowSource:       err.NewHTTPError(http.StatusBadRequest, "Target must not be a subcategory of the source."),
+	DuplicateName:           err.NewHTTPError(http.StatusConflict, "A subcategory of the source has the same name as one of the target."),
+	FailedToMergeCategories: err.NewHTTPError(http.StatusInternalServerError, "Failed to merge categories."),
+}
@@ -0,0 +1,86 @@
+package merge
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/category"
+	"github.com/rsmrtk/mybox/internal/rest/services/audit"
+	"github.com/rsmrtk/mybox/internal/rest/services/category/tree"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_audit"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_category"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx    context.Context
+	req    *category.MergeRequest
+	f      *Facade
+	tree   *tree.Tree
+	target *m_category.Data
+	merged *m_category.Merged
+}
+
+func (s *service) merge() error {
+	sourceID, err := uuid.Parse(s.req.SourceID)
+	if err != nil {
+		return errs.InvalidCategoryID
+	}
+	targetID, err := uuid.Parse(s.req.TargetID)
+	if err != nil {
+		return errs.InvalidCategoryID
+	}
+	if sourceID == targetID {
+		return errs.SameCategory
+	}
+
+	workspaceID := utils.AuthCtx(s.ctx)
+	items, err := s.f.pkg.M.Category.List(s.ctx, workspaceID)
+	if err != nil {
+		return errs.FailedToMergeCategories
+	}
+	s.tree = tree.New(items)
+
+	source := s.tree.Get(sourceID.String())
+	s.target = s.tree.Get(targetID.String())
+	if source == nil || s.target == nil {
+		return errs.CategoryNotFound
+	}
+	// The children of the source move to the target, which must not be one of them
+	if s.tree.IsDescendant(s.target.CategoryID, source.CategoryID) {
+		return errs.TargetBelowSource
+	}
+
+	s.merged, err = s.f.pkg.M.Category.Merge(s.ctx, workspaceID, source, s.target)
+	if errors.Is(err, m_category.ErrDuplicateName) {
+		return errs.DuplicateName
+	}
+	if err != nil {
+		return errs.FailedToMergeCategories
+	}
+
+	before := audit.Snapshot{}.WithCategory(source.CategoryID)
+	for _, id := range s.merged.Incomes {
+		after := audit.Snapshot{"income_type": s.target.Name}.WithCategory(s.target.CategoryID)
+		if err := audit.Record(s.ctx, s.f.pkg, audit.EntityIncome, id, m_audit.ActionUpdate, before, after); err != nil {
+			return errs.FailedToMergeCategories
+		}
+	}
+	for _, id := range s.merged.Expenses {
+		after := audit.Snapshot{"expense_type": s.target.Name}.WithCategory(s.target.CategoryID)
+		if err := audit.Record(s.ctx, s.f.pkg, audit.EntityExpense, id, m_audit.ActionUpdate, before, after); err != nil {
+			return errs.FailedToMergeCategories
+		}
+	}
+
+	return nil
+}
+
+func (s *service) reply() *category.MergeResponse {
+	return &category.MergeResponse{
+		Target:        s.tree.Convert(s.target),
+		MovedIncomes:  len(s.merged.Incomes),
+		MovedExpenses: len(s.merged.Expenses),
+	}
+}
@@ -0,0 +1,24 @@
+package category
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_category"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+// Resolve looks up a category referenced by an income or expense. Malformed
+// IDs, unauthenticated callers and categories of other workspaces all yield
+// m_category.ErrNotFound.
+func Resolve(ctx context.Context, f *pkg.Facade, id string) (*m_category.Data, error) {
+	workspaceID, ok := utils.AuthLookup(ctx)
+	if !ok {
+		return nil, m_category.ErrNotFound
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, m_category.ErrNotFound
+	}
+	return f.M.Category.Find(ctx, workspaceID, id)
+}
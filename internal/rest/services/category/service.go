@@ -0,0 +1,33 @@
+package category
+
+import (
+	"github.com/rsmrtk/mybox/internal/rest/services/category/create"
+	"github.com/rsmrtk/mybox/internal/rest/services/category/delete"
+	"github.com/rsmrtk/mybox/internal/rest/services/category/get"
+	"github.com/rsmrtk/mybox/internal/rest/services/category/list"
+	"github.com/rsmrtk/mybox/internal/rest/services/category/merge"
+	"github.com/rsmrtk/mybox/internal/rest/services/category/update"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Service is the category service facade
+type Service struct {
+	Get    *get.Facade
+	List   *list.Facade
+	Create *create.Facade
+	Update *update.Facade
+	Delete *delete.Facade
+	Merge  *merge.Facade
+}
+
+// New creates a new category service
+func New(f *pkg.Facade) *Service {
+	return &Service{
+		Get:    get.New(f),
+		List:   list.New(f),
+		Create: create.New(f),
+		Update: update.New(f),
+		Delete: delete.New(f),
+		Merge:  merge.New(f),
+	}
+}
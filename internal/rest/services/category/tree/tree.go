@@ -0,0 +1,131 @@
+// Package tree arranges the categories of a workspace into their hierarchy.
+// The category services and the category report share it to build paths,
+// detect cycles and roll totals up to parents.
+package tree
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	dc "github.com/rsmrtk/mybox/internal/rest/domain/category"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_category"
+)
+
+// Tree is the category hierarchy of one workspace.
+type Tree struct {
+	byID     map[string]*m_category.Data
+	children map[string][]string
+}
+
+// New builds the tree of the given categories.
+func New(items []*m_category.Data) *Tree {
+	t := &Tree{byID: map[string]*m_category.Data{}, children: map[string][]string{}}
+	for _, d := range items {
+		t.byID[d.CategoryID] = d
+	}
+	for _, d := range items {
+		parent := ""
+		if d.ParentID != nil {
+			parent = *d.ParentID
+		}
+		t.children[parent] = append(t.children[parent], d.CategoryID)
+	}
+	for _, ids := range t.children {
+		sort.Slice(ids, func(i, j int) bool {
+			return strings.ToLower(t.byID[ids[i]].Name) < strings.ToLower(t.byID[ids[j]].Name)
+		})
+	}
+	return t
+}
+
+// Get returns a category of the tree, or nil.
+func (t *Tree) Get(id string) *m_category.Data {
+	return t.byID[id]
+}
+
+// Children returns the direct children of id; "" selects the top level.
+func (t *Tree) Children(id string) []string {
+	return t.children[id]
+}
+
+// Walk returns every category with parents before their children and
+// siblings ordered by name.
+func (t *Tree) Walk() []*m_category.Data {
+	out := make([]*m_category.Data, 0, len(t.byID))
+	var walk func(parent string)
+	walk = func(parent string) {
+		for _, id := range t.children[parent] {
+			out = append(out, t.byID[id])
+			walk(id)
+		}
+	}
+	walk("")
+	return out
+}
+
+// Ancestors returns the IDs from the root down to and including id.
+func (t *Tree) Ancestors(id string) []string {
+	var ids []string
+	for d := t.byID[id]; d != nil; {
+		ids = append([]string{d.CategoryID}, ids...)
+		if d.ParentID == nil {
+			break
+		}
+		d = t.byID[*d.ParentID]
+	}
+	return ids
+}
+
+// Path returns the names from the root down to and including id.
+func (t *Tree) Path(id string) []string {
+	ids := t.Ancestors(id)
+	names := make([]string, len(ids))
+	for i, a := range ids {
+		names[i] = t.byID[a].Name
+	}
+	return names
+}
+
+// IsDescendant reports whether id is below or equal to ancestor.
+func (t *Tree) IsDescendant(id, ancestor string) bool {
+	for _, a := range t.Ancestors(id) {
+		if a == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+// Convert returns the API form of a category of the tree.
+func (t *Tree) Convert(d *m_category.Data) dc.Category {
+	c := dc.Category{
+		CategoryID: d.CategoryID,
+		Name:       d.Name,
+		Path:       t.Path(d.CategoryID),
+		CreatedAt:  d.CreatedAt,
+		UpdatedAt:  d.UpdatedAt,
+	}
+	if d.ParentID != nil {
+		c.ParentID = *d.ParentID
+	}
+	if d.Icon != nil {
+		c.Icon = *d.Icon
+	}
+	if d.Color != nil {
+		c.Color = *d.Color
+	}
+	return c
+}
+
+var color = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// ValidColor reports whether s is empty or a #rrggbb colour.
+func ValidColor(s string) bool {
+	return s == "" || color.MatchString(s)
+}
+
+// Name trims a category name as the unique index compares it.
+func Name(s string) string {
+	return strings.TrimSpace(s)
+}
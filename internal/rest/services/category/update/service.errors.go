@@ -0,0 +1,27 @@
+package update
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	CategoryNotFound       *err.HTTPError
+	InvalidCategoryID      *err.HTTPError
+	InvalidName            *err.HTTPError
+	InvalidColor           *err.HTTPError
+	ParentNotFound         *err.HTTPError
+	ParentCycle            *err.HTTPError
+	DuplicateName          *err.HTTPError
+	FailedToUpdateCategory *err.HTTPError
+}{
+	CategoryNotFound:       err.NewHTTPError(http.StatusNotFound, "Category not found."),
+	InvalidCategoryID:      err.NewHTTPError(http.StatusBadRequest, "Invalid category ID format."),
+	InvalidName:            err.NewHTTPError(http.StatusBadRequest, "Name must not be blank."),
+	InvalidColor:           err.NewHTTPError(http.StatusBadRequest, "Color must be a #rrggbb hex value."),
+	ParentNotFound:         err.NewHTTPError(http.StatusBadRequest, "Parent category not found."),
+	ParentCycle:            err.NewHTTPError(http.StatusBadRequest, "A category cannot be moved below itself."),
+	DuplicateName:          err.NewHTTPError(http.StatusConflict, "A category with this name already exists under the same parent."),
+	FailedToUpdateCategory: err.NewHTTPError(http.StatusInternalServerError, "Failed to update category."),
+}
@@ -0,0 +1,130 @@
+package update
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/category"
+	"github.com/rsmrtk/mybox/internal/rest/services/audit"
+	"github.com/rsmrtk/mybox/internal/rest/services/category/tree"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_audit"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_category"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx  context.Context
+	req  *category.UpdateRequest
+	f    *Facade
+	data *m_category.Data
+	tree *tree.Tree
+}
+
+func (s *service) update() error {
+	id, err := uuid.Parse(s.req.CategoryID)
+	if err != nil {
+		return errs.InvalidCategoryID
+	}
+
+	items, err := s.f.pkg.M.Category.List(s.ctx, utils.AuthCtx(s.ctx))
+	if err != nil {
+		return errs.FailedToUpdateCategory
+	}
+	s.tree = tree.New(items)
+	s.data = s.tree.Get(id.String())
+	if s.data == nil {
+		return errs.CategoryNotFound
+	}
+	name := s.data.Name
+
+	if s.req.Name != "" {
+		if s.data.Name = tree.Name(s.req.Name); s.data.Name == "" {
+			return errs.InvalidName
+		}
+	}
+	if s.req.Icon != nil {
+		s.data.Icon = optional(*s.req.Icon)
+	}
+	if s.req.Color != nil {
+		if !tree.ValidColor(*s.req.Color) {
+			return errs.InvalidColor
+		}
+		s.data.Color = optional(*s.req.Color)
+	}
+	if s.req.ParentID != nil {
+		if err := s.move(*s.req.ParentID); err != nil {
+			return err
+		}
+	}
+
+	err = s.f.pkg.M.Category.Update(s.ctx, s.data)
+	if errors.Is(err, m_category.ErrDuplicateName) {
+		return errs.DuplicateName
+	}
+	if err != nil {
+		return errs.FailedToUpdateCategory
+	}
+
+	if s.data.Name != name {
+		return s.rename(name)
+	}
+	return nil
+}
+
+// rename carries the new name over to the type of the records in the
+// category, in the transaction that renamed it.
+func (s *service) rename(previous string) error {
+	renamed, err := s.f.pkg.M.Category.Rename(s.ctx, s.data)
+	if err != nil {
+		return errs.FailedToUpdateCategory
+	}
+
+	for _, id := range renamed.Incomes {
+		before := audit.Snapshot{"income_type": previous}
+		after := audit.Snapshot{"income_type": s.data.Name}
+		if err := audit.Record(s.ctx, s.f.pkg, audit.EntityIncome, id, m_audit.ActionUpdate, before, after); err != nil {
+			return errs.FailedToUpdateCategory
+		}
+	}
+	for _, id := range renamed.Expenses {
+		before := audit.Snapshot{"expense_type": previous}
+		after := audit.Snapshot{"expense_type": s.data.Name}
+		if err := audit.Record(s.ctx, s.f.pkg, audit.EntityExpense, id, m_audit.ActionUpdate, before, after); err != nil {
+			return errs.FailedToUpdateCategory
+		}
+	}
+	return nil
+}
+
+// move re-parents the category, refusing moves below itself or one of its
+// subcategories.
+func (s *service) move(parent string) error {
+	if parent == "" {
+		s.data.ParentID = nil
+		return nil
+	}
+	id, err := uuid.Parse(parent)
+	if err != nil || s.tree.Get(id.String()) == nil {
+		return errs.ParentNotFound
+	}
+	parentID := id.String()
+	if s.tree.IsDescendant(parentID, s.data.CategoryID) {
+		return errs.ParentCycle
+	}
+	s.data.ParentID = &parentID
+	return nil
+}
+
+func (s *service) reply() *category.UpdateResponse {
+	// The tree holds s.data, so the path reflects the new name and parent
+	return &category.UpdateResponse{Category: s.tree.Convert(s.data)}
+}
+
+// optional stores an empty string as NULL.
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
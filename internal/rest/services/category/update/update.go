@@ -0,0 +1,42 @@
+package update
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/category"
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/dbtx"
+)
+
+// Facade is the update category facade
+type Facade struct {
+	pkg *pkg.Facade
+}
+
+// New creates a new update category facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg: pkg,
+	}
+}
+
+// Handle handles the update category request
+func (f *Facade) Handle(ctx context.Context, req *category.UpdateRequest) (*category.UpdateResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	// The parent is checked and the records are renamed in the transaction that
+	// writes the category
+	err := dbtx.InTx(ctx, f.pkg.M.DB, func(ctx context.Context) error {
+		s.ctx = ctx
+		return s.update()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
@@ -0,0 +1,25 @@
+package list
+
+import (
+	"fmt"
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+	"github.com/rsmrtk/mybox/internal/rest/services/duplicate/match"
+)
+
+var errs = struct {
+	InvalidDirection       *err.HTTPError
+	InvalidDateRange       *err.HTTPError
+	InvalidDays            *err.HTTPError
+	InvalidMinScore        *err.HTTPError
+	UnknownAccount         *err.HTTPError
+	FailedToListDuplicates *err.HTTPError
+}{
+	InvalidDirection:       err.NewHTTPError(http.StatusBadRequest, "Direction must be income or expense."),
+	InvalidDateRange:       err.NewHTTPError(http.StatusBadRequest, "From must not be after to."),
+	InvalidDays:            err.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Days must be between 0 and %d.", match.MaxDays)),
+	InvalidMinScore:        err.NewHTTPError(http.StatusBadRequest, "Min score must be between 0 and 1."),
+	UnknownAccount:         err.NewHTTPError(http.StatusBadRequest, "Account not found."),
+	FailedToListDuplicates: err.NewHTTPError(http.StatusInternalServerError, "Failed to list duplicates."),
+}
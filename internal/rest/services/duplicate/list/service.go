@@ -0,0 +1,172 @@
+package list
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/duplicate"
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+	"github.com/rsmrtk/mybox/internal/rest/services/account"
+	"github.com/rsmrtk/mybox/internal/rest/services/duplicate/match"
+	"github.com/rsmrtk/mybox/internal/rest/services/record"
+	"github.com/rsmrtk/mybox/internal/rest/services/tag"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_account"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_transaction"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_trash"
+)
+
+// maxPairs caps the candidate pairs read before scoring; the newest are
+// read first.
+const maxPairs = 5000
+
+// pair is a candidate pair with its score.
+type pair struct {
+	a, b  *record.Record
+	score match.Score
+}
+
+type service struct {
+	ctx   context.Context
+	req   *duplicate.ListRequest
+	f     *Facade
+	pairs []*pair
+	total int
+	tags  map[string][]string
+}
+
+func (s *service) list() error {
+	// Set default values if not provided
+	if s.req.Limit <= 0 || s.req.Limit > 500 {
+		s.req.Limit = 100 // Default limit
+	}
+	if s.req.Offset < 0 {
+		s.req.Offset = 0
+	}
+	if s.req.Days == 0 {
+		s.req.Days = match.DefaultDays
+	}
+	if s.req.MinScore == nil {
+		minScore := match.DefaultMinScore
+		s.req.MinScore = &minScore
+	}
+
+	switch s.req.Direction {
+	case "", record.DirectionIncome, record.DirectionExpense:
+	default:
+		return errs.InvalidDirection
+	}
+	if s.req.Days < 0 || s.req.Days > match.MaxDays {
+		return errs.InvalidDays
+	}
+	if *s.req.MinScore < 0 || *s.req.MinScore > 1 {
+		return errs.InvalidMinScore
+	}
+	if s.req.From != nil && s.req.To != nil && s.req.From.After(s.req.To.Time) {
+		return errs.InvalidDateRange
+	}
+
+	filter := m_transaction.Filter{
+		Direction: s.req.Direction,
+		Limit:     maxPairs,
+	}
+	if s.req.From != nil {
+		filter.From = &s.req.From.Time
+	}
+	if s.req.To != nil {
+		// To is inclusive, so the range ends at the start of the next day
+		to := s.req.To.Time.AddDate(0, 0, 1)
+		filter.To = &to
+	}
+	if s.req.Account != "" {
+		a, err := account.Resolve(s.ctx, s.f.pkg, s.req.Account)
+		if errors.Is(err, m_account.ErrNotFound) {
+			return errs.UnknownAccount
+		}
+		if err != nil {
+			return errs.FailedToListDuplicates
+		}
+		filter.AccountID = a.AccountID
+	}
+
+	candidates, err := s.f.pkg.M.Transaction.Pairs(s.ctx, filter, s.req.Days, match.Tolerance)
+	if err != nil {
+		return errs.FailedToListDuplicates
+	}
+	var scored []*pair
+	for _, c := range candidates {
+		p := &pair{a: record.FromTransaction(c.A), b: record.FromTransaction(c.B)}
+		if p.score = match.Compare(p.a, p.b, s.req.Days); p.score.Total >= *s.req.MinScore {
+			scored = append(scored, p)
+		}
+	}
+	// Best first; equal scores stay newest first
+	slices.SortStableFunc(scored, func(x, y *pair) int {
+		switch {
+		case x.score.Total > y.score.Total:
+			return -1
+		case x.score.Total < y.score.Total:
+			return 1
+		}
+		return 0
+	})
+
+	s.total = len(scored)
+	start := min(s.req.Offset, len(scored))
+	end := min(start+s.req.Limit, len(scored))
+	s.pairs = scored[start:end]
+
+	// Tags are looked up per record type; IDs do not collide across the two
+	byKind := map[m_trash.Kind][]string{}
+	for _, p := range s.pairs {
+		kind := m_trash.Kind(p.a.Direction)
+		byKind[kind] = append(byKind[kind], p.a.ID, p.b.ID)
+	}
+	s.tags = map[string][]string{}
+	for kind, ids := range byKind {
+		tags, err := tag.Of(s.ctx, s.f.pkg, kind, ids)
+		if err != nil {
+			return errs.FailedToListDuplicates
+		}
+		maps.Copy(s.tags, tags)
+	}
+
+	return nil
+}
+
+func (s *service) reply() *duplicate.ListResponse {
+	items := make([]*duplicate.Pair, 0, len(s.pairs))
+	for _, p := range s.pairs {
+		items = append(items, &duplicate.Pair{
+			Direction:   p.a.Direction,
+			Score:       p.score.Total,
+			AmountScore: p.score.Amount,
+			DateScore:   p.score.Date,
+			NameScore:   p.score.Name,
+			Records:     []*duplicate.Record{s.convert(p.a), s.convert(p.b)},
+		})
+	}
+
+	return &duplicate.ListResponse{
+		Items:      items,
+		TotalCount: s.total,
+		Limit:      s.req.Limit,
+		Offset:     s.req.Offset,
+	}
+}
+
+func (s *service) convert(r *record.Record) *duplicate.Record {
+	return &duplicate.Record{
+		ID:         r.ID,
+		Name:       r.Name,
+		Amount:     r.Amounts(),
+		Type:       r.Type,
+		Date:       models.NewDate(r.Date),
+		CreatedAt:  models.NewDate(r.CreatedAt),
+		CategoryID: r.CategoryID,
+		AccountID:  r.AccountID,
+		Tags:       s.tags[r.ID],
+		Version:    r.Version,
+	}
+}
@@ -0,0 +1,193 @@
+// Package match scores how likely two incomes or two expenses are one money
+// movement entered twice, by their amounts, the distance between their
+// dates and the similarity of their names.
+package match
+
+import (
+	"context"
+	"math"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+	"github.com/rsmrtk/mybox/internal/rest/services/record"
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_transaction"
+)
+
+const (
+	// DefaultDays is how many days apart two duplicates may be dated.
+	DefaultDays = 3
+	// MaxDays is the widest window a caller may ask for.
+	MaxDays = 31
+	// DefaultMinScore is the score from which a pair is reported.
+	DefaultMinScore = 0.7
+	// Tolerance is the largest difference between the amounts of two
+	// duplicates, as a fraction of the larger one; a cent is always allowed.
+	Tolerance = 0.01
+)
+
+// Weights of the parts of a score; they add up to 1.
+const (
+	weightAmount = 0.35
+	weightDate   = 0.2
+	weightName   = 0.45
+)
+
+// maxCandidates caps the records Find compares a new record with.
+const maxCandidates = 50
+
+// Score is how alike two records are, from 0 to 1, with its parts.
+type Score struct {
+	Total  float64
+	Amount float64
+	Date   float64
+	Name   float64
+}
+
+// Compare scores two records that are at most days apart and whose amounts
+// are within Tolerance of each other.
+func Compare(a, b *record.Record, days int) Score {
+	s := Score{
+		Amount: amountScore(a.Amount, b.Amount),
+		Date:   dateScore(a.Date, b.Date, days),
+		Name:   Name(a.Name, b.Name),
+	}
+	s.Total = round(weightAmount*s.Amount + weightDate*s.Date + weightName*s.Name)
+	s.Amount, s.Date, s.Name = round(s.Amount), round(s.Date), round(s.Name)
+	return s
+}
+
+// amountScore is 1 for amounts equal to the cent and falls to 0 at the
+// tolerance.
+func amountScore(a, b float64) float64 {
+	diff := math.Abs(a - b)
+	if diff < 0.005 {
+		return 1
+	}
+	limit := max(0.01, Tolerance*max(math.Abs(a), math.Abs(b)))
+	return math.Max(0, 1-diff/limit)
+}
+
+// dateScore is 1 for the same day and falls by a step per day apart, to
+// 1/(days+1) at the edge of the window.
+func dateScore(a, b time.Time, days int) float64 {
+	apart := math.Round(math.Abs(day(a).Sub(day(b)).Hours()) / 24)
+	return math.Max(0, 1-apart/float64(days+1))
+}
+
+func day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Name returns the similarity of two names from 0 to 1: the Dice
+// coefficient of the letter pairs of their words, ignoring case and
+// punctuation. Names without letters or digits match nothing.
+func Name(a, b string) float64 {
+	pa, pb := bigrams(a), bigrams(b)
+	var na, nb, common int
+	for k, n := range pa {
+		na += n
+		common += min(n, pb[k])
+	}
+	for _, n := range pb {
+		nb += n
+	}
+	if na+nb == 0 {
+		return 0
+	}
+	return 2 * float64(common) / float64(na+nb)
+}
+
+// bigrams counts the letter pairs of the words of s; a word of one letter
+// counts as itself.
+func bigrams(s string) map[string]int {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	m := make(map[string]int)
+	for _, w := range words {
+		rs := []rune(w)
+		if len(rs) == 1 {
+			m[w]++
+			continue
+		}
+		for i := 0; i+1 < len(rs); i++ {
+			m[string(rs[i:i+2])]++
+		}
+	}
+	return m
+}
+
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// Match is a live record that may be a duplicate.
+type Match struct {
+	Record *record.Record
+	Score  Score
+}
+
+// Find returns the live records of the direction of r that score at least
+// DefaultMinScore against it, best first. r itself is left out, and so are
+// records of another account than r's.
+func Find(ctx context.Context, f *pkg.Facade, r *record.Record) ([]*Match, error) {
+	from := day(r.Date).AddDate(0, 0, -DefaultDays)
+	to := day(r.Date).AddDate(0, 0, DefaultDays+1)
+	limit := max(0.01, Tolerance*math.Abs(r.Amount))
+	minAmount, maxAmount := r.Amount-limit, r.Amount+limit
+
+	items, _, err := f.M.Transaction.List(ctx, m_transaction.Filter{
+		Direction: r.Direction,
+		From:      &from,
+		To:        &to,
+		MinAmount: &minAmount,
+		MaxAmount: &maxAmount,
+		Limit:     maxCandidates,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*Match
+	for _, item := range items {
+		c := record.FromTransaction(item)
+		if c.ID == r.ID || r.AccountID != "" && c.AccountID != "" && c.AccountID != r.AccountID {
+			continue
+		}
+		if s := Compare(r, c, DefaultDays); s.Total >= DefaultMinScore {
+			matches = append(matches, &Match{Record: c, Score: s})
+		}
+	}
+	slices.SortStableFunc(matches, func(a, b *Match) int {
+		switch {
+		case a.Score.Total > b.Score.Total:
+			return -1
+		case a.Score.Total < b.Score.Total:
+			return 1
+		}
+		return 0
+	})
+	return matches, nil
+}
+
+// Duplicates converts matches to the warnings a create reply carries.
+func Duplicates(matches []*Match) []*models.Duplicate {
+	if len(matches) == 0 {
+		return nil
+	}
+	duplicates := make([]*models.Duplicate, 0, len(matches))
+	for _, m := range matches {
+		duplicates = append(duplicates, &models.Duplicate{
+			ID:     m.Record.ID,
+			Name:   m.Record.Name,
+			Amount: m.Record.Amounts(),
+			Date:   models.NewDate(m.Record.Date),
+			Score:  m.Score.Total,
+		})
+	}
+	return duplicates
+}
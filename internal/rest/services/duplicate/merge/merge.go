@@ -0,0 +1,54 @@
+package merge
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/duplicate"
+	expensedelete "github.com/rsmrtk/mybox/internal/rest/services/expense/delete"
+	expenseupdate "github.com/rsmrtk/mybox/internal/rest/services/expense/update"
+	incomedelete "github.com/rsmrtk/mybox/internal/rest/services/income/delete"
+	incomeupdate "github.com/rsmrtk/mybox/internal/rest/services/income/update"
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/dbtx"
+)
+
+// Facade is the merge duplicates facade
+type Facade struct {
+	pkg *pkg.Facade
+
+	updateIncome  *incomeupdate.Facade
+	deleteIncome  *incomedelete.Facade
+	updateExpense *expenseupdate.Facade
+	deleteExpense *expensedelete.Facade
+}
+
+// New creates a new merge duplicates facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg:           pkg,
+		updateIncome:  incomeupdate.New(pkg),
+		deleteIncome:  incomedelete.New(pkg),
+		updateExpense: expenseupdate.New(pkg),
+		deleteExpense: expensedelete.New(pkg),
+	}
+}
+
+// Handle handles the merge duplicates request
+func (f *Facade) Handle(ctx context.Context, req *duplicate.MergeRequest) (*duplicate.MergeResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	// Both records are read, the kept one retagged and the other deleted together
+	err := dbtx.InTx(ctx, f.pkg.M.DB, func(ctx context.Context) error {
+		s.ctx = ctx
+		return s.merge()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
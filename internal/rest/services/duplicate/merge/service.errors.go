@@ -0,0 +1,21 @@
+package merge
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	InvalidDirection *err.HTTPError
+	InvalidRecordID  *err.HTTPError
+	SameRecord       *err.HTTPError
+	RecordNotFound   *err.HTTPError
+	FailedToMerge    *err.HTTPError
+}{
+	InvalidDirection: err.NewHTTPError(http.StatusBadRequest, "Direction must be income or expense."),
+	InvalidRecordID:  err.NewHTTPError(http.StatusBadRequest, "Invalid record ID format."),
+	SameRecord:       err.NewHTTPError(http.StatusBadRequest, "Kept and removed record must be different."),
+	RecordNotFound:   err.NewHTTPError(http.StatusNotFound, "Record not found."),
+	FailedToMerge:    err.NewHTTPError(http.StatusInternalServerError, "Failed to merge records."),
+}
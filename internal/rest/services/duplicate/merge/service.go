@@ -0,0 +1,152 @@
+package merge
+
+import (
+	"context"
+	"math"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/duplicate"
+	"github.com/rsmrtk/mybox/internal/rest/domain/expense"
+	"github.com/rsmrtk/mybox/internal/rest/domain/income"
+	"github.com/rsmrtk/mybox/internal/rest/services/account"
+	"github.com/rsmrtk/mybox/internal/rest/services/record"
+	"github.com/rsmrtk/mybox/internal/rest/services/split"
+	"github.com/rsmrtk/mybox/internal/rest/services/tag"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_trash"
+)
+
+type service struct {
+	ctx     context.Context
+	req     *duplicate.MergeRequest
+	f       *Facade
+	kind    m_trash.Kind
+	tags    []string
+	version int64
+}
+
+func (s *service) merge() error {
+	switch s.req.Direction {
+	case record.DirectionIncome, record.DirectionExpense:
+		s.kind = m_trash.Kind(s.req.Direction)
+	default:
+		return errs.InvalidDirection
+	}
+	keepID, err := uuid.Parse(s.req.KeepID)
+	if err != nil {
+		return errs.InvalidRecordID
+	}
+	removeID, err := uuid.Parse(s.req.RemoveID)
+	if err != nil {
+		return errs.InvalidRecordID
+	}
+	if keepID == removeID {
+		return errs.SameRecord
+	}
+	keep, remove := keepID.String(), removeID.String()
+
+	// Trashed records have no version
+	versions, err := s.f.pkg.M.Version.GetMany(s.ctx, s.kind, []string{keep, remove})
+	if err != nil {
+		return errs.FailedToMerge
+	}
+	if len(versions) < 2 {
+		return errs.RecordNotFound
+	}
+	s.version = versions[keep]
+
+	tagged, err := tag.Of(s.ctx, s.f.pkg, s.kind, []string{keep, remove})
+	if err != nil {
+		return errs.FailedToMerge
+	}
+	s.tags = tagged[keep]
+	var tags []string
+	if combined := union(tagged[keep], tagged[remove]); len(combined) > len(s.tags) {
+		tags = combined
+	}
+
+	// The kept record is only written when it gains something, and through
+	// the update path so that the change is versioned and audited
+	switch s.kind {
+	case m_trash.Income:
+		if tags != nil {
+			res, err := s.f.updateIncome.Handle(s.ctx, &income.UpdateRequest{IncomeID: keep, Tags: tags})
+			if err != nil {
+				return err
+			}
+			s.tags, s.version = res.Tags, res.Version
+		}
+		_, err := s.f.deleteIncome.Handle(s.ctx, &income.DeleteRequest{IncomeID: remove})
+		return err
+	case m_trash.Expense:
+		splits, err := s.splits(keep, remove)
+		if err != nil {
+			return err
+		}
+		if tags != nil || splits != nil {
+			res, err := s.f.updateExpense.Handle(s.ctx, &expense.UpdateRequest{ExpenseID: keep, Tags: tags, Splits: splits})
+			if err != nil {
+				return err
+			}
+			s.tags, s.version = res.Tags, res.Version
+		}
+		_, err = s.f.deleteExpense.Handle(s.ctx, &expense.DeleteRequest{ExpenseID: remove})
+		return err
+	}
+	return nil
+}
+
+// splits returns the split lines, with their notes, that the kept expense
+// takes over: those of the removed one when the kept expense has none and
+// both have the same amount. Otherwise it returns nil.
+func (s *service) splits(keep, remove string) ([]*expense.Split, error) {
+	lines, err := s.f.pkg.M.Split.List(s.ctx, []string{keep, remove})
+	if err != nil {
+		return nil, errs.FailedToMerge
+	}
+	if len(lines[keep]) > 0 || len(lines[remove]) == 0 {
+		return nil, nil
+	}
+
+	var amounts [2]float64
+	for i, id := range []string{keep, remove} {
+		d, err := s.f.pkg.M.Record.FindExpense(s.ctx, id)
+		if err != nil {
+			return nil, errs.FailedToMerge
+		}
+		amounts[i] = record.FromExpense(d).Amount
+	}
+	if math.Round(amounts[0]*100) != math.Round(amounts[1]*100) {
+		return nil, nil
+	}
+	accounts, err := s.f.pkg.M.Account.Assigned(s.ctx, m_trash.Expense, []string{keep})
+	if err != nil {
+		return nil, errs.FailedToMerge
+	}
+	currencies, err := account.Currencies(s.ctx, s.f.pkg, accounts)
+	if err != nil {
+		return nil, errs.FailedToMerge
+	}
+	return split.Convert(lines[remove], record.Currency(currencies[keep])), nil
+}
+
+// union returns the names of a followed by those of b that a lacks.
+func union(a, b []string) []string {
+	names := slices.Clone(a)
+	for _, n := range b {
+		if !slices.Contains(names, n) {
+			names = append(names, n)
+		}
+	}
+	return names
+}
+
+func (s *service) reply() *duplicate.MergeResponse {
+	return &duplicate.MergeResponse{
+		Direction: s.req.Direction,
+		KeepID:    s.req.KeepID,
+		RemovedID: s.req.RemoveID,
+		Tags:      s.tags,
+		Version:   s.version,
+	}
+}
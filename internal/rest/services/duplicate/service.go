@@ -0,0 +1,21 @@
+package duplicate
+
+import (
+	"github.com/rsmrtk/mybox/internal/rest/services/duplicate/list"
+	"github.com/rsmrtk/mybox/internal/rest/services/duplicate/merge"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Service is the duplicate service facade
+type Service struct {
+	List  *list.Facade
+	Merge *merge.Facade
+}
+
+// New creates a new duplicate service
+func New(f *pkg.Facade) *Service {
+	return &Service{
+		List:  list.New(f),
+		Merge: merge.New(f),
+	}
+}
@@ -0,0 +1,50 @@
+package batch
+
+import (
+	"context"
+
+	bt "github.com/rsmrtk/mybox/internal/rest/domain/batch"
+	"github.com/rsmrtk/mybox/internal/rest/domain/expense"
+	batchrun "github.com/rsmrtk/mybox/internal/rest/services/batch"
+)
+
+type service struct {
+	ctx context.Context
+	req *bt.Request
+	f   *Facade
+	res *bt.Response
+}
+
+func (s *service) run() error {
+	var err error
+	s.res, err = batchrun.Run(s.ctx, s.f.pkg, s.req, s.handle)
+	return err
+}
+
+// handle dispatches one operation to the single expense services, so that a
+// batch is validated, versioned and audited exactly like separate requests.
+func (s *service) handle(ctx context.Context, op *bt.Operation) (any, error) {
+	switch op.Op {
+	case bt.OpCreate:
+		var req expense.CreateRequest
+		if err := batchrun.Decode(op, &req); err != nil {
+			return nil, err
+		}
+		return s.f.create.Handle(ctx, &req)
+	case bt.OpUpdate:
+		var req expense.UpdateRequest
+		if err := batchrun.Decode(op, &req); err != nil {
+			return nil, err
+		}
+		req.IfMatch = op.IfMatch
+		return s.f.update.Handle(ctx, &req)
+	case bt.OpDelete:
+		var req expense.DeleteRequest
+		if err := batchrun.Decode(op, &req); err != nil {
+			return nil, err
+		}
+		req.IfMatch = op.IfMatch
+		return s.f.delete.Handle(ctx, &req)
+	}
+	return nil, batchrun.UnknownOperation(op)
+}
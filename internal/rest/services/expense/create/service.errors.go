@@ -7,7 +7,19 @@ import (
 )
 
 var errs = struct {
+	InvalidTag            *err.HTTPError
+	TagsRequireAuth       *err.HTTPError
+	InvalidSplit          *err.HTTPError
+	SplitTotalMismatch    *err.HTTPError
+	UnknownCategory       *err.HTTPError
+	UnknownAccount        *err.HTTPError
 	FailedToCreateExpense *err.HTTPError
 }{
+	InvalidTag:            err.NewHTTPError(http.StatusBadRequest, "Tags must be 1 to 64 characters and must not contain commas."),
+	TagsRequireAuth:       err.NewHTTPError(http.StatusUnauthorized, "Tags require an API key."),
+	InvalidSplit:          err.NewHTTPError(http.StatusBadRequest, "Each split line needs a positive amount and a note of at most 255 characters."),
+	SplitTotalMismatch:    err.NewHTTPError(http.StatusBadRequest, "Split lines must add up to the expense amount."),
+	UnknownCategory:       err.NewHTTPError(http.StatusBadRequest, "Category not found."),
+	UnknownAccount:        err.NewHTTPError(http.StatusBadRequest, "Account not found."),
 	FailedToCreateExpense: err.NewHTTPError(http.StatusInternalServerError, "Failed to create expense."),
 }
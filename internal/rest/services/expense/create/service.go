@@ -3,13 +3,25 @@ package create
 import (
 	"context"
 	"database/sql"
-	"math/big"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/rsmrtk/db-fd-model/m_expense"
 	"github.com/rsmrtk/mybox/internal/rest/domain/expense"
 	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+	"github.com/rsmrtk/mybox/internal/rest/services/account"
+	"github.com/rsmrtk/mybox/internal/rest/services/audit"
+	"github.com/rsmrtk/mybox/internal/rest/services/category"
+	"github.com/rsmrtk/mybox/internal/rest/services/duplicate/match"
+	"github.com/rsmrtk/mybox/internal/rest/services/record"
+	"github.com/rsmrtk/mybox/internal/rest/services/split"
+	"github.com/rsmrtk/mybox/internal/rest/services/tag"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_account"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_audit"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_category"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_split"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_trash"
 )
 
 type service struct {
@@ -17,14 +29,46 @@ type service struct {
 	req  *expense.CreateRequest
 	f    *Facade
 	data *m_expense.Data
+
+	categoryID string
+	accountID  string
+	currency   string
+	tags       []string
+	duplicates []*match.Match
+	splits     []*m_split.Data
 }
 
 func (s *service) create() error {
+	expenseType := s.req.ExpenseType
+	if s.req.CategoryID != "" {
+		c, err := category.Resolve(s.ctx, s.f.pkg, s.req.CategoryID)
+		if errors.Is(err, m_category.ErrNotFound) {
+			return errs.UnknownCategory
+		}
+		if err != nil {
+			return errs.FailedToCreateExpense
+		}
+		s.categoryID, expenseType = c.CategoryID, c.Name
+	}
+	if s.req.AccountID != "" {
+		a, err := account.Resolve(s.ctx, s.f.pkg, s.req.AccountID)
+		if errors.Is(err, m_account.ErrNotFound) {
+			return errs.UnknownAccount
+		}
+		if err != nil {
+			return errs.FailedToCreateExpense
+		}
+		s.accountID, s.currency = a.AccountID, a.Currency
+	}
+
 	// Convert amount array to float64
 	var expenseAmount float64
 	if len(s.req.ExpenseAmount) > 0 {
 		expenseAmount = s.req.ExpenseAmount[0].Amount
 	}
+	if err := s.splitLines(expenseAmount); err != nil {
+		return err
+	}
 
 	// Generate new UUID for expense
 	expenseID := uuid.New()
@@ -39,65 +83,96 @@ func (s *service) create() error {
 		ExpenseID:     expenseID,
 		ExpenseName:   s.req.ExpenseName,
 		ExpenseAmount: sql.NullFloat64{Float64: expenseAmount, Valid: true},
-		ExpenseType:   s.req.ExpenseType,
+		ExpenseType:   expenseType,
 		ExpenseDate:   expenseDateNull,
 		CreatedAt:     sql.NullTime{Time: createdAt, Valid: true},
 	}
 
-	err := s.f.pkg.M.FinDash.Expense.Create(s.ctx, s.data)
+	err := s.f.pkg.M.Record.CreateExpense(s.ctx, s.data)
 	if err != nil {
 		return errs.FailedToCreateExpense
 	}
 
-	return nil
-}
-
-func (s *service) reply() *expense.CreateResponse {
-	var expenseName, expenseType string
-	var expenseAmount float64
-	var expenseDate time.Time
-
-	// Handle interface{} types for ExpenseName and ExpenseType
-	if s.data.ExpenseName != nil {
-		if name, ok := s.data.ExpenseName.(string); ok {
-			expenseName = name
+	if s.categoryID != "" {
+		if err := s.f.pkg.M.Category.Assign(s.ctx, m_trash.Expense, expenseID.String(), &s.categoryID); err != nil {
+			return errs.FailedToCreateExpense
 		}
 	}
 
-	if s.data.ExpenseAmount.Valid {
-		expenseAmount = s.data.ExpenseAmount.Float64
+	if s.accountID != "" {
+		if err := s.f.pkg.M.Account.Assign(s.ctx, m_trash.Expense, expenseID.String(), &s.accountID); err != nil {
+			return errs.FailedToCreateExpense
+		}
 	}
 
-	if s.data.ExpenseType != nil {
-		if typ, ok := s.data.ExpenseType.(string); ok {
-			expenseType = typ
+	if len(s.req.Tags) > 0 {
+		s.tags, err = tag.Apply(s.ctx, s.f.pkg, m_trash.Expense, expenseID.String(), s.req.Tags)
+		switch {
+		case errors.Is(err, tag.ErrInvalidName):
+			return errs.InvalidTag
+		case errors.Is(err, tag.ErrNoWorkspace):
+			return errs.TagsRequireAuth
+		case err != nil:
+			return errs.FailedToCreateExpense
 		}
 	}
 
-	if s.data.ExpenseDate.Valid {
-		expenseDate = s.data.ExpenseDate.Time
+	if len(s.splits) > 0 {
+		if err := s.f.pkg.M.Split.Replace(s.ctx, expenseID.String(), s.splits); err != nil {
+			return errs.FailedToCreateExpense
+		}
 	}
 
-	// Convert float64 to big.Rat for precise decimal handling
-	expenseAmountRat := *big.NewRat(int64(expenseAmount*100), 100)
-	amountValue, _ := expenseAmountRat.Float64()
+	if err := audit.Record(s.ctx, s.f.pkg, audit.EntityExpense, expenseID.String(), m_audit.ActionCreate, nil,
+		audit.ExpenseSnapshot(s.data).WithCategory(s.categoryID).WithAccount(s.accountID).WithTags(s.tags).WithSplits(s.splits)); err != nil {
+		return errs.FailedToCreateExpense
+	}
 
-	// Handle ExpenseID conversion
-	expenseIDStr := ""
-	if id, ok := s.data.ExpenseID.(uuid.UUID); ok {
-		expenseIDStr = id.String()
+	if s.req.CheckDuplicates {
+		r := record.FromExpense(s.data)
+		r.AccountID, r.Currency = s.accountID, s.currency
+		s.duplicates, err = match.Find(s.ctx, s.f.pkg, r)
+		if err != nil {
+			return errs.FailedToCreateExpense
+		}
 	}
 
+	return nil
+}
+
+func (s *service) reply() *expense.CreateResponse {
+	// New rows start at the column default version
+	r := record.FromExpense(s.data).WithVersion(1).WithCurrency(s.currency)
+
 	return &expense.CreateResponse{
-		ExpenseID:   expenseIDStr,
-		ExpenseName: expenseName,
-		ExpenseAmount: []*models.Amount{{
-			Amount:         amountValue,
-			CurrencyCode:   "USD",
-			CurrencySymbol: "$",
-		}},
-		ExpenseType: expenseType,
-		ExpenseDate: models.NewDate(expenseDate),
-		CreatedAt:   models.NewDate(time.Now()),
+		ExpenseID:     r.ID,
+		ExpenseName:   r.Name,
+		ExpenseAmount: r.Amounts(),
+		ExpenseType:   r.Type,
+		ExpenseDate:   models.NewDate(r.Date),
+		CreatedAt:     models.NewDate(r.CreatedAt),
+		CategoryID:    s.categoryID,
+		AccountID:     s.accountID,
+		Tags:          s.tags,
+		Splits:        split.Convert(s.splits, r.CurrencyCode()),
+		Version:       r.Version,
+		Duplicates:    match.Duplicates(s.duplicates),
 	}
 }
+
+// splitLines validates the requested split lines against the amount.
+func (s *service) splitLines(total float64) error {
+	var err error
+	s.splits, err = split.Lines(s.ctx, s.f.pkg, s.req.Splits, total)
+	switch {
+	case errors.Is(err, split.ErrInvalidLine):
+		return errs.InvalidSplit
+	case errors.Is(err, split.ErrTotalMismatch):
+		return errs.SplitTotalMismatch
+	case errors.Is(err, m_category.ErrNotFound):
+		return errs.UnknownCategory
+	case err != nil:
+		return errs.FailedToCreateExpense
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/rsmrtk/mybox/internal/rest/domain/expense"
 	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/dbtx"
 )
 
 // Facade is the delete expense facade
@@ -27,7 +28,12 @@ func (f *Facade) Handle(ctx context.Context, req *expense.DeleteRequest) (*expen
 		f:   f,
 	}
 
-	if err := s.delete(); err != nil {
+	// The row, its version and its audit entry are written together
+	err := dbtx.InTx(ctx, f.pkg.M.DB, func(ctx context.Context) error {
+		s.ctx = ctx
+		return s.delete()
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -7,9 +7,13 @@ import (
 )
 
 var errs = struct {
+	ExpenseNotFound       *err.HTTPError
 	InvalidExpenseID      *err.HTTPError
 	FailedToDeleteExpense *err.HTTPError
+	VersionMismatch       *err.HTTPError
 }{
+	ExpenseNotFound:       err.NewHTTPError(http.StatusNotFound, "Expense not found."),
 	InvalidExpenseID:      err.NewHTTPError(http.StatusBadRequest, "Invalid expense ID format."),
 	FailedToDeleteExpense: err.NewHTTPError(http.StatusInternalServerError, "Failed to delete expense."),
+	VersionMismatch:       err.NewHTTPError(http.StatusPreconditionFailed, "Expense was modified by another request."),
 }
@@ -2,16 +2,22 @@ package delete
 
 import (
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
-	"github.com/rsmrtk/db-fd-model/m_expense"
 	"github.com/rsmrtk/mybox/internal/rest/domain/expense"
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+	"github.com/rsmrtk/mybox/internal/rest/services/audit"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_audit"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_trash"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_version"
 )
 
 type service struct {
-	ctx context.Context
-	req *expense.DeleteRequest
-	f   *Facade
+	ctx     context.Context
+	req     *expense.DeleteRequest
+	f       *Facade
+	version int64
 }
 
 func (s *service) delete() error {
@@ -20,21 +26,41 @@ func (s *service) delete() error {
 		return errs.InvalidExpenseID
 	}
 
-	pk := m_expense.PrimaryKey{
-		ExpenseID: s.req.ExpenseID,
+	s.version, err = s.f.pkg.M.Version.Claim(s.ctx, m_trash.Expense, s.req.ExpenseID, models.IfMatch(s.req.IfMatch))
+	switch {
+	case errors.Is(err, m_version.ErrConflict):
+		return errs.VersionMismatch
+	case errors.Is(err, m_version.ErrNotFound):
+		return errs.ExpenseNotFound
+	case err != nil:
+		return errs.FailedToDeleteExpense
+	}
+
+	// The claim locks the row, so the audit entry gets its latest values
+	data, err := s.f.pkg.M.Record.FindExpense(s.ctx, s.req.ExpenseID)
+	if err != nil {
+		return errs.ExpenseNotFound
 	}
 
-	err = s.f.pkg.M.FinDash.Expense.Delete(s.ctx, pk)
+	// Deleted expenses go to the trash; they are purged after the retention period
+	err = s.f.pkg.M.Trash.SoftDelete(s.ctx, m_trash.Expense, s.req.ExpenseID)
+	if errors.Is(err, m_trash.ErrNotFound) {
+		return errs.ExpenseNotFound
+	}
 	if err != nil {
 		return errs.FailedToDeleteExpense
 	}
 
+	if err := audit.Record(s.ctx, s.f.pkg, audit.EntityExpense, s.req.ExpenseID, m_audit.ActionDelete, audit.ExpenseSnapshot(data), nil); err != nil {
+		return errs.FailedToDeleteExpense
+	}
+
 	return nil
 }
 
 func (s *service) reply() *expense.DeleteResponse {
 	return &expense.DeleteResponse{
 		Success: true,
-		Message: "Expense deleted successfully",
+		Message: "Expense moved to trash",
 	}
 }
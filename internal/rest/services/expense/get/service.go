@@ -2,20 +2,31 @@ package get
 
 import (
 	"context"
-	"math/big"
-	"time"
 
 	"github.com/google/uuid"
 	"github.com/rsmrtk/db-fd-model/m_expense"
 	"github.com/rsmrtk/mybox/internal/rest/domain/expense"
 	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+	"github.com/rsmrtk/mybox/internal/rest/services/account"
+	"github.com/rsmrtk/mybox/internal/rest/services/record"
+	"github.com/rsmrtk/mybox/internal/rest/services/split"
+	"github.com/rsmrtk/mybox/internal/rest/services/tag"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_split"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_trash"
 )
 
 type service struct {
-	ctx  context.Context
-	req  *expense.GetRequest
-	f    *Facade
-	data *m_expense.Data
+	ctx     context.Context
+	req     *expense.GetRequest
+	f       *Facade
+	data    *m_expense.Data
+	version int64
+
+	categoryID string
+	accountID  string
+	currency   string
+	tags       []string
+	splits     []*m_split.Data
 }
 
 func (s *service) find() error {
@@ -42,59 +53,58 @@ func (s *service) find() error {
 		return errs.ExpenseNotFound
 	}
 
-	return nil
-}
-
-func (s *service) reply() *expense.GetResponse {
-	var expenseName, expenseType string
-	var expenseAmount float64
-	var expenseDate, createdAt time.Time
-
-	// Handle interface{} types for ExpenseName and ExpenseType
-	if s.data.ExpenseName != nil {
-		if name, ok := s.data.ExpenseName.(string); ok {
-			expenseName = name
-		}
+	// Trashed expenses have no current version and are not found
+	s.version, err = s.f.pkg.M.Version.Get(s.ctx, m_trash.Expense, s.req.ExpenseID)
+	if err != nil {
+		return errs.ExpenseNotFound
 	}
 
-	if s.data.ExpenseAmount.Valid {
-		expenseAmount = s.data.ExpenseAmount.Float64
+	assigned, err := s.f.pkg.M.Category.Assigned(s.ctx, m_trash.Expense, []string{s.req.ExpenseID})
+	if err != nil {
+		return errs.ExpenseNotFound
 	}
+	s.categoryID = assigned[s.req.ExpenseID]
 
-	if s.data.ExpenseType != nil {
-		if typ, ok := s.data.ExpenseType.(string); ok {
-			expenseType = typ
-		}
+	accounts, err := s.f.pkg.M.Account.Assigned(s.ctx, m_trash.Expense, []string{s.req.ExpenseID})
+	if err != nil {
+		return errs.ExpenseNotFound
 	}
+	s.accountID = accounts[s.req.ExpenseID]
+	currencies, err := account.Currencies(s.ctx, s.f.pkg, accounts)
+	if err != nil {
+		return errs.ExpenseNotFound
+	}
+	s.currency = currencies[s.req.ExpenseID]
 
-	if s.data.ExpenseDate.Valid {
-		expenseDate = s.data.ExpenseDate.Time
+	tagged, err := tag.Of(s.ctx, s.f.pkg, m_trash.Expense, []string{s.req.ExpenseID})
+	if err != nil {
+		return errs.ExpenseNotFound
 	}
+	s.tags = tagged[s.req.ExpenseID]
 
-	if s.data.CreatedAt.Valid {
-		createdAt = s.data.CreatedAt.Time
+	lines, err := s.f.pkg.M.Split.List(s.ctx, []string{s.req.ExpenseID})
+	if err != nil {
+		return errs.ExpenseNotFound
 	}
+	s.splits = lines[s.req.ExpenseID]
 
-	// Convert float64 to big.Rat for precise decimal handling
-	expenseAmountRat := *big.NewRat(int64(expenseAmount*100), 100)
-	amountValue, _ := expenseAmountRat.Float64()
+	return nil
+}
 
-	// Handle ExpenseID conversion
-	expenseIDStr := ""
-	if id, ok := s.data.ExpenseID.(uuid.UUID); ok {
-		expenseIDStr = id.String()
-	}
+func (s *service) reply() *expense.GetResponse {
+	r := record.FromExpense(s.data).WithVersion(s.version).WithCurrency(s.currency)
 
 	return &expense.GetResponse{
-		ExpenseID:   expenseIDStr,
-		ExpenseName: expenseName,
-		ExpenseAmount: []*models.Amount{{
-			Amount:         amountValue,
-			CurrencyCode:   "USD",
-			CurrencySymbol: "$",
-		}},
-		ExpenseType: expenseType,
-		ExpenseDate: models.NewDate(expenseDate),
-		CreatedAt:   models.NewDate(createdAt),
+		ExpenseID:     r.ID,
+		ExpenseName:   r.Name,
+		ExpenseAmount: r.Amounts(),
+		ExpenseType:   r.Type,
+		ExpenseDate:   models.NewDate(r.Date),
+		CreatedAt:     models.NewDate(r.CreatedAt),
+		CategoryID:    s.categoryID,
+		AccountID:     s.accountID,
+		Tags:          s.tags,
+		Splits:        split.Convert(s.splits, r.CurrencyCode()),
+		Version:       r.Version,
 	}
 }
@@ -2,13 +2,18 @@ package list
 
 import (
 	"context"
-	"math/big"
-	"time"
+	"errors"
 
 	"github.com/google/uuid"
 	"github.com/rsmrtk/db-fd-model/m_expense"
 	"github.com/rsmrtk/mybox/internal/rest/domain/expense"
 	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+	"github.com/rsmrtk/mybox/internal/rest/services/account"
+	"github.com/rsmrtk/mybox/internal/rest/services/record"
+	"github.com/rsmrtk/mybox/internal/rest/services/split"
+	"github.com/rsmrtk/mybox/internal/rest/services/tag"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_split"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_trash"
 )
 
 type service struct {
@@ -17,6 +22,13 @@ type service struct {
 	f     *Facade
 	items []*m_expense.Data
 	total int
+
+	versions   map[string]int64
+	categories map[string]string
+	accounts   map[string]string
+	currencies map[string]string
+	tags       map[string][]string
+	splits     map[string][]*m_split.Data
 }
 
 func (s *service) list() error {
@@ -41,6 +53,35 @@ func (s *service) list() error {
 		return errs.FailedToListExpenses
 	}
 
+	// Hide expenses that are in the trash
+	deleted, err := s.f.pkg.M.Trash.DeletedIDs(s.ctx, m_trash.Expense)
+	if err != nil {
+		return errs.FailedToListExpenses
+	}
+	live := s.items[:0]
+	for _, item := range s.items {
+		if id, ok := item.ExpenseID.(uuid.UUID); ok && deleted[id.String()] {
+			continue
+		}
+		live = append(live, item)
+	}
+	s.items = live
+
+	// Keep only expenses with the requested tags
+	if s.req.Tags != nil {
+		matching, err := s.matchTags()
+		if err != nil {
+			return err
+		}
+		tagged := s.items[:0]
+		for _, item := range s.items {
+			if id, ok := item.ExpenseID.(uuid.UUID); ok && matching[id.String()] {
+				tagged = append(tagged, item)
+			}
+		}
+		s.items = tagged
+	}
+
 	// Manual pagination since API doesn't support it
 	s.total = len(s.items)
 	if s.req.Offset < len(s.items) {
@@ -53,6 +94,37 @@ func (s *service) list() error {
 		s.items = []*m_expense.Data{}
 	}
 
+	ids := make([]string, 0, len(s.items))
+	for _, item := range s.items {
+		if id, ok := item.ExpenseID.(uuid.UUID); ok {
+			ids = append(ids, id.String())
+		}
+	}
+	s.versions, err = s.f.pkg.M.Version.GetMany(s.ctx, m_trash.Expense, ids)
+	if err != nil {
+		return errs.FailedToListExpenses
+	}
+	s.categories, err = s.f.pkg.M.Category.Assigned(s.ctx, m_trash.Expense, ids)
+	if err != nil {
+		return errs.FailedToListExpenses
+	}
+	s.accounts, err = s.f.pkg.M.Account.Assigned(s.ctx, m_trash.Expense, ids)
+	if err != nil {
+		return errs.FailedToListExpenses
+	}
+	s.currencies, err = account.Currencies(s.ctx, s.f.pkg, s.accounts)
+	if err != nil {
+		return errs.FailedToListExpenses
+	}
+	s.tags, err = tag.Of(s.ctx, s.f.pkg, m_trash.Expense, ids)
+	if err != nil {
+		return errs.FailedToListExpenses
+	}
+	s.splits, err = s.f.pkg.M.Split.List(s.ctx, ids)
+	if err != nil {
+		return errs.FailedToListExpenses
+	}
+
 	return nil
 }
 
@@ -60,60 +132,25 @@ func (s *service) reply() *expense.ListResponse {
 	items := make([]*expense.ListItem, 0, len(s.items))
 
 	for _, data := range s.items {
-		var expenseName, expenseType string
-		var expenseAmount float64
-		var expenseDate, createdAt time.Time
-
-		// Extract nullable fields
-		// Handle interface{} types for ExpenseName and ExpenseType
-		if data.ExpenseName != nil {
-			if name, ok := data.ExpenseName.(string); ok {
-				expenseName = name
-			}
-		}
-
-		if data.ExpenseAmount.Valid {
-			expenseAmount = data.ExpenseAmount.Float64
-		}
-
-		if data.ExpenseType != nil {
-			if typ, ok := data.ExpenseType.(string); ok {
-				expenseType = typ
-			}
-		}
-
-		if data.ExpenseDate.Valid {
-			expenseDate = data.ExpenseDate.Time
-		}
-
-		if data.CreatedAt.Valid {
-			createdAt = data.CreatedAt.Time
-		}
-
-		// Convert float64 to big.Rat for precise decimal handling
-		expenseAmountRat := *big.NewRat(int64(expenseAmount*100), 100)
-		amountValue, _ := expenseAmountRat.Float64()
-
-		// Handle ExpenseID conversion
-		expenseIDStr := ""
-		if id, ok := data.ExpenseID.(uuid.UUID); ok {
-			expenseIDStr = id.String()
-		}
-
-		item := &expense.ListItem{
-			ExpenseID:   expenseIDStr,
-			ExpenseName: expenseName,
-			ExpenseAmount: []*models.Amount{{
-				Amount:         amountValue,
-				CurrencyCode:   "USD",
-				CurrencySymbol: "$",
-			}},
-			ExpenseType: expenseType,
-			ExpenseDate: models.NewDate(expenseDate),
-			CreatedAt:   models.NewDate(createdAt),
-		}
-
-		items = append(items, item)
+		r := record.FromExpense(data)
+		r.Version = s.versions[r.ID]
+		r.CategoryID = s.categories[r.ID]
+		r.AccountID = s.accounts[r.ID]
+		r.Currency = s.currencies[r.ID]
+
+		items = append(items, &expense.ListItem{
+			ExpenseID:     r.ID,
+			ExpenseName:   r.Name,
+			ExpenseAmount: r.Amounts(),
+			ExpenseType:   r.Type,
+			ExpenseDate:   models.NewDate(r.Date),
+			CreatedAt:     models.NewDate(r.CreatedAt),
+			CategoryID:    r.CategoryID,
+			AccountID:     r.AccountID,
+			Tags:          s.tags[r.ID],
+			Splits:        split.Convert(s.splits[r.ID], r.CurrencyCode()),
+			Version:       r.Version,
+		})
 	}
 
 	return &expense.ListResponse{
@@ -123,3 +160,27 @@ func (s *service) reply() *expense.ListResponse {
 		Offset:     s.req.Offset,
 	}
 }
+
+// matchTags returns the IDs of the expenses carrying the requested tags.
+func (s *service) matchTags() (map[string]bool, error) {
+	all, ok := tag.MatchAll(s.req.TagMode)
+	if !ok {
+		return nil, errs.InvalidTagMode
+	}
+	ids, err := tag.Resolve(s.ctx, s.f.pkg, s.req.Tags, all)
+	if errors.Is(err, tag.ErrInvalidName) {
+		return nil, errs.InvalidTag
+	}
+	if err != nil {
+		return nil, errs.FailedToListExpenses
+	}
+	if len(ids) == 0 {
+		return map[string]bool{}, nil
+	}
+
+	matching, err := s.f.pkg.M.Tag.Matching(s.ctx, m_trash.Expense, ids, all)
+	if err != nil {
+		return nil, errs.FailedToListExpenses
+	}
+	return matching, nil
+}
@@ -0,0 +1,41 @@
+package restore
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/expense"
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/dbtx"
+)
+
+// Facade is the restore expense facade
+type Facade struct {
+	pkg *pkg.Facade
+}
+
+// New creates a new restore expense facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg: pkg,
+	}
+}
+
+// Handle handles the restore expense request
+func (f *Facade) Handle(ctx context.Context, req *expense.RestoreRequest) (*expense.RestoreResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	// The row, its version and its audit entry are written together
+	err := dbtx.InTx(ctx, f.pkg.M.DB, func(ctx context.Context) error {
+		s.ctx = ctx
+		return s.restore()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
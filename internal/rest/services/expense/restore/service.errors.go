@@ -0,0 +1,17 @@
+package restore
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	ExpenseNotInTrash      *err.HTTPError
+	InvalidExpenseID       *err.HTTPError
+	FailedToRestoreExpense *err.HTTPError
+}{
+	ExpenseNotInTrash:      err.NewHTTPError(http.StatusNotFound, "Expense not found in trash."),
+	InvalidExpenseID:       err.NewHTTPError(http.StatusBadRequest, "Invalid expense ID format."),
+	FailedToRestoreExpense: err.NewHTTPError(http.StatusInternalServerError, "Failed to restore expense."),
+}
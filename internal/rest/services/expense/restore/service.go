@@ -0,0 +1,58 @@
+package restore
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/expense"
+	"github.com/rsmrtk/mybox/internal/rest/services/audit"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_audit"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_trash"
+)
+
+type service struct {
+	ctx     context.Context
+	req     *expense.RestoreRequest
+	f       *Facade
+	version int64
+}
+
+func (s *service) restore() error {
+	_, err := uuid.Parse(s.req.ExpenseID)
+	if err != nil {
+		return errs.InvalidExpenseID
+	}
+
+	err = s.f.pkg.M.Trash.Restore(s.ctx, m_trash.Expense, s.req.ExpenseID)
+	if errors.Is(err, m_trash.ErrNotFound) {
+		return errs.ExpenseNotInTrash
+	}
+	if err != nil {
+		return errs.FailedToRestoreExpense
+	}
+
+	// Edits based on the version that was deleted must not apply to the restored expense
+	s.version, err = s.f.pkg.M.Version.Bump(s.ctx, m_trash.Expense, s.req.ExpenseID, 0)
+	if err != nil {
+		return errs.FailedToRestoreExpense
+	}
+
+	var after audit.Snapshot
+	if data, err := s.f.pkg.M.Record.FindExpense(s.ctx, s.req.ExpenseID); err == nil {
+		after = audit.ExpenseSnapshot(data)
+	}
+	if err := audit.Record(s.ctx, s.f.pkg, audit.EntityExpense, s.req.ExpenseID, m_audit.ActionRestore, nil, after); err != nil {
+		return errs.FailedToRestoreExpense
+	}
+
+	return nil
+}
+
+func (s *service) reply() *expense.RestoreResponse {
+	return &expense.RestoreResponse{
+		Success: true,
+		Message: "Expense restored successfully",
+		Version: s.version,
+	}
+}
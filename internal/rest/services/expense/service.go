@@ -1,30 +1,36 @@
 package expense
 
 import (
+	"github.com/rsmrtk/mybox/internal/rest/services/expense/batch"
 	"github.com/rsmrtk/mybox/internal/rest/services/expense/create"
 	"github.com/rsmrtk/mybox/internal/rest/services/expense/delete"
 	"github.com/rsmrtk/mybox/internal/rest/services/expense/get"
 	"github.com/rsmrtk/mybox/internal/rest/services/expense/list"
+	"github.com/rsmrtk/mybox/internal/rest/services/expense/restore"
 	"github.com/rsmrtk/mybox/internal/rest/services/expense/update"
 	"github.com/rsmrtk/mybox/pkg"
 )
 
 // Service is the expense service facade
 type Service struct {
-	Get    *get.Facade
-	List   *list.Facade
-	Create *create.Facade
-	Update *update.Facade
-	Delete *delete.Facade
+	Get     *get.Facade
+	List    *list.Facade
+	Create  *create.Facade
+	Update  *update.Facade
+	Delete  *delete.Facade
+	Restore *restore.Facade
+	Batch   *batch.Facade
 }
 
 // New creates a new expense service
 func New(f *pkg.Facade) *Service {
 	return &Service{
-		Get:    get.New(f),
-		List:   list.New(f),
-		Create: create.New(f),
-		Update: update.New(f),
-		Delete: delete.New(f),
+		Get:     get.New(f),
+		List:    list.New(f),
+		Create:  create.New(f),
+		Update:  update.New(f),
+		Delete:  delete.New(f),
+		Restore: restore.New(f),
+		Batch:   batch.New(f),
 	}
 }
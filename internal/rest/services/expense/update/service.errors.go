@@ -10,8 +10,22 @@ var errs = struct {
 	ExpenseNotFound       *err.HTTPError
 	InvalidExpenseID      *err.HTTPError
 	FailedToUpdateExpense *err.HTTPError
+	InvalidTag            *err.HTTPError
+	TagsRequireAuth       *err.HTTPError
+	InvalidSplit          *err.HTTPError
+	SplitTotalMismatch    *err.HTTPError
+	UnknownCategory       *err.HTTPError
+	UnknownAccount        *err.HTTPError
+	VersionMismatch       *err.HTTPError
 }{
 	ExpenseNotFound:       err.NewHTTPError(http.StatusNotFound, "Expense not found."),
 	InvalidExpenseID:      err.NewHTTPError(http.StatusBadRequest, "Invalid expense ID format."),
 	FailedToUpdateExpense: err.NewHTTPError(http.StatusInternalServerError, "Failed to update expense."),
+	InvalidTag:            err.NewHTTPError(http.StatusBadRequest, "Tags must be 1 to 64 characters and must not contain commas."),
+	TagsRequireAuth:       err.NewHTTPError(http.StatusUnauthorized, "Tags require an API key."),
+	InvalidSplit:          err.NewHTTPError(http.StatusBadRequest, "Each split line needs a positive amount and a note of at most 255 characters."),
+	SplitTotalMismatch:    err.NewHTTPError(http.StatusBadRequest, "Split lines must add up to the expense amount."),
+	UnknownCategory:       err.NewHTTPError(http.StatusBadRequest, "Category not found."),
+	UnknownAccount:        err.NewHTTPError(http.StatusBadRequest, "Account not found."),
+	VersionMismatch:       err.NewHTTPError(http.StatusPreconditionFailed, "Expense was modified by another request."),
 }
@@ -3,20 +3,39 @@ package update
 import (
 	"context"
 	"database/sql"
-	"math/big"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/rsmrtk/db-fd-model/m_expense"
 	"github.com/rsmrtk/mybox/internal/rest/domain/expense"
 	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+	"github.com/rsmrtk/mybox/internal/rest/services/account"
+	"github.com/rsmrtk/mybox/internal/rest/services/audit"
+	"github.com/rsmrtk/mybox/internal/rest/services/category"
+	"github.com/rsmrtk/mybox/internal/rest/services/record"
+	"github.com/rsmrtk/mybox/internal/rest/services/split"
+	"github.com/rsmrtk/mybox/internal/rest/services/tag"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_account"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_audit"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_category"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_split"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_trash"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_version"
 )
 
 type service struct {
-	ctx  context.Context
-	req  *expense.UpdateRequest
-	f    *Facade
-	data *m_expense.Data
+	ctx     context.Context
+	req     *expense.UpdateRequest
+	f       *Facade
+	data    *m_expense.Data
+	version int64
+
+	categoryID string
+	accountID  string
+	currency   string
+	tags       []string
+	splits     []*m_split.Data
 }
 
 func (s *service) update() error {
@@ -25,101 +44,214 @@ func (s *service) update() error {
 		return errs.InvalidExpenseID
 	}
 
-	pk := m_expense.PrimaryKey{
-		ExpenseID: s.req.ExpenseID,
+	// Trashed expenses have to be restored before they can be edited
+	s.version, err = s.f.pkg.M.Version.Claim(s.ctx, m_trash.Expense, s.req.ExpenseID, models.IfMatch(s.req.IfMatch))
+	switch {
+	case errors.Is(err, m_version.ErrConflict):
+		return errs.VersionMismatch
+	case errors.Is(err, m_version.ErrNotFound):
+		return errs.ExpenseNotFound
+	case err != nil:
+		return errs.FailedToUpdateExpense
 	}
 
-	fields := []m_expense.Field{
-		m_expense.ExpenseID,
-		m_expense.ExpenseName,
-		m_expense.ExpenseAmount,
-		m_expense.ExpenseType,
-		m_expense.ExpenseDate,
+	// The claim locks the row, so this reads what a concurrent edit committed
+	s.data, err = s.f.pkg.M.Record.FindExpense(s.ctx, s.req.ExpenseID)
+	if err != nil {
+		return errs.ExpenseNotFound
 	}
 
-	s.data, err = s.f.pkg.M.FinDash.Expense.Find(s.ctx, pk, fields)
+	assigned, err := s.f.pkg.M.Category.Assigned(s.ctx, m_trash.Expense, []string{s.req.ExpenseID})
 	if err != nil {
-		return errs.ExpenseNotFound
+		return errs.FailedToUpdateExpense
+	}
+	s.categoryID = assigned[s.req.ExpenseID]
+	previous := s.categoryID
+
+	accounts, err := s.f.pkg.M.Account.Assigned(s.ctx, m_trash.Expense, []string{s.req.ExpenseID})
+	if err != nil {
+		return errs.FailedToUpdateExpense
+	}
+	s.accountID = accounts[s.req.ExpenseID]
+	currencies, err := account.Currencies(s.ctx, s.f.pkg, accounts)
+	if err != nil {
+		return errs.FailedToUpdateExpense
+	}
+	s.currency = currencies[s.req.ExpenseID]
+	previousAccount := s.accountID
+
+	tagged, err := tag.Of(s.ctx, s.f.pkg, m_trash.Expense, []string{s.req.ExpenseID})
+	if err != nil {
+		return errs.FailedToUpdateExpense
+	}
+	s.tags = tagged[s.req.ExpenseID]
+
+	lines, err := s.f.pkg.M.Split.List(s.ctx, []string{s.req.ExpenseID})
+	if err != nil {
+		return errs.FailedToUpdateExpense
 	}
+	s.splits = lines[s.req.ExpenseID]
 
-	// Prepare update fields
-	updateFields := m_expense.UpdateFields{}
+	before := audit.ExpenseSnapshot(s.data).WithCategory(s.categoryID).WithAccount(s.accountID).WithTags(s.tags).WithSplits(s.splits)
 
 	if s.req.ExpenseName != "" {
-		updateFields[m_expense.ExpenseName] = s.req.ExpenseName
 		s.data.ExpenseName = s.req.ExpenseName
 	}
 
 	if len(s.req.ExpenseAmount) > 0 {
 		amount := s.req.ExpenseAmount[0].Amount
-		updateFields[m_expense.ExpenseAmount] = amount
 		s.data.ExpenseAmount = sql.NullFloat64{Float64: amount, Valid: true}
 	}
 
 	if s.req.ExpenseType != "" {
-		updateFields[m_expense.ExpenseType] = s.req.ExpenseType
 		s.data.ExpenseType = s.req.ExpenseType
+		s.categoryID = ""
+	}
+	if err := s.category(); err != nil {
+		return err
+	}
+	if err := s.account(); err != nil {
+		return err
 	}
 
 	if s.req.ExpenseDate != nil {
-		updateFields[m_expense.ExpenseDate] = s.req.ExpenseDate.Time
 		s.data.ExpenseDate = sql.NullTime{Time: s.req.ExpenseDate.Time, Valid: true}
 	}
 
-	err = s.f.pkg.M.FinDash.Expense.Update(s.ctx, pk, updateFields)
+	if err := s.splitLines(); err != nil {
+		return err
+	}
+
+	err = s.f.pkg.M.Record.UpdateExpense(s.ctx, s.data)
 	if err != nil {
 		return errs.FailedToUpdateExpense
 	}
 
-	return nil
-}
+	if s.categoryID != previous {
+		var categoryID *string
+		if s.categoryID != "" {
+			categoryID = &s.categoryID
+		}
+		if err := s.f.pkg.M.Category.Assign(s.ctx, m_trash.Expense, s.req.ExpenseID, categoryID); err != nil {
+			return errs.FailedToUpdateExpense
+		}
+	}
 
-func (s *service) reply() *expense.UpdateResponse {
-	var expenseName, expenseType string
-	var expenseAmount float64
-	var expenseDate time.Time
-
-	// Handle interface{} types for ExpenseName and ExpenseType
-	if s.data.ExpenseName != nil {
-		if name, ok := s.data.ExpenseName.(string); ok {
-			expenseName = name
+	if s.accountID != previousAccount {
+		var accountID *string
+		if s.accountID != "" {
+			accountID = &s.accountID
+		}
+		if err := s.f.pkg.M.Account.Assign(s.ctx, m_trash.Expense, s.req.ExpenseID, accountID); err != nil {
+			return errs.FailedToUpdateExpense
 		}
 	}
 
-	if s.data.ExpenseAmount.Valid {
-		expenseAmount = s.data.ExpenseAmount.Float64
+	if s.req.Tags != nil {
+		s.tags, err = tag.Apply(s.ctx, s.f.pkg, m_trash.Expense, s.req.ExpenseID, s.req.Tags)
+		switch {
+		case errors.Is(err, tag.ErrInvalidName):
+			return errs.InvalidTag
+		case errors.Is(err, tag.ErrNoWorkspace):
+			return errs.TagsRequireAuth
+		case err != nil:
+			return errs.FailedToUpdateExpense
+		}
 	}
 
-	if s.data.ExpenseType != nil {
-		if typ, ok := s.data.ExpenseType.(string); ok {
-			expenseType = typ
+	if s.req.Splits != nil {
+		if err := s.f.pkg.M.Split.Replace(s.ctx, s.req.ExpenseID, s.splits); err != nil {
+			return errs.FailedToUpdateExpense
 		}
 	}
 
-	if s.data.ExpenseDate.Valid {
-		expenseDate = s.data.ExpenseDate.Time
+	if err := audit.Record(s.ctx, s.f.pkg, audit.EntityExpense, s.req.ExpenseID, m_audit.ActionUpdate, before,
+		audit.ExpenseSnapshot(s.data).WithCategory(s.categoryID).WithAccount(s.accountID).WithTags(s.tags).WithSplits(s.splits)); err != nil {
+		return errs.FailedToUpdateExpense
 	}
 
-	// Convert float64 to big.Rat for precise decimal handling
-	expenseAmountRat := *big.NewRat(int64(expenseAmount*100), 100)
-	amountValue, _ := expenseAmountRat.Float64()
+	return nil
+}
 
-	// Handle ExpenseID conversion
-	expenseIDStr := ""
-	if id, ok := s.data.ExpenseID.(uuid.UUID); ok {
-		expenseIDStr = id.String()
-	}
+func (s *service) reply() *expense.UpdateResponse {
+	r := record.FromExpense(s.data).WithVersion(s.version).WithCurrency(s.currency)
 
 	return &expense.UpdateResponse{
-		ExpenseID:   expenseIDStr,
-		ExpenseName: expenseName,
-		ExpenseAmount: []*models.Amount{{
-			Amount:         amountValue,
-			CurrencyCode:   "USD",
-			CurrencySymbol: "$",
-		}},
-		ExpenseType: expenseType,
-		ExpenseDate: models.NewDate(expenseDate),
-		UpdatedAt:   models.NewDate(time.Now()),
+		ExpenseID:     r.ID,
+		ExpenseName:   r.Name,
+		ExpenseAmount: r.Amounts(),
+		ExpenseType:   r.Type,
+		ExpenseDate:   models.NewDate(r.Date),
+		UpdatedAt:     models.NewDate(time.Now()),
+		CategoryID:    s.categoryID,
+		AccountID:     s.accountID,
+		Tags:          s.tags,
+		Splits:        split.Convert(s.splits, r.CurrencyCode()),
+		Version:       r.Version,
+	}
+}
+
+// category moves the expense to the requested catalogue category and takes
+// the category name as its type.
+func (s *service) category() error {
+	if s.req.CategoryID == "" {
+		return nil
+	}
+	c, err := category.Resolve(s.ctx, s.f.pkg, s.req.CategoryID)
+	if errors.Is(err, m_category.ErrNotFound) {
+		return errs.UnknownCategory
+	}
+	if err != nil {
+		return errs.FailedToUpdateExpense
+	}
+	s.data.ExpenseType = c.Name
+	s.categoryID = c.CategoryID
+	return nil
+}
+
+// splitLines replaces the split lines when the request has them and otherwise
+// checks that the kept lines still add up to the possibly changed amount.
+func (s *service) splitLines() error {
+	total := s.data.ExpenseAmount.Float64
+	if s.req.Splits == nil {
+		if err := split.Check(s.splits, total); err != nil {
+			return errs.SplitTotalMismatch
+		}
+		return nil
+	}
+
+	var err error
+	s.splits, err = split.Lines(s.ctx, s.f.pkg, s.req.Splits, total)
+	switch {
+	case errors.Is(err, split.ErrInvalidLine):
+		return errs.InvalidSplit
+	case errors.Is(err, split.ErrTotalMismatch):
+		return errs.SplitTotalMismatch
+	case errors.Is(err, m_category.ErrNotFound):
+		return errs.UnknownCategory
+	case err != nil:
+		return errs.FailedToUpdateExpense
+	}
+	return nil
+}
+
+// account moves the expense to the requested account, or unlinks it for an
+// empty ID.
+func (s *service) account() error {
+	if s.req.AccountID == nil {
+		return nil
+	}
+	if *s.req.AccountID == "" {
+		s.accountID, s.currency = "", ""
+		return nil
+	}
+	a, err := account.Resolve(s.ctx, s.f.pkg, *s.req.AccountID)
+	if errors.Is(err, m_account.ErrNotFound) {
+		return errs.UnknownAccount
 	}
+	if err != nil {
+		return errs.FailedToUpdateExpense
+	}
+	s.accountID, s.currency = a.AccountID, a.Currency
+	return nil
 }
@@ -0,0 +1,35 @@
+package create
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/goal"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Facade is the create goal facade
+type Facade struct {
+	pkg *pkg.Facade
+}
+
+// New creates a new create goal facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg: pkg,
+	}
+}
+
+// Handle handles the create goal request
+func (f *Facade) Handle(ctx context.Context, req *goal.CreateRequest) (*goal.CreateResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	if err := s.create(); err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
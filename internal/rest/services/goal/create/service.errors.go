@@ -0,0 +1,27 @@
+package create
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	InvalidName          *err.HTTPError
+	InvalidTargetAmount  *err.HTTPError
+	InvalidInitialAmount *err.HTTPError
+	LinkRequired         *err.HTTPError
+	UnknownAccount       *err.HTTPError
+	UnknownTag           *err.HTTPError
+	DuplicateName        *err.HTTPError
+	FailedToCreateGoal   *err.HTTPError
+}{
+	InvalidName:          err.NewHTTPError(http.StatusBadRequest, "Name must not be blank."),
+	InvalidTargetAmount:  err.NewHTTPError(http.StatusBadRequest, "Target amount must be positive."),
+	InvalidInitialAmount: err.NewHTTPError(http.StatusBadRequest, "Initial amount must not be negative."),
+	LinkRequired:         err.NewHTTPError(http.StatusBadRequest, "Exactly one of account_id and tag_id is required."),
+	UnknownAccount:       err.NewHTTPError(http.StatusBadRequest, "Account not found."),
+	UnknownTag:           err.NewHTTPError(http.StatusBadRequest, "Tag not found."),
+	DuplicateName:        err.NewHTTPError(http.StatusConflict, "Another goal already has this name."),
+	FailedToCreateGoal:   err.NewHTTPError(http.StatusInternalServerError, "Failed to create goal."),
+}
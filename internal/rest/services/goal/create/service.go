@@ -0,0 +1,89 @@
+package create
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/goal"
+	"github.com/rsmrtk/mybox/internal/rest/services/goal/form"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_account"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_goal"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_tag"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx  context.Context
+	req  *goal.CreateRequest
+	f    *Facade
+	goal goal.Goal
+}
+
+func (s *service) create() error {
+	now := time.Now().UTC()
+	d := &m_goal.Data{
+		GoalID:        uuid.New().String(),
+		WorkspaceID:   utils.AuthCtx(s.ctx),
+		Name:          s.req.Name,
+		TargetAmount:  s.req.TargetAmount,
+		InitialAmount: s.req.InitialAmount,
+		StartsOn:      now,
+		CreatedAt:     now,
+	}
+	if s.req.TargetDate != nil && !s.req.TargetDate.IsZero() {
+		d.TargetDate = &s.req.TargetDate.Time
+	}
+	if s.req.StartsOn != nil && !s.req.StartsOn.IsZero() {
+		d.StartsOn = s.req.StartsOn.Time
+	}
+	if s.req.AccountID != "" {
+		d.AccountID = &s.req.AccountID
+	}
+	if s.req.TagID != "" {
+		d.TagID = &s.req.TagID
+	}
+
+	err := form.Check(s.ctx, s.f.pkg, d)
+	switch {
+	case errors.Is(err, form.ErrInvalidName):
+		return errs.InvalidName
+	case errors.Is(err, form.ErrInvalidTarget):
+		return errs.InvalidTargetAmount
+	case errors.Is(err, form.ErrInvalidInitial):
+		return errs.InvalidInitialAmount
+	case errors.Is(err, form.ErrLinkRequired):
+		return errs.LinkRequired
+	case errors.Is(err, m_account.ErrNotFound):
+		return errs.UnknownAccount
+	case errors.Is(err, m_tag.ErrNotFound):
+		return errs.UnknownTag
+	case err != nil:
+		return errs.FailedToCreateGoal
+	}
+
+	err = s.f.pkg.M.Goal.Create(s.ctx, d)
+	if errors.Is(err, m_goal.ErrDuplicateName) {
+		return errs.DuplicateName
+	}
+	if err != nil {
+		return errs.FailedToCreateGoal
+	}
+
+	currency, err := form.Currency(s.ctx, s.f.pkg, d)
+	if err != nil {
+		return errs.FailedToCreateGoal
+	}
+	p, err := form.Progress(s.ctx, s.f.pkg, d, currency, now)
+	if err != nil {
+		return errs.FailedToCreateGoal
+	}
+	s.goal = form.Convert(d, p, currency)
+
+	return nil
+}
+
+func (s *service) reply() *goal.CreateResponse {
+	return &goal.CreateResponse{Goal: s.goal}
+}
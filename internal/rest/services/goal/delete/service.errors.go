@@ -0,0 +1,17 @@
+package delete
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	GoalNotFound       *err.HTTPError
+	InvalidGoalID      *err.HTTPError
+	FailedToDeleteGoal *err.HTTPError
+}{
+	GoalNotFound:       err.NewHTTPError(http.StatusNotFound, "Goal not found."),
+	InvalidGoalID:      err.NewHTTPError(http.StatusBadRequest, "Invalid goal ID format."),
+	FailedToDeleteGoal: err.NewHTTPError(http.StatusInternalServerError, "Failed to delete goal."),
+}
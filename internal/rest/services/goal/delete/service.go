@@ -0,0 +1,41 @@
+package delete
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/goal"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_goal"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx context.Context
+	req *goal.DeleteRequest
+	f   *Facade
+}
+
+func (s *service) delete() error {
+	id, err := uuid.Parse(s.req.GoalID)
+	if err != nil {
+		return errs.InvalidGoalID
+	}
+
+	err = s.f.pkg.M.Goal.Delete(s.ctx, utils.AuthCtx(s.ctx), id.String())
+	if errors.Is(err, m_goal.ErrNotFound) {
+		return errs.GoalNotFound
+	}
+	if err != nil {
+		return errs.FailedToDeleteGoal
+	}
+
+	return nil
+}
+
+func (s *service) reply() *goal.DeleteResponse {
+	return &goal.DeleteResponse{
+		Success: true,
+		Message: "Goal deleted successfully",
+	}
+}
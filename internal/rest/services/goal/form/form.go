@@ -0,0 +1,180 @@
+// Package form validates savings goals, works out their progress and
+// converts them for the API. The goal services share it.
+package form
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	dg "github.com/rsmrtk/mybox/internal/rest/domain/goal"
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+	"github.com/rsmrtk/mybox/internal/rest/services/account"
+	"github.com/rsmrtk/mybox/internal/rest/services/record"
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_goal"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_tag"
+)
+
+var (
+	// ErrInvalidName is returned for a blank name.
+	ErrInvalidName = errors.New("invalid goal name")
+	// ErrInvalidTarget is returned for a target amount that is not positive.
+	ErrInvalidTarget = errors.New("invalid goal target amount")
+	// ErrInvalidInitial is returned for a negative initial amount.
+	ErrInvalidInitial = errors.New("invalid goal initial amount")
+	// ErrLinkRequired is returned unless exactly one of an account and a tag is set.
+	ErrLinkRequired = errors.New("goal account or tag required")
+)
+
+// rateWindow is the number of days, up to today, the recent contribution
+// rate is measured over.
+const rateWindow = 90
+
+// daysPerMonth converts daily rates to monthly ones.
+const daysPerMonth = 365.25 / 12
+
+// Check validates a goal and normalizes it: the account and tag are stored
+// by their resolved IDs and the dates truncated to days. Errors are the
+// package errors or the ErrNotFound of m_account and m_tag.
+func Check(ctx context.Context, f *pkg.Facade, d *m_goal.Data) error {
+	if d.Name = strings.TrimSpace(d.Name); d.Name == "" {
+		return ErrInvalidName
+	}
+	if d.TargetAmount <= 0 {
+		return ErrInvalidTarget
+	}
+	if d.InitialAmount < 0 {
+		return ErrInvalidInitial
+	}
+	d.StartsOn = day(d.StartsOn)
+	if d.TargetDate != nil {
+		t := day(*d.TargetDate)
+		d.TargetDate = &t
+	}
+
+	if d.AccountID != nil && *d.AccountID == "" {
+		d.AccountID = nil
+	}
+	if d.TagID != nil && *d.TagID == "" {
+		d.TagID = nil
+	}
+	if (d.AccountID == nil) == (d.TagID == nil) {
+		return ErrLinkRequired
+	}
+
+	if d.AccountID != nil {
+		a, err := account.Resolve(ctx, f, *d.AccountID)
+		if err != nil {
+			return err
+		}
+		d.AccountID = &a.AccountID
+		return nil
+	}
+	if _, err := uuid.Parse(*d.TagID); err != nil {
+		return m_tag.ErrNotFound
+	}
+	t, err := f.M.Tag.Find(ctx, d.WorkspaceID, *d.TagID)
+	if err != nil {
+		return err
+	}
+	d.TagID = &t.TagID
+	return nil
+}
+
+// Currency returns the currency of a goal: that of its account, or
+// record.DefaultCurrency for a goal following a tag.
+func Currency(ctx context.Context, f *pkg.Facade, d *m_goal.Data) (string, error) {
+	if d.AccountID == nil {
+		return record.DefaultCurrency, nil
+	}
+	currencies, err := f.M.Account.Currencies(ctx, []string{*d.AccountID})
+	if err != nil {
+		return "", err
+	}
+	return record.Currency(currencies[*d.AccountID]), nil
+}
+
+// Progress works out how far the goal has come as of now, in currency.
+// Money dated after today does not count yet.
+func Progress(ctx context.Context, f *pkg.Facade, d *m_goal.Data, currency string, now time.Time) (dg.Progress, error) {
+	today := day(now)
+	end := today.AddDate(0, 0, 1)
+
+	contributed, err := f.M.Goal.Contributed(ctx, d, d.StartsOn, end)
+	if err != nil {
+		return dg.Progress{}, err
+	}
+
+	// The rate is measured over the window, or since the goal started when
+	// that is more recent
+	from := today.AddDate(0, 0, 1-rateWindow)
+	if d.StartsOn.After(from) {
+		from = d.StartsOn
+	}
+	var daily float64
+	if days := end.Sub(from).Hours() / 24; days > 0 {
+		recent, err := f.M.Goal.Contributed(ctx, d, from, end)
+		if err != nil {
+			return dg.Progress{}, err
+		}
+		daily = recent / days
+	}
+
+	saved := d.InitialAmount + contributed
+	remaining := math.Max(d.TargetAmount-saved, 0)
+	p := dg.Progress{
+		Saved:             record.AmountsIn(saved, currency),
+		Remaining:         record.AmountsIn(remaining, currency),
+		PercentComplete:   math.Round(saved/d.TargetAmount*10000) / 100,
+		Reached:           remaining == 0,
+		RecentMonthlyRate: record.AmountsIn(daily*daysPerMonth, currency),
+	}
+
+	if !p.Reached && daily > 0 {
+		projected := models.NewDate(today.AddDate(0, 0, int(math.Ceil(remaining/daily))))
+		p.ProjectedOn = &projected
+	}
+	if d.TargetDate != nil {
+		onTrack := p.Reached || (p.ProjectedOn != nil && !p.ProjectedOn.After(*d.TargetDate))
+		p.OnTrack = &onTrack
+		if !p.Reached {
+			// Whatever is left is due at once when the target date has passed
+			months := math.Max(d.TargetDate.Sub(today).Hours()/24/daysPerMonth, 1)
+			p.MonthlyNeeded = record.AmountsIn(remaining/months, currency)
+		}
+	}
+	return p, nil
+}
+
+// Convert returns the API form of a goal in currency with its progress.
+func Convert(d *m_goal.Data, p dg.Progress, currency string) dg.Goal {
+	g := dg.Goal{
+		GoalID:        d.GoalID,
+		Name:          d.Name,
+		TargetAmount:  record.AmountsIn(d.TargetAmount, currency),
+		InitialAmount: record.AmountsIn(d.InitialAmount, currency),
+		StartsOn:      models.NewDate(d.StartsOn),
+		Progress:      p,
+		CreatedAt:     d.CreatedAt,
+		UpdatedAt:     d.UpdatedAt,
+	}
+	if d.TargetDate != nil {
+		t := models.NewDate(*d.TargetDate)
+		g.TargetDate = &t
+	}
+	if d.AccountID != nil {
+		g.AccountID = *d.AccountID
+	}
+	if d.TagID != nil {
+		g.TagID = *d.TagID
+	}
+	return g
+}
+
+func day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,17 @@
+package get
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	GoalNotFound    *err.HTTPError
+	InvalidGoalID   *err.HTTPError
+	FailedToGetGoal *err.HTTPError
+}{
+	GoalNotFound:    err.NewHTTPError(http.StatusNotFound, "Goal not found."),
+	InvalidGoalID:   err.NewHTTPError(http.StatusBadRequest, "Invalid goal ID format."),
+	FailedToGetGoal: err.NewHTTPError(http.StatusInternalServerError, "Failed to get goal."),
+}
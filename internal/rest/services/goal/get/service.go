@@ -0,0 +1,51 @@
+package get
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/goal"
+	"github.com/rsmrtk/mybox/internal/rest/services/goal/form"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_goal"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx  context.Context
+	req  *goal.GetRequest
+	f    *Facade
+	goal goal.Goal
+}
+
+func (s *service) find() error {
+	id, err := uuid.Parse(s.req.GoalID)
+	if err != nil {
+		return errs.InvalidGoalID
+	}
+
+	d, err := s.f.pkg.M.Goal.Find(s.ctx, utils.AuthCtx(s.ctx), id.String())
+	if errors.Is(err, m_goal.ErrNotFound) {
+		return errs.GoalNotFound
+	}
+	if err != nil {
+		return errs.FailedToGetGoal
+	}
+
+	currency, err := form.Currency(s.ctx, s.f.pkg, d)
+	if err != nil {
+		return errs.FailedToGetGoal
+	}
+	p, err := form.Progress(s.ctx, s.f.pkg, d, currency, time.Now().UTC())
+	if err != nil {
+		return errs.FailedToGetGoal
+	}
+	s.goal = form.Convert(d, p, currency)
+
+	return nil
+}
+
+func (s *service) reply() *goal.GetResponse {
+	return &goal.GetResponse{Goal: s.goal}
+}
@@ -0,0 +1,13 @@
+package list
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	FailedToListGoals *err.HTTPError
+}{
+	FailedToListGoals: err.NewHTTPError(http.StatusInternalServerError, "Failed to list goals."),
+}
@@ -0,0 +1,45 @@
+package list
+
+import (
+	"context"
+	"time"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/goal"
+	"github.com/rsmrtk/mybox/internal/rest/services/goal/form"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx   context.Context
+	req   *goal.ListRequest
+	f     *Facade
+	items []*goal.Goal
+}
+
+func (s *service) list() error {
+	items, err := s.f.pkg.M.Goal.List(s.ctx, utils.AuthCtx(s.ctx))
+	if err != nil {
+		return errs.FailedToListGoals
+	}
+
+	now := time.Now().UTC()
+	s.items = make([]*goal.Goal, 0, len(items))
+	for _, d := range items {
+		currency, err := form.Currency(s.ctx, s.f.pkg, d)
+		if err != nil {
+			return errs.FailedToListGoals
+		}
+		p, err := form.Progress(s.ctx, s.f.pkg, d, currency, now)
+		if err != nil {
+			return errs.FailedToListGoals
+		}
+		g := form.Convert(d, p, currency)
+		s.items = append(s.items, &g)
+	}
+
+	return nil
+}
+
+func (s *service) reply() *goal.ListResponse {
+	return &goal.ListResponse{Items: s.items}
+}
@@ -0,0 +1,30 @@
+package goal
+
+import (
+	"github.com/rsmrtk/mybox/internal/rest/services/goal/create"
+	"github.com/rsmrtk/mybox/internal/rest/services/goal/delete"
+	"github.com/rsmrtk/mybox/internal/rest/services/goal/get"
+	"github.com/rsmrtk/mybox/internal/rest/services/goal/list"
+	"github.com/rsmrtk/mybox/internal/rest/services/goal/update"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Service is the goal service facade
+type Service struct {
+	Get    *get.Facade
+	List   *list.Facade
+	Create *create.Facade
+	Update *update.Facade
+	Delete *delete.Facade
+}
+
+// New creates a new goal service
+func New(f *pkg.Facade) *Service {
+	return &Service{
+		Get:    get.New(f),
+		List:   list.New(f),
+		Create: create.New(f),
+		Update: update.New(f),
+		Delete: delete.New(f),
+	}
+}
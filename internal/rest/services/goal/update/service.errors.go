@@ -0,0 +1,31 @@
+package update
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	GoalNotFound         *err.HTTPError
+	InvalidGoalID        *err.HTTPError
+	InvalidName          *err.HTTPError
+	InvalidTargetAmount  *err.HTTPError
+	InvalidInitialAmount *err.HTTPError
+	LinkRequired         *err.HTTPError
+	UnknownAccount       *err.HTTPError
+	UnknownTag           *err.HTTPError
+	DuplicateName        *err.HTTPError
+	FailedToUpdateGoal   *err.HTTPError
+}{
+	GoalNotFound:         err.NewHTTPError(http.StatusNotFound, "Goal not found."),
+	InvalidGoalID:        err.NewHTTPError(http.StatusBadRequest, "Invalid goal ID format."),
+	InvalidName:          err.NewHTTPError(http.StatusBadRequest, "Name must not be blank."),
+	InvalidTargetAmount:  err.NewHTTPError(http.StatusBadRequest, "Target amount must be positive."),
+	InvalidInitialAmount: err.NewHTTPError(http.StatusBadRequest, "Initial amount must not be negative."),
+	LinkRequired:         err.NewHTTPError(http.StatusBadRequest, "Exactly one of account_id and tag_id is required."),
+	UnknownAccount:       err.NewHTTPError(http.StatusBadRequest, "Account not found."),
+	UnknownTag:           err.NewHTTPError(http.StatusBadRequest, "Tag not found."),
+	DuplicateName:        err.NewHTTPError(http.StatusConflict, "Another goal already has this name."),
+	FailedToUpdateGoal:   err.NewHTTPError(http.StatusInternalServerError, "Failed to update goal."),
+}
@@ -0,0 +1,107 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/goal"
+	"github.com/rsmrtk/mybox/internal/rest/services/goal/form"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_account"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_goal"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_tag"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx  context.Context
+	req  *goal.UpdateRequest
+	f    *Facade
+	goal goal.Goal
+}
+
+func (s *service) update() error {
+	id, err := uuid.Parse(s.req.GoalID)
+	if err != nil {
+		return errs.InvalidGoalID
+	}
+
+	d, err := s.f.pkg.M.Goal.Find(s.ctx, utils.AuthCtx(s.ctx), id.String())
+	if errors.Is(err, m_goal.ErrNotFound) {
+		return errs.GoalNotFound
+	}
+	if err != nil {
+		return errs.FailedToUpdateGoal
+	}
+
+	if s.req.Name != nil {
+		d.Name = *s.req.Name
+	}
+	if s.req.TargetAmount != nil {
+		d.TargetAmount = *s.req.TargetAmount
+	}
+	if s.req.TargetDate != nil {
+		d.TargetDate = nil
+		if !s.req.TargetDate.IsZero() {
+			d.TargetDate = &s.req.TargetDate.Time
+		}
+	}
+	if s.req.InitialAmount != nil {
+		d.InitialAmount = *s.req.InitialAmount
+	}
+	if s.req.StartsOn != nil && !s.req.StartsOn.IsZero() {
+		d.StartsOn = s.req.StartsOn.Time
+	}
+	// A goal follows one thing; naming the other switches it over
+	if s.req.AccountID != nil && *s.req.AccountID != "" {
+		d.AccountID, d.TagID = s.req.AccountID, nil
+	}
+	if s.req.TagID != nil && *s.req.TagID != "" {
+		d.AccountID, d.TagID = nil, s.req.TagID
+	}
+
+	err = form.Check(s.ctx, s.f.pkg, d)
+	switch {
+	case errors.Is(err, form.ErrInvalidName):
+		return errs.InvalidName
+	case errors.Is(err, form.ErrInvalidTarget):
+		return errs.InvalidTargetAmount
+	case errors.Is(err, form.ErrInvalidInitial):
+		return errs.InvalidInitialAmount
+	case errors.Is(err, form.ErrLinkRequired):
+		return errs.LinkRequired
+	case errors.Is(err, m_account.ErrNotFound):
+		return errs.UnknownAccount
+	case errors.Is(err, m_tag.ErrNotFound):
+		return errs.UnknownTag
+	case err != nil:
+		return errs.FailedToUpdateGoal
+	}
+
+	err = s.f.pkg.M.Goal.Update(s.ctx, d)
+	switch {
+	case errors.Is(err, m_goal.ErrNotFound):
+		return errs.GoalNotFound
+	case errors.Is(err, m_goal.ErrDuplicateName):
+		return errs.DuplicateName
+	case err != nil:
+		return errs.FailedToUpdateGoal
+	}
+
+	currency, err := form.Currency(s.ctx, s.f.pkg, d)
+	if err != nil {
+		return errs.FailedToUpdateGoal
+	}
+	p, err := form.Progress(s.ctx, s.f.pkg, d, currency, time.Now().UTC())
+	if err != nil {
+		return errs.FailedToUpdateGoal
+	}
+	s.goal = form.Convert(d, p, currency)
+
+	return nil
+}
+
+func (s *service) reply() *goal.UpdateResponse {
+	return &goal.UpdateResponse{Goal: s.goal}
+}
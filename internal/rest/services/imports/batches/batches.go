@@ -0,0 +1,35 @@
+package batches
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/imports"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Facade is the list import batches facade
+type Facade struct {
+	pkg *pkg.Facade
+}
+
+// New creates a new list import batches facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg: pkg,
+	}
+}
+
+// Handle handles the list import batches request
+func (f *Facade) Handle(ctx context.Context, req *imports.BatchesRequest) (*imports.BatchesResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	if err := s.list(); err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
@@ -0,0 +1,13 @@
+package batches
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	FailedToListBatches *err.HTTPError
+}{
+	FailedToListBatches: err.NewHTTPError(http.StatusInternalServerError, "Failed to list import batches."),
+}
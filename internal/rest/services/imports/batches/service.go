@@ -0,0 +1,35 @@
+package batches
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/imports"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/form"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx   context.Context
+	req   *imports.BatchesRequest
+	f     *Facade
+	items []*imports.Batch
+}
+
+func (s *service) list() error {
+	items, err := s.f.pkg.M.Import.ListBatches(s.ctx, utils.AuthCtx(s.ctx))
+	if err != nil {
+		return errs.FailedToListBatches
+	}
+
+	s.items = make([]*imports.Batch, 0, len(items))
+	for _, b := range items {
+		c := form.ConvertBatch(b)
+		s.items = append(s.items, &c)
+	}
+
+	return nil
+}
+
+func (s *service) reply() *imports.BatchesResponse {
+	return &imports.BatchesResponse{Items: s.items}
+}
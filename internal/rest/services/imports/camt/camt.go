@@ -0,0 +1,39 @@
+package camt
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/imports"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/importer"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Facade is the camt.053 import facade
+type Facade struct {
+	pkg *pkg.Facade
+
+	importer *importer.Importer
+}
+
+// New creates a new camt.053 import facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg:      pkg,
+		importer: importer.New(pkg),
+	}
+}
+
+// Handle handles the camt.053 import request
+func (f *Facade) Handle(ctx context.Context, req *imports.CAMTRequest) (*imports.ImportResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	if err := s.run(); err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
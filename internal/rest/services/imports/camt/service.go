@@ -0,0 +1,56 @@
+package camt
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/imports"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/camtfile"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/importer"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_account"
+)
+
+type service struct {
+	ctx context.Context
+	req *imports.CAMTRequest
+	f   *Facade
+	res *imports.ImportResponse
+}
+
+func (s *service) run() error {
+	maxRows := s.f.pkg.Config.Import.MaxRows
+	rows, err := camtfile.Parse(strings.NewReader(s.req.Content), strings.TrimSpace(s.req.DefaultType), maxRows)
+	switch {
+	case errors.Is(err, camtfile.ErrNotCAMT):
+		return errs.NotCAMT
+	case errors.Is(err, importer.ErrTooManyRows):
+		return tooManyRows(maxRows)
+	case err != nil:
+		return errs.UnreadableFile
+	}
+
+	s.res, err = s.f.importer.Run(s.ctx, rows, importer.Options{
+		Source:    "camt053",
+		FileName:  s.req.FileName,
+		AccountID: s.req.AccountID,
+		Accounts:  s.req.Accounts,
+		DryRun:    s.req.DryRun,
+	})
+	if errors.Is(err, m_account.ErrNotFound) {
+		return errs.UnknownAccount
+	}
+	var currency *importer.CurrencyError
+	if errors.As(err, &currency) {
+		return currencyMismatch(currency)
+	}
+	if err != nil {
+		return errs.FailedToImport
+	}
+
+	return nil
+}
+
+func (s *service) reply() *imports.ImportResponse {
+	return s.res
+}
@@ -0,0 +1,361 @@
+// Package camtfile reads the booked entries of ISO 20022 camt.053 bank to
+// customer statements into import rows. Elements are matched by their local
+// name, so every version of the message from camt.053.001.02 on is read.
+package camtfile
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/csvfile"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/importer"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_import"
+)
+
+// ErrNotCAMT is returned by Parse for XML without a BkToCstmrStmt element.
+var ErrNotCAMT = errors.New("not a camt.053 file")
+
+// maxText is the longest type a record can have.
+const maxText = 255
+
+type amount struct {
+	Value string `xml:",chardata"`
+	Ccy   string `xml:"Ccy,attr"`
+}
+
+type date struct {
+	Dt   string `xml:"Dt"`
+	DtTm string `xml:"DtTm"`
+}
+
+// party is a debtor or a creditor; newer versions nest the name in Pty.
+type party struct {
+	Nm  string `xml:"Nm"`
+	Pty struct {
+		Nm string `xml:"Nm"`
+	} `xml:"Pty"`
+}
+
+func (p party) name() string {
+	if p.Nm != "" {
+		return p.Nm
+	}
+	return p.Pty.Nm
+}
+
+type acct struct {
+	ID struct {
+		IBAN string `xml:"IBAN"`
+		Othr struct {
+			ID string `xml:"Id"`
+		} `xml:"Othr"`
+	} `xml:"Id"`
+	Ccy string `xml:"Ccy"`
+}
+
+type entry struct {
+	Amt       amount `xml:"Amt"`
+	CdtDbtInd string `xml:"CdtDbtInd"`
+	Sts       struct {
+		Value string `xml:",chardata"`
+		Cd    string `xml:"Cd"`
+	} `xml:"Sts"`
+	BookgDt      date   `xml:"BookgDt"`
+	ValDt        date   `xml:"ValDt"`
+	NtryRef      string `xml:"NtryRef"`
+	AcctSvcrRef  string `xml:"AcctSvcrRef"`
+	AddtlNtryInf string `xml:"AddtlNtryInf"`
+	NtryDtls     []struct {
+		TxDtls []transaction `xml:"TxDtls"`
+	} `xml:"NtryDtls"`
+}
+
+// transaction is one of the transactions booked together as an entry.
+type transaction struct {
+	Refs struct {
+		AcctSvcrRef string `xml:"AcctSvcrRef"`
+	} `xml:"Refs"`
+	Amt     amount `xml:"Amt"`
+	AmtDtls struct {
+		TxAmt struct {
+			Amt amount `xml:"Amt"`
+		} `xml:"TxAmt"`
+	} `xml:"AmtDtls"`
+	CdtDbtInd string `xml:"CdtDbtInd"`
+	RltdPties struct {
+		Dbtr      party `xml:"Dbtr"`
+		Cdtr      party `xml:"Cdtr"`
+		UltmtDbtr party `xml:"UltmtDbtr"`
+		UltmtCdtr party `xml:"UltmtCdtr"`
+	} `xml:"RltdPties"`
+	RmtInf struct {
+		Ustrd []string `xml:"Ustrd"`
+		Strd  []struct {
+			CdtrRefInf struct {
+				Ref string `xml:"Ref"`
+			} `xml:"CdtrRefInf"`
+			AddtlRmtInf []string `xml:"AddtlRmtInf"`
+		} `xml:"Strd"`
+	} `xml:"RmtInf"`
+	AddtlTxInf string `xml:"AddtlTxInf"`
+}
+
+func (t *transaction) amount() amount {
+	if t.Amt.Value != "" {
+		return t.Amt
+	}
+	return t.AmtDtls.TxAmt.Amt
+}
+
+func (t *transaction) remittance() string {
+	if len(t.RmtInf.Ustrd) > 0 {
+		return strings.Join(t.RmtInf.Ustrd, " ")
+	}
+	var parts []string
+	for _, s := range t.RmtInf.Strd {
+		if s.CdtrRefInf.Ref != "" {
+			parts = append(parts, s.CdtrRefInf.Ref)
+		}
+		parts = append(parts, s.AddtlRmtInf...)
+	}
+	if len(parts) > 0 {
+		return strings.Join(parts, " ")
+	}
+	return t.AddtlTxInf
+}
+
+// Parse reads the booked entries of every statement in a file; pending and
+// informational entries are left out. Credits become incomes and debits
+// expenses, named after the counterparty and the remittance information.
+// An entry that books several transactions with their own amounts gives a
+// row per transaction. The external ID of a row is the account servicer's
+// reference, prefixed with the IBAN of the statement. Rows take
+// defaultType, or else credit or debit.
+func Parse(r io.Reader, defaultType string, maxRows int) ([]*importer.Row, error) {
+	dec := xml.NewDecoder(r)
+	dec.CharsetReader = charset
+
+	var (
+		rows    []*importer.Row
+		found   bool
+		inStmt  bool
+		account string
+		ccy     string
+	)
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read camt.053: %w", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "BkToCstmrStmt":
+				found = true
+			case "Stmt":
+				inStmt, account, ccy = true, "", ""
+			case "Acct":
+				if !inStmt {
+					continue
+				}
+				var a acct
+				if err := dec.DecodeElement(&a, &t); err != nil {
+					return nil, fmt.Errorf("failed to read camt.053: %w", err)
+				}
+				account, ccy = a.ID.IBAN, a.Ccy
+				if account == "" {
+					account = a.ID.Othr.ID
+				}
+			case "Ntry":
+				line, _ := dec.InputPos()
+				var e entry
+				if err := dec.DecodeElement(&e, &t); err != nil {
+					return nil, fmt.Errorf("failed to read camt.053: %w", err)
+				}
+				if !booked(&e) {
+					continue
+				}
+				rows = append(rows, entryRows(line, &e, account, ccy, defaultType)...)
+				if len(rows) > maxRows {
+					return nil, importer.ErrTooManyRows
+				}
+			}
+		case xml.EndElement:
+			if t.Name.Local == "Stmt" {
+				inStmt = false
+			}
+		}
+	}
+	if !found {
+		return nil, ErrNotCAMT
+	}
+	return rows, nil
+}
+
+// charset reads the Latin-1 statements some banks still send; others are
+// UTF-8.
+func charset(label string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(label) {
+	case "iso-8859-1", "iso8859-1", "latin1", "latin-1":
+	default:
+		return nil, fmt.Errorf("unsupported encoding %q", label)
+	}
+	b, err := io.ReadAll(input)
+	if err != nil {
+		return nil, err
+	}
+	runes := make([]rune, len(b))
+	for i, c := range b {
+		runes[i] = rune(c)
+	}
+	return strings.NewReader(string(runes)), nil
+}
+
+func booked(e *entry) bool {
+	status := strings.TrimSpace(e.Sts.Cd)
+	if status == "" {
+		status = strings.TrimSpace(e.Sts.Value)
+	}
+	return status == "" || strings.EqualFold(status, "BOOK")
+}
+
+// entryRows splits an entry into its transactions when they carry their
+// own amounts.
+func entryRows(line int, e *entry, account, ccy, defaultType string) []*importer.Row {
+	var txs []transaction
+	for _, d := range e.NtryDtls {
+		txs = append(txs, d.TxDtls...)
+	}
+
+	split := len(txs) > 1
+	for i := range txs {
+		split = split && txs[i].amount().Value != ""
+	}
+	if !split {
+		var tx transaction
+		if len(txs) > 0 {
+			tx = txs[0]
+		}
+		ref := tx.Refs.AcctSvcrRef
+		if ref == "" {
+			ref = e.AcctSvcrRef
+		}
+		if ref == "" {
+			ref = e.NtryRef
+		}
+		return []*importer.Row{row(line, e, &tx, e.Amt, e.CdtDbtInd, ref, account, ccy, defaultType)}
+	}
+
+	rows := make([]*importer.Row, 0, len(txs))
+	for i := range txs {
+		tx := &txs[i]
+		ref := tx.Refs.AcctSvcrRef
+		if ref == "" {
+			// The entry's reference with the position of the transaction
+			if ref = e.AcctSvcrRef; ref == "" {
+				ref = e.NtryRef
+			}
+			if ref != "" {
+				ref += "/" + strconv.Itoa(i+1)
+			}
+		}
+		indicator := tx.CdtDbtInd
+		if indicator == "" {
+			indicator = e.CdtDbtInd
+		}
+		rows = append(rows, row(line, e, tx, tx.amount(), indicator, ref, account, ccy, defaultType))
+	}
+	return rows
+}
+
+func row(line int, e *entry, tx *transaction, amt amount, indicator, ref, account, ccy, defaultType string) *importer.Row {
+	r := &importer.Row{Line: line, BankAccount: account, Currency: amt.Ccy}
+	if r.Currency == "" {
+		r.Currency = ccy
+	}
+	r.Currency = strings.ToUpper(strings.TrimSpace(r.Currency))
+	if ref = strings.TrimSpace(ref); ref != "" {
+		r.ExternalID = ref
+		if account != "" {
+			r.ExternalID = account + ":" + ref
+		}
+	}
+	if err := fill(r, e, tx, amt, indicator, defaultType); err != nil {
+		r.Err = err
+	}
+	return r
+}
+
+func fill(r *importer.Row, e *entry, tx *transaction, amt amount, indicator, defaultType string) error {
+	switch strings.TrimSpace(indicator) {
+	case "CRDT":
+		r.Direction = m_import.DirectionIncome
+	case "DBIT":
+		r.Direction = m_import.DirectionExpense
+	default:
+		return fmt.Errorf("CdtDbtInd %q is neither CRDT nor DBIT", indicator)
+	}
+
+	// The counterparty of a credit is its debtor, of a debit its creditor
+	parties := tx.RltdPties
+	counterparty := parties.Cdtr.name()
+	if counterparty == "" {
+		counterparty = parties.UltmtCdtr.name()
+	}
+	if r.Direction == m_import.DirectionIncome {
+		counterparty = parties.Dbtr.name()
+		if counterparty == "" {
+			counterparty = parties.UltmtDbtr.name()
+		}
+	}
+	remittance := tx.remittance()
+	if counterparty == "" && remittance == "" {
+		remittance = e.AddtlNtryInf
+	}
+	if r.Name = importer.Describe(counterparty, remittance); r.Name == "" {
+		return errors.New("entry has no counterparty or remittance information")
+	}
+
+	booking := e.BookgDt
+	if booking.Dt == "" && booking.DtTm == "" {
+		booking = e.ValDt
+	}
+	d := booking.Dt
+	if d == "" && len(booking.DtTm) >= 10 {
+		d = booking.DtTm[:10]
+	}
+	var err error
+	if r.Date, err = time.Parse(time.DateOnly, strings.TrimSpace(d)); err != nil {
+		return fmt.Errorf("booking date %q is not a date", d)
+	}
+
+	r.Type = defaultType
+	if r.Type == "" {
+		r.Type = "credit"
+		if r.Direction == m_import.DirectionExpense {
+			r.Type = "debit"
+		}
+	}
+	if len(r.Type) > maxText {
+		return fmt.Errorf("type is longer than %d characters", maxText)
+	}
+
+	v, ok := csvfile.ParseAmount(amt.Value, ".")
+	if !ok {
+		return fmt.Errorf("amount %q is not a number", amt.Value)
+	}
+	if v <= 0 {
+		return fmt.Errorf("amount %q is not positive", amt.Value)
+	}
+	r.Amount = v
+	return nil
+}
@@ -0,0 +1,122 @@
+package camtfile
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/importer"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/importer/importertest"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_import"
+)
+
+const iban = "DE89370400440532013000"
+
+const statement = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.08">
+<BkToCstmrStmt>
+<Stmt>
+<Acct><Id><IBAN>DE89370400440532013000</IBAN></Id><Ccy>EUR</Ccy></Acct>
+<Ntry>
+<Amt Ccy="EUR">1500.00</Amt><CdtDbtInd>CRDT</CdtDbtInd><Sts>BOOK</Sts>
+<BookgDt><Dt>2024-01-05</Dt></BookgDt><AcctSvcrRef>REF1</AcctSvcrRef>
+<NtryDtls><TxDtls><RltdPties><Dbtr><Nm>ACME  Corp</Nm></Dbtr><Cdtr><Nm>Me</Nm></Cdtr></RltdPties><RmtInf><Ustrd>Invoice</Ustrd><Ustrd>42</Ustrd></RmtInf></TxDtls></NtryDtls>
+</Ntry>
+<Ntry>
+<Amt Ccy="EUR">9.99</Amt><CdtDbtInd>DBIT</CdtDbtInd><Sts><Cd>PDNG</Cd></Sts>
+<BookgDt><Dt>2024-01-06</Dt></BookgDt><AcctSvcrRef>PENDING</AcctSvcrRef>
+</Ntry>
+<Ntry>
+<Amt Ccy="EUR">45.10</Amt><CdtDbtInd>DBIT</CdtDbtInd><Sts><Cd>BOOK</Cd></Sts>
+<BookgDt><DtTm>2024-01-07T10:00:00+01:00</DtTm></BookgDt><NtryRef>E2</NtryRef>
+<NtryDtls><TxDtls><RltdPties><Cdtr><Pty><Nm>Grocer</Nm></Pty></Cdtr></RltdPties><RmtInf><Strd><CdtrRefInf><Ref>RF18</Ref></CdtrRefInf><AddtlRmtInf>Weekly shop</AddtlRmtInf></Strd></RmtInf></TxDtls></NtryDtls>
+</Ntry>
+<Ntry>
+<Amt Ccy="EUR">30.00</Amt><CdtDbtInd>DBIT</CdtDbtInd><Sts>BOOK</Sts>
+<BookgDt><Dt>2024-01-08</Dt></BookgDt><AcctSvcrRef>E3</AcctSvcrRef>
+<NtryDtls><TxDtls><Refs><AcctSvcrRef>T1</AcctSvcrRef></Refs><Amt Ccy="EUR">10.00</Amt><RltdPties><Cdtr><Nm>Gym</Nm></Cdtr></RltdPties></TxDtls><TxDtls><AmtDtls><TxAmt><Amt Ccy="USD">20.00</Amt></TxAmt></AmtDtls><RltdPties><Cdtr><Nm>Cloud</Nm></Cdtr></RltdPties></TxDtls></NtryDtls>
+</Ntry>
+<Ntry>
+<Amt>2.50</Amt><CdtDbtInd>DBIT</CdtDbtInd>
+<ValDt><Dt>2024-01-09</Dt></ValDt>
+<AddtlNtryInf>Account fee</AddtlNtryInf>
+</Ntry>
+<Ntry>
+<Amt Ccy="EUR">1.00</Amt><CdtDbtInd>XXXX</CdtDbtInd><Sts>BOOK</Sts>
+<BookgDt><Dt>2024-01-10</Dt></BookgDt><AcctSvcrRef>E5</AcctSvcrRef>
+<AddtlNtryInf>Odd</AddtlNtryInf>
+</Ntry>
+</Stmt>
+<Stmt>
+<Acct><Id><Othr><Id>12345</Id></Othr></Id></Acct>
+<Ntry>
+<Amt Ccy="chf">5.00</Amt><CdtDbtInd>CRDT</CdtDbtInd>
+<BookgDt><Dt>2024-01-11</Dt></BookgDt><AcctSvcrRef>X1</AcctSvcrRef>
+<NtryDtls><TxDtls><RltdPties><UltmtDbtr><Nm>Friend</Nm></UltmtDbtr></RltdPties></TxDtls></NtryDtls>
+</Ntry>
+</Stmt>
+</BkToCstmrStmt>
+</Document>
+`
+
+// latin1 is a statement in ISO-8859-1 whose creditor is named Café.
+const latin1 = "<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?>\n" +
+	"<Document><BkToCstmrStmt><Stmt>\n" +
+	"<Ntry><Amt Ccy=\"EUR\">3.20</Amt><CdtDbtInd>DBIT</CdtDbtInd><BookgDt><Dt>2024-02-01</Dt></BookgDt>" +
+	"<NtryDtls><TxDtls><RltdPties><Cdtr><Nm>Caf\xe9</Nm></Cdtr></RltdPties></TxDtls></NtryDtls></Ntry>\n" +
+	"</Stmt></BkToCstmrStmt></Document>\n"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		defaultType string
+		maxRows     int
+		want        []importertest.Want
+		err         error
+	}{
+		{
+			name: "statements", content: statement, maxRows: 10,
+			want: []importertest.Want{
+				{Line: 6, Direction: m_import.DirectionIncome, Name: "ACME Corp: Invoice 42", Amount: 1500, Date: "2024-01-05", Type: "credit", ExternalID: iban + ":REF1", Currency: "EUR", BankAccount: iban},
+				{Line: 15, Direction: m_import.DirectionExpense, Name: "Grocer: RF18 Weekly shop", Amount: 45.1, Date: "2024-01-07", Type: "debit", ExternalID: iban + ":E2", Currency: "EUR", BankAccount: iban},
+				{Line: 20, Direction: m_import.DirectionExpense, Name: "Gym", Amount: 10, Date: "2024-01-08", Type: "debit", ExternalID: iban + ":T1", Currency: "EUR", BankAccount: iban},
+				{Line: 20, Direction: m_import.DirectionExpense, Name: "Cloud", Amount: 20, Date: "2024-01-08", Type: "debit", ExternalID: iban + ":E3/2", Currency: "USD", BankAccount: iban},
+				{Line: 25, Direction: m_import.DirectionExpense, Name: "Account fee", Amount: 2.5, Date: "2024-01-09", Type: "debit", Currency: "EUR", BankAccount: iban},
+				{Line: 30, ExternalID: iban + ":E5", Err: true},
+				{Line: 38, Direction: m_import.DirectionIncome, Name: "Friend", Amount: 5, Date: "2024-01-11", Type: "credit", ExternalID: "12345:X1", Currency: "CHF", BankAccount: "12345"},
+			},
+		},
+		{
+			name: "default type and latin-1", content: latin1, defaultType: "bank", maxRows: 10,
+			want: []importertest.Want{
+				{Line: 3, Direction: m_import.DirectionExpense, Name: "Café", Amount: 3.2, Date: "2024-02-01", Type: "bank", Currency: "EUR"},
+			},
+		},
+		{name: "too many rows", content: statement, maxRows: 2, err: importer.ErrTooManyRows},
+		{name: "notification", content: `<Document><BkToCstmrNtfctn/></Document>`, maxRows: 10, err: ErrNotCAMT},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := Parse(strings.NewReader(tt.content), tt.defaultType, tt.maxRows)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Parse() error = %v, want %v", err, tt.err)
+			}
+			importertest.CheckRows(t, rows, tt.want)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string]string{
+		"encoding":  `<?xml version="1.0" encoding="EBCDIC"?><Document><BkToCstmrStmt/></Document>`,
+		"malformed": `<Document><BkToCstmrStmt><Stmt></BkToCstmrStmt></Document>`,
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Parse(strings.NewReader(content), "", 10); err == nil || errors.Is(err, ErrNotCAMT) {
+				t.Errorf("Parse() error = %v, want a read error", err)
+			}
+		})
+	}
+}
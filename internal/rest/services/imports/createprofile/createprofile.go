@@ -0,0 +1,35 @@
+package createprofile
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/imports"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Facade is the create import profile facade
+type Facade struct {
+	pkg *pkg.Facade
+}
+
+// New creates a new create import profile facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg: pkg,
+	}
+}
+
+// Handle handles the create import profile request
+func (f *Facade) Handle(ctx context.Context, req *imports.CreateProfileRequest) (*imports.CreateProfileResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	if err := s.create(); err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
@@ -0,0 +1,23 @@
+package createprofile
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/csvfile"
+)
+
+var errs = struct {
+	InvalidName           *err.HTTPError
+	DuplicateName         *err.HTTPError
+	FailedToCreateProfile *err.HTTPError
+}{
+	InvalidName:           err.NewHTTPError(http.StatusBadRequest, "Name must not be blank."),
+	DuplicateName:         err.NewHTTPError(http.StatusConflict, "Another import profile already has this name."),
+	FailedToCreateProfile: err.NewHTTPError(http.StatusInternalServerError, "Failed to create import profile."),
+}
+
+// invalidMapping reports why a mapping was rejected.
+func invalidMapping(e *csvfile.MappingError) *err.HTTPError {
+	return err.NewHTTPError(http.StatusBadRequest, "Invalid mapping: "+e.Reason+".")
+}
@@ -0,0 +1,71 @@
+package createprofile
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/imports"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/csvfile"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/form"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_import"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx     context.Context
+	req     *imports.CreateProfileRequest
+	f       *Facade
+	profile imports.Profile
+}
+
+func (s *service) create() error {
+	name := strings.TrimSpace(s.req.Name)
+	if name == "" {
+		return errs.InvalidName
+	}
+
+	// Save the mapping with its defaults filled in
+	var me *csvfile.MappingError
+	err := csvfile.Check(&s.req.Mapping)
+	if errors.As(err, &me) {
+		return invalidMapping(me)
+	}
+	if err != nil {
+		return errs.FailedToCreateProfile
+	}
+	mapping, err := form.Encode(&s.req.Mapping)
+	if err != nil {
+		return errs.FailedToCreateProfile
+	}
+
+	now := time.Now().UTC()
+	p := &m_import.Profile{
+		ProfileID:   uuid.New().String(),
+		WorkspaceID: utils.AuthCtx(s.ctx),
+		Name:        name,
+		Mapping:     mapping,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	err = s.f.pkg.M.Import.CreateProfile(s.ctx, p)
+	if errors.Is(err, m_import.ErrDuplicateName) {
+		return errs.DuplicateName
+	}
+	if err != nil {
+		return errs.FailedToCreateProfile
+	}
+
+	s.profile, err = form.ConvertProfile(p)
+	if err != nil {
+		return errs.FailedToCreateProfile
+	}
+
+	return nil
+}
+
+func (s *service) reply() *imports.CreateProfileResponse {
+	return &imports.CreateProfileResponse{Profile: s.profile}
+}
@@ -0,0 +1,35 @@
+package csv
+
+import (
+	"fmt"
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/csvfile"
+)
+
+var errs = struct {
+	MappingRequired  *err.HTTPError
+	InvalidProfileID *err.HTTPError
+	ProfileNotFound  *err.HTTPError
+	UnreadableFile   *err.HTTPError
+	UnknownAccount   *err.HTTPError
+	FailedToImport   *err.HTTPError
+}{
+	MappingRequired:  err.NewHTTPError(http.StatusBadRequest, "Exactly one of profile_id and mapping is required."),
+	InvalidProfileID: err.NewHTTPError(http.StatusBadRequest, "Invalid import profile ID format."),
+	ProfileNotFound:  err.NewHTTPError(http.StatusNotFound, "Import profile not found."),
+	UnreadableFile:   err.NewHTTPError(http.StatusBadRequest, "File is not valid CSV."),
+	UnknownAccount:   err.NewHTTPError(http.StatusBadRequest, "Account not found."),
+	FailedToImport:   err.NewHTTPError(http.StatusInternalServerError, "Failed to import file."),
+}
+
+// invalidMapping reports why a mapping was rejected.
+func invalidMapping(e *csvfile.MappingError) *err.HTTPError {
+	return err.NewHTTPError(http.StatusBadRequest, "Invalid mapping: "+e.Reason+".")
+}
+
+// tooManyRows reports the row limit a file exceeds.
+func tooManyRows(max int) *err.HTTPError {
+	return err.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("File has more than %d rows; split it up.", max))
+}
@@ -0,0 +1,86 @@
+package csv
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/imports"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/csvfile"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/form"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/importer"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_account"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_import"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx context.Context
+	req *imports.CSVRequest
+	f   *Facade
+	res *imports.ImportResponse
+}
+
+func (s *service) run() error {
+	if (s.req.ProfileID == "") == (s.req.Mapping == nil) {
+		return errs.MappingRequired
+	}
+
+	m := s.req.Mapping
+	if s.req.ProfileID != "" {
+		if _, err := uuid.Parse(s.req.ProfileID); err != nil {
+			return errs.InvalidProfileID
+		}
+		p, err := s.f.pkg.M.Import.FindProfile(s.ctx, utils.AuthCtx(s.ctx), s.req.ProfileID)
+		if errors.Is(err, m_import.ErrNotFound) {
+			return errs.ProfileNotFound
+		}
+		if err != nil {
+			return errs.FailedToImport
+		}
+		if m, err = form.Decode(p); err != nil {
+			return errs.FailedToImport
+		}
+	}
+
+	var me *csvfile.MappingError
+	err := csvfile.Check(m)
+	if errors.As(err, &me) {
+		return invalidMapping(me)
+	}
+	if err != nil {
+		return errs.FailedToImport
+	}
+
+	maxRows := s.f.pkg.Config.Import.MaxRows
+	rows, err := csvfile.Parse(strings.NewReader(s.req.Content), m, maxRows)
+	switch {
+	case errors.As(err, &me):
+		return invalidMapping(me)
+	case errors.Is(err, importer.ErrTooManyRows):
+		return tooManyRows(maxRows)
+	case err != nil:
+		return errs.UnreadableFile
+	}
+
+	s.res, err = s.f.importer.Run(s.ctx, rows, importer.Options{
+		Source:    "csv",
+		FileName:  s.req.FileName,
+		ProfileID: s.req.ProfileID,
+		AccountID: s.req.AccountID,
+		DryRun:    s.req.DryRun,
+	})
+	if errors.Is(err, m_account.ErrNotFound) {
+		return errs.UnknownAccount
+	}
+	if err != nil {
+		return errs.FailedToImport
+	}
+
+	return nil
+}
+
+func (s *service) reply() *imports.ImportResponse {
+	return s.res
+}
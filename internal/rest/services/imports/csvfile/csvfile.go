@@ -0,0 +1,383 @@
+// Package csvfile reads bank CSV files into import rows following a mapping
+// (see imports.Mapping).
+package csvfile
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/imports"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/importer"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_import"
+)
+
+// MappingError is returned for mappings that cannot be applied, either on
+// their own or to the header of a file.
+type MappingError struct {
+	Reason string
+}
+
+func (e *MappingError) Error() string {
+	return "invalid mapping: " + e.Reason
+}
+
+func mappingErrorf(format string, a ...any) error {
+	return &MappingError{Reason: fmt.Sprintf(format, a...)}
+}
+
+// maxText is the longest name or type a record can have.
+const maxText = 255
+
+var signs = []string{
+	imports.SignNegativeExpense, imports.SignNegativeIncome, imports.SignDebitCredit,
+	imports.SignExpense, imports.SignIncome,
+}
+
+// Check validates a mapping and fills in its defaults.
+func Check(m *imports.Mapping) error {
+	if m.Delimiter == "" {
+		m.Delimiter = ","
+	}
+	if d, _ := utf8.DecodeRuneInString(m.Delimiter); utf8.RuneCountInString(m.Delimiter) != 1 || d == '"' || d == '\r' || d == '\n' {
+		return mappingErrorf("delimiter must be one character other than a quote or a line break")
+	}
+	if m.SkipRows < 0 {
+		return mappingErrorf("skip_rows must not be negative")
+	}
+	if m.DecimalSeparator == "" {
+		m.DecimalSeparator = "."
+	}
+	if m.DecimalSeparator != "." && m.DecimalSeparator != "," {
+		return mappingErrorf(`decimal_separator must be "." or ","`)
+	}
+	if m.Sign == "" {
+		m.Sign = imports.SignNegativeExpense
+	}
+	if !slices.Contains(signs, m.Sign) {
+		return mappingErrorf("sign must be one of %s", strings.Join(signs, ", "))
+	}
+	if _, err := Layout(m.DateFormat); err != nil {
+		return err
+	}
+	m.DefaultType = strings.TrimSpace(m.DefaultType)
+	if len(m.DefaultType) > maxText {
+		return mappingErrorf("default_type must be at most %d characters", maxText)
+	}
+
+	c := &m.Columns
+	if c.Name == "" || c.Date == "" {
+		return mappingErrorf("the name and date columns are required")
+	}
+	if m.Sign == imports.SignDebitCredit {
+		if c.Debit == "" || c.Credit == "" {
+			return mappingErrorf("sign debit_credit needs the debit and credit columns")
+		}
+	} else if c.Amount == "" {
+		return mappingErrorf("the amount column is required")
+	}
+	if m.NoHeader {
+		for _, col := range []string{c.Name, c.Amount, c.Debit, c.Credit, c.Date, c.Type} {
+			if n, err := strconv.Atoi(col); col != "" && (err != nil || n < 1) {
+				return mappingErrorf("without a header, columns must be positions starting at 1, not %q", col)
+			}
+		}
+	}
+	return nil
+}
+
+// Layout turns a date format such as DD.MM.YYYY into a time layout.
+func Layout(format string) (string, error) {
+	if format == "" {
+		return "", mappingErrorf("date_format is required")
+	}
+	tokens := []struct{ from, to string }{
+		{"YYYY", "2006"}, {"MMM", "Jan"}, {"YY", "06"}, {"MM", "01"}, {"DD", "02"}, {"M", "1"}, {"D", "2"},
+	}
+
+	var b strings.Builder
+	var year, month, day bool
+next:
+	for rest := format; rest != ""; {
+		for _, t := range tokens {
+			if strings.HasPrefix(rest, t.from) {
+				b.WriteString(t.to)
+				rest = rest[len(t.from):]
+				switch t.from[0] {
+				case 'Y':
+					year = true
+				case 'M':
+					month = true
+				case 'D':
+					day = true
+				}
+				continue next
+			}
+		}
+		r, size := utf8.DecodeRuneInString(rest)
+		if r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' {
+			return "", mappingErrorf("date_format %q may only combine YYYY, YY, MM, M, MMM, DD and D with separators", format)
+		}
+		b.WriteString(rest[:size])
+		rest = rest[size:]
+	}
+	if !year || !month || !day {
+		return "", mappingErrorf("date_format %q must have a year, a month and a day", format)
+	}
+	return b.String(), nil
+}
+
+// Parse reads the rows of a file. The mapping must have passed Check. Rows
+// that cannot be read carry their error; a header that lacks a mapped
+// column yields a MappingError.
+func Parse(r io.Reader, m *imports.Mapping, maxRows int) ([]*importer.Row, error) {
+	layout, err := Layout(m.DateFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	cr := csv.NewReader(r)
+	cr.Comma, _ = utf8.DecodeRuneInString(m.Delimiter)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	cr.TrimLeadingSpace = true
+
+	p := &parser{m: m, layout: layout}
+	var rows []*importer.Row
+	for n := 0; ; n++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+		if n < m.SkipRows {
+			continue
+		}
+		if n == m.SkipRows && p.columns == nil {
+			if err := p.header(record); err != nil {
+				return nil, err
+			}
+			if !m.NoHeader {
+				continue
+			}
+		}
+		if len(rows) == maxRows {
+			return nil, importer.ErrTooManyRows
+		}
+		line, _ := cr.FieldPos(0)
+		rows = append(rows, p.row(line, record))
+	}
+	return rows, nil
+}
+
+type parser struct {
+	m       *imports.Mapping
+	layout  string
+	columns map[string]int // mapped column to its index in a record
+}
+
+// header resolves the mapped columns against the header row, or against
+// positions when the file has none.
+func (p *parser) header(record []string) error {
+	c := p.m.Columns
+	p.columns = map[string]int{}
+	for key, col := range map[string]string{
+		"name": c.Name, "amount": c.Amount, "debit": c.Debit, "credit": c.Credit, "date": c.Date, "type": c.Type,
+	} {
+		if col == "" {
+			continue
+		}
+		i := -1
+		if !p.m.NoHeader {
+			for j, h := range record {
+				if strings.EqualFold(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")), strings.TrimSpace(col)) {
+					i = j
+					break
+				}
+			}
+		}
+		if n, err := strconv.Atoi(col); i < 0 && err == nil && n >= 1 {
+			i = n - 1
+		}
+		if i < 0 {
+			return mappingErrorf("column %q is not in the header", col)
+		}
+		p.columns[key] = i
+	}
+	return nil
+}
+
+func (p *parser) field(record []string, key string) (string, error) {
+	i, ok := p.columns[key]
+	if !ok {
+		return "", nil
+	}
+	if i >= len(record) {
+		return "", fmt.Errorf("line has %d columns, %s is column %d", len(record), key, i+1)
+	}
+	return strings.TrimSpace(record[i]), nil
+}
+
+func (p *parser) row(line int, record []string) *importer.Row {
+	r := &importer.Row{Line: line}
+	if err := p.fill(r, record); err != nil {
+		r.Err = err
+	}
+	return r
+}
+
+func (p *parser) fill(r *importer.Row, record []string) error {
+	var err error
+	if r.Name, err = p.field(record, "name"); err != nil {
+		return err
+	}
+	if r.Name == "" {
+		return errors.New("name is empty")
+	}
+	if len(r.Name) > maxText {
+		return fmt.Errorf("name is longer than %d characters", maxText)
+	}
+
+	date, err := p.field(record, "date")
+	if err != nil {
+		return err
+	}
+	if r.Date, err = time.Parse(p.layout, date); err != nil {
+		return fmt.Errorf("date %q does not match %s", date, p.m.DateFormat)
+	}
+
+	if r.Type, err = p.field(record, "type"); err != nil {
+		return err
+	}
+	if r.Type == "" {
+		r.Type = p.m.DefaultType
+	}
+	if r.Type == "" {
+		return errors.New("type is empty; map a type column or set default_type")
+	}
+	if len(r.Type) > maxText {
+		return fmt.Errorf("type is longer than %d characters", maxText)
+	}
+
+	return p.amount(r, record)
+}
+
+// amount sets the amount and the direction of a row following the sign
+// convention of the mapping.
+func (p *parser) amount(r *importer.Row, record []string) error {
+	if p.m.Sign == imports.SignDebitCredit {
+		debit, err := p.number(record, "debit")
+		if err != nil {
+			return err
+		}
+		credit, err := p.number(record, "credit")
+		if err != nil {
+			return err
+		}
+		switch {
+		case debit != 0 && credit != 0:
+			return errors.New("both debit and credit are set")
+		case debit != 0:
+			r.Direction, r.Amount = m_import.DirectionExpense, math.Abs(debit)
+		case credit != 0:
+			r.Direction, r.Amount = m_import.DirectionIncome, math.Abs(credit)
+		default:
+			return errors.New("debit and credit are both empty")
+		}
+		return nil
+	}
+
+	amount, err := p.number(record, "amount")
+	if err != nil {
+		return err
+	}
+	if amount == 0 {
+		return errors.New("amount is empty or zero")
+	}
+	switch p.m.Sign {
+	case imports.SignExpense:
+		r.Direction = m_import.DirectionExpense
+	case imports.SignIncome:
+		r.Direction = m_import.DirectionIncome
+	case imports.SignNegativeIncome:
+		r.Direction = m_import.DirectionExpense
+		if amount < 0 {
+			r.Direction = m_import.DirectionIncome
+		}
+	default:
+		r.Direction = m_import.DirectionIncome
+		if amount < 0 {
+			r.Direction = m_import.DirectionExpense
+		}
+	}
+	r.Amount = math.Abs(amount)
+	return nil
+}
+
+// number reads an amount column; empty cells read as zero.
+func (p *parser) number(record []string, key string) (float64, error) {
+	s, err := p.field(record, key)
+	if err != nil {
+		return 0, err
+	}
+	if s == "" {
+		return 0, nil
+	}
+	v, ok := ParseAmount(s, p.m.DecimalSeparator)
+	if !ok {
+		return 0, fmt.Errorf("%s %q is not a number", key, s)
+	}
+	return v, nil
+}
+
+// ParseAmount reads amounts the way banks write them: with currency signs,
+// thousands separators, a trailing minus or parentheses for negatives. The
+// result is rounded to cents.
+func ParseAmount(s, decimal string) (float64, bool) {
+	s = strings.TrimSpace(s)
+	negative := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		negative, s = true, s[1:len(s)-1]
+	}
+
+	var b strings.Builder
+	digits := false
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			digits = true
+			b.WriteRune(r)
+		case r == '-' || r == '\u2212':
+			negative = !negative
+		case string(r) == decimal:
+			b.WriteByte('.')
+		case r == '.' || r == ',' || r == ' ' || r == '\'' || r == '+':
+			// thousands separators and an explicit plus
+		case unicode.IsLetter(r) || unicode.Is(unicode.Sc, r) || unicode.IsSpace(r):
+			// currency signs and codes, and other spaces between thousands
+		default:
+			return 0, false
+		}
+	}
+	if !digits {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(b.String(), 64)
+	if err != nil {
+		return 0, false
+	}
+	if negative {
+		v = -v
+	}
+	return math.Round(v*100) / 100, true
+}
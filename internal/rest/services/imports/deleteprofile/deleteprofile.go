@@ -0,0 +1,35 @@
+package deleteprofile
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/imports"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Facade is the delete import profile facade
+type Facade struct {
+	pkg *pkg.Facade
+}
+
+// New creates a new delete import profile facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg: pkg,
+	}
+}
+
+// Handle handles the delete import profile request
+func (f *Facade) Handle(ctx context.Context, req *imports.DeleteProfileRequest) (*imports.DeleteProfileResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	if err := s.delete(); err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
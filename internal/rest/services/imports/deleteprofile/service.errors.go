@@ -0,0 +1,17 @@
+package deleteprofile
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	InvalidProfileID      *err.HTTPError
+	ProfileNotFound       *err.HTTPError
+	FailedToDeleteProfile *err.HTTPError
+}{
+	InvalidProfileID:      err.NewHTTPError(http.StatusBadRequest, "Invalid import profile ID format."),
+	ProfileNotFound:       err.NewHTTPError(http.StatusNotFound, "Import profile not found."),
+	FailedToDeleteProfile: err.NewHTTPError(http.StatusInternalServerError, "Failed to delete import profile."),
+}
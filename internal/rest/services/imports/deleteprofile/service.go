@@ -0,0 +1,40 @@
+package deleteprofile
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/imports"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_import"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx context.Context
+	req *imports.DeleteProfileRequest
+	f   *Facade
+}
+
+func (s *service) delete() error {
+	if _, err := uuid.Parse(s.req.ProfileID); err != nil {
+		return errs.InvalidProfileID
+	}
+
+	err := s.f.pkg.M.Import.DeleteProfile(s.ctx, utils.AuthCtx(s.ctx), s.req.ProfileID)
+	if errors.Is(err, m_import.ErrNotFound) {
+		return errs.ProfileNotFound
+	}
+	if err != nil {
+		return errs.FailedToDeleteProfile
+	}
+
+	return nil
+}
+
+func (s *service) reply() *imports.DeleteProfileResponse {
+	return &imports.DeleteProfileResponse{
+		Success: true,
+		Message: "Import profile deleted successfully",
+	}
+}
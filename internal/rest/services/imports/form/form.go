@@ -0,0 +1,63 @@
+// Package form converts import profiles and batches for the API. The import
+// services share it.
+package form
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/imports"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_import"
+)
+
+// Encode stores a mapping as the JSON saved with a profile.
+func Encode(m *imports.Mapping) ([]byte, error) {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode import mapping: %w", err)
+	}
+	return b, nil
+}
+
+// Decode reads the mapping saved with a profile.
+func Decode(p *m_import.Profile) (*imports.Mapping, error) {
+	var m imports.Mapping
+	if err := json.Unmarshal(p.Mapping, &m); err != nil {
+		return nil, fmt.Errorf("failed to decode import mapping: %w", err)
+	}
+	return &m, nil
+}
+
+// ConvertProfile converts a saved profile for the API.
+func ConvertProfile(p *m_import.Profile) (imports.Profile, error) {
+	m, err := Decode(p)
+	if err != nil {
+		return imports.Profile{}, err
+	}
+	return imports.Profile{
+		ProfileID: p.ProfileID,
+		Name:      p.Name,
+		Mapping:   *m,
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: p.UpdatedAt,
+	}, nil
+}
+
+// ConvertBatch converts a saved batch for the API.
+func ConvertBatch(b *m_import.Batch) imports.Batch {
+	res := imports.Batch{
+		BatchID:      b.BatchID,
+		Source:       b.Source,
+		TotalRows:    b.TotalRows,
+		CreatedRows:  b.CreatedRows,
+		CreatedAt:    b.CreatedAt,
+		RolledBackAt: b.RolledBackAt,
+	}
+	if b.FileName != nil {
+		res.FileName = *b.FileName
+	}
+	if b.ProfileID != nil {
+		res.ProfileID = *b.ProfileID
+	}
+	return res
+}
@@ -0,0 +1,389 @@
+// Package importer creates the incomes and expenses read from an imported
+// file. Parsers for the different formats turn a file into Rows; Run sends
+// them through the regular create services, so imported records are
+// validated, tagged and audited like any other.
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	er "github.com/rsmrtk/fd-er"
+	"github.com/rsmrtk/mybox/internal/rest/domain/expense"
+	"github.com/rsmrtk/mybox/internal/rest/domain/imports"
+	"github.com/rsmrtk/mybox/internal/rest/domain/income"
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+	"github.com/rsmrtk/mybox/internal/rest/services/account"
+	expensecreate "github.com/rsmrtk/mybox/internal/rest/services/expense/create"
+	incomecreate "github.com/rsmrtk/mybox/internal/rest/services/income/create"
+	"github.com/rsmrtk/mybox/internal/rest/services/record"
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/dbtx"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_account"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_import"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+// ErrTooManyRows is returned by parsers for files with more rows than allowed.
+var ErrTooManyRows = errors.New("too many rows")
+
+// maxName is the longest name a record can have.
+const maxName = 255
+
+// CurrencyError is returned by Run, before any row is imported, when rows
+// are in another currency than the account they go to.
+type CurrencyError struct {
+	Currency string          // of the first such row
+	Account  *m_account.Data // it goes to; nil outside an account
+	Rows     int             // number of such rows
+}
+
+func (e *CurrencyError) Error() string {
+	if e.Account == nil {
+		return fmt.Sprintf("%d row(s) in %s cannot be imported outside an account, where records are in %s",
+			e.Rows, e.Currency, record.DefaultCurrency)
+	}
+	return fmt.Sprintf("%d row(s) in %s cannot be imported into account %s, which is in %s",
+		e.Rows, e.Currency, e.Account.Name, e.Account.Currency)
+}
+
+// errDryRun rolls back the transaction of a dry run once every row was tried.
+var errDryRun = errors.New("dry run")
+
+// Row is one income or expense read from a file. Rows that could not be
+// read carry Err and are reported but not created.
+type Row struct {
+	Line      int
+	Direction string // m_import.DirectionIncome or DirectionExpense
+	Name      string
+	Amount    float64 // positive
+	Date      time.Time
+	Type      string
+	Err       error
+
+	// CategoryID and Tags are set by formats that carry them.
+	CategoryID string
+	Tags       []string
+
+	// ExternalID is the bank's ID of the transaction, such as an OFX FITID.
+	// A row whose ID an earlier import already created is skipped.
+	ExternalID string
+
+	// Currency is the ISO 4217 code of the amount when the file has one.
+	// Records take the currency of their account, USD outside of one, so
+	// Run refuses files with rows bound for an account in another currency.
+	Currency string
+	// BankAccount identifies the account of the statement at the bank,
+	// such as an IBAN; Options.Accounts maps it onto an account.
+	BankAccount string
+}
+
+// Options describe where the rows come from and how to import them.
+type Options struct {
+	Source    string // file format, such as csv
+	FileName  string
+	ProfileID string
+	AccountID string            // links every record to the account when set
+	Accounts  map[string]string // links the records of a bank account to an account instead
+	DryRun    bool
+}
+
+type Importer struct {
+	pkg     *pkg.Facade
+	income  *incomecreate.Facade
+	expense *expensecreate.Facade
+}
+
+func New(f *pkg.Facade) *Importer {
+	return &Importer{
+		pkg:     f,
+		income:  incomecreate.New(f),
+		expense: expensecreate.New(f),
+	}
+}
+
+// Run creates the valid rows in one transaction, each in a savepoint, so
+// that a row the create service rejects is reported and left out while the
+// others are kept. The created records are saved as a batch. A dry run
+// does the same and rolls everything back. Rows with an external ID that
+// an earlier import of the workspace created, or that came earlier in the
+// file, are reported as duplicates and skipped. An unknown account yields
+// m_account.ErrNotFound, and rows in a currency other than that of their
+// account a *CurrencyError.
+func (i *Importer) Run(ctx context.Context, rows []*Row, o Options) (*imports.ImportResponse, error) {
+	accounts, err := i.accounts(ctx, o)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &imports.ImportResponse{
+		DryRun: o.DryRun,
+		Total:  len(rows),
+		Rows:   make([]*imports.Row, len(rows)),
+	}
+	if err := currencies(rows, accounts); err != nil {
+		return nil, err
+	}
+
+	for n, r := range rows {
+		res.Rows[n] = convert(r, target(accounts, r))
+	}
+
+	workspaceID := utils.AuthCtx(ctx)
+	seen := make(map[string]bool)
+
+	var records []*m_import.Record
+	err = dbtx.InTx(ctx, i.pkg.M.DB, func(ctx context.Context) error {
+		// Overlapping statements imported at the same time would both miss
+		// the other's external IDs
+		if err := i.pkg.M.Import.LockWorkspace(ctx, workspaceID); err != nil {
+			return err
+		}
+		for n, r := range rows {
+			if r.Err != nil {
+				continue
+			}
+			if r.ExternalID != "" {
+				dup := seen[r.ExternalID]
+				if !dup {
+					var err error
+					if dup, err = i.pkg.M.Import.Imported(ctx, workspaceID, r.ExternalID); err != nil {
+						return err
+					}
+				}
+				seen[r.ExternalID] = true
+				if dup {
+					res.Rows[n].Status = imports.StatusDuplicate
+					continue
+				}
+			}
+			var id string
+			err := dbtx.Savepoint(ctx, "import_row", func(ctx context.Context) error {
+				var err error
+				id, err = i.create(ctx, r, target(accounts, r))
+				return err
+			})
+			if err != nil {
+				res.Rows[n].Status, res.Rows[n].Error = imports.StatusFailed, message(err)
+				continue
+			}
+			res.Rows[n].Status = imports.StatusCreated
+			if o.DryRun {
+				res.Rows[n].Status = imports.StatusValid
+			} else {
+				res.Rows[n].RecordID = id
+			}
+			rec := &m_import.Record{Direction: r.Direction, RecordID: id, Line: r.Line}
+			if r.ExternalID != "" {
+				rec.ExternalID = &r.ExternalID
+			}
+			records = append(records, rec)
+		}
+
+		if o.DryRun {
+			return errDryRun
+		}
+		if len(records) == 0 {
+			return nil
+		}
+
+		b := &m_import.Batch{
+			BatchID:     uuid.New().String(),
+			WorkspaceID: workspaceID,
+			Source:      o.Source,
+			TotalRows:   len(rows),
+			CreatedRows: len(records),
+			CreatedAt:   time.Now().UTC(),
+		}
+		if o.FileName != "" {
+			b.FileName = &o.FileName
+		}
+		if o.ProfileID != "" {
+			b.ProfileID = &o.ProfileID
+		}
+		if err := i.pkg.M.Import.CreateBatch(ctx, b, records); err != nil {
+			return err
+		}
+		res.BatchID, res.Committed = b.BatchID, true
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+
+	for _, r := range res.Rows {
+		switch r.Status {
+		case imports.StatusValid, imports.StatusCreated:
+			res.Valid++
+		case imports.StatusInvalid:
+			res.Invalid++
+		case imports.StatusFailed:
+			res.Failed++
+		case imports.StatusDuplicate:
+			res.Duplicate++
+		}
+	}
+	return res, nil
+}
+
+// accounts resolves the accounts of the options, keyed by bank account;
+// the key "" holds Options.AccountID, or nil.
+func (i *Importer) accounts(ctx context.Context, o Options) (map[string]*m_account.Data, error) {
+	res := map[string]*m_account.Data{"": nil}
+	resolve := func(key, id string) error {
+		a, err := account.Resolve(ctx, i.pkg, id)
+		if err != nil {
+			return err
+		}
+		res[key] = a
+		return nil
+	}
+	if o.AccountID != "" {
+		if err := resolve("", o.AccountID); err != nil {
+			return nil, err
+		}
+	}
+	for bank, id := range o.Accounts {
+		if err := resolve(bank, id); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// currencies checks that every valid row with a currency goes to an account
+// in that currency, or outside an account if it is in DefaultCurrency.
+func currencies(rows []*Row, accounts map[string]*m_account.Data) error {
+	var res *CurrencyError
+	for _, r := range rows {
+		if r.Err != nil || r.Currency == "" {
+			continue
+		}
+		a := target(accounts, r)
+		want := record.DefaultCurrency
+		if a != nil {
+			want = a.Currency
+		}
+		if r.Currency == want {
+			continue
+		}
+		if res == nil {
+			res = &CurrencyError{Currency: r.Currency, Account: a}
+		}
+		res.Rows++
+	}
+	if res != nil {
+		return res
+	}
+	return nil
+}
+
+// create makes the record of one row and returns its ID.
+func (i *Importer) create(ctx context.Context, r *Row, a *m_account.Data) (string, error) {
+	var accountID string
+	if a != nil {
+		accountID = a.AccountID
+	}
+	amount := []*models.Amount{{Amount: r.Amount}}
+	switch r.Direction {
+	case m_import.DirectionIncome:
+		res, err := i.income.Handle(ctx, &income.CreateRequest{
+			IncomeName:   r.Name,
+			IncomeAmount: amount,
+			IncomeType:   r.Type,
+			IncomeDate:   models.NewDate(r.Date),
+			CategoryID:   r.CategoryID,
+			AccountID:    accountID,
+			Tags:         r.Tags,
+		})
+		if err != nil {
+			return "", err
+		}
+		return res.IncomeID, nil
+
+	case m_import.DirectionExpense:
+		res, err := i.expense.Handle(ctx, &expense.CreateRequest{
+			ExpenseName:   r.Name,
+			ExpenseAmount: amount,
+			ExpenseType:   r.Type,
+			ExpenseDate:   models.NewDate(r.Date),
+			CategoryID:    r.CategoryID,
+			AccountID:     accountID,
+			Tags:          r.Tags,
+		})
+		if err != nil {
+			return "", err
+		}
+		return res.ExpenseID, nil
+	}
+	return "", fmt.Errorf("unknown direction %q", r.Direction)
+}
+
+// target returns the account a row goes to: that of its bank account, or
+// else the default one, which is nil for rows outside an account.
+func target(accounts map[string]*m_account.Data, r *Row) *m_account.Data {
+	if a, ok := accounts[r.BankAccount]; ok {
+		return a
+	}
+	return accounts[""]
+}
+
+func convert(r *Row, a *m_account.Data) *imports.Row {
+	if r.Err != nil {
+		return &imports.Row{Line: r.Line, Status: imports.StatusInvalid, ExternalID: r.ExternalID, Error: r.Err.Error()}
+	}
+	date := models.NewDate(r.Date)
+	return &imports.Row{
+		Line:       r.Line,
+		Direction:  r.Direction,
+		Name:       r.Name,
+		Amount:     amounts(r, a),
+		Date:       &date,
+		Type:       r.Type,
+		ExternalID: r.ExternalID,
+	}
+}
+
+// Describe names the record of a bank transaction after its counterparty
+// and its remittance information, such as "ACME Corp: Invoice 42", cut to
+// the longest name a record can have.
+func Describe(counterparty, remittance string) string {
+	counterparty = strings.Join(strings.Fields(counterparty), " ")
+	remittance = strings.Join(strings.Fields(remittance), " ")
+	name := counterparty
+	switch {
+	case name == "":
+		name = remittance
+	case remittance != "":
+		name += ": " + remittance
+	}
+	if len(name) > maxName {
+		// Cut at a rune boundary
+		name = strings.ToValidUTF8(name[:maxName], "")
+	}
+	return name
+}
+
+// amounts formats the amount of a row in its own currency or, for rows
+// without one, in that of the account it goes to.
+func amounts(r *Row, a *m_account.Data) []*models.Amount {
+	currency := r.Currency
+	if currency == "" && a != nil {
+		currency = a.Currency
+	}
+	return record.AmountsIn(r.Amount, record.Currency(currency))
+}
+
+// message returns what the create service said about a rejected row.
+func message(err error) string {
+	var herr *er.HTTPError
+	if errors.As(err, &herr) {
+		return fmt.Sprint(herr.Message)
+	}
+	return http.StatusText(http.StatusInternalServerError)
+}
@@ -0,0 +1,62 @@
+// Package importertest compares the rows read by the statement parsers with
+// what their tests expect.
+package importertest
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/importer"
+)
+
+// Want is what a test expects of a row. A row with Err set is only compared
+// by its line and external ID.
+type Want struct {
+	Line        int
+	Direction   string
+	Name        string
+	Amount      float64
+	Date        string // YYYY-MM-DD
+	Type        string
+	ExternalID  string
+	Currency    string
+	BankAccount string
+	CategoryID  string
+	Tags        []string
+	Err         bool
+}
+
+// CheckRows reports every row of got that differs from wants.
+func CheckRows(t *testing.T, got []*importer.Row, wants []Want) {
+	t.Helper()
+	if len(got) != len(wants) {
+		t.Fatalf("got %d rows, want %d", len(got), len(wants))
+	}
+	for i, w := range wants {
+		r := got[i]
+		if r.Line != w.Line || r.ExternalID != w.ExternalID {
+			t.Errorf("row %d: line %d, external ID %q; want %d, %q", i, r.Line, r.ExternalID, w.Line, w.ExternalID)
+		}
+		if w.Err {
+			if r.Err == nil {
+				t.Errorf("row %d: no error", i)
+			}
+			continue
+		}
+		if r.Err != nil {
+			t.Errorf("row %d: %v", i, r.Err)
+			continue
+		}
+		if r.Direction != w.Direction || r.Name != w.Name || r.Amount != w.Amount ||
+			r.Date.Format(time.DateOnly) != w.Date || r.Type != w.Type ||
+			r.Currency != w.Currency || r.BankAccount != w.BankAccount {
+			t.Errorf("row %d = {%s %q %v %s %q %s %s}, want {%s %q %v %s %q %s %s}", i,
+				r.Direction, r.Name, r.Amount, r.Date.Format(time.DateOnly), r.Type, r.Currency, r.BankAccount,
+				w.Direction, w.Name, w.Amount, w.Date, w.Type, w.Currency, w.BankAccount)
+		}
+		if r.CategoryID != w.CategoryID || !slices.Equal(r.Tags, w.Tags) {
+			t.Errorf("row %d: category %q, tags %v; want %q, %v", i, r.CategoryID, r.Tags, w.CategoryID, w.Tags)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package journal
+
+import (
+	"fmt"
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/importer"
+	"github.com/rsmrtk/mybox/internal/rest/services/record"
+)
+
+var errs = struct {
+	NotJournal     *err.HTTPError
+	UnreadableFile *err.HTTPError
+	UnknownAccount *err.HTTPError
+	FailedToImport *err.HTTPError
+}{
+	NotJournal:     err.NewHTTPError(http.StatusBadRequest, "File has no dated Beancount or Ledger entries."),
+	UnreadableFile: err.NewHTTPError(http.StatusBadRequest, "File is not a readable journal."),
+	UnknownAccount: err.NewHTTPError(http.StatusBadRequest, "Account not found."),
+	FailedToImport: err.NewHTTPError(http.StatusInternalServerError, "Failed to import file."),
+}
+
+// tooManyRows reports the row limit a file exceeds.
+func tooManyRows(max int) *err.HTTPError {
+	return err.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("File has more than %d income and expense postings; split it up.", max))
+}
+
+// currencyMismatch reports rows in another currency than their account.
+func currencyMismatch(e *importer.CurrencyError) *err.HTTPError {
+	if e.Account == nil {
+		return err.NewHTTPError(http.StatusBadRequest, fmt.Sprintf(
+			"%d transaction(s) are in %s, but records outside an account are in %s; import them into an account in %s.",
+			e.Rows, e.Currency, record.DefaultCurrency, e.Currency))
+	}
+	return err.NewHTTPError(http.StatusBadRequest, fmt.Sprintf(
+		"%d transaction(s) are in %s, but account %s is in %s.", e.Rows, e.Currency, e.Account.Name, e.Account.Currency))
+}
@@ -0,0 +1,60 @@
+package journal
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/imports"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/importer"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/journalfile"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_account"
+)
+
+type service struct {
+	ctx context.Context
+	req *imports.JournalRequest
+	f   *Facade
+	res *imports.ImportResponse
+}
+
+func (s *service) run() error {
+	maxRows := s.f.pkg.Config.Import.MaxRows
+	rows, err := journalfile.Parse(strings.NewReader(s.req.Content), journalfile.Options{
+		Types:       s.req.Types,
+		Categories:  s.req.Categories,
+		DefaultType: strings.TrimSpace(s.req.DefaultType),
+	}, maxRows)
+	switch {
+	case errors.Is(err, journalfile.ErrNotJournal):
+		return errs.NotJournal
+	case errors.Is(err, importer.ErrTooManyRows):
+		return tooManyRows(maxRows)
+	case err != nil:
+		return errs.UnreadableFile
+	}
+
+	s.res, err = s.f.importer.Run(s.ctx, rows, importer.Options{
+		Source:    journalfile.Detect(s.req.Content),
+		FileName:  s.req.FileName,
+		AccountID: s.req.AccountID,
+		Accounts:  s.req.Accounts,
+		DryRun:    s.req.DryRun,
+	})
+	if errors.Is(err, m_account.ErrNotFound) {
+		return errs.UnknownAccount
+	}
+	var currency *importer.CurrencyError
+	if errors.As(err, &currency) {
+		return currencyMismatch(currency)
+	}
+	if err != nil {
+		return errs.FailedToImport
+	}
+
+	return nil
+}
+
+func (s *service) reply() *imports.ImportResponse {
+	return s.res
+}
@@ -0,0 +1,487 @@
+// Package journalfile reads the transactions of plain text accounting
+// journals, written for Beancount or for Ledger and hledger, into import
+// rows. Each posting to an income or expense account becomes a row; the
+// asset or liability account on the other side tells where the money went
+// into or came out of. Transfers between asset accounts, opening balances
+// and directives such as open, price or account are skipped.
+package journalfile
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/csvfile"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/importer"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_import"
+)
+
+// ErrNotJournal is returned by Parse for files without dated entries.
+var ErrNotJournal = errors.New("not a journal")
+
+// Formats of a journal, as told by Detect.
+const (
+	Beancount = "beancount"
+	Ledger    = "ledger"
+)
+
+// maxText is the longest type a record can have.
+const maxText = 255
+
+// beancountSyntax matches lines only Beancount writes: quoted options and
+// descriptions, and dated directives.
+var beancountSyntax = regexp.MustCompile(`(?m)^(?:(?:option|plugin|include) "|` +
+	`\d{4}-\d{2}-\d{2}\s+(?:(?:txn|[*!])\s+)?"|` +
+	`\d{4}-\d{2}-\d{2}\s+(?:open|close|balance|pad|commodity|price|note|document|event|custom|query)\s)`)
+
+// Detect tells whether a journal is written for Beancount or for Ledger.
+func Detect(content string) string {
+	if beancountSyntax.MatchString(content) {
+		return Beancount
+	}
+	return Ledger
+}
+
+// directives are the dated Beancount entries that are not transactions.
+var directives = map[string]bool{
+	"open": true, "close": true, "balance": true, "pad": true, "commodity": true, "price": true,
+	"note": true, "document": true, "event": true, "custom": true, "query": true,
+}
+
+// metaPattern matches a key and value, as Beancount writes metadata and
+// Ledger writes it in comments.
+var metaPattern = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9_-]*):(?:\s+(.*))?$`)
+
+// symbols are the currency signs read as ISO 4217 codes.
+var symbols = map[string]string{"$": "USD", "€": "EUR", "£": "GBP", "¥": "JPY"}
+
+// Options describe how journal accounts become records.
+type Options struct {
+	Types       map[string]string // income or expense account to record type
+	Categories  map[string]string // income or expense account to category ID
+	DefaultType string            // type of the other accounts; defaults to the record's type metadata or the account's last part
+}
+
+// Parse reads the transactions of a journal. A posting into an expense
+// account becomes an expense and one out of an income account an income,
+// named after the payee and narration. The first asset or liability
+// posting gives the rows their bank account, which import options map onto
+// an account. A transaction with several income or expense postings gives a
+// row per posting. Its id metadata, as written by the journal export,
+// becomes the external ID, so that importing a journal twice skips what was
+// imported before.
+func Parse(r io.Reader, o Options, maxRows int) ([]*importer.Row, error) {
+	p := &parser{o: o}
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, 1<<20)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimRight(sc.Text(), "\r")
+		if n == 1 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+		p.line(n, line)
+		if len(p.rows) > maxRows {
+			return nil, importer.ErrTooManyRows
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+	p.finish()
+	if len(p.rows) > maxRows {
+		return nil, importer.ErrTooManyRows
+	}
+	if !p.found {
+		return nil, ErrNotJournal
+	}
+	return p.rows, nil
+}
+
+type posting struct {
+	account   string
+	amount    float64
+	currency  string
+	hasAmount bool
+}
+
+type transaction struct {
+	line      int
+	date      time.Time
+	payee     string
+	narration string
+	tags      []string
+	meta      map[string]string
+	postings  []*posting
+	err       error
+}
+
+type parser struct {
+	o       Options
+	rows    []*importer.Row
+	found   bool // a dated entry was seen
+	block   bool // inside a Ledger comment block
+	current *transaction
+}
+
+func (p *parser) line(n int, line string) {
+	trimmed := strings.TrimSpace(line)
+	if p.block {
+		if strings.HasPrefix(trimmed, "end comment") || strings.HasPrefix(trimmed, "end test") {
+			p.block = false
+		}
+		return
+	}
+	if trimmed == "" {
+		p.finish()
+		return
+	}
+
+	if line[0] == ' ' || line[0] == '\t' {
+		// Postings, metadata and comments of the current entry
+		if p.current != nil {
+			p.indented(trimmed)
+		}
+		return
+	}
+
+	p.finish()
+	switch {
+	case strings.ContainsRune(";#%|*", rune(line[0])):
+		// comments, and Org mode headings in Beancount files
+	case trimmed == "comment" || trimmed == "test":
+		p.block = true
+	case line[0] >= '0' && line[0] <= '9':
+		p.found = true
+		p.header(n, trimmed)
+	default:
+		// option, plugin, include, account, commodity, P and other directives
+	}
+}
+
+// header starts a transaction, or skips a dated directive.
+func (p *parser) header(n int, line string) {
+	date, rest := line, ""
+	if i := strings.IndexFunc(line, unicode.IsSpace); i >= 0 {
+		date, rest = line[:i], line[i:]
+	}
+	// Ledger may add an auxiliary date after =
+	date, _, _ = strings.Cut(date, "=")
+	rest = strings.TrimSpace(rest)
+
+	keyword, _, _ := strings.Cut(rest, " ")
+	if directives[keyword] {
+		return
+	}
+
+	t := &transaction{line: n, meta: map[string]string{}}
+	p.current = t
+	d, err := time.Parse("2006-1-2", strings.NewReplacer("/", "-", ".", "-").Replace(date))
+	if err != nil {
+		t.err = fmt.Errorf("date %q is not a date", date)
+		return
+	}
+	t.date = d
+
+	// Flag, then Ledger's optional code in parentheses
+	switch {
+	case keyword == "txn" || keyword == "*" || keyword == "!":
+		rest = strings.TrimSpace(rest[len(keyword):])
+	case len(keyword) == 1 && strings.HasPrefix(strings.TrimSpace(rest[1:]), `"`):
+		// Beancount's other flags
+		rest = strings.TrimSpace(rest[1:])
+	}
+	if strings.HasPrefix(rest, "(") {
+		if end := strings.IndexByte(rest, ')'); end > 0 {
+			rest = strings.TrimSpace(rest[end+1:])
+		}
+	}
+
+	if strings.HasPrefix(rest, `"`) {
+		p.beancountHeader(t, rest)
+		return
+	}
+	description, comment := cutComment(rest)
+	t.payee, t.narration, _ = strings.Cut(description, "|")
+	p.comment(t, comment)
+}
+
+// beancountHeader reads the payee, narration, tags and links of a
+// Beancount transaction.
+func (p *parser) beancountHeader(t *transaction, rest string) {
+	var strs []string
+	for strings.HasPrefix(rest, `"`) {
+		s, tail, ok := quoted(rest)
+		if !ok {
+			t.err = errors.New("description has no closing quote")
+			return
+		}
+		strs, rest = append(strs, s), strings.TrimSpace(tail)
+	}
+	switch len(strs) {
+	case 1:
+		t.narration = strs[0]
+	default:
+		t.payee, t.narration = strs[0], strs[1]
+	}
+	rest, _ = cutComment(rest)
+	for _, word := range strings.Fields(rest) {
+		if tag, ok := strings.CutPrefix(word, "#"); ok && tag != "" {
+			t.tags = append(t.tags, tag)
+		}
+	}
+}
+
+// quoted reads the Beancount string s starts with and returns what follows.
+func quoted(s string) (string, string, bool) {
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if i+1 < len(s) {
+				i++
+				b.WriteByte(s[i])
+			}
+		case '"':
+			return b.String(), s[i+1:], true
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return "", "", false
+}
+
+// cutComment splits a line at its ; comment.
+func cutComment(s string) (string, string) {
+	before, after, _ := strings.Cut(s, ";")
+	return strings.TrimSpace(before), strings.TrimSpace(after)
+}
+
+// comment reads Ledger tags (:food:work:) and metadata (key: value).
+func (p *parser) comment(t *transaction, comment string) {
+	if len(comment) > 1 && strings.HasPrefix(comment, ":") && strings.HasSuffix(comment, ":") {
+		for _, tag := range strings.Split(comment[1:len(comment)-1], ":") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				t.tags = append(t.tags, tag)
+			}
+		}
+		return
+	}
+	if m := metaPattern.FindStringSubmatch(comment); m != nil {
+		t.meta[strings.ToLower(m[1])] = strings.TrimSpace(m[2])
+	}
+}
+
+// indented reads a posting, a metadata line or a comment of the current
+// transaction.
+func (p *parser) indented(line string) {
+	t := p.current
+	if strings.HasPrefix(line, ";") || strings.HasPrefix(line, "#") {
+		p.comment(t, strings.TrimSpace(line[1:]))
+		return
+	}
+	// Beancount metadata; an account has no space after its colons
+	if m := metaPattern.FindStringSubmatch(line); m != nil {
+		value := strings.TrimSpace(m[2])
+		if s, _, ok := quoted(value); ok && strings.HasPrefix(value, `"`) {
+			value = s
+		}
+		t.meta[strings.ToLower(m[1])] = value
+		return
+	}
+
+	line, _ = cutComment(line)
+	line = strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(line, "* "), "! "))
+	account, amount := splitPosting(line)
+	if strings.HasPrefix(account, "(") || strings.HasPrefix(account, "[") {
+		// Ledger's virtual postings do not move money
+		return
+	}
+	pst := &posting{account: account}
+	t.postings = append(t.postings, pst)
+	if amount == "" {
+		return
+	}
+
+	// A cost, a price or a balance assertion may follow the amount
+	if i := strings.IndexAny(amount, "@{="); i >= 0 {
+		amount = strings.TrimSpace(amount[:i])
+	}
+	v, ok := csvfile.ParseAmount(amount, ".")
+	if !ok {
+		if t.err == nil {
+			t.err = fmt.Errorf("amount %q of %s is not a number", amount, account)
+		}
+		return
+	}
+	pst.amount, pst.hasAmount = v, true
+	pst.currency = strings.Trim(strings.TrimFunc(amount, func(r rune) bool {
+		return unicode.IsDigit(r) || unicode.IsSpace(r) || strings.ContainsRune("-+.,", r)
+	}), `"`)
+	if code, ok := symbols[pst.currency]; ok {
+		pst.currency = code
+	}
+}
+
+// splitPosting cuts a posting into its account and amount. Ledger ends an
+// account, which may hold spaces, at two spaces or a tab; Beancount
+// accounts have no spaces, so one is enough before an amount.
+func splitPosting(line string) (string, string) {
+	if i := strings.IndexAny(line, "\t"); i >= 0 {
+		return line[:i], strings.TrimSpace(line[i:])
+	}
+	if i := strings.Index(line, "  "); i >= 0 {
+		return line[:i], strings.TrimSpace(line[i:])
+	}
+	account, amount, ok := strings.Cut(line, " ")
+	if ok && amount != "" && strings.ContainsFunc(amount, unicode.IsDigit) {
+		first := []rune(amount)[0]
+		if unicode.IsDigit(first) || strings.ContainsRune("-+.$€£¥(", first) {
+			return account, strings.TrimSpace(amount)
+		}
+	}
+	return line, ""
+}
+
+// finish turns the current transaction into rows.
+func (p *parser) finish() {
+	t := p.current
+	if t == nil {
+		return
+	}
+	p.current = nil
+
+	if t.err == nil {
+		t.err = balance(t)
+	}
+
+	var funds string
+	var flows []*posting
+	for _, pst := range t.postings {
+		switch root(pst.account) {
+		case m_import.DirectionIncome, m_import.DirectionExpense:
+			flows = append(flows, pst)
+		default:
+			if funds == "" {
+				funds = pst.account
+			}
+		}
+	}
+	if t.err != nil && len(flows) == 0 {
+		// Not knowing what it was, report it
+		p.rows = append(p.rows, &importer.Row{Line: t.line, Err: t.err})
+		return
+	}
+
+	for i, pst := range flows {
+		r := &importer.Row{Line: t.line, BankAccount: funds, Currency: pst.currency, Date: t.date}
+		if id := t.meta["id"]; id != "" {
+			r.ExternalID = id
+			if len(flows) > 1 {
+				r.ExternalID += "/" + strconv.Itoa(i+1)
+			}
+		}
+		if t.err != nil {
+			r.Err = t.err
+		} else {
+			r.Err = p.fill(r, t, pst, len(flows) == 1)
+		}
+		p.rows = append(p.rows, r)
+	}
+}
+
+// balance works out the amount a posting leaves out, which is what the
+// other postings do not add up to.
+func balance(t *transaction) error {
+	var missing *posting
+	sums := map[string]float64{}
+	for _, pst := range t.postings {
+		if !pst.hasAmount {
+			if missing != nil {
+				return errors.New("more than one posting has no amount")
+			}
+			missing = pst
+			continue
+		}
+		sums[pst.currency] += pst.amount
+	}
+	if missing == nil {
+		return nil
+	}
+	if len(sums) != 1 {
+		return errors.New("posting without an amount cannot be worked out from several currencies")
+	}
+	for currency, sum := range sums {
+		missing.amount, missing.currency, missing.hasAmount = -math.Round(sum*100)/100, currency, true
+	}
+	return nil
+}
+
+// root tells whether an account is an income or an expense account, or
+// neither.
+func root(account string) string {
+	first, _, _ := strings.Cut(account, ":")
+	switch strings.ToLower(first) {
+	case "income", "incomes", "revenue", "revenues":
+		return m_import.DirectionIncome
+	case "expense", "expenses":
+		return m_import.DirectionExpense
+	}
+	return ""
+}
+
+func (p *parser) fill(r *importer.Row, t *transaction, pst *posting, only bool) error {
+	r.Direction = root(pst.account)
+	switch {
+	case r.Direction == m_import.DirectionExpense && pst.amount < 0,
+		r.Direction == m_import.DirectionIncome && pst.amount > 0:
+		return fmt.Errorf("money going back through %s, such as a refund, is not imported", pst.account)
+	case pst.amount == 0:
+		return fmt.Errorf("posting to %s is zero", pst.account)
+	}
+	r.Amount = math.Abs(pst.amount)
+
+	last := pst.account[strings.LastIndex(pst.account, ":")+1:]
+	if r.Name = importer.Describe(t.payee, t.narration); r.Name == "" {
+		r.Name = last
+	}
+
+	r.CategoryID = p.o.Categories[pst.account]
+	r.Tags = t.tags
+	switch typ, ok := p.o.Types[pst.account]; {
+	case ok:
+		r.Type = typ
+	case only && t.meta["type"] != "":
+		// The type the journal export wrote
+		r.Type = t.meta["type"]
+	case p.o.DefaultType != "":
+		r.Type = p.o.DefaultType
+	default:
+		r.Type = last
+	}
+	if len(r.Type) > maxText {
+		return fmt.Errorf("type is longer than %d characters", maxText)
+	}
+	if r.Currency != "" && !isCode(r.Currency) {
+		return fmt.Errorf("commodity %q is not an ISO 4217 currency", r.Currency)
+	}
+	return nil
+}
+
+func isCode(s string) bool {
+	if len(s) != 3 {
+		return false
+	}
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,177 @@
+package journalfile
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/importer"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/importer/importertest"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_import"
+)
+
+const beancount = `option "operating_currency" "EUR"
+
+2024-01-01 open Assets:Checking EUR
+
+2024-01-05 * "Employer" "January salary" #work ^payslip
+  id: "abc"
+  type: "salary"
+  Assets:Checking   1500.00 EUR
+  Income:Salary
+
+2024-01-06 txn "Groceries"
+  id: "g1"
+  Expenses:Food  20.00 EUR
+  Expenses:Household  5.50 EUR
+  Liabilities:Visa
+
+2024-01-07 * "Transfer"
+  Assets:Savings  100 EUR
+  Assets:Checking
+
+2024-01-08 * "Refund"
+  Expenses:Food  -5 EUR
+  Assets:Checking
+
+2024-01-09 * "Broken"
+  Expenses:Food  10 EUR
+  Expenses:Other
+  Assets:Checking
+
+2024-01-10 * "Stock"
+  Expenses:Fees  1 HOOL
+  Assets:Broker
+
+2024-13-01 * "Bad date"
+  Expenses:Food  1 EUR
+  Assets:Checking
+`
+
+const ledger = `; comment
+account Assets:Checking
+
+2024/01/05=2024/01/06 * (123) Employer | January salary  ; :work:home:
+    Assets:Checking Account    $1,500.00
+    Income:Salary
+
+comment
+2024/01/06 Ignored
+    Expenses:Food  $1
+    Assets:Cash
+end comment
+
+2024-01-07 ! Grocer
+    ; id: xyz
+    Expenses:Food	€20.00 @ 1.1 USD
+    (Budget:Food)  -20
+    Assets:Cash
+`
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		o       Options
+		maxRows int
+		want    []importertest.Want
+		err     error
+	}{
+		{
+			name: "beancount", content: beancount, maxRows: 20,
+			want: []importertest.Want{
+				{Line: 5, Direction: m_import.DirectionIncome, Name: "Employer: January salary", Amount: 1500, Date: "2024-01-05", Type: "salary", ExternalID: "abc", Currency: "EUR", BankAccount: "Assets:Checking", Tags: []string{"work"}},
+				{Line: 11, Direction: m_import.DirectionExpense, Name: "Groceries", Amount: 20, Date: "2024-01-06", Type: "Food", ExternalID: "g1/1", Currency: "EUR", BankAccount: "Liabilities:Visa"},
+				{Line: 11, Direction: m_import.DirectionExpense, Name: "Groceries", Amount: 5.5, Date: "2024-01-06", Type: "Household", ExternalID: "g1/2", Currency: "EUR", BankAccount: "Liabilities:Visa"},
+				{Line: 21, Err: true},
+				{Line: 25, Err: true},
+				{Line: 25, Err: true},
+				{Line: 30, Err: true},
+				{Line: 34, Err: true},
+			},
+		},
+		{
+			name: "beancount with options", content: beancount, maxRows: 20,
+			o: Options{
+				Types:       map[string]string{"Expenses:Food": "groceries"},
+				Categories:  map[string]string{"Expenses:Food": "cat-1"},
+				DefaultType: "misc",
+			},
+			want: []importertest.Want{
+				{Line: 5, Direction: m_import.DirectionIncome, Name: "Employer: January salary", Amount: 1500, Date: "2024-01-05", Type: "salary", ExternalID: "abc", Currency: "EUR", BankAccount: "Assets:Checking", Tags: []string{"work"}},
+				{Line: 11, Direction: m_import.DirectionExpense, Name: "Groceries", Amount: 20, Date: "2024-01-06", Type: "groceries", ExternalID: "g1/1", Currency: "EUR", BankAccount: "Liabilities:Visa", CategoryID: "cat-1"},
+				{Line: 11, Direction: m_import.DirectionExpense, Name: "Groceries", Amount: 5.5, Date: "2024-01-06", Type: "misc", ExternalID: "g1/2", Currency: "EUR", BankAccount: "Liabilities:Visa"},
+				{Line: 21, Err: true},
+				{Line: 25, Err: true},
+				{Line: 25, Err: true},
+				{Line: 30, Err: true},
+				{Line: 34, Err: true},
+			},
+		},
+		{
+			name: "ledger", content: ledger, maxRows: 20,
+			want: []importertest.Want{
+				{Line: 4, Direction: m_import.DirectionIncome, Name: "Employer: January salary", Amount: 1500, Date: "2024-01-05", Type: "Salary", Currency: "USD", BankAccount: "Assets:Checking Account", Tags: []string{"work", "home"}},
+				{Line: 14, Direction: m_import.DirectionExpense, Name: "Grocer", Amount: 20, Date: "2024-01-07", Type: "Food", ExternalID: "xyz", Currency: "EUR", BankAccount: "Assets:Cash"},
+			},
+		},
+		{
+			name: "payee from the account", content: "2024-02-01\n  Expenses:Rent  800 USD\n  Assets:Checking\n", maxRows: 20,
+			want: []importertest.Want{
+				{Line: 1, Direction: m_import.DirectionExpense, Name: "Rent", Amount: 800, Date: "2024-02-01", Type: "Rent", Currency: "USD", BankAccount: "Assets:Checking"},
+			},
+		},
+		{name: "too many rows", content: beancount, maxRows: 2, err: importer.ErrTooManyRows},
+		{name: "no entries", content: "; nothing\naccount Assets:Cash\n", maxRows: 20, err: ErrNotJournal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := Parse(strings.NewReader(tt.content), tt.o, tt.maxRows)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Parse() error = %v, want %v", err, tt.err)
+			}
+			importertest.CheckRows(t, rows, tt.want)
+		})
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		content string
+		want    string
+	}{
+		{beancount, Beancount},
+		{ledger, Ledger},
+		{"2024-01-01 open Assets:Cash\n", Beancount},
+		{"include \"2023.beancount\"\n", Beancount},
+		{"2024-01-01 * \"Shop\"\n  Expenses:Food  1 EUR\n  Assets:Cash\n", Beancount},
+		{"2024-01-01 * Shop\n  Expenses:Food  1 EUR\n  Assets:Cash\n", Ledger},
+		{"", Ledger},
+	}
+	for _, tt := range tests {
+		if got := Detect(tt.content); got != tt.want {
+			t.Errorf("Detect(%.30q) = %s, want %s", tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestSplitPosting(t *testing.T) {
+	tests := []struct {
+		line    string
+		account string
+		amount  string
+	}{
+		{"Assets:Checking 10.00 EUR", "Assets:Checking", "10.00 EUR"},
+		{"Assets:Checking Account  $10", "Assets:Checking Account", "$10"},
+		{"Assets:Checking Account\t-5 USD", "Assets:Checking Account", "-5 USD"},
+		{"Assets:Checking Account", "Assets:Checking Account", ""},
+		{"Expenses:Food €3", "Expenses:Food", "€3"},
+		{"Assets:Petty Cash", "Assets:Petty Cash", ""},
+	}
+	for _, tt := range tests {
+		account, amount := splitPosting(tt.line)
+		if account != tt.account || amount != tt.amount {
+			t.Errorf("splitPosting(%q) = %q, %q; want %q, %q", tt.line, account, amount, tt.account, tt.amount)
+		}
+	}
+}
@@ -0,0 +1,331 @@
+// Package mt940file reads the statement lines of SWIFT MT940 files into
+// import rows. A file holds one or more statements, each opened by the
+// account (:25:) and the opening balance (:60F: or :60M:), which carries
+// the currency, followed by statement lines (:61:) and their information
+// to the account owner (:86:).
+package mt940file
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/csvfile"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/importer"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_import"
+)
+
+// ErrNotMT940 is returned by Parse for files without statement lines or an
+// account.
+var ErrNotMT940 = errors.New("not an MT940 file")
+
+// maxText is the longest type a record can have.
+const maxText = 255
+
+// tagPattern matches the start of a field, such as :61: or :60F:.
+var tagPattern = regexp.MustCompile(`^:([0-9]{2}[A-Z]?):`)
+
+// field is a tag with its lines.
+type field struct {
+	tag   string
+	line  int
+	lines []string
+}
+
+// Parse reads the statement lines of every statement in a file. Credits
+// become incomes and debits expenses, named after the counterparty and the
+// remittance information of :86:. The booking date is the entry date of a
+// statement line, or its value date. The external ID of a row is the bank's
+// reference (after //), or else the account owner's reference, prefixed
+// with the account. Rows take defaultType, or else credit or debit.
+func Parse(r io.Reader, defaultType string, maxRows int) ([]*importer.Row, error) {
+	fields, err := split(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		rows     []*importer.Row
+		account  string
+		currency string
+		last     *importer.Row  // the row :86: describes
+		lastLine *statementLine // its statement line, unless it could not be read
+	)
+	for _, f := range fields {
+		switch f.tag {
+		case "25":
+			account = strings.TrimSpace(strings.Join(f.lines, ""))
+		case "60F", "60M":
+			// C or D, YYMMDD, then the currency
+			if v := strings.TrimSpace(f.lines[0]); len(v) >= 10 {
+				currency = v[7:10]
+			}
+		case "61":
+			if account == "" {
+				return nil, ErrNotMT940
+			}
+			if len(rows) == maxRows {
+				return nil, importer.ErrTooManyRows
+			}
+			sl, err := parseLine(f.lines[0])
+			last, lastLine = &importer.Row{Line: f.line, BankAccount: account, Currency: currency}, sl
+			if err != nil {
+				last.Err, lastLine = err, nil
+			} else {
+				last.ExternalID = sl.reference(account)
+				fill(last, sl, "", defaultType)
+			}
+			rows = append(rows, last)
+		case "86":
+			if lastLine != nil {
+				fill(last, lastLine, strings.Join(f.lines, "\n"), defaultType)
+			}
+			last, lastLine = nil, nil
+		}
+	}
+	if len(rows) == 0 && account == "" {
+		return nil, ErrNotMT940
+	}
+	return rows, nil
+}
+
+// split cuts a file into its fields. The lines of the SWIFT envelope, such
+// as {1:...}{2:...}{4: and -}, and the - closing a statement are dropped.
+func split(r io.Reader) ([]*field, error) {
+	var fields []*field
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, 1<<20)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimRight(sc.Text(), "\r")
+		if n == 1 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+		if i := strings.LastIndex(line, "{4:"); i >= 0 {
+			line = line[i+3:]
+		}
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || trimmed == "-" || strings.HasPrefix(trimmed, "-}") || strings.HasPrefix(trimmed, "{") {
+			continue
+		}
+		if m := tagPattern.FindStringSubmatch(line); m != nil {
+			fields = append(fields, &field{tag: m[1], line: n, lines: []string{line[len(m[0]):]}})
+			continue
+		}
+		if len(fields) > 0 {
+			f := fields[len(fields)-1]
+			f.lines = append(f.lines, line)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read MT940: %w", err)
+	}
+	return fields, nil
+}
+
+// statementLine is the first line of a :61: field.
+type statementLine struct {
+	date     time.Time
+	credit   bool
+	amount   float64
+	owner    string // reference for the account owner
+	bank     string // reference of the account servicing institution
+	typeCode string // such as NTRF or NMSC
+}
+
+func (s *statementLine) reference(account string) string {
+	ref := s.bank
+	if ref == "" && !strings.EqualFold(s.owner, "NONREF") {
+		ref = s.owner
+	}
+	if ref == "" {
+		return ""
+	}
+	return account + ":" + ref
+}
+
+// parseLine reads value date (YYMMDD), optional entry date (MMDD), mark (C,
+// D, RC or RD), optional funds code, amount, transaction type, owner
+// reference and //bank reference.
+func parseLine(v string) (*statementLine, error) {
+	v = strings.TrimSpace(v)
+	if len(v) < 6 {
+		return nil, fmt.Errorf("statement line %q is too short", v)
+	}
+	value, err := time.Parse("060102", v[:6])
+	if err != nil {
+		return nil, fmt.Errorf("value date %q is not a date", v[:6])
+	}
+	s := &statementLine{date: value}
+	v = v[6:]
+
+	if len(v) >= 4 && isDigits(v[:4]) {
+		entry, err := time.Parse("0102", v[:4])
+		if err != nil {
+			return nil, fmt.Errorf("entry date %q is not a date", v[:4])
+		}
+		// The entry date may fall in the year before or after the value date
+		s.date = time.Date(value.Year(), entry.Month(), entry.Day(), 0, 0, 0, 0, time.UTC)
+		switch diff := s.date.Sub(value); {
+		case diff > 180*24*time.Hour:
+			s.date = s.date.AddDate(-1, 0, 0)
+		case diff < -180*24*time.Hour:
+			s.date = s.date.AddDate(1, 0, 0)
+		}
+		v = v[4:]
+	}
+
+	switch {
+	case strings.HasPrefix(v, "RC"):
+		// A reversed credit takes money out
+		s.credit, v = false, v[2:]
+	case strings.HasPrefix(v, "RD"):
+		s.credit, v = true, v[2:]
+	case strings.HasPrefix(v, "C"):
+		s.credit, v = true, v[1:]
+	case strings.HasPrefix(v, "D"):
+		s.credit, v = false, v[1:]
+	default:
+		return nil, fmt.Errorf("statement line has no debit or credit mark before %q", v)
+	}
+	if v != "" && (v[0] < '0' || v[0] > '9') {
+		// funds code, the third letter of the currency
+		v = v[1:]
+	}
+
+	end := strings.IndexFunc(v, func(r rune) bool { return !(r >= '0' && r <= '9' || r == ',') })
+	if end < 0 {
+		end = len(v)
+	}
+	amount, ok := csvfile.ParseAmount(v[:end], ",")
+	if !ok || amount <= 0 {
+		return nil, fmt.Errorf("amount %q is not a positive number", v[:end])
+	}
+	s.amount = amount
+	v = v[end:]
+
+	if len(v) >= 4 {
+		s.typeCode, v = v[:4], v[4:]
+	}
+	s.owner, s.bank, _ = strings.Cut(v, "//")
+	s.owner, s.bank = strings.TrimSpace(s.owner), strings.TrimSpace(s.bank)
+	return s, nil
+}
+
+// fill sets a row from its statement line and the :86: information.
+func fill(r *importer.Row, s *statementLine, info, defaultType string) {
+	r.Direction, r.Type = m_import.DirectionExpense, "debit"
+	if s.credit {
+		r.Direction, r.Type = m_import.DirectionIncome, "credit"
+	}
+	if defaultType != "" {
+		r.Type = defaultType
+	}
+	r.Amount, r.Date = s.amount, s.date
+
+	counterparty, remittance := describe(info)
+	r.Name = importer.Describe(counterparty, remittance)
+	if r.Name == "" {
+		r.Name = s.typeCode
+	}
+	switch {
+	case r.Name == "":
+		r.Err = errors.New("statement line has no information to the account owner")
+	case len(r.Type) > maxText:
+		r.Err = fmt.Errorf("type is longer than %d characters", maxText)
+	default:
+		r.Err = nil
+	}
+}
+
+// subfield matches the ?NN subfields of the structured :86: used by German
+// banks.
+var subfield = regexp.MustCompile(`\?([0-9]{2})`)
+
+// sepaKeys are the keys SEPA remittance information is tagged with.
+var sepaKeys = regexp.MustCompile(`(EREF|KREF|MREF|CRED|DEBT|COAM|OAMT|SVWZ|ABWA|ABWE|IBAN|BIC)\+`)
+
+// describe reads the counterparty and the remittance information from the
+// :86: field. It knows the ?NN subfields of German banks, the /NAME/, /CNTP/
+// and /REMI/ codes of Dutch and other banks, and takes anything else as
+// remittance information.
+func describe(info string) (counterparty, remittance string) {
+	if info == "" {
+		return "", ""
+	}
+
+	if len(info) > 3 && isDigits(info[:3]) && info[3] == '?' {
+		// Lines break anywhere, subfields mark the parts
+		flat := strings.ReplaceAll(info, "\n", "")
+		subs := map[string]string{}
+		locs := subfield.FindAllStringSubmatchIndex(flat, -1)
+		for i, loc := range locs {
+			end := len(flat)
+			if i+1 < len(locs) {
+				end = locs[i+1][0]
+			}
+			subs[flat[loc[2]:loc[3]]] += flat[loc[1]:end]
+		}
+		var remi strings.Builder
+		for _, key := range []string{"20", "21", "22", "23", "24", "25", "26", "27", "28", "29", "60", "61", "62", "63"} {
+			remi.WriteString(subs[key])
+		}
+		return subs["32"] + subs["33"], sepa(remi.String())
+	}
+
+	if strings.HasPrefix(info, "/") {
+		flat := strings.ReplaceAll(info, "\n", "")
+		counterparty = code(flat, "NAME", 0)
+		if counterparty == "" {
+			// /CNTP/account/BIC/name/city/
+			counterparty = code(flat, "CNTP", 2)
+		}
+		if remittance = code(flat, "REMI/USTD/", 0); remittance == "" {
+			remittance = code(flat, "REMI", 0)
+		}
+		return counterparty, remittance
+	}
+
+	return "", strings.ReplaceAll(info, "\n", " ")
+}
+
+// code returns the nth value after /key/ in a :86: of codes.
+func code(s, key string, n int) string {
+	_, rest, ok := strings.Cut(s, "/"+key+"/")
+	if !ok {
+		return ""
+	}
+	values := strings.Split(rest, "/")
+	if n >= len(values) {
+		return ""
+	}
+	return strings.TrimSpace(values[n])
+}
+
+// sepa returns the SVWZ+ part of SEPA remittance information, or all of it.
+func sepa(s string) string {
+	locs := sepaKeys.FindAllStringSubmatchIndex(s, -1)
+	for i, loc := range locs {
+		if s[loc[2]:loc[3]] != "SVWZ" {
+			continue
+		}
+		end := len(s)
+		if i+1 < len(locs) {
+			end = locs[i+1][0]
+		}
+		return s[loc[1]:end]
+	}
+	return s
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
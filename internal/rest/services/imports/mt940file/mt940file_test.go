@@ -0,0 +1,168 @@
+package mt940file
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/importer"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/importer/importertest"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_import"
+)
+
+const iban = "DE89370400440532013000"
+
+const statements = `{1:F01BANKDEFFXXXX0000000000}{2:O9401200240112BANKDEFFXXXX00000000002401121200N}{4:
+:20:STMT1
+:25:DE89370400440532013000
+:28C:1/1
+:60F:C240101EUR1000,00
+:61:2401050105C1500,00NTRFNONREF//B1
+:86:166?00GUTSCHRIFT?20EREF+123 ?21SVWZ+Invoice 42?32ACME
+ Corp
+:61:2312310102D45,10NMSCREF2
+:86:/CNTP/NL91ABNA0417164300/ABNANL2A/Grocer/Amsterdam//REMI/USTD//Weekly shop/
+:61:240110RC5,00NCHGNONREF
+:61:240111DR12,00NTRFOWN1
+:86:Card payment
+Coffee
+:61:24011X
+:86:Ignored
+:62F:C240131EUR2437,40
+-}
+{1:F01BANKDEFFXXXX0000000000}{2:O9401200240112BANKDEFFXXXX00000000002401121200N}{4:
+:20:STMT2
+:25:12345
+:60M:C240201USD0,00
+:61:240201C1,00
+:86:/NAME/Friend/
+:61:240202D2,00
+-}
+`
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		defaultType string
+		maxRows     int
+		want        []importertest.Want
+		err         error
+	}{
+		{
+			name: "statements", content: statements, maxRows: 10,
+			want: []importertest.Want{
+				{Line: 6, Direction: m_import.DirectionIncome, Name: "ACME Corp: Invoice 42", Amount: 1500, Date: "2024-01-05", Type: "credit", ExternalID: iban + ":B1", Currency: "EUR", BankAccount: iban},
+				{Line: 9, Direction: m_import.DirectionExpense, Name: "Grocer: Weekly shop", Amount: 45.1, Date: "2024-01-02", Type: "debit", ExternalID: iban + ":REF2", Currency: "EUR", BankAccount: iban},
+				{Line: 11, Direction: m_import.DirectionExpense, Name: "NCHG", Amount: 5, Date: "2024-01-10", Type: "debit", Currency: "EUR", BankAccount: iban},
+				{Line: 12, Direction: m_import.DirectionExpense, Name: "Card payment Coffee", Amount: 12, Date: "2024-01-11", Type: "debit", ExternalID: iban + ":OWN1", Currency: "EUR", BankAccount: iban},
+				{Line: 15, Err: true},
+				{Line: 23, Direction: m_import.DirectionIncome, Name: "Friend", Amount: 1, Date: "2024-02-01", Type: "credit", Currency: "USD", BankAccount: "12345"},
+				{Line: 25, Err: true},
+			},
+		},
+		{
+			name: "default type", content: ":25:ACC\n:60F:C240101EUR0,00\n:61:240105C7,00NTRF\n:86:Refund\n",
+			defaultType: "bank", maxRows: 10,
+			want: []importertest.Want{
+				{Line: 3, Direction: m_import.DirectionIncome, Name: "Refund", Amount: 7, Date: "2024-01-05", Type: "bank", Currency: "EUR", BankAccount: "ACC"},
+			},
+		},
+		{name: "too many rows", content: statements, maxRows: 3, err: importer.ErrTooManyRows},
+		{name: "no account", content: ":20:STMT\n:61:240101C1,00\n", maxRows: 10, err: ErrNotMT940},
+		{name: "empty", content: "", maxRows: 10, err: ErrNotMT940},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := Parse(strings.NewReader(tt.content), tt.defaultType, tt.maxRows)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Parse() error = %v, want %v", err, tt.err)
+			}
+			importertest.CheckRows(t, rows, tt.want)
+		})
+	}
+}
+
+func TestParseLine(t *testing.T) {
+	tests := []struct {
+		line   string
+		date   string // "" when the line is invalid
+		credit bool
+		amount float64
+		code   string
+		owner  string
+		bank   string
+	}{
+		{line: "2401050105C1500,00NTRFNONREF//B1", date: "2024-01-05", credit: true, amount: 1500, code: "NTRF", owner: "NONREF", bank: "B1"},
+		{line: "2312310102D45,10NMSCREF2", date: "2024-01-02", amount: 45.1, code: "NMSC", owner: "REF2"},
+		{line: "2401021231D1,00NMSC", date: "2023-12-31", amount: 1, code: "NMSC"},
+		{line: "240110RC5,00NCHG", date: "2024-01-10", amount: 5, code: "NCHG"},
+		{line: "240110RD5,00NCHG", date: "2024-01-10", credit: true, amount: 5, code: "NCHG"},
+		{line: "240111CR12,5NTRFOWN1//", date: "2024-01-11", credit: true, amount: 12.5, code: "NTRF", owner: "OWN1"},
+		{line: "2401"},
+		{line: "240230C1,00"},
+		{line: "2401011340C1,00"},
+		{line: "240101X1,00"},
+		{line: "240101C0,00"},
+		{line: "240101CNTRF"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			s, err := parseLine(tt.line)
+			if tt.date == "" {
+				if err == nil {
+					t.Errorf("parseLine() = %+v, want an error", s)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseLine(): %v", err)
+			}
+			if s.date.Format(time.DateOnly) != tt.date || s.credit != tt.credit || s.amount != tt.amount ||
+				s.typeCode != tt.code || s.owner != tt.owner || s.bank != tt.bank {
+				t.Errorf("parseLine() = %+v", s)
+			}
+		})
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		name         string
+		info         string
+		counterparty string
+		remittance   string
+	}{
+		{name: "empty"},
+		{
+			name: "german subfields", info: "166?00GUTSCHRIFT?20EREF+123 ?21SVWZ+Invoice 42?32ACME\n Corp",
+			counterparty: "ACME Corp", remittance: "Invoice 42",
+		},
+		{
+			name: "german subfields without sepa keys", info: "020?00LASTSCHRIFT?20Rent Jan?21uary?32Landlord?33 Ltd",
+			counterparty: "Landlord Ltd", remittance: "Rent January",
+		},
+		{
+			name: "sepa keys after the purpose", info: "166?20SVWZ+Invoice 7 EREF+ABC?32Shop",
+			counterparty: "Shop", remittance: "Invoice 7 ",
+		},
+		{
+			name: "codes with a name", info: "/TRTP/SEPA OVERBOEKING/NAME/Friend/REMI/Dinner/",
+			counterparty: "Friend", remittance: "Dinner",
+		},
+		{
+			name: "codes with a counterparty", info: "/CNTP/NL91ABNA0417164300/ABNANL2A/Grocer/Amsterdam//REMI/USTD//Weekly\n shop/",
+			counterparty: "Grocer", remittance: "Weekly shop",
+		},
+		{name: "free text", info: "Card payment\nCoffee", remittance: "Card payment Coffee"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counterparty, remittance := describe(tt.info)
+			if counterparty != tt.counterparty || remittance != tt.remittance {
+				t.Errorf("describe() = %q, %q; want %q, %q", counterparty, remittance, tt.counterparty, tt.remittance)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package ofx
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/imports"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/importer"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Facade is the OFX import facade
+type Facade struct {
+	pkg *pkg.Facade
+
+	importer *importer.Importer
+}
+
+// New creates a new OFX import facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg:      pkg,
+		importer: importer.New(pkg),
+	}
+}
+
+// Handle handles the OFX import request
+func (f *Facade) Handle(ctx context.Context, req *imports.OFXRequest) (*imports.ImportResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	if err := s.run(); err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
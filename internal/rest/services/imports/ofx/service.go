@@ -0,0 +1,55 @@
+package ofx
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/imports"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/importer"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/ofxfile"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_account"
+)
+
+type service struct {
+	ctx context.Context
+	req *imports.OFXRequest
+	f   *Facade
+	res *imports.ImportResponse
+}
+
+func (s *service) run() error {
+	maxRows := s.f.pkg.Config.Import.MaxRows
+	rows, err := ofxfile.Parse(s.req.Content, strings.TrimSpace(s.req.DefaultType), maxRows)
+	switch {
+	case errors.Is(err, ofxfile.ErrNotOFX):
+		return errs.NotOFX
+	case errors.Is(err, importer.ErrTooManyRows):
+		return tooManyRows(maxRows)
+	case err != nil:
+		return errs.FailedToImport
+	}
+
+	s.res, err = s.f.importer.Run(s.ctx, rows, importer.Options{
+		Source:    "ofx",
+		FileName:  s.req.FileName,
+		AccountID: s.req.AccountID,
+		DryRun:    s.req.DryRun,
+	})
+	if errors.Is(err, m_account.ErrNotFound) {
+		return errs.UnknownAccount
+	}
+	var currency *importer.CurrencyError
+	if errors.As(err, &currency) {
+		return currencyMismatch(currency)
+	}
+	if err != nil {
+		return errs.FailedToImport
+	}
+
+	return nil
+}
+
+func (s *service) reply() *imports.ImportResponse {
+	return s.res
+}
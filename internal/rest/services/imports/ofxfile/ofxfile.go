@@ -0,0 +1,212 @@
+// Package ofxfile reads the transactions of OFX and QFX bank statements into
+// import rows. Both OFX 1.x, which is SGML whose elements have no end tags,
+// and the XML of OFX 2.x are read by the same tokenizer: a value is the text
+// that follows a start tag.
+package ofxfile
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/csvfile"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/importer"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_import"
+)
+
+// ErrNotOFX is returned by Parse for files without an OFX element.
+var ErrNotOFX = errors.New("not an OFX file")
+
+// maxText is the longest name or type a record can have.
+const maxText = 255
+
+// Parse reads the transactions of every statement in a file, credits as
+// incomes and debits as expenses. The external ID of a row is its FITID,
+// prefixed with the account it belongs to since banks only keep FITIDs
+// unique per account. Rows without a type take defaultType, or else the
+// transaction type, such as pos or atm. Amounts are in the CURDEF of their
+// statement, unless a transaction has a CURRENCY aggregate of its own.
+func Parse(content, defaultType string, maxRows int) ([]*importer.Row, error) {
+	start := strings.Index(content, "<OFX")
+	if start < 0 {
+		start = strings.Index(content, "<ofx")
+	}
+	if start < 0 {
+		return nil, ErrNotOFX
+	}
+
+	p := &parser{line: 1 + strings.Count(content[:start], "\n"), defaultType: defaultType}
+	rest := content[start:]
+	for {
+		open := strings.IndexByte(rest, '<')
+		if open < 0 {
+			break
+		}
+		p.line += strings.Count(rest[:open], "\n")
+		end := strings.IndexByte(rest[open:], '>')
+		if end < 0 {
+			break
+		}
+		tag := strings.ToUpper(strings.TrimSpace(rest[open+1 : open+end]))
+		if i := strings.IndexAny(tag, " \t\r\n"); i >= 0 {
+			// attributes, as in <OFX xmlns="...">
+			tag = tag[:i]
+		}
+		rest = rest[open+end+1:]
+
+		// The value of an element runs up to the next tag
+		next := strings.IndexByte(rest, '<')
+		if next < 0 {
+			next = len(rest)
+		}
+		value := html.UnescapeString(strings.TrimSpace(rest[:next]))
+
+		switch {
+		case strings.HasPrefix(tag, "?") || strings.HasPrefix(tag, "!"):
+			// processing instructions and comments
+		case strings.HasPrefix(tag, "/"):
+			p.end(tag[1:])
+		default:
+			p.start(strings.TrimSuffix(tag, "/"), value)
+		}
+		if len(p.rows) > maxRows {
+			return nil, importer.ErrTooManyRows
+		}
+	}
+	p.end("STMTTRN")
+	if len(p.rows) > maxRows {
+		return nil, importer.ErrTooManyRows
+	}
+	return p.rows, nil
+}
+
+type parser struct {
+	line        int
+	defaultType string
+	account     string // ACCTID of the statement being read
+	inAccount   bool   // within BANKACCTFROM or CCACCTFROM
+	currency    string // CURDEF of the statement being read
+	inCurrency  bool   // within the CURRENCY aggregate of a transaction
+	trn         map[string]string
+	trnLine     int
+	rows        []*importer.Row
+}
+
+func (p *parser) start(tag, value string) {
+	switch tag {
+	case "STMTTRN":
+		// Close a transaction that lacks its end tag
+		p.end("STMTTRN")
+		p.trn, p.trnLine = make(map[string]string), p.line
+	case "BANKACCTFROM", "CCACCTFROM":
+		p.inAccount = true
+	case "CURRENCY":
+		// Unlike ORIGCURRENCY, which only says what was converted
+		p.inCurrency = p.trn != nil
+	}
+	if value == "" {
+		return
+	}
+	switch {
+	case p.trn != nil && p.inCurrency && tag == "CURSYM":
+		p.trn["CURRENCY"] = value
+	case p.trn != nil:
+		// The first value wins, so the NAME of a PAYEE does not replace
+		// the NAME of the transaction
+		if _, ok := p.trn[tag]; !ok {
+			p.trn[tag] = value
+		}
+	case p.inAccount && tag == "ACCTID":
+		p.account = value
+	case tag == "CURDEF":
+		p.currency = value
+	}
+}
+
+func (p *parser) end(tag string) {
+	switch tag {
+	case "STMTTRN":
+		if p.trn != nil {
+			p.rows = append(p.rows, p.row())
+			p.trn, p.inCurrency = nil, false
+		}
+	case "BANKACCTFROM", "CCACCTFROM":
+		p.inAccount = false
+	case "CURRENCY":
+		p.inCurrency = false
+	}
+}
+
+func (p *parser) row() *importer.Row {
+	r := &importer.Row{Line: p.trnLine, Currency: strings.ToUpper(p.currency)}
+	if currency := p.trn["CURRENCY"]; currency != "" {
+		r.Currency = strings.ToUpper(currency)
+	}
+	if id := p.trn["FITID"]; id != "" {
+		r.ExternalID = id
+		if p.account != "" {
+			r.ExternalID = p.account + ":" + id
+		}
+	}
+	if err := p.fill(r); err != nil {
+		r.Err = err
+	}
+	return r
+}
+
+func (p *parser) fill(r *importer.Row) error {
+	r.Name = p.trn["NAME"]
+	if r.Name == "" {
+		r.Name = p.trn["MEMO"]
+	}
+	if r.Name == "" {
+		return errors.New("NAME and MEMO are empty")
+	}
+	if len(r.Name) > maxText {
+		return fmt.Errorf("name is longer than %d characters", maxText)
+	}
+
+	posted := p.trn["DTPOSTED"]
+	if len(posted) < 8 {
+		return fmt.Errorf("DTPOSTED %q is not a date", posted)
+	}
+	var err error
+	if r.Date, err = time.Parse("20060102", posted[:8]); err != nil {
+		return fmt.Errorf("DTPOSTED %q is not a date", posted)
+	}
+
+	r.Type = p.defaultType
+	if r.Type == "" {
+		r.Type = strings.ToLower(p.trn["TRNTYPE"])
+	}
+	if r.Type == "" {
+		return errors.New("TRNTYPE is empty; set default_type")
+	}
+	if len(r.Type) > maxText {
+		return fmt.Errorf("type is longer than %d characters", maxText)
+	}
+
+	// The specification has a decimal point, but some banks write a comma
+	s := p.trn["TRNAMT"]
+	decimal := "."
+	if strings.Contains(s, ",") && !strings.Contains(s, ".") {
+		decimal = ","
+	}
+	amount, ok := csvfile.ParseAmount(s, decimal)
+	if !ok {
+		return fmt.Errorf("TRNAMT %q is not a number", s)
+	}
+	switch {
+	case amount > 0:
+		r.Direction = m_import.DirectionIncome
+	case amount < 0:
+		r.Direction = m_import.DirectionExpense
+	default:
+		return errors.New("TRNAMT is zero")
+	}
+	r.Amount = math.Abs(amount)
+	return nil
+}
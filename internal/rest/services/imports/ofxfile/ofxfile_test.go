@@ -0,0 +1,119 @@
+package ofxfile
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/importer"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/importer/importertest"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_import"
+)
+
+const sgml = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+
+<OFX>
+<BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<CURDEF>eur
+<BANKACCTFROM>
+<BANKID>123
+<ACCTID>NL01BANK
+</BANKACCTFROM>
+<BANKTRANLIST>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20240105120000[-5:EST]
+<TRNAMT>1500.00
+<FITID>A1
+<NAME>Salary
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>POS
+<DTPOSTED>20240106
+<TRNAMT>-12,50
+<FITID>A2
+<MEMO>Bakery &amp; Co
+</BANKTRANLIST>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>
+`
+
+const xml = `<?xml version="1.0" encoding="UTF-8"?>
+<?OFX OFXHEADER="200" VERSION="220"?>
+<OFX xmlns="http://ofx.net/ifx/2.0/ofx">
+<CREDITCARDMSGSRSV1><CCSTMTTRNRS><CCSTMTRS>
+<CURDEF>USD</CURDEF>
+<CCACCTFROM><ACCTID>4111</ACCTID></CCACCTFROM>
+<BANKTRANLIST>
+<STMTTRN><TRNTYPE>DEBIT</TRNTYPE><DTPOSTED>20240201</DTPOSTED><TRNAMT>-20.00</TRNAMT><FITID>B1</FITID><NAME>Hotel</NAME><PAYEE><NAME>Hotel Paris SA</NAME></PAYEE><CURRENCY><CURRATE>1.1</CURRATE><CURSYM>eur</CURSYM></CURRENCY></STMTTRN>
+<STMTTRN><TRNTYPE>DEBIT</TRNTYPE><DTPOSTED>20240202</DTPOSTED><TRNAMT>-5.00</TRNAMT><NAME>Fee</NAME><ORIGCURRENCY><CURRATE>1.1</CURRATE><CURSYM>EUR</CURSYM></ORIGCURRENCY></STMTTRN>
+</BANKTRANLIST>
+</CCSTMTRS></CCSTMTTRNRS></CREDITCARDMSGSRSV1>
+</OFX>
+`
+
+const invalid = `<OFX>
+<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20240301<TRNAMT>-1.00<FITID>C1<MEMO>Memo only</STMTTRN>
+<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20240301<TRNAMT>-1.00<FITID>C2</STMTTRN>
+<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>2024<TRNAMT>-1.00<FITID>C3<NAME>Short date</STMTTRN>
+<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20240301<TRNAMT>0.00<FITID>C4<NAME>Zero</STMTTRN>
+<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20240301<TRNAMT>abc<FITID>C5<NAME>Text</STMTTRN>
+<STMTTRN><DTPOSTED>20240301<TRNAMT>-1.00<FITID>C6<NAME>No type</STMTTRN>
+</OFX>`
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		defaultType string
+		maxRows     int
+		want        []importertest.Want
+		err         error
+	}{
+		{
+			name: "sgml", content: sgml, maxRows: 10,
+			want: []importertest.Want{
+				{Line: 13, Direction: m_import.DirectionIncome, Name: "Salary", Amount: 1500, Date: "2024-01-05", Type: "credit", ExternalID: "NL01BANK:A1", Currency: "EUR"},
+				{Line: 20, Direction: m_import.DirectionExpense, Name: "Bakery & Co", Amount: 12.5, Date: "2024-01-06", Type: "pos", ExternalID: "NL01BANK:A2", Currency: "EUR"},
+			},
+		},
+		{
+			name: "default type", content: sgml, defaultType: "bank", maxRows: 10,
+			want: []importertest.Want{
+				{Line: 13, Direction: m_import.DirectionIncome, Name: "Salary", Amount: 1500, Date: "2024-01-05", Type: "bank", ExternalID: "NL01BANK:A1", Currency: "EUR"},
+				{Line: 20, Direction: m_import.DirectionExpense, Name: "Bakery & Co", Amount: 12.5, Date: "2024-01-06", Type: "bank", ExternalID: "NL01BANK:A2", Currency: "EUR"},
+			},
+		},
+		{
+			name: "xml with a currency aggregate", content: xml, maxRows: 10,
+			want: []importertest.Want{
+				{Line: 8, Direction: m_import.DirectionExpense, Name: "Hotel", Amount: 20, Date: "2024-02-01", Type: "debit", ExternalID: "4111:B1", Currency: "EUR"},
+				{Line: 9, Direction: m_import.DirectionExpense, Name: "Fee", Amount: 5, Date: "2024-02-02", Type: "debit", Currency: "USD"},
+			},
+		},
+		{
+			name: "invalid transactions", content: invalid, maxRows: 10,
+			want: []importertest.Want{
+				{Line: 2, Direction: m_import.DirectionExpense, Name: "Memo only", Amount: 1, Date: "2024-03-01", Type: "debit", ExternalID: "C1"},
+				{Line: 3, ExternalID: "C2", Err: true},
+				{Line: 4, ExternalID: "C3", Err: true},
+				{Line: 5, ExternalID: "C4", Err: true},
+				{Line: 6, ExternalID: "C5", Err: true},
+				{Line: 7, ExternalID: "C6", Err: true},
+			},
+		},
+		{name: "too many rows", content: sgml, maxRows: 1, err: importer.ErrTooManyRows},
+		{name: "not ofx", content: "Date,Amount\n2024-01-01,1\n", maxRows: 10, err: ErrNotOFX},
+		{name: "lower case", content: "<ofx></ofx>", maxRows: 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := Parse(tt.content, tt.defaultType, tt.maxRows)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Parse() error = %v, want %v", err, tt.err)
+			}
+			importertest.CheckRows(t, rows, tt.want)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package profiles
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/imports"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Facade is the list import profiles facade
+type Facade struct {
+	pkg *pkg.Facade
+}
+
+// New creates a new list import profiles facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg: pkg,
+	}
+}
+
+// Handle handles the list import profiles request
+func (f *Facade) Handle(ctx context.Context, req *imports.ListProfilesRequest) (*imports.ListProfilesResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	if err := s.list(); err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
@@ -0,0 +1,13 @@
+package profiles
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	FailedToListProfiles *err.HTTPError
+}{
+	FailedToListProfiles: err.NewHTTPError(http.StatusInternalServerError, "Failed to list import profiles."),
+}
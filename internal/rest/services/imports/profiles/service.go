@@ -0,0 +1,38 @@
+package profiles
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/imports"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/form"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx   context.Context
+	req   *imports.ListProfilesRequest
+	f     *Facade
+	items []*imports.Profile
+}
+
+func (s *service) list() error {
+	items, err := s.f.pkg.M.Import.ListProfiles(s.ctx, utils.AuthCtx(s.ctx))
+	if err != nil {
+		return errs.FailedToListProfiles
+	}
+
+	s.items = make([]*imports.Profile, 0, len(items))
+	for _, p := range items {
+		c, err := form.ConvertProfile(p)
+		if err != nil {
+			return errs.FailedToListProfiles
+		}
+		s.items = append(s.items, &c)
+	}
+
+	return nil
+}
+
+func (s *service) reply() *imports.ListProfilesResponse {
+	return &imports.ListProfilesResponse{Items: s.items}
+}
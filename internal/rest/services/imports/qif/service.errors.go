@@ -0,0 +1,33 @@
+package qif
+
+import (
+	"fmt"
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/csvfile"
+)
+
+var errs = struct {
+	InvalidDecimalSeparator *err.HTTPError
+	NotQIF                  *err.HTTPError
+	UnreadableFile          *err.HTTPError
+	UnknownAccount          *err.HTTPError
+	FailedToImport          *err.HTTPError
+}{
+	InvalidDecimalSeparator: err.NewHTTPError(http.StatusBadRequest, `Decimal separator must be "." or ",".`),
+	NotQIF:                  err.NewHTTPError(http.StatusBadRequest, "File has no bank, cash or credit card transactions in QIF."),
+	UnreadableFile:          err.NewHTTPError(http.StatusBadRequest, "File is not valid QIF."),
+	UnknownAccount:          err.NewHTTPError(http.StatusBadRequest, "Account not found."),
+	FailedToImport:          err.NewHTTPError(http.StatusInternalServerError, "Failed to import file."),
+}
+
+// invalidDateFormat reports why a date format was rejected.
+func invalidDateFormat(e *csvfile.MappingError) *err.HTTPError {
+	return err.NewHTTPError(http.StatusBadRequest, "Invalid "+e.Reason+".")
+}
+
+// tooManyRows reports the row limit a file exceeds.
+func tooManyRows(max int) *err.HTTPError {
+	return err.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("File has more than %d transactions; split it up.", max))
+}
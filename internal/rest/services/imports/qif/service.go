@@ -0,0 +1,63 @@
+package qif
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/imports"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/csvfile"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/importer"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/qiffile"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_account"
+)
+
+type service struct {
+	ctx context.Context
+	req *imports.QIFRequest
+	f   *Facade
+	res *imports.ImportResponse
+}
+
+func (s *service) run() error {
+	if d := s.req.DecimalSeparator; d != "" && d != "." && d != "," {
+		return errs.InvalidDecimalSeparator
+	}
+
+	maxRows := s.f.pkg.Config.Import.MaxRows
+	rows, err := qiffile.Parse(strings.NewReader(s.req.Content), qiffile.Options{
+		DateFormat:       s.req.DateFormat,
+		DecimalSeparator: s.req.DecimalSeparator,
+		DefaultType:      strings.TrimSpace(s.req.DefaultType),
+	}, maxRows)
+	var me *csvfile.MappingError
+	switch {
+	case errors.As(err, &me):
+		return invalidDateFormat(me)
+	case errors.Is(err, qiffile.ErrNotQIF):
+		return errs.NotQIF
+	case errors.Is(err, importer.ErrTooManyRows):
+		return tooManyRows(maxRows)
+	case err != nil:
+		return errs.UnreadableFile
+	}
+
+	s.res, err = s.f.importer.Run(s.ctx, rows, importer.Options{
+		Source:    "qif",
+		FileName:  s.req.FileName,
+		AccountID: s.req.AccountID,
+		DryRun:    s.req.DryRun,
+	})
+	if errors.Is(err, m_account.ErrNotFound) {
+		return errs.UnknownAccount
+	}
+	if err != nil {
+		return errs.FailedToImport
+	}
+
+	return nil
+}
+
+func (s *service) reply() *imports.ImportResponse {
+	return s.res
+}
@@ -0,0 +1,227 @@
+// Package qiffile reads the transactions of QIF (Quicken Interchange
+// Format) files into import rows. QIF has no transaction IDs, so its rows
+// cannot be told apart from ones imported before.
+package qiffile
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/csvfile"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/importer"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_import"
+)
+
+// ErrNotQIF is returned by Parse for files without a section of bank, cash
+// or credit card transactions.
+var ErrNotQIF = errors.New("not a QIF file")
+
+// maxText is the longest name or type a record can have.
+const maxText = 255
+
+// sections lists the !Type headers of the transaction sections read;
+// investments, categories, classes and memorized transactions are skipped.
+var sections = map[string]bool{"bank": true, "cash": true, "ccard": true, "oth a": true, "oth l": true}
+
+// Options describe how a file writes its dates and amounts.
+type Options struct {
+	DateFormat       string // as in imports.Mapping; empty reads the month first, as Quicken does
+	DecimalSeparator string // "." or ","
+	DefaultType      string // type of rows without a category
+}
+
+// Parse reads the transactions of a file, positive amounts as incomes and
+// negative ones as expenses. The category (L) becomes the type; splits are
+// imported as their total.
+func Parse(r io.Reader, o Options, maxRows int) ([]*importer.Row, error) {
+	var layout string
+	if o.DateFormat != "" {
+		var err error
+		if layout, err = csvfile.Layout(o.DateFormat); err != nil {
+			return nil, err
+		}
+	}
+	if o.DecimalSeparator == "" {
+		o.DecimalSeparator = "."
+	}
+
+	var (
+		rows   []*importer.Row
+		found  bool // a transaction section was seen
+		read   bool // the current section holds transactions
+		fields map[byte]string
+		start  int
+	)
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, 1<<20)
+	for line := 1; sc.Scan(); line++ {
+		text := strings.TrimRight(sc.Text(), "\r")
+		if line == 1 {
+			text = strings.TrimPrefix(text, "\ufeff")
+		}
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		switch text[0] {
+		case '!':
+			header := strings.ToLower(strings.TrimSpace(text[1:]))
+			if t, ok := strings.CutPrefix(header, "type:"); ok {
+				read = sections[strings.TrimSpace(t)]
+				found = found || read
+			} else if header == "account" {
+				// Account details, up to the next header
+				read = false
+			}
+			fields = nil
+			continue
+		case '^':
+			if read && fields != nil {
+				if len(rows) == maxRows {
+					return nil, importer.ErrTooManyRows
+				}
+				rows = append(rows, row(start, fields, layout, o))
+			}
+			fields = nil
+			continue
+		}
+		if !read {
+			continue
+		}
+		if fields == nil {
+			fields, start = make(map[byte]string), line
+		}
+		// Split lines (S, E, $) repeat; the first of any code is kept
+		if _, ok := fields[text[0]]; !ok {
+			fields[text[0]] = strings.TrimSpace(text[1:])
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read QIF: %w", err)
+	}
+	if !found {
+		return nil, ErrNotQIF
+	}
+	// The last transaction may lack its ^
+	if read && fields != nil {
+		if len(rows) == maxRows {
+			return nil, importer.ErrTooManyRows
+		}
+		rows = append(rows, row(start, fields, layout, o))
+	}
+	return rows, nil
+}
+
+func row(line int, fields map[byte]string, layout string, o Options) *importer.Row {
+	r := &importer.Row{Line: line}
+	if err := fill(r, fields, layout, o); err != nil {
+		r.Err = err
+	}
+	return r
+}
+
+func fill(r *importer.Row, fields map[byte]string, layout string, o Options) error {
+	r.Name = fields['P']
+	if r.Name == "" {
+		r.Name = fields['M']
+	}
+	if r.Name == "" {
+		return errors.New("payee (P) and memo (M) are empty")
+	}
+	if len(r.Name) > maxText {
+		return fmt.Errorf("name is longer than %d characters", maxText)
+	}
+
+	d := fields['D']
+	var err error
+	if layout != "" {
+		r.Date, err = time.Parse(layout, d)
+	} else {
+		r.Date, err = parseDate(d)
+	}
+	if err != nil {
+		return fmt.Errorf("date %q is not a date", d)
+	}
+
+	r.Type = category(fields['L'])
+	if r.Type == "" {
+		r.Type = o.DefaultType
+	}
+	if r.Type == "" {
+		return errors.New("category (L) is empty; set default_type")
+	}
+	if len(r.Type) > maxText {
+		return fmt.Errorf("type is longer than %d characters", maxText)
+	}
+
+	s, ok := fields['T']
+	if !ok {
+		s = fields['U']
+	}
+	amount, ok := csvfile.ParseAmount(s, o.DecimalSeparator)
+	if !ok {
+		return fmt.Errorf("amount %q is not a number", s)
+	}
+	switch {
+	case amount > 0:
+		r.Direction = m_import.DirectionIncome
+	case amount < 0:
+		r.Direction = m_import.DirectionExpense
+	default:
+		return errors.New("amount is zero")
+	}
+	r.Amount = math.Abs(amount)
+	return nil
+}
+
+// category returns the category of an L field without its class. Transfers
+// name an account in brackets and have no category.
+func category(l string) string {
+	if strings.HasPrefix(l, "[") {
+		return ""
+	}
+	l, _, _ = strings.Cut(l, "/")
+	return strings.TrimSpace(l)
+}
+
+// parseDate reads dates the way Quicken writes them, month first: 1/31/2024,
+// 01/31/24, or 1/31'24 and 1/31' 4 where the apostrophe marks a year after
+// 2000.
+func parseDate(s string) (time.Time, error) {
+	s = strings.ReplaceAll(s, " ", "")
+	after2000 := strings.Contains(s, "'")
+	parts := strings.FieldsFunc(s, func(r rune) bool { return r == '/' || r == '\'' || r == '-' || r == '.' })
+	if len(parts) != 3 {
+		return time.Time{}, errors.New("invalid date")
+	}
+
+	var n [3]int
+	for i, p := range parts {
+		v, err := strconv.Atoi(p)
+		if err != nil {
+			return time.Time{}, err
+		}
+		n[i] = v
+	}
+	month, day, year := n[0], n[1], n[2]
+	if len(parts[2]) <= 2 {
+		switch {
+		case after2000 || year < 70:
+			year += 2000
+		default:
+			year += 1900
+		}
+	}
+
+	t := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if t.Month() != time.Month(month) || t.Day() != day {
+		return time.Time{}, errors.New("invalid date")
+	}
+	return t, nil
+}
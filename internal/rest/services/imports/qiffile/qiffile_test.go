@@ -0,0 +1,187 @@
+package qiffile
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/importer"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/importer/importertest"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_import"
+)
+
+// bank is written with the BOM and line ends of Windows; its last
+// transaction lacks the closing ^.
+var bank = "\ufeff" + strings.ReplaceAll(`!Type:Bank
+D1/31/2024
+T1,500.00
+PEmployer
+LSalary/Work
+^
+D01/02'24
+T-45.10
+PGrocer
+MWeekly shop
+LFood
+SHousehold
+$-20.00
+SFood
+$-25.10
+^
+D2/3' 4
+U-100
+MTransfer to savings
+L[Savings]
+^
+
+D12/31/85
+T-1
+PLast
+LMisc`, "\n", "\r\n")
+
+const skipped = `!Type:Cat
+NFood
+D
+^
+!Account
+NChecking
+TBank
+^
+!Type:Invst
+D1/1/2024
+NBuy
+T100
+^
+!Type:CCard
+D1/5/2024
+T-10
+PCafe
+^
+!Type:Memorized
+D1/6/2024
+T-99
+PSkipped
+^
+`
+
+const european = `!Type:Bank
+D31.01.2024
+T-1.234,56
+PRent
+LHousing
+^
+`
+
+const invalid = `!Type:Bank
+D1/1/2024
+T-1
+LFood
+^
+D13/45/2024
+T-1
+PBad date
+LFood
+^
+D1/1/2024
+T0
+PZero
+LFood
+^
+D1/1/2024
+T-1
+PNo category
+^
+D1/1/2024
+Tabc
+PNo amount
+LFood
+^
+`
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		o       Options
+		maxRows int
+		want    []importertest.Want
+		err     error
+	}{
+		{
+			name: "bank", content: bank, o: Options{DefaultType: "other"}, maxRows: 10,
+			want: []importertest.Want{
+				{Line: 2, Direction: m_import.DirectionIncome, Name: "Employer", Amount: 1500, Date: "2024-01-31", Type: "Salary"},
+				{Line: 7, Direction: m_import.DirectionExpense, Name: "Grocer", Amount: 45.1, Date: "2024-01-02", Type: "Food"},
+				{Line: 17, Direction: m_import.DirectionExpense, Name: "Transfer to savings", Amount: 100, Date: "2004-02-03", Type: "other"},
+				{Line: 23, Direction: m_import.DirectionExpense, Name: "Last", Amount: 1, Date: "1985-12-31", Type: "Misc"},
+			},
+		},
+		{
+			name: "other sections are skipped", content: skipped, o: Options{DefaultType: "other"}, maxRows: 10,
+			want: []importertest.Want{
+				{Line: 15, Direction: m_import.DirectionExpense, Name: "Cafe", Amount: 10, Date: "2024-01-05", Type: "other"},
+			},
+		},
+		{
+			name: "date format and decimal comma", content: european,
+			o: Options{DateFormat: "DD.MM.YYYY", DecimalSeparator: ","}, maxRows: 10,
+			want: []importertest.Want{
+				{Line: 2, Direction: m_import.DirectionExpense, Name: "Rent", Amount: 1234.56, Date: "2024-01-31", Type: "Housing"},
+			},
+		},
+		{
+			name: "invalid transactions", content: invalid, maxRows: 10,
+			want: []importertest.Want{
+				{Line: 2, Err: true},
+				{Line: 6, Err: true},
+				{Line: 11, Err: true},
+				{Line: 16, Err: true},
+				{Line: 20, Err: true},
+			},
+		},
+		{name: "too many rows", content: bank, maxRows: 3, err: importer.ErrTooManyRows},
+		{name: "no transactions", content: "!Type:Cat\nNFood\n^\n", maxRows: 10, err: ErrNotQIF},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := Parse(strings.NewReader(tt.content), tt.o, tt.maxRows)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Parse() error = %v, want %v", err, tt.err)
+			}
+			importertest.CheckRows(t, rows, tt.want)
+		})
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string // "" when the date is invalid
+	}{
+		{"1/31/2024", "2024-01-31"},
+		{"01/31/24", "2024-01-31"},
+		{"12/31/85", "1985-12-31"},
+		{"1/31'24", "2024-01-31"},
+		{"1/31' 4", "2004-01-31"},
+		{"2-29-2024", "2024-02-29"},
+		{"2/29/2023", ""},
+		{"31/1/2024", ""},
+		{"1/31", ""},
+		{"a/b/c", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			d, err := parseDate(tt.s)
+			if tt.want == "" {
+				if err == nil {
+					t.Errorf("parseDate(%q) = %s, want an error", tt.s, d)
+				}
+				return
+			}
+			if err != nil || d.Format(time.DateOnly) != tt.want {
+				t.Errorf("parseDate(%q) = %s, %v; want %s", tt.s, d, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package rollback
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/imports"
+	expensedelete "github.com/rsmrtk/mybox/internal/rest/services/expense/delete"
+	incomedelete "github.com/rsmrtk/mybox/internal/rest/services/income/delete"
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/dbtx"
+)
+
+// Facade is the import rollback facade
+type Facade struct {
+	pkg *pkg.Facade
+
+	income  *incomedelete.Facade
+	expense *expensedelete.Facade
+}
+
+// New creates a new import rollback facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg:     pkg,
+		income:  incomedelete.New(pkg),
+		expense: expensedelete.New(pkg),
+	}
+}
+
+// Handle handles the import rollback request
+func (f *Facade) Handle(ctx context.Context, req *imports.RollbackRequest) (*imports.RollbackResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	// The records are deleted and the batch marked together
+	err := dbtx.InTx(ctx, f.pkg.M.DB, func(ctx context.Context) error {
+		s.ctx = ctx
+		return s.rollback()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
@@ -0,0 +1,19 @@
+package rollback
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	InvalidBatchID    *err.HTTPError
+	BatchNotFound     *err.HTTPError
+	AlreadyRolledBack *err.HTTPError
+	FailedToRollBack  *err.HTTPError
+}{
+	InvalidBatchID:    err.NewHTTPError(http.StatusBadRequest, "Invalid import batch ID format."),
+	BatchNotFound:     err.NewHTTPError(http.StatusNotFound, "Import batch not found."),
+	AlreadyRolledBack: err.NewHTTPError(http.StatusConflict, "Import batch was already rolled back."),
+	FailedToRollBack:  err.NewHTTPError(http.StatusInternalServerError, "Failed to roll back import batch."),
+}
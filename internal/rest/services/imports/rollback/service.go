@@ -0,0 +1,95 @@
+package rollback
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	er "github.com/rsmrtk/fd-er"
+	"github.com/rsmrtk/mybox/internal/rest/domain/expense"
+	"github.com/rsmrtk/mybox/internal/rest/domain/imports"
+	"github.com/rsmrtk/mybox/internal/rest/domain/income"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/form"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_import"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx     context.Context
+	req     *imports.RollbackRequest
+	f       *Facade
+	batch   imports.Batch
+	deleted int
+	skipped int
+}
+
+func (s *service) rollback() error {
+	if _, err := uuid.Parse(s.req.BatchID); err != nil {
+		return errs.InvalidBatchID
+	}
+
+	// Lock the batch so that concurrent rollbacks wait for each other
+	b, err := s.f.pkg.M.Import.LockBatch(s.ctx, utils.AuthCtx(s.ctx), s.req.BatchID)
+	if errors.Is(err, m_import.ErrNotFound) {
+		return errs.BatchNotFound
+	}
+	if err != nil {
+		return errs.FailedToRollBack
+	}
+	if b.RolledBackAt != nil {
+		return errs.AlreadyRolledBack
+	}
+
+	records, err := s.f.pkg.M.Import.Records(s.ctx, b.BatchID)
+	if err != nil {
+		return errs.FailedToRollBack
+	}
+	for _, r := range records {
+		err := s.delete(r)
+		if notFound(err) {
+			// Deleted since the import
+			s.skipped++
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		s.deleted++
+	}
+
+	now := time.Now().UTC()
+	if err := s.f.pkg.M.Import.MarkRolledBack(s.ctx, b.BatchID, now); err != nil {
+		return errs.FailedToRollBack
+	}
+	b.RolledBackAt = &now
+	s.batch = form.ConvertBatch(b)
+
+	return nil
+}
+
+// delete moves one imported record to the trash.
+func (s *service) delete(r *m_import.Record) error {
+	var err error
+	switch r.Direction {
+	case m_import.DirectionIncome:
+		_, err = s.f.income.Handle(s.ctx, &income.DeleteRequest{IncomeID: r.RecordID})
+	case m_import.DirectionExpense:
+		_, err = s.f.expense.Handle(s.ctx, &expense.DeleteRequest{ExpenseID: r.RecordID})
+	}
+	return err
+}
+
+func notFound(err error) bool {
+	var herr *er.HTTPError
+	return errors.As(err, &herr) && herr.Code == http.StatusNotFound
+}
+
+func (s *service) reply() *imports.RollbackResponse {
+	return &imports.RollbackResponse{
+		Batch:   s.batch,
+		Deleted: s.deleted,
+		Skipped: s.skipped,
+	}
+}
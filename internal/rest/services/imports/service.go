@@ -0,0 +1,51 @@
+package imports
+
+import (
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/batches"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/camt"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/createprofile"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/csv"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/deleteprofile"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/journal"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/mt940"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/ofx"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/profiles"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/qif"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/rollback"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/updateprofile"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Service is the import service facade
+type Service struct {
+	Profiles      *profiles.Facade
+	CreateProfile *createprofile.Facade
+	UpdateProfile *updateprofile.Facade
+	DeleteProfile *deleteprofile.Facade
+	CSV           *csv.Facade
+	OFX           *ofx.Facade
+	QIF           *qif.Facade
+	CAMT          *camt.Facade
+	MT940         *mt940.Facade
+	Journal       *journal.Facade
+	Batches       *batches.Facade
+	Rollback      *rollback.Facade
+}
+
+// New creates a new import service
+func New(f *pkg.Facade) *Service {
+	return &Service{
+		Profiles:      profiles.New(f),
+		CreateProfile: createprofile.New(f),
+		UpdateProfile: updateprofile.New(f),
+		DeleteProfile: deleteprofile.New(f),
+		CSV:           csv.New(f),
+		OFX:           ofx.New(f),
+		QIF:           qif.New(f),
+		CAMT:          camt.New(f),
+		MT940:         mt940.New(f),
+		Journal:       journal.New(f),
+		Batches:       batches.New(f),
+		Rollback:      rollback.New(f),
+	}
+}
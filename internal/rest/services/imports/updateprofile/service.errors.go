@@ -0,0 +1,27 @@
+package updateprofile
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/csvfile"
+)
+
+var errs = struct {
+	InvalidProfileID      *err.HTTPError
+	ProfileNotFound       *err.HTTPError
+	InvalidName           *err.HTTPError
+	DuplicateName         *err.HTTPError
+	FailedToUpdateProfile *err.HTTPError
+}{
+	InvalidProfileID:      err.NewHTTPError(http.StatusBadRequest, "Invalid import profile ID format."),
+	ProfileNotFound:       err.NewHTTPError(http.StatusNotFound, "Import profile not found."),
+	InvalidName:           err.NewHTTPError(http.StatusBadRequest, "Name must not be blank."),
+	DuplicateName:         err.NewHTTPError(http.StatusConflict, "Another import profile already has this name."),
+	FailedToUpdateProfile: err.NewHTTPError(http.StatusInternalServerError, "Failed to update import profile."),
+}
+
+// invalidMapping reports why a mapping was rejected.
+func invalidMapping(e *csvfile.MappingError) *err.HTTPError {
+	return err.NewHTTPError(http.StatusBadRequest, "Invalid mapping: "+e.Reason+".")
+}
@@ -0,0 +1,78 @@
+package updateprofile
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/imports"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/csvfile"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports/form"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_import"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx     context.Context
+	req     *imports.UpdateProfileRequest
+	f       *Facade
+	profile imports.Profile
+}
+
+func (s *service) update() error {
+	if _, err := uuid.Parse(s.req.ProfileID); err != nil {
+		return errs.InvalidProfileID
+	}
+
+	p, err := s.f.pkg.M.Import.FindProfile(s.ctx, utils.AuthCtx(s.ctx), s.req.ProfileID)
+	if errors.Is(err, m_import.ErrNotFound) {
+		return errs.ProfileNotFound
+	}
+	if err != nil {
+		return errs.FailedToUpdateProfile
+	}
+
+	if s.req.Name != nil {
+		p.Name = strings.TrimSpace(*s.req.Name)
+		if p.Name == "" {
+			return errs.InvalidName
+		}
+	}
+	if s.req.Mapping != nil {
+		var me *csvfile.MappingError
+		err := csvfile.Check(s.req.Mapping)
+		if errors.As(err, &me) {
+			return invalidMapping(me)
+		}
+		if err != nil {
+			return errs.FailedToUpdateProfile
+		}
+		if p.Mapping, err = form.Encode(s.req.Mapping); err != nil {
+			return errs.FailedToUpdateProfile
+		}
+	}
+	p.UpdatedAt = time.Now().UTC()
+
+	err = s.f.pkg.M.Import.UpdateProfile(s.ctx, p)
+	switch {
+	case errors.Is(err, m_import.ErrNotFound):
+		return errs.ProfileNotFound
+	case errors.Is(err, m_import.ErrDuplicateName):
+		return errs.DuplicateName
+	case err != nil:
+		return errs.FailedToUpdateProfile
+	}
+
+	s.profile, err = form.ConvertProfile(p)
+	if err != nil {
+		return errs.FailedToUpdateProfile
+	}
+
+	return nil
+}
+
+func (s *service) reply() *imports.UpdateProfileResponse {
+	return &imports.UpdateProfileResponse{Profile: s.profile}
+}
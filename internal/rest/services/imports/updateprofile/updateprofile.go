@@ -0,0 +1,35 @@
+package updateprofile
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/imports"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Facade is the update import profile facade
+type Facade struct {
+	pkg *pkg.Facade
+}
+
+// New creates a new update import profile facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg: pkg,
+	}
+}
+
+// Handle handles the update import profile request
+func (f *Facade) Handle(ctx context.Context, req *imports.UpdateProfileRequest) (*imports.UpdateProfileResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	if err := s.update(); err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
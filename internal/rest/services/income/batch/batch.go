@@ -0,0 +1,45 @@
+package batch
+
+import (
+	"context"
+
+	bt "github.com/rsmrtk/mybox/internal/rest/domain/batch"
+	"github.com/rsmrtk/mybox/internal/rest/services/income/create"
+	"github.com/rsmrtk/mybox/internal/rest/services/income/delete"
+	"github.com/rsmrtk/mybox/internal/rest/services/income/update"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Facade is the batch income facade
+type Facade struct {
+	pkg *pkg.Facade
+
+	create *create.Facade
+	update *update.Facade
+	delete *delete.Facade
+}
+
+// New creates a new batch income facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg:    pkg,
+		create: create.New(pkg),
+		update: update.New(pkg),
+		delete: delete.New(pkg),
+	}
+}
+
+// Handle handles the batch income request
+func (f *Facade) Handle(ctx context.Context, req *bt.Request) (*bt.Response, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	if err := s.run(); err != nil {
+		return nil, err
+	}
+
+	return s.res, nil
+}
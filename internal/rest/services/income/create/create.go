@@ -5,6 +5,7 @@ import (
 
 	"github.com/rsmrtk/mybox/internal/rest/domain/income"
 	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/dbtx"
 )
 
 type Facade struct {
@@ -22,7 +23,12 @@ func (f *Facade) Handle(ctx context.Context, req *income.CreateRequest) (*income
 		f:   f,
 	}
 
-	if err := serv.create(); err != nil {
+	// The row, its version and its audit entry are written together
+	err := dbtx.InTx(ctx, f.pkg.M.DB, func(ctx context.Context) error {
+		serv.ctx = ctx
+		return serv.create()
+	})
+	if err != nil {
 		return nil, err
 	}
 	return serv.reply(), nil
@@ -7,7 +7,15 @@ import (
 )
 
 var errs = struct {
+	InvalidTag           *err.HTTPError
+	TagsRequireAuth      *err.HTTPError
+	UnknownCategory      *err.HTTPError
+	UnknownAccount       *err.HTTPError
 	FailedToCreateIncome *err.HTTPError
 }{
+	InvalidTag:           err.NewHTTPError(http.StatusBadRequest, "Tags must be 1 to 64 characters and must not contain commas."),
+	TagsRequireAuth:      err.NewHTTPError(http.StatusUnauthorized, "Tags require an API key."),
+	UnknownCategory:      err.NewHTTPError(http.StatusBadRequest, "Category not found."),
+	UnknownAccount:       err.NewHTTPError(http.StatusBadRequest, "Account not found."),
 	FailedToCreateIncome: err.NewHTTPError(http.StatusNotFound, "Failed to create income."),
 }
@@ -2,13 +2,23 @@ package create
 
 import (
 	"context"
-	"math/big"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/rsmrtk/db-fd-model/m_income"
 	di "github.com/rsmrtk/mybox/internal/rest/domain/income"
 	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+	"github.com/rsmrtk/mybox/internal/rest/services/account"
+	"github.com/rsmrtk/mybox/internal/rest/services/audit"
+	"github.com/rsmrtk/mybox/internal/rest/services/category"
+	"github.com/rsmrtk/mybox/internal/rest/services/duplicate/match"
+	"github.com/rsmrtk/mybox/internal/rest/services/record"
+	"github.com/rsmrtk/mybox/internal/rest/services/tag"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_account"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_audit"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_category"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_trash"
 )
 
 type service struct {
@@ -16,15 +26,37 @@ type service struct {
 	req *di.CreateRequest
 	f   *Facade
 
-	incomeID     string
-	incomeName   string
-	incomeAmount big.Rat
-	incomeType   string
-	incomeDate   models.Date
-	createdAt    models.Date
+	data       *m_income.Data
+	categoryID string
+	accountID  string
+	currency   string
+	tags       []string
+	duplicates []*match.Match
 }
 
 func (s *service) create() error {
+	incomeType := s.req.IncomeType
+	if s.req.CategoryID != "" {
+		c, err := category.Resolve(s.ctx, s.f.pkg, s.req.CategoryID)
+		if errors.Is(err, m_category.ErrNotFound) {
+			return errs.UnknownCategory
+		}
+		if err != nil {
+			return errs.FailedToCreateIncome
+		}
+		s.categoryID, incomeType = c.CategoryID, c.Name
+	}
+	if s.req.AccountID != "" {
+		a, err := account.Resolve(s.ctx, s.f.pkg, s.req.AccountID)
+		if errors.Is(err, m_account.ErrNotFound) {
+			return errs.UnknownAccount
+		}
+		if err != nil {
+			return errs.FailedToCreateIncome
+		}
+		s.accountID, s.currency = a.AccountID, a.Currency
+	}
+
 	// Convert amount from request (assuming first amount in array)
 	var incomeAmountFloat float64
 	if len(s.req.IncomeAmount) > 0 && s.req.IncomeAmount[0] != nil {
@@ -38,49 +70,77 @@ func (s *service) create() error {
 	// Create income in database using new pgx models
 	// Convert values to pointers for nullable fields
 	incomeName := s.req.IncomeName
-	incomeType := s.req.IncomeType
 	incomeDate := s.req.IncomeDate.Time
 
-	err := s.f.pkg.M.FinDash.Income.Create(s.ctx, &m_income.Data{
+	s.data = &m_income.Data{
 		IncomeID:     incomeID,
 		IncomeName:   &incomeName,
 		IncomeAmount: &incomeAmountFloat,
 		IncomeType:   &incomeType,
 		IncomeDate:   &incomeDate,
 		CreatedAt:    &createdAt,
-	})
+	}
+	err := s.f.pkg.M.Record.CreateIncome(s.ctx, s.data)
 	if err != nil {
 		return errs.FailedToCreateIncome
 	}
 
-	// Store data in service for reply
-	s.incomeID = incomeID
-	s.incomeName = s.req.IncomeName
-	s.incomeAmount = *big.NewRat(int64(incomeAmountFloat*100), 100) // Convert to big.Rat
-	s.incomeType = s.req.IncomeType
-	s.incomeDate = s.req.IncomeDate
-	s.createdAt = models.NewDate(createdAt)
+	if s.categoryID != "" {
+		if err := s.f.pkg.M.Category.Assign(s.ctx, m_trash.Income, incomeID, &s.categoryID); err != nil {
+			return errs.FailedToCreateIncome
+		}
+	}
+
+	if s.accountID != "" {
+		if err := s.f.pkg.M.Account.Assign(s.ctx, m_trash.Income, incomeID, &s.accountID); err != nil {
+			return errs.FailedToCreateIncome
+		}
+	}
+
+	if len(s.req.Tags) > 0 {
+		s.tags, err = tag.Apply(s.ctx, s.f.pkg, m_trash.Income, incomeID, s.req.Tags)
+		switch {
+		case errors.Is(err, tag.ErrInvalidName):
+			return errs.InvalidTag
+		case errors.Is(err, tag.ErrNoWorkspace):
+			return errs.TagsRequireAuth
+		case err != nil:
+			return errs.FailedToCreateIncome
+		}
+	}
+
+	if err := audit.Record(s.ctx, s.f.pkg, audit.EntityIncome, incomeID, m_audit.ActionCreate, nil,
+		audit.IncomeSnapshot(s.data).WithCategory(s.categoryID).WithAccount(s.accountID).WithTags(s.tags)); err != nil {
+		return errs.FailedToCreateIncome
+	}
+
+	if s.req.CheckDuplicates {
+		r := record.FromIncome(s.data)
+		r.AccountID, r.Currency = s.accountID, s.currency
+		s.duplicates, err = match.Find(s.ctx, s.f.pkg, r)
+		if err != nil {
+			return errs.FailedToCreateIncome
+		}
+	}
 
 	return nil
 }
 
 func (s *service) reply() *di.CreateResponse {
-	// Convert big.Rat to float64
-	amountValue, _ := s.incomeAmount.Float64()
-
-	// Create amount structure
-	amountObj := &models.Amount{
-		Amount:         amountValue,
-		CurrencyCode:   "USD", // Default, adjust as needed
-		CurrencySymbol: "$",   // Default, adjust as needed
-	}
+	// New rows start at the column default version
+	r := record.FromIncome(s.data).WithVersion(1).WithCurrency(s.currency)
 
 	return &di.CreateResponse{
-		IncomeID:     s.incomeID,
-		IncomeName:   s.incomeName,
-		IncomeAmount: []*models.Amount{amountObj},
-		IncomeType:   s.incomeType,
-		IncomeDate:   s.incomeDate,
-		CreatedAt:    s.createdAt,
+		IncomeID:     r.ID,
+		IncomeName:   r.Name,
+		IncomeAmount: r.Amounts(),
+		IncomeType:   r.Type,
+		IncomeDate:   models.NewDate(r.Date),
+		CreatedAt:    models.NewDate(r.CreatedAt),
+		CategoryID:   s.categoryID,
+		AccountID:    s.accountID,
+		Tags:         s.tags,
+		Version:      r.Version,
+		Duplicates:   match.Duplicates(s.duplicates),
 	}
 }
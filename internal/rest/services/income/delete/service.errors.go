@@ -7,9 +7,13 @@ import (
 )
 
 var errs = struct {
+	IncomeNotFound       *err.HTTPError
 	InvalidIncomeID      *err.HTTPError
 	FailedToDeleteIncome *err.HTTPError
+	VersionMismatch      *err.HTTPError
 }{
+	IncomeNotFound:       err.NewHTTPError(http.StatusNotFound, "Income not found."),
 	InvalidIncomeID:      err.NewHTTPError(http.StatusBadRequest, "Invalid income ID format."),
 	FailedToDeleteIncome: err.NewHTTPError(http.StatusInternalServerError, "Failed to delete income."),
+	VersionMismatch:      err.NewHTTPError(http.StatusPreconditionFailed, "Income was modified by another request."),
 }
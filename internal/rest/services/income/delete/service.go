@@ -2,15 +2,22 @@ package delete
 
 import (
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
 	"github.com/rsmrtk/mybox/internal/rest/domain/income"
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+	"github.com/rsmrtk/mybox/internal/rest/services/audit"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_audit"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_trash"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_version"
 )
 
 type service struct {
-	ctx context.Context
-	req *income.DeleteRequest
-	f   *Facade
+	ctx     context.Context
+	req     *income.DeleteRequest
+	f       *Facade
+	version int64
 }
 
 func (s *service) delete() error {
@@ -20,18 +27,42 @@ func (s *service) delete() error {
 		return errs.InvalidIncomeID
 	}
 
-	// Delete the income
-	err = s.f.pkg.M.FinDash.Income.Delete(s.ctx, s.req.IncomeID)
+	s.version, err = s.f.pkg.M.Version.Claim(s.ctx, m_trash.Income, s.req.IncomeID, models.IfMatch(s.req.IfMatch))
+	switch {
+	case errors.Is(err, m_version.ErrConflict):
+		return errs.VersionMismatch
+	case errors.Is(err, m_version.ErrNotFound):
+		return errs.IncomeNotFound
+	case err != nil:
+		return errs.FailedToDeleteIncome
+	}
+
+	// Keep the current values for the audit log; the claim locks the row, so
+	// they are the latest
+	data, err := s.f.pkg.M.Record.FindIncome(s.ctx, s.req.IncomeID)
+	if err != nil {
+		return errs.IncomeNotFound
+	}
+
+	// Move the income to the trash; it is purged after the retention period
+	err = s.f.pkg.M.Trash.SoftDelete(s.ctx, m_trash.Income, s.req.IncomeID)
+	if errors.Is(err, m_trash.ErrNotFound) {
+		return errs.IncomeNotFound
+	}
 	if err != nil {
 		return errs.FailedToDeleteIncome
 	}
 
+	if err := audit.Record(s.ctx, s.f.pkg, audit.EntityIncome, s.req.IncomeID, m_audit.ActionDelete, audit.IncomeSnapshot(data), nil); err != nil {
+		return errs.FailedToDeleteIncome
+	}
+
 	return nil
 }
 
 func (s *service) reply() *income.DeleteResponse {
 	return &income.DeleteResponse{
 		Success: true,
-		Message: "Income deleted successfully",
+		Message: "Income moved to trash",
 	}
 }
@@ -2,11 +2,14 @@ package get
 
 import (
 	"context"
-	"math/big"
 
 	"github.com/rsmrtk/db-fd-model/m_income"
 	di "github.com/rsmrtk/mybox/internal/rest/domain/income"
 	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+	"github.com/rsmrtk/mybox/internal/rest/services/account"
+	"github.com/rsmrtk/mybox/internal/rest/services/record"
+	"github.com/rsmrtk/mybox/internal/rest/services/tag"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_trash"
 )
 
 type service struct {
@@ -14,17 +17,20 @@ type service struct {
 	req *di.GetRequest
 	f   *Facade
 
-	incomeID     string
-	incomeName   string
-	incomeAmount big.Rat
-	incomeType   string
-	incomeDate   models.Date
-	createdAt    models.Date
+	data    *m_income.Data
+	version int64
+
+	categoryID string
+	accountID  string
+	currency   string
+	tags       []string
 }
 
 func (s *service) find() error {
+	var err error
+
 	// Use the Find method to get a single income by ID
-	data, err := s.f.pkg.M.FinDash.Income.Find(s.ctx,
+	s.data, err = s.f.pkg.M.FinDash.Income.Find(s.ctx,
 		s.req.IncomeID,
 		[]m_income.Field{
 			m_income.IncomeID,
@@ -39,47 +45,51 @@ func (s *service) find() error {
 		return errs.FailedToFindIncome
 	}
 
-	// Extract data from the model
-	s.incomeID = data.IncomeID
-
-	if data.IncomeName != nil {
-		s.incomeName = *data.IncomeName
+	// Trashed incomes have no current version and are not found
+	s.version, err = s.f.pkg.M.Version.Get(s.ctx, m_trash.Income, s.req.IncomeID)
+	if err != nil {
+		return errs.FailedToFindIncome
 	}
 
-	if data.IncomeAmount != nil {
-		// Convert float64 to big.Rat
-		s.incomeAmount = *big.NewRat(int64(*data.IncomeAmount*100), 100)
+	assigned, err := s.f.pkg.M.Category.Assigned(s.ctx, m_trash.Income, []string{s.req.IncomeID})
+	if err != nil {
+		return errs.FailedToFindIncome
 	}
+	s.categoryID = assigned[s.req.IncomeID]
 
-	if data.IncomeType != nil {
-		s.incomeType = *data.IncomeType
+	accounts, err := s.f.pkg.M.Account.Assigned(s.ctx, m_trash.Income, []string{s.req.IncomeID})
+	if err != nil {
+		return errs.FailedToFindIncome
 	}
-
-	if data.IncomeDate != nil {
-		s.incomeDate = models.NewDate(*data.IncomeDate)
+	s.accountID = accounts[s.req.IncomeID]
+	currencies, err := account.Currencies(s.ctx, s.f.pkg, accounts)
+	if err != nil {
+		return errs.FailedToFindIncome
 	}
+	s.currency = currencies[s.req.IncomeID]
 
-	if data.CreatedAt != nil {
-		s.createdAt = models.NewDate(*data.CreatedAt)
+	tagged, err := tag.Of(s.ctx, s.f.pkg, m_trash.Income, []string{s.req.IncomeID})
+	if err != nil {
+		return errs.FailedToFindIncome
 	}
+	s.tags = tagged[s.req.IncomeID]
 
 	return nil
 }
 
 func (s *service) reply() *di.GetResponse {
-	amountValue, _ := s.incomeAmount.Float64()
-	amountObj := &models.Amount{
-		Amount:         amountValue,
-		CurrencyCode:   "USD",
-		CurrencySymbol: "$",
-	}
+	r := record.FromIncome(s.data).WithVersion(s.version).WithCurrency(s.currency)
 
 	return &di.GetResponse{
-		IncomeID:     s.incomeID,
-		IncomeName:   s.incomeName,
-		IncomeAmount: []*models.Amount{amountObj},
-		IncomeType:   s.incomeType,
-		IncomeDate:   s.incomeDate,
-		CreatedAt:    s.createdAt,
+		IncomeID:     r.ID,
+		IncomeName:   r.Name,
+		IncomeAmount: r.Amounts(),
+		IncomeType:   r.Type,
+		IncomeDate:   models.NewDate(r.Date),
+		CreatedAt:    models.NewDate(r.CreatedAt),
+		CategoryID:   s.categoryID,
+		AccountID:    s.accountID,
+		Tags:         s.tags,
+		Version:      r.Version,
 	}
 }
@@ -7,7 +7,11 @@ import (
 )
 
 var errs = struct {
+	InvalidTag          *err.HTTPError
+	InvalidTagMode      *err.HTTPError
 	FailedToListIncomes *err.HTTPError
 }{
+	InvalidTag:          err.NewHTTPError(http.StatusBadRequest, "Tags must be 1 to 64 characters and must not contain commas."),
+	InvalidTagMode:      err.NewHTTPError(http.StatusBadRequest, "Tag mode must be any or all."),
 	FailedToListIncomes: err.NewHTTPError(http.StatusInternalServerError, "Failed to list incomes."),
 }
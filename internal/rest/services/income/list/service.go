@@ -2,12 +2,15 @@ package list
 
 import (
 	"context"
-	"math/big"
-	"time"
+	"errors"
 
 	"github.com/rsmrtk/db-fd-model/m_income"
 	"github.com/rsmrtk/mybox/internal/rest/domain/income"
 	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+	"github.com/rsmrtk/mybox/internal/rest/services/account"
+	"github.com/rsmrtk/mybox/internal/rest/services/record"
+	"github.com/rsmrtk/mybox/internal/rest/services/tag"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_trash"
 )
 
 type service struct {
@@ -16,6 +19,12 @@ type service struct {
 	f     *Facade
 	items []*m_income.Data
 	total int
+
+	versions   map[string]int64
+	categories map[string]string
+	accounts   map[string]string
+	currencies map[string]string
+	tags       map[string][]string
 }
 
 func (s *service) list() error {
@@ -41,6 +50,34 @@ func (s *service) list() error {
 		return errs.FailedToListIncomes
 	}
 
+	// Hide incomes that are in the trash
+	deleted, err := s.f.pkg.M.Trash.DeletedIDs(s.ctx, m_trash.Income)
+	if err != nil {
+		return errs.FailedToListIncomes
+	}
+	live := s.items[:0]
+	for _, item := range s.items {
+		if !deleted[item.IncomeID] {
+			live = append(live, item)
+		}
+	}
+	s.items = live
+
+	// Keep only incomes with the requested tags
+	if s.req.Tags != nil {
+		matching, err := s.matchTags()
+		if err != nil {
+			return err
+		}
+		tagged := s.items[:0]
+		for _, item := range s.items {
+			if matching[item.IncomeID] {
+				tagged = append(tagged, item)
+			}
+		}
+		s.items = tagged
+	}
+
 	// Calculate total count
 	s.total = len(s.items)
 
@@ -55,6 +92,31 @@ func (s *service) list() error {
 		s.items = []*m_income.Data{}
 	}
 
+	ids := make([]string, 0, len(s.items))
+	for _, item := range s.items {
+		ids = append(ids, item.IncomeID)
+	}
+	s.versions, err = s.f.pkg.M.Version.GetMany(s.ctx, m_trash.Income, ids)
+	if err != nil {
+		return errs.FailedToListIncomes
+	}
+	s.categories, err = s.f.pkg.M.Category.Assigned(s.ctx, m_trash.Income, ids)
+	if err != nil {
+		return errs.FailedToListIncomes
+	}
+	s.accounts, err = s.f.pkg.M.Account.Assigned(s.ctx, m_trash.Income, ids)
+	if err != nil {
+		return errs.FailedToListIncomes
+	}
+	s.currencies, err = account.Currencies(s.ctx, s.f.pkg, s.accounts)
+	if err != nil {
+		return errs.FailedToListIncomes
+	}
+	s.tags, err = tag.Of(s.ctx, s.f.pkg, m_trash.Income, ids)
+	if err != nil {
+		return errs.FailedToListIncomes
+	}
+
 	return nil
 }
 
@@ -62,45 +124,24 @@ func (s *service) reply() *income.ListResponse {
 	items := make([]*income.ListItem, 0, len(s.items))
 
 	for _, data := range s.items {
-		var incomeName, incomeType string
-		var incomeAmount float64
-		var incomeDate, createdAt time.Time
-
-		// Extract nullable fields
-		if data.IncomeName != nil {
-			incomeName = *data.IncomeName
-		}
-		if data.IncomeAmount != nil {
-			incomeAmount = *data.IncomeAmount
-		}
-		if data.IncomeType != nil {
-			incomeType = *data.IncomeType
-		}
-		if data.IncomeDate != nil {
-			incomeDate = *data.IncomeDate
-		}
-		if data.CreatedAt != nil {
-			createdAt = *data.CreatedAt
-		}
-
-		// Convert float64 to big.Rat for precise decimal handling
-		incomeAmountRat := *big.NewRat(int64(incomeAmount*100), 100)
-		amountValue, _ := incomeAmountRat.Float64()
-
-		item := &income.ListItem{
-			IncomeID:   data.IncomeID,
-			IncomeName: incomeName,
-			IncomeAmount: []*models.Amount{{
-				Amount:         amountValue,
-				CurrencyCode:   "USD",
-				CurrencySymbol: "$",
-			}},
-			IncomeType: incomeType,
-			IncomeDate: models.NewDate(incomeDate),
-			CreatedAt:  models.NewDate(createdAt),
-		}
-
-		items = append(items, item)
+		r := record.FromIncome(data)
+		r.Version = s.versions[r.ID]
+		r.CategoryID = s.categories[r.ID]
+		r.AccountID = s.accounts[r.ID]
+		r.Currency = s.currencies[r.ID]
+
+		items = append(items, &income.ListItem{
+			IncomeID:     r.ID,
+			IncomeName:   r.Name,
+			IncomeAmount: r.Amounts(),
+			IncomeType:   r.Type,
+			IncomeDate:   models.NewDate(r.Date),
+			CreatedAt:    models.NewDate(r.CreatedAt),
+			CategoryID:   r.CategoryID,
+			AccountID:    r.AccountID,
+			Tags:         s.tags[r.ID],
+			Version:      r.Version,
+		})
 	}
 
 	return &income.ListResponse{
@@ -110,3 +151,27 @@ func (s *service) reply() *income.ListResponse {
 		Offset:     s.req.Offset,
 	}
 }
+
+// matchTags returns the IDs of the incomes carrying the requested tags.
+func (s *service) matchTags() (map[string]bool, error) {
+	all, ok := tag.MatchAll(s.req.TagMode)
+	if !ok {
+		return nil, errs.InvalidTagMode
+	}
+	ids, err := tag.Resolve(s.ctx, s.f.pkg, s.req.Tags, all)
+	if errors.Is(err, tag.ErrInvalidName) {
+		return nil, errs.InvalidTag
+	}
+	if err != nil {
+		return nil, errs.FailedToListIncomes
+	}
+	if len(ids) == 0 {
+		return map[string]bool{}, nil
+	}
+
+	matching, err := s.f.pkg.M.Tag.Matching(s.ctx, m_trash.Income, ids, all)
+	if err != nil {
+		return nil, errs.FailedToListIncomes
+	}
+	return matching, nil
+}
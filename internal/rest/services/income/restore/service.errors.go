@@ -0,0 +1,17 @@
+package restore
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	IncomeNotInTrash      *err.HTTPError
+	InvalidIncomeID       *err.HTTPError
+	FailedToRestoreIncome *err.HTTPError
+}{
+	IncomeNotInTrash:      err.NewHTTPError(http.StatusNotFound, "Income not found in trash."),
+	InvalidIncomeID:       err.NewHTTPError(http.StatusBadRequest, "Invalid income ID format."),
+	FailedToRestoreIncome: err.NewHTTPError(http.StatusInternalServerError, "Failed to restore income."),
+}
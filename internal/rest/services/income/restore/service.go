@@ -0,0 +1,60 @@
+package restore
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/income"
+	"github.com/rsmrtk/mybox/internal/rest/services/audit"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_audit"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_trash"
+)
+
+type service struct {
+	ctx     context.Context
+	req     *income.RestoreRequest
+	f       *Facade
+	version int64
+}
+
+func (s *service) restore() error {
+	// Validate the income ID format
+	_, err := uuid.Parse(s.req.IncomeID)
+	if err != nil {
+		return errs.InvalidIncomeID
+	}
+
+	err = s.f.pkg.M.Trash.Restore(s.ctx, m_trash.Income, s.req.IncomeID)
+	if errors.Is(err, m_trash.ErrNotFound) {
+		return errs.IncomeNotInTrash
+	}
+	if err != nil {
+		return errs.FailedToRestoreIncome
+	}
+
+	// Edits based on the version that was deleted must not apply to the restored income
+	s.version, err = s.f.pkg.M.Version.Bump(s.ctx, m_trash.Income, s.req.IncomeID, 0)
+	if err != nil {
+		return errs.FailedToRestoreIncome
+	}
+
+	var after audit.Snapshot
+	data, err := s.f.pkg.M.Record.FindIncome(s.ctx, s.req.IncomeID)
+	if err == nil {
+		after = audit.IncomeSnapshot(data)
+	}
+	if err := audit.Record(s.ctx, s.f.pkg, audit.EntityIncome, s.req.IncomeID, m_audit.ActionRestore, nil, after); err != nil {
+		return errs.FailedToRestoreIncome
+	}
+
+	return nil
+}
+
+func (s *service) reply() *income.RestoreResponse {
+	return &income.RestoreResponse{
+		Success: true,
+		Message: "Income restored successfully",
+		Version: s.version,
+	}
+}
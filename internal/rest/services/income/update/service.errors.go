@@ -10,8 +10,18 @@ var errs = struct {
 	IncomeNotFound       *err.HTTPError
 	InvalidIncomeID      *err.HTTPError
 	FailedToUpdateIncome *err.HTTPError
+	InvalidTag           *err.HTTPError
+	TagsRequireAuth      *err.HTTPError
+	UnknownCategory      *err.HTTPError
+	UnknownAccount       *err.HTTPError
+	VersionMismatch      *err.HTTPError
 }{
 	IncomeNotFound:       err.NewHTTPError(http.StatusNotFound, "Income not found."),
 	InvalidIncomeID:      err.NewHTTPError(http.StatusBadRequest, "Invalid income ID format."),
 	FailedToUpdateIncome: err.NewHTTPError(http.StatusInternalServerError, "Failed to update income."),
+	InvalidTag:           err.NewHTTPError(http.StatusBadRequest, "Tags must be 1 to 64 characters and must not contain commas."),
+	TagsRequireAuth:      err.NewHTTPError(http.StatusUnauthorized, "Tags require an API key."),
+	UnknownCategory:      err.NewHTTPError(http.StatusBadRequest, "Category not found."),
+	UnknownAccount:       err.NewHTTPError(http.StatusBadRequest, "Account not found."),
+	VersionMismatch:      err.NewHTTPError(http.StatusPreconditionFailed, "Income was modified by another request."),
 }
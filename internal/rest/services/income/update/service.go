@@ -2,20 +2,36 @@ package update
 
 import (
 	"context"
-	"math/big"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 	m_income "github.com/rsmrtk/db-fd-model/m_income"
 	"github.com/rsmrtk/mybox/internal/rest/domain/income"
 	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+	"github.com/rsmrtk/mybox/internal/rest/services/account"
+	"github.com/rsmrtk/mybox/internal/rest/services/audit"
+	"github.com/rsmrtk/mybox/internal/rest/services/category"
+	"github.com/rsmrtk/mybox/internal/rest/services/record"
+	"github.com/rsmrtk/mybox/internal/rest/services/tag"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_account"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_audit"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_category"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_trash"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_version"
 )
 
 type service struct {
-	ctx  context.Context
-	req  *income.UpdateRequest
-	f    *Facade
-	data *m_income.Data
+	ctx     context.Context
+	req     *income.UpdateRequest
+	f       *Facade
+	data    *m_income.Data
+	version int64
+
+	categoryID string
+	accountID  string
+	currency   string
+	tags       []string
 }
 
 func (s *service) update() error {
@@ -25,20 +41,51 @@ func (s *service) update() error {
 		return errs.InvalidIncomeID
 	}
 
-	// First, fetch the existing income
-	fields := []m_income.Field{
-		m_income.IncomeID,
-		m_income.IncomeName,
-		m_income.IncomeAmount,
-		m_income.IncomeType,
-		m_income.IncomeDate,
+	// Trashed incomes have to be restored before they can be edited
+	s.version, err = s.f.pkg.M.Version.Claim(s.ctx, m_trash.Income, s.req.IncomeID, models.IfMatch(s.req.IfMatch))
+	switch {
+	case errors.Is(err, m_version.ErrConflict):
+		return errs.VersionMismatch
+	case errors.Is(err, m_version.ErrNotFound):
+		return errs.IncomeNotFound
+	case err != nil:
+		return errs.FailedToUpdateIncome
 	}
 
-	s.data, err = s.f.pkg.M.FinDash.Income.Find(s.ctx, s.req.IncomeID, fields)
+	// Then fetch the existing income; the claim locks the row, so this reads
+	// what a concurrent edit committed
+	s.data, err = s.f.pkg.M.Record.FindIncome(s.ctx, s.req.IncomeID)
 	if err != nil {
 		return errs.IncomeNotFound
 	}
 
+	assigned, err := s.f.pkg.M.Category.Assigned(s.ctx, m_trash.Income, []string{s.req.IncomeID})
+	if err != nil {
+		return errs.FailedToUpdateIncome
+	}
+	s.categoryID = assigned[s.req.IncomeID]
+	previous := s.categoryID
+
+	accounts, err := s.f.pkg.M.Account.Assigned(s.ctx, m_trash.Income, []string{s.req.IncomeID})
+	if err != nil {
+		return errs.FailedToUpdateIncome
+	}
+	s.accountID = accounts[s.req.IncomeID]
+	currencies, err := account.Currencies(s.ctx, s.f.pkg, accounts)
+	if err != nil {
+		return errs.FailedToUpdateIncome
+	}
+	s.currency = currencies[s.req.IncomeID]
+	previousAccount := s.accountID
+
+	tagged, err := tag.Of(s.ctx, s.f.pkg, m_trash.Income, []string{s.req.IncomeID})
+	if err != nil {
+		return errs.FailedToUpdateIncome
+	}
+	s.tags = tagged[s.req.IncomeID]
+
+	before := audit.IncomeSnapshot(s.data).WithCategory(s.categoryID).WithAccount(s.accountID).WithTags(s.tags)
+
 	// Update local data for response
 	if s.req.IncomeName != "" {
 		s.data.IncomeName = &s.req.IncomeName
@@ -49,54 +96,116 @@ func (s *service) update() error {
 	}
 	if s.req.IncomeType != "" {
 		s.data.IncomeType = &s.req.IncomeType
+		s.categoryID = ""
+	}
+	if err := s.category(); err != nil {
+		return err
+	}
+	if err := s.account(); err != nil {
+		return err
 	}
 	if s.req.IncomeDate != nil {
 		incomeDate := s.req.IncomeDate.Time
 		s.data.IncomeDate = &incomeDate
 	}
 
-	// TODO: Implement Update when the proper API is known
-	// The Update method signature needs to be verified with the db-fd-model package
-	// err = s.f.pkg.M.FinDash.Income.Update(s.ctx, s.req.IncomeID, updateData)
-	// if err != nil {
-	// 	return errs.FailedToUpdateIncome
-	// }
+	err = s.f.pkg.M.Record.UpdateIncome(s.ctx, s.data)
+	if err != nil {
+		return errs.FailedToUpdateIncome
+	}
+
+	if s.categoryID != previous {
+		var categoryID *string
+		if s.categoryID != "" {
+			categoryID = &s.categoryID
+		}
+		if err := s.f.pkg.M.Category.Assign(s.ctx, m_trash.Income, s.req.IncomeID, categoryID); err != nil {
+			return errs.FailedToUpdateIncome
+		}
+	}
+
+	if s.accountID != previousAccount {
+		var accountID *string
+		if s.accountID != "" {
+			accountID = &s.accountID
+		}
+		if err := s.f.pkg.M.Account.Assign(s.ctx, m_trash.Income, s.req.IncomeID, accountID); err != nil {
+			return errs.FailedToUpdateIncome
+		}
+	}
+
+	if s.req.Tags != nil {
+		s.tags, err = tag.Apply(s.ctx, s.f.pkg, m_trash.Income, s.req.IncomeID, s.req.Tags)
+		switch {
+		case errors.Is(err, tag.ErrInvalidName):
+			return errs.InvalidTag
+		case errors.Is(err, tag.ErrNoWorkspace):
+			return errs.TagsRequireAuth
+		case err != nil:
+			return errs.FailedToUpdateIncome
+		}
+	}
+
+	if err := audit.Record(s.ctx, s.f.pkg, audit.EntityIncome, s.req.IncomeID, m_audit.ActionUpdate, before,
+		audit.IncomeSnapshot(s.data).WithCategory(s.categoryID).WithAccount(s.accountID).WithTags(s.tags)); err != nil {
+		return errs.FailedToUpdateIncome
+	}
 
 	return nil
 }
 
 func (s *service) reply() *income.UpdateResponse {
-	var incomeName, incomeType string
-	var incomeAmount float64
-	var incomeDate time.Time
+	r := record.FromIncome(s.data).WithVersion(s.version).WithCurrency(s.currency)
 
-	if s.data.IncomeName != nil {
-		incomeName = *s.data.IncomeName
+	return &income.UpdateResponse{
+		IncomeID:     r.ID,
+		IncomeName:   r.Name,
+		IncomeAmount: r.Amounts(),
+		IncomeType:   r.Type,
+		IncomeDate:   models.NewDate(r.Date),
+		UpdatedAt:    models.NewDate(time.Now()),
+		CategoryID:   s.categoryID,
+		AccountID:    s.accountID,
+		Tags:         s.tags,
+		Version:      r.Version,
 	}
-	if s.data.IncomeAmount != nil {
-		incomeAmount = *s.data.IncomeAmount
+}
+
+// category moves the income to the requested catalogue category and takes
+// the category name as its type.
+func (s *service) category() error {
+	if s.req.CategoryID == "" {
+		return nil
 	}
-	if s.data.IncomeType != nil {
-		incomeType = *s.data.IncomeType
+	c, err := category.Resolve(s.ctx, s.f.pkg, s.req.CategoryID)
+	if errors.Is(err, m_category.ErrNotFound) {
+		return errs.UnknownCategory
 	}
-	if s.data.IncomeDate != nil {
-		incomeDate = *s.data.IncomeDate
+	if err != nil {
+		return errs.FailedToUpdateIncome
 	}
+	s.data.IncomeType = &c.Name
+	s.categoryID = c.CategoryID
+	return nil
+}
 
-	// Convert float64 to big.Rat for precise decimal handling
-	incomeAmountRat := *big.NewRat(int64(incomeAmount*100), 100)
-	amountValue, _ := incomeAmountRat.Float64()
-
-	return &income.UpdateResponse{
-		IncomeID:   s.data.IncomeID,
-		IncomeName: incomeName,
-		IncomeAmount: []*models.Amount{{
-			Amount:         amountValue,
-			CurrencyCode:   "USD",
-			CurrencySymbol: "$",
-		}},
-		IncomeType: incomeType,
-		IncomeDate: models.NewDate(incomeDate),
-		UpdatedAt:  models.NewDate(time.Now()),
+// account moves the income to the requested account, or unlinks it for an
+// empty ID.
+func (s *service) account() error {
+	if s.req.AccountID == nil {
+		return nil
+	}
+	if *s.req.AccountID == "" {
+		s.accountID, s.currency = "", ""
+		return nil
 	}
+	a, err := account.Resolve(s.ctx, s.f.pkg, *s.req.AccountID)
+	if errors.Is(err, m_account.ErrNotFound) {
+		return errs.UnknownAccount
+	}
+	if err != nil {
+		return errs.FailedToUpdateIncome
+	}
+	s.accountID, s.currency = a.AccountID, a.Currency
+	return nil
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/rsmrtk/mybox/internal/rest/domain/income"
 	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/dbtx"
 )
 
 // Facade is the update income facade
@@ -27,7 +28,12 @@ func (f *Facade) Handle(ctx context.Context, req *income.UpdateRequest) (*income
 		f:   f,
 	}
 
-	if err := s.update(); err != nil {
+	// The row, its version and its audit entry are written together
+	err := dbtx.InTx(ctx, f.pkg.M.DB, func(ctx context.Context) error {
+		s.ctx = ctx
+		return s.update()
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,218 @@
+// Package record is the conversion layer between stored incomes and
+// expenses and API responses. Every reply that shows an income, an expense
+// or a transaction goes through it, so the two sides format names, amounts
+// and dates the same way.
+package record
+
+import (
+	"maps"
+	"math"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/db-fd-model/m_expense"
+	"github.com/rsmrtk/db-fd-model/m_income"
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_transaction"
+)
+
+// Directions of a record.
+const (
+	DirectionIncome  = m_transaction.DirectionIncome
+	DirectionExpense = m_transaction.DirectionExpense
+)
+
+// Record is an income or an expense with nulls replaced by zero values.
+type Record struct {
+	Direction string
+	ID        string
+	Name      string
+	Amount    float64
+	Type      string
+	Date      time.Time
+	CreatedAt time.Time
+	Version   int64
+
+	// CategoryID is empty for records outside the category catalogue.
+	CategoryID string
+	// AccountID is empty for records not linked to an account.
+	AccountID string
+	// Currency is the currency of the account, empty for records outside
+	// one, which are in DefaultCurrency.
+	Currency string
+}
+
+// FromExpense converts a db-fd-model expense, whose untyped columns hold
+// strings and the ID a uuid.UUID.
+func FromExpense(d *m_expense.Data) *Record {
+	r := &Record{Direction: DirectionExpense}
+	if id, ok := d.ExpenseID.(uuid.UUID); ok {
+		r.ID = id.String()
+	}
+	if name, ok := d.ExpenseName.(string); ok {
+		r.Name = name
+	}
+	if d.ExpenseAmount.Valid {
+		r.Amount = d.ExpenseAmount.Float64
+	}
+	if typ, ok := d.ExpenseType.(string); ok {
+		r.Type = typ
+	}
+	if d.ExpenseDate.Valid {
+		r.Date = d.ExpenseDate.Time
+	}
+	if d.CreatedAt.Valid {
+		r.CreatedAt = d.CreatedAt.Time
+	}
+	return r
+}
+
+// FromIncome converts a db-fd-model income.
+func FromIncome(d *m_income.Data) *Record {
+	return &Record{
+		Direction: DirectionIncome,
+		ID:        d.IncomeID,
+		Name:      deref(d.IncomeName),
+		Amount:    deref(d.IncomeAmount),
+		Type:      deref(d.IncomeType),
+		Date:      deref(d.IncomeDate),
+		CreatedAt: deref(d.CreatedAt),
+	}
+}
+
+// FromTransaction converts a row of the unified transaction list.
+func FromTransaction(d *m_transaction.Data) *Record {
+	return &Record{
+		Direction: d.Direction,
+		ID:        d.ID,
+		Name:      deref(d.Name),
+		Amount:    deref(d.Amount),
+		Type:      deref(d.Type),
+		Date:      deref(d.Date),
+		CreatedAt: deref(d.CreatedAt),
+		Version:   d.Version,
+
+		CategoryID: deref(d.CategoryID),
+		AccountID:  deref(d.AccountID),
+		Currency:   deref(d.Currency),
+	}
+}
+
+// WithVersion sets the version, which the db-fd-model types do not carry.
+func (r *Record) WithVersion(version int64) *Record {
+	r.Version = version
+	return r
+}
+
+// WithCurrency sets the currency of the record's account, which the
+// db-fd-model types do not carry either.
+func (r *Record) WithCurrency(currency string) *Record {
+	r.Currency = currency
+	return r
+}
+
+// Amounts returns the amount in the list form used by the API, in the
+// currency of the record.
+func (r *Record) Amounts() []*models.Amount {
+	return AmountsIn(r.Amount, r.CurrencyCode())
+}
+
+// CurrencyCode returns the currency of the record's amount.
+func (r *Record) CurrencyCode() string {
+	return Currency(r.Currency)
+}
+
+// DefaultCurrency is the currency of records outside an account.
+const DefaultCurrency = "USD"
+
+// Currency returns the currency of a record whose account is in currency,
+// which is empty for records outside an account.
+func Currency(currency string) string {
+	if currency == "" {
+		return DefaultCurrency
+	}
+	return currency
+}
+
+// Amounts formats an amount for the API. Amounts are cut to whole cents and
+// reported in DefaultCurrency.
+func Amounts(amount float64) []*models.Amount {
+	return AmountsIn(amount, DefaultCurrency)
+}
+
+// AmountsIn formats an amount in the given ISO 4217 currency.
+func AmountsIn(amount float64, currency string) []*models.Amount {
+	// Convert float64 to big.Rat for precise decimal handling; rounding to
+	// cents keeps values like 0.29 (28.999... cents) from losing a cent
+	amountRat := *big.NewRat(int64(math.Round(amount*100)), 100)
+	amountValue, _ := amountRat.Float64()
+
+	return []*models.Amount{{
+		Amount:         amountValue,
+		CurrencyCode:   currency,
+		CurrencySymbol: Symbol(currency),
+	}}
+}
+
+// Totals adds up amounts per currency, since amounts in different
+// currencies cannot be added to each other.
+type Totals map[string]float64
+
+// Add adds an amount in the currency of an account, empty for records
+// outside one.
+func (t Totals) Add(currency string, amount float64) {
+	t[Currency(currency)] += amount
+}
+
+// Merge adds every total of o.
+func (t Totals) Merge(o Totals) {
+	for currency, amount := range o {
+		t[currency] += amount
+	}
+}
+
+// Amounts returns one amount per currency, ordered by currency code, or a
+// zero amount in DefaultCurrency when there are none.
+func (t Totals) Amounts() []*models.Amount {
+	if len(t) == 0 {
+		return Amounts(0)
+	}
+	var res []*models.Amount
+	for _, currency := range slices.Sorted(maps.Keys(t)) {
+		res = append(res, AmountsIn(t[currency], currency)...)
+	}
+	return res
+}
+
+// symbols holds the signs of common currencies.
+var symbols = map[string]string{
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+	"JPY": "¥",
+	"CNY": "¥",
+	"CHF": "CHF",
+	"CAD": "$",
+	"AUD": "$",
+	"PLN": "zł",
+	"UAH": "₴",
+	"INR": "₹",
+}
+
+// Symbol returns the sign of a currency, or its code when it has none.
+func Symbol(currency string) string {
+	if s, ok := symbols[currency]; ok {
+		return s
+	}
+	return currency
+}
+
+func deref[T any](p *T) T {
+	var v T
+	if p != nil {
+		v = *p
+	}
+	return v
+}
@@ -0,0 +1,41 @@
+package create
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/recurring"
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/dbtx"
+)
+
+// Facade is the create recurring template facade
+type Facade struct {
+	pkg *pkg.Facade
+}
+
+// New creates a new create recurring template facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg: pkg,
+	}
+}
+
+// Handle handles the create recurring template request
+func (f *Facade) Handle(ctx context.Context, req *recurring.CreateRequest) (*recurring.CreateResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	// The category, account and tags are checked in the same transaction that writes the template
+	err := dbtx.InTx(ctx, f.pkg.M.DB, func(ctx context.Context) error {
+		s.ctx = ctx
+		return s.create()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
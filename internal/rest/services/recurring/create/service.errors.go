@@ -0,0 +1,31 @@
+package create
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	InvalidDirection        *err.HTTPError
+	InvalidName             *err.HTTPError
+	InvalidAmount           *err.HTTPError
+	TypeRequired            *err.HTTPError
+	InvalidRule             *err.HTTPError
+	NoOccurrences           *err.HTTPError
+	UnknownCategory         *err.HTTPError
+	UnknownAccount          *err.HTTPError
+	InvalidTag              *err.HTTPError
+	FailedToCreateRecurring *err.HTTPError
+}{
+	InvalidDirection:        err.NewHTTPError(http.StatusBadRequest, "Direction must be income or expense."),
+	InvalidName:             err.NewHTTPError(http.StatusBadRequest, "Name must not be blank."),
+	InvalidAmount:           err.NewHTTPError(http.StatusBadRequest, "Amount must be positive."),
+	TypeRequired:            err.NewHTTPError(http.StatusBadRequest, "Either a type or a category is required."),
+	InvalidRule:             err.NewHTTPError(http.StatusBadRequest, "Rule must be an RRULE with FREQ=DAILY, WEEKLY, MONTHLY or YEARLY and optionally INTERVAL, BYDAY and one of UNTIL or COUNT."),
+	NoOccurrences:           err.NewHTTPError(http.StatusBadRequest, "Rule has no occurrences from the start date on."),
+	UnknownCategory:         err.NewHTTPError(http.StatusBadRequest, "Category not found."),
+	UnknownAccount:          err.NewHTTPError(http.StatusBadRequest, "Account not found."),
+	InvalidTag:              err.NewHTTPError(http.StatusBadRequest, "Tags must be 1 to 64 characters and must not contain commas."),
+	FailedToCreateRecurring: err.NewHTTPError(http.StatusInternalServerError, "Failed to create recurring template."),
+}
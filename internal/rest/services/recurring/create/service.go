@@ -0,0 +1,86 @@
+package create
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/recurring"
+	"github.com/rsmrtk/mybox/internal/rest/services/recurring/form"
+	"github.com/rsmrtk/mybox/internal/rest/services/recurring/rrule"
+	"github.com/rsmrtk/mybox/internal/rest/services/tag/name"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_account"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_category"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_recurring"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx  context.Context
+	req  *recurring.CreateRequest
+	f    *Facade
+	data *m_recurring.Data
+}
+
+func (s *service) create() error {
+	s.data = &m_recurring.Data{
+		RecurringID: uuid.New().String(),
+		WorkspaceID: utils.AuthCtx(s.ctx),
+		Direction:   s.req.Direction,
+		Name:        s.req.Name,
+		Amount:      s.req.Amount,
+		Tags:        s.req.Tags,
+		Rule:        s.req.Rule,
+		StartsOn:    s.req.StartsOn.Time,
+		CreatedAt:   time.Now().UTC(),
+	}
+	if s.req.Type != "" {
+		s.data.Type = &s.req.Type
+	}
+	if s.req.CategoryID != "" {
+		s.data.CategoryID = &s.req.CategoryID
+	}
+	if s.req.AccountID != "" {
+		s.data.AccountID = &s.req.AccountID
+	}
+
+	err := form.Check(s.ctx, s.f.pkg, s.data)
+	switch {
+	case errors.Is(err, form.ErrInvalidDirection):
+		return errs.InvalidDirection
+	case errors.Is(err, form.ErrInvalidName):
+		return errs.InvalidName
+	case errors.Is(err, form.ErrInvalidAmount):
+		return errs.InvalidAmount
+	case errors.Is(err, form.ErrTypeRequired):
+		return errs.TypeRequired
+	case errors.Is(err, rrule.ErrInvalid):
+		return errs.InvalidRule
+	case errors.Is(err, m_category.ErrNotFound):
+		return errs.UnknownCategory
+	case errors.Is(err, m_account.ErrNotFound):
+		return errs.UnknownAccount
+	case errors.Is(err, name.ErrInvalid):
+		return errs.InvalidTag
+	case err != nil:
+		return errs.FailedToCreateRecurring
+	}
+
+	if err := form.Schedule(s.data, s.data.StartsOn); err != nil {
+		return errs.FailedToCreateRecurring
+	}
+	if s.data.NextOn == nil {
+		return errs.NoOccurrences
+	}
+
+	if err := s.f.pkg.M.Recurring.Create(s.ctx, s.data); err != nil {
+		return errs.FailedToCreateRecurring
+	}
+
+	return nil
+}
+
+func (s *service) reply() *recurring.CreateResponse {
+	return &recurring.CreateResponse{Recurring: form.Convert(s.data)}
+}
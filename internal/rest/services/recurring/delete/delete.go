@@ -0,0 +1,41 @@
+package delete
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/recurring"
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/dbtx"
+)
+
+// Facade is the delete recurring template facade
+type Facade struct {
+	pkg *pkg.Facade
+}
+
+// New creates a new delete recurring template facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg: pkg,
+	}
+}
+
+// Handle handles the delete recurring template request
+func (f *Facade) Handle(ctx context.Context, req *recurring.DeleteRequest) (*recurring.DeleteResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	// The template is locked so that the scheduler is not materializing it while it goes
+	err := dbtx.InTx(ctx, f.pkg.M.DB, func(ctx context.Context) error {
+		s.ctx = ctx
+		return s.delete()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
@@ -0,0 +1,17 @@
+package delete
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	RecurringNotFound       *err.HTTPError
+	InvalidRecurringID      *err.HTTPError
+	FailedToDeleteRecurring *err.HTTPError
+}{
+	RecurringNotFound:       err.NewHTTPError(http.StatusNotFound, "Recurring template not found."),
+	InvalidRecurringID:      err.NewHTTPError(http.StatusBadRequest, "Invalid recurring template ID format."),
+	FailedToDeleteRecurring: err.NewHTTPError(http.StatusInternalServerError, "Failed to delete recurring template."),
+}
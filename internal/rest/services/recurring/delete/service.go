@@ -0,0 +1,50 @@
+package delete
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/recurring"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_recurring"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx context.Context
+	req *recurring.DeleteRequest
+	f   *Facade
+}
+
+func (s *service) delete() error {
+	id, err := uuid.Parse(s.req.RecurringID)
+	if err != nil {
+		return errs.InvalidRecurringID
+	}
+
+	workspaceID := utils.AuthCtx(s.ctx)
+	data, err := s.f.pkg.M.Recurring.Lock(s.ctx, workspaceID, id.String())
+	if errors.Is(err, m_recurring.ErrNotFound) {
+		return errs.RecurringNotFound
+	}
+	if err != nil {
+		return errs.FailedToDeleteRecurring
+	}
+
+	err = s.f.pkg.M.Recurring.Delete(s.ctx, workspaceID, data.RecurringID)
+	if errors.Is(err, m_recurring.ErrNotFound) {
+		return errs.RecurringNotFound
+	}
+	if err != nil {
+		return errs.FailedToDeleteRecurring
+	}
+
+	return nil
+}
+
+func (s *service) reply() *recurring.DeleteResponse {
+	return &recurring.DeleteResponse{
+		Success: true,
+		Message: "Recurring template deleted successfully",
+	}
+}
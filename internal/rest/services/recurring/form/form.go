@@ -0,0 +1,183 @@
+// Package form validates recurring templates and converts them for the API.
+// The recurring services share it so that a template is checked the same way
+// whether it is created, edited as a whole or split.
+package form
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+	dr "github.com/rsmrtk/mybox/internal/rest/domain/recurring"
+	"github.com/rsmrtk/mybox/internal/rest/services/account"
+	"github.com/rsmrtk/mybox/internal/rest/services/category"
+	"github.com/rsmrtk/mybox/internal/rest/services/record"
+	"github.com/rsmrtk/mybox/internal/rest/services/recurring/rrule"
+	"github.com/rsmrtk/mybox/internal/rest/services/tag/name"
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_recurring"
+)
+
+var (
+	// ErrInvalidDirection is returned for a direction other than income or expense.
+	ErrInvalidDirection = errors.New("invalid recurring direction")
+	// ErrInvalidName is returned for a blank name.
+	ErrInvalidName = errors.New("invalid recurring name")
+	// ErrInvalidAmount is returned for an amount that is not positive.
+	ErrInvalidAmount = errors.New("invalid recurring amount")
+	// ErrTypeRequired is returned when neither a type nor a category is set.
+	ErrTypeRequired = errors.New("recurring type or category required")
+	// ErrNoOccurrences is returned when the rule has no occurrence left.
+	ErrNoOccurrences = errors.New("recurrence rule has no occurrences")
+)
+
+// Edit holds the optional changes of an update or a split. Nil fields keep
+// their value; an empty CategoryID or AccountID clears it.
+type Edit struct {
+	Name       *string
+	Amount     *float64
+	Type       *string
+	CategoryID *string
+	AccountID  *string
+	Tags       []string
+	Rule       *string
+	StartsOn   *models.Date
+}
+
+// Apply copies the changes onto d. Check validates the result.
+func (e Edit) Apply(d *m_recurring.Data) {
+	if e.Name != nil {
+		d.Name = *e.Name
+	}
+	if e.Amount != nil {
+		d.Amount = *e.Amount
+	}
+	if e.Type != nil {
+		d.Type = e.Type
+	}
+	if e.CategoryID != nil {
+		d.CategoryID = e.CategoryID
+	}
+	if e.AccountID != nil {
+		d.AccountID = e.AccountID
+	}
+	if e.Tags != nil {
+		d.Tags = e.Tags
+	}
+	if e.Rule != nil {
+		d.Rule = *e.Rule
+	}
+	if e.StartsOn != nil && !e.StartsOn.IsZero() {
+		d.StartsOn = e.StartsOn.Time
+	}
+}
+
+// Check validates a template and normalizes it: the rule is stored in
+// canonical form, the category and account by their resolved IDs and the
+// tags cleaned. Errors are the package errors, rrule.ErrInvalid,
+// name.ErrInvalid or the ErrNotFound of m_category and m_account.
+func Check(ctx context.Context, f *pkg.Facade, d *m_recurring.Data) error {
+	if !slices.Contains([]string{m_recurring.DirectionIncome, m_recurring.DirectionExpense}, d.Direction) {
+		return ErrInvalidDirection
+	}
+	if d.Name = strings.TrimSpace(d.Name); d.Name == "" {
+		return ErrInvalidName
+	}
+	if d.Amount <= 0 {
+		return ErrInvalidAmount
+	}
+
+	rule, err := rrule.Parse(d.Rule)
+	if err != nil {
+		return err
+	}
+	d.Rule = rule.String()
+	d.StartsOn = Day(d.StartsOn)
+
+	if d.Type != nil && strings.TrimSpace(*d.Type) == "" {
+		d.Type = nil
+	}
+	if d.CategoryID != nil && *d.CategoryID == "" {
+		d.CategoryID = nil
+	}
+	if d.AccountID != nil && *d.AccountID == "" {
+		d.AccountID = nil
+	}
+	if d.Type == nil && d.CategoryID == nil {
+		return ErrTypeRequired
+	}
+
+	if d.CategoryID != nil {
+		c, err := category.Resolve(ctx, f, *d.CategoryID)
+		if err != nil {
+			return err
+		}
+		d.CategoryID = &c.CategoryID
+	}
+	if d.AccountID != nil {
+		a, err := account.Resolve(ctx, f, *d.AccountID)
+		if err != nil {
+			return err
+		}
+		d.AccountID = &a.AccountID
+	}
+
+	d.Tags, err = name.CleanAll(d.Tags)
+	return err
+}
+
+// Schedule sets NextOn to the first occurrence of the template's rule on or
+// after from, or clears it when there is none. The rule must have passed Check.
+func Schedule(d *m_recurring.Data, from time.Time) error {
+	rule, err := rrule.Parse(d.Rule)
+	if err != nil {
+		return err
+	}
+	d.NextOn = nil
+	if next := rule.Next(d.StartsOn, from, 1); len(next) > 0 {
+		d.NextOn = &next[0]
+	}
+	return nil
+}
+
+// Convert returns the API form of a template.
+func Convert(d *m_recurring.Data) dr.Recurring {
+	r := dr.Recurring{
+		RecurringID: d.RecurringID,
+		Direction:   d.Direction,
+		Name:        d.Name,
+		Amount:      record.Amounts(d.Amount),
+		Type:        deref(d.Type),
+		CategoryID:  deref(d.CategoryID),
+		AccountID:   deref(d.AccountID),
+		Tags:        d.Tags,
+		Rule:        d.Rule,
+		StartsOn:    models.NewDate(d.StartsOn),
+		CreatedAt:   d.CreatedAt,
+		UpdatedAt:   d.UpdatedAt,
+	}
+	if d.NextOn != nil {
+		next := models.NewDate(*d.NextOn)
+		r.NextOn = &next
+	}
+	if d.LastError != nil {
+		r.LastError, r.RetryAt = *d.LastError, d.RetryAt
+	}
+	return r
+}
+
+// Day truncates t to midnight UTC of its calendar day, the form occurrences
+// are compared in.
+func Day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,35 @@
+package get
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/recurring"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Facade is the get recurring template facade
+type Facade struct {
+	pkg *pkg.Facade
+}
+
+// New creates a new get recurring template facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg: pkg,
+	}
+}
+
+// Handle handles the get recurring template request
+func (f *Facade) Handle(ctx context.Context, req *recurring.GetRequest) (*recurring.GetResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	if err := s.find(); err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
@@ -0,0 +1,17 @@
+package get
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	RecurringNotFound    *err.HTTPError
+	InvalidRecurringID   *err.HTTPError
+	FailedToGetRecurring *err.HTTPError
+}{
+	RecurringNotFound:    err.NewHTTPError(http.StatusNotFound, "Recurring template not found."),
+	InvalidRecurringID:   err.NewHTTPError(http.StatusBadRequest, "Invalid recurring template ID format."),
+	FailedToGetRecurring: err.NewHTTPError(http.StatusInternalServerError, "Failed to get recurring template."),
+}
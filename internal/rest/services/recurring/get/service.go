@@ -0,0 +1,40 @@
+package get
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/recurring"
+	"github.com/rsmrtk/mybox/internal/rest/services/recurring/form"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_recurring"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx  context.Context
+	req  *recurring.GetRequest
+	f    *Facade
+	data *m_recurring.Data
+}
+
+func (s *service) find() error {
+	id, err := uuid.Parse(s.req.RecurringID)
+	if err != nil {
+		return errs.InvalidRecurringID
+	}
+
+	s.data, err = s.f.pkg.M.Recurring.Find(s.ctx, utils.AuthCtx(s.ctx), id.String())
+	if errors.Is(err, m_recurring.ErrNotFound) {
+		return errs.RecurringNotFound
+	}
+	if err != nil {
+		return errs.FailedToGetRecurring
+	}
+
+	return nil
+}
+
+func (s *service) reply() *recurring.GetResponse {
+	return &recurring.GetResponse{Recurring: form.Convert(s.data)}
+}
@@ -0,0 +1,13 @@
+package list
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	FailedToListRecurring *err.HTTPError
+}{
+	FailedToListRecurring: err.NewHTTPError(http.StatusInternalServerError, "Failed to list recurring templates."),
+}
@@ -0,0 +1,36 @@
+package list
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/recurring"
+	"github.com/rsmrtk/mybox/internal/rest/services/recurring/form"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_recurring"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx   context.Context
+	req   *recurring.ListRequest
+	f     *Facade
+	items []*m_recurring.Data
+}
+
+func (s *service) list() error {
+	var err error
+	s.items, err = s.f.pkg.M.Recurring.List(s.ctx, utils.AuthCtx(s.ctx))
+	if err != nil {
+		return errs.FailedToListRecurring
+	}
+
+	return nil
+}
+
+func (s *service) reply() *recurring.ListResponse {
+	items := make([]*recurring.Recurring, 0, len(s.items))
+	for _, d := range s.items {
+		r := form.Convert(d)
+		items = append(items, &r)
+	}
+	return &recurring.ListResponse{Items: items}
+}
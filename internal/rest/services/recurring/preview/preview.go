@@ -0,0 +1,35 @@
+package preview
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/recurring"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Facade is the preview recurring occurrences facade
+type Facade struct {
+	pkg *pkg.Facade
+}
+
+// New creates a new preview recurring occurrences facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg: pkg,
+	}
+}
+
+// Handle handles the preview recurring occurrences request
+func (f *Facade) Handle(ctx context.Context, req *recurring.PreviewRequest) (*recurring.PreviewResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	if err := s.preview(); err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
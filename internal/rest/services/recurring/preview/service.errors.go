@@ -0,0 +1,21 @@
+package preview
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	RecurringNotFound        *err.HTTPError
+	InvalidRecurringID       *err.HTTPError
+	RuleRequired             *err.HTTPError
+	InvalidRule              *err.HTTPError
+	FailedToPreviewRecurring *err.HTTPError
+}{
+	RecurringNotFound:        err.NewHTTPError(http.StatusNotFound, "Recurring template not found."),
+	InvalidRecurringID:       err.NewHTTPError(http.StatusBadRequest, "Invalid recurring template ID format."),
+	RuleRequired:             err.NewHTTPError(http.StatusBadRequest, "Either recurring_id, or rule and starts_on, are required."),
+	InvalidRule:              err.NewHTTPError(http.StatusBadRequest, "Rule must be an RRULE with FREQ=DAILY, WEEKLY, MONTHLY or YEARLY and optionally INTERVAL, BYDAY and one of UNTIL or COUNT."),
+	FailedToPreviewRecurring: err.NewHTTPError(http.StatusInternalServerError, "Failed to preview recurring template."),
+}
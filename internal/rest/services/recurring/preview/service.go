@@ -0,0 +1,102 @@
+package preview
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+	"github.com/rsmrtk/mybox/internal/rest/domain/recurring"
+	"github.com/rsmrtk/mybox/internal/rest/services/recurring/rrule"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_recurring"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx     context.Context
+	req     *recurring.PreviewRequest
+	f       *Facade
+	rule    *rrule.Rule
+	dates   []time.Time
+	handled map[string]*m_recurring.Occurrence
+}
+
+func (s *service) preview() error {
+	if s.req.Count <= 0 || s.req.Count > 100 {
+		s.req.Count = 10 // Default count
+	}
+
+	if s.req.RecurringID == "" {
+		return s.previewRule()
+	}
+
+	id, err := uuid.Parse(s.req.RecurringID)
+	if err != nil {
+		return errs.InvalidRecurringID
+	}
+	data, err := s.f.pkg.M.Recurring.Find(s.ctx, utils.AuthCtx(s.ctx), id.String())
+	if errors.Is(err, m_recurring.ErrNotFound) {
+		return errs.RecurringNotFound
+	}
+	if err != nil {
+		return errs.FailedToPreviewRecurring
+	}
+
+	s.rule, err = rrule.Parse(data.Rule)
+	if err != nil {
+		return errs.FailedToPreviewRecurring
+	}
+	if data.NextOn == nil {
+		return nil
+	}
+
+	s.handled, err = s.f.pkg.M.Recurring.Occurrences(s.ctx, data.RecurringID, *data.NextOn)
+	if err != nil {
+		return errs.FailedToPreviewRecurring
+	}
+	s.dates = s.rule.Next(data.StartsOn, *data.NextOn, s.req.Count+len(s.handled))
+
+	return nil
+}
+
+// previewRule expands a rule that is not saved yet.
+func (s *service) previewRule() error {
+	if s.req.Rule == "" || s.req.StartsOn == nil || s.req.StartsOn.IsZero() {
+		return errs.RuleRequired
+	}
+
+	var err error
+	s.rule, err = rrule.Parse(s.req.Rule)
+	if err != nil {
+		return errs.InvalidRule
+	}
+	s.dates = s.rule.Next(s.req.StartsOn.Time, s.req.StartsOn.Time, s.req.Count)
+
+	return nil
+}
+
+func (s *service) reply() *recurring.PreviewResponse {
+	res := &recurring.PreviewResponse{
+		Rule:        s.rule.String(),
+		Occurrences: make([]*recurring.Occurrence, 0, s.req.Count),
+	}
+	for _, d := range s.dates {
+		if len(res.Occurrences) == s.req.Count {
+			break
+		}
+		status := recurring.StatusScheduled
+		if o, ok := s.handled[d.Format(time.DateOnly)]; ok {
+			// Materialized occurrences are no longer upcoming
+			if o.Status != m_recurring.StatusSkipped {
+				continue
+			}
+			status = recurring.StatusSkipped
+		}
+		res.Occurrences = append(res.Occurrences, &recurring.Occurrence{
+			OccursOn: models.NewDate(d),
+			Status:   status,
+		})
+	}
+	return res
+}
@@ -0,0 +1,353 @@
+// Package rrule parses and expands the subset of RFC 5545 recurrence rules
+// used by recurring templates: FREQ (DAILY, WEEKLY, MONTHLY, YEARLY),
+// INTERVAL, BYDAY, UNTIL and COUNT. Occurrences are whole days in UTC; weeks
+// start on Monday.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalid is returned by Parse for rules outside the supported subset.
+var ErrInvalid = errors.New("invalid recurrence rule")
+
+// Frequencies.
+const (
+	Daily   = "DAILY"
+	Weekly  = "WEEKLY"
+	Monthly = "MONTHLY"
+	Yearly  = "YEARLY"
+)
+
+// maxIdle bounds the number of consecutive periods without an occurrence
+// before expansion gives up, so that rules like every 29 February with a
+// three year interval still terminate.
+const maxIdle = 1000
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday, "TH": time.Thursday,
+	"FR": time.Friday, "SA": time.Saturday, "SU": time.Sunday,
+}
+
+// Day is one BYDAY entry. N is the ordinal within the month or year
+// ("2MO", "-1FR"), or 0 for every such weekday.
+type Day struct {
+	N       int
+	Weekday time.Weekday
+}
+
+type Rule struct {
+	Freq     string
+	Interval int
+	ByDay    []Day
+	Until    *time.Time // inclusive
+	Count    int        // 0 when unbounded or bounded by Until
+}
+
+// Parse reads a rule such as "FREQ=MONTHLY;INTERVAL=1;BYDAY=-1FR;COUNT=12".
+// An "RRULE:" prefix is accepted.
+func Parse(s string) (*Rule, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	r := &Rule{Interval: 1}
+	seen := map[string]bool{}
+
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		key = strings.ToUpper(strings.TrimSpace(key))
+		value = strings.ToUpper(strings.TrimSpace(value))
+		if !ok || value == "" || seen[key] {
+			return nil, fmt.Errorf("%w: %q", ErrInvalid, part)
+		}
+		seen[key] = true
+
+		switch key {
+		case "FREQ":
+			if !slices.Contains([]string{Daily, Weekly, Monthly, Yearly}, value) {
+				return nil, fmt.Errorf("%w: unsupported FREQ %q", ErrInvalid, value)
+			}
+			r.Freq = value
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 || n > 1000 {
+				return nil, fmt.Errorf("%w: INTERVAL must be between 1 and 1000", ErrInvalid)
+			}
+			r.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("%w: COUNT must be positive", ErrInvalid)
+			}
+			r.Count = n
+		case "UNTIL":
+			t, err := parseUntil(value)
+			if err != nil {
+				return nil, err
+			}
+			r.Until = &t
+		case "BYDAY":
+			for _, v := range strings.Split(value, ",") {
+				d, err := parseDay(v)
+				if err != nil {
+					return nil, err
+				}
+				if !slices.Contains(r.ByDay, d) {
+					r.ByDay = append(r.ByDay, d)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("%w: unsupported part %q", ErrInvalid, key)
+		}
+	}
+
+	if r.Freq == "" {
+		return nil, fmt.Errorf("%w: FREQ is required", ErrInvalid)
+	}
+	if r.Count > 0 && r.Until != nil {
+		return nil, fmt.Errorf("%w: COUNT and UNTIL are mutually exclusive", ErrInvalid)
+	}
+	for _, d := range r.ByDay {
+		switch {
+		case d.N == 0:
+		case r.Freq == Monthly && d.N >= -5 && d.N <= 5:
+		case r.Freq == Yearly && d.N >= -53 && d.N <= 53:
+		default:
+			return nil, fmt.Errorf("%w: BYDAY ordinal %d not allowed with FREQ=%s", ErrInvalid, d.N, r.Freq)
+		}
+	}
+	return r, nil
+}
+
+func parseUntil(v string) (time.Time, error) {
+	for _, layout := range []string{"20060102", "20060102T150405Z", "20060102T150405"} {
+		if t, err := time.Parse(layout, v); err == nil {
+			return day(t), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: UNTIL must look like 20060102 or 20060102T150405Z", ErrInvalid)
+}
+
+func parseDay(v string) (Day, error) {
+	if len(v) < 2 {
+		return Day{}, fmt.Errorf("%w: BYDAY %q", ErrInvalid, v)
+	}
+	w, ok := weekdays[v[len(v)-2:]]
+	if !ok {
+		return Day{}, fmt.Errorf("%w: BYDAY %q", ErrInvalid, v)
+	}
+	d := Day{Weekday: w}
+	if n := v[:len(v)-2]; n != "" {
+		var err error
+		if d.N, err = strconv.Atoi(n); err != nil || d.N == 0 {
+			return Day{}, fmt.Errorf("%w: BYDAY %q", ErrInvalid, v)
+		}
+	}
+	return d, nil
+}
+
+// String formats the rule in canonical form.
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, d := range r.ByDay {
+			days[i] = d.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	return strings.Join(parts, ";")
+}
+
+func (d Day) String() string {
+	for k, w := range weekdays {
+		if w == d.Weekday {
+			if d.N != 0 {
+				return strconv.Itoa(d.N) + k
+			}
+			return k
+		}
+	}
+	return ""
+}
+
+// Next returns up to n occurrences of the rule started on start that fall
+// on or after from, in order.
+func (r *Rule) Next(start, from time.Time, n int) []time.Time {
+	from = day(from)
+	var dates []time.Time
+	r.each(start, func(_ int, d time.Time) bool {
+		if !d.Before(from) {
+			dates = append(dates, d)
+		}
+		return len(dates) < n
+	})
+	return dates
+}
+
+// Between returns the occurrences of the rule started on start that fall
+// in [from, to], in order, stopping after n.
+func (r *Rule) Between(start, from, to time.Time, n int) []time.Time {
+	from, to = day(from), day(to)
+	var dates []time.Time
+	r.each(start, func(_ int, d time.Time) bool {
+		if d.After(to) {
+			return false
+		}
+		if !d.Before(from) {
+			dates = append(dates, d)
+		}
+		return len(dates) < n
+	})
+	return dates
+}
+
+// Occurs reports whether d is an occurrence of the rule started on start.
+func (r *Rule) Occurs(start, d time.Time) bool {
+	d = day(d)
+	found := false
+	r.each(start, func(_ int, o time.Time) bool {
+		found = o.Equal(d)
+		return o.Before(d)
+	})
+	return found
+}
+
+// Before returns the number of occurrences of the rule started on start
+// that fall before d.
+func (r *Rule) Before(start, d time.Time) int {
+	d = day(d)
+	count := 0
+	r.each(start, func(i int, o time.Time) bool {
+		if !o.Before(d) {
+			return false
+		}
+		count = i
+		return true
+	})
+	return count
+}
+
+// each calls fn with the 1-based index and date of every occurrence on or
+// after start until fn returns false or the rule ends.
+func (r *Rule) each(start time.Time, fn func(i int, d time.Time) bool) {
+	start = day(start)
+	i, idle := 0, 0
+	for k := 0; idle < maxIdle; k++ {
+		found := false
+		for _, d := range r.period(start, k) {
+			if d.Before(start) {
+				continue
+			}
+			if r.Until != nil && d.After(*r.Until) {
+				return
+			}
+			i++
+			found = true
+			if !fn(i, d) || (r.Count > 0 && i >= r.Count) {
+				return
+			}
+		}
+		if found {
+			idle = 0
+		} else {
+			idle++
+		}
+	}
+}
+
+// period returns the candidate dates of the k-th period of the rule, sorted.
+func (r *Rule) period(start time.Time, k int) []time.Time {
+	step := k * r.Interval
+	var dates []time.Time
+
+	switch r.Freq {
+	case Daily:
+		d := start.AddDate(0, 0, step)
+		if len(r.ByDay) == 0 || r.matches(d) {
+			dates = append(dates, d)
+		}
+
+	case Weekly:
+		monday := start.AddDate(0, 0, -((int(start.Weekday())+6)%7)+7*step)
+		if len(r.ByDay) == 0 {
+			return []time.Time{monday.AddDate(0, 0, (int(start.Weekday())+6)%7)}
+		}
+		for i := range 7 {
+			if d := monday.AddDate(0, 0, i); r.matches(d) {
+				dates = append(dates, d)
+			}
+		}
+
+	case Monthly:
+		first := time.Date(start.Year(), start.Month()+time.Month(step), 1, 0, 0, 0, 0, time.UTC)
+		if len(r.ByDay) == 0 {
+			if d := first.AddDate(0, 0, start.Day()-1); d.Month() == first.Month() {
+				dates = append(dates, d)
+			}
+			return dates
+		}
+		dates = r.byDay(first, first.AddDate(0, 1, 0))
+
+	case Yearly:
+		year := start.Year() + step
+		if len(r.ByDay) == 0 {
+			d := time.Date(year, start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+			if d.Month() == start.Month() {
+				dates = append(dates, d)
+			}
+			return dates
+		}
+		first := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		dates = r.byDay(first, first.AddDate(1, 0, 0))
+	}
+	return dates
+}
+
+// byDay expands the BYDAY entries within [first, end).
+func (r *Rule) byDay(first, end time.Time) []time.Time {
+	var dates []time.Time
+	for _, bd := range r.ByDay {
+		var all []time.Time
+		offset := (int(bd.Weekday) - int(first.Weekday()) + 7) % 7
+		for d := first.AddDate(0, 0, offset); d.Before(end); d = d.AddDate(0, 0, 7) {
+			all = append(all, d)
+		}
+		switch {
+		case bd.N == 0:
+			dates = append(dates, all...)
+		case bd.N > 0 && bd.N <= len(all):
+			dates = append(dates, all[bd.N-1])
+		case bd.N < 0 && -bd.N <= len(all):
+			dates = append(dates, all[len(all)+bd.N])
+		}
+	}
+	slices.SortFunc(dates, func(a, b time.Time) int { return a.Compare(b) })
+	return slices.CompactFunc(dates, func(a, b time.Time) bool { return a.Equal(b) })
+}
+
+func (r *Rule) matches(d time.Time) bool {
+	for _, bd := range r.ByDay {
+		if bd.Weekday == d.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+// day truncates t to midnight UTC of its calendar day.
+func day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
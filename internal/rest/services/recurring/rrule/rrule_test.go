@@ -0,0 +1,311 @@
+package rrule
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func dates(ss ...string) []time.Time {
+	res := make([]time.Time, len(ss))
+	for i, s := range ss {
+		res[i] = date(s)
+	}
+	return res
+}
+
+func mustParse(t *testing.T, s string) *Rule {
+	t.Helper()
+	r, err := Parse(s)
+	if err != nil {
+		t.Fatalf("Parse(%q): %v", s, err)
+	}
+	return r
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		rule string
+		want string // canonical form, or "" when the rule is invalid
+	}{
+		{"FREQ=DAILY", "FREQ=DAILY"},
+		{"RRULE:freq=monthly;byday=-1fr;count=12", "FREQ=MONTHLY;BYDAY=-1FR;COUNT=12"},
+		{"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE,MO", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE"},
+		{"FREQ=YEARLY;BYDAY=-53SU;UNTIL=20301231T235959Z", "FREQ=YEARLY;BYDAY=-53SU;UNTIL=20301231"},
+		{"FREQ=MONTHLY;INTERVAL=1", "FREQ=MONTHLY"},
+		{"INTERVAL=2", ""},
+		{"FREQ=HOURLY", ""},
+		{"FREQ=DAILY;FREQ=WEEKLY", ""},
+		{"FREQ=DAILY;INTERVAL=0", ""},
+		{"FREQ=DAILY;INTERVAL=1001", ""},
+		{"FREQ=DAILY;COUNT=0", ""},
+		{"FREQ=DAILY;COUNT=2;UNTIL=20240101", ""},
+		{"FREQ=DAILY;UNTIL=2024-01-01", ""},
+		{"FREQ=DAILY;BYMONTH=1", ""},
+		{"FREQ=DAILY;COUNT=", ""},
+		{"FREQ=MONTHLY;BYDAY=0MO", ""},
+		{"FREQ=MONTHLY;BYDAY=XX", ""},
+		{"FREQ=MONTHLY;BYDAY=6MO", ""},
+		{"FREQ=WEEKLY;BYDAY=1MO", ""},
+		{"FREQ=YEARLY;BYDAY=54MO", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			r, err := Parse(tt.rule)
+			if tt.want == "" {
+				if !errors.Is(err, ErrInvalid) {
+					t.Fatalf("Parse(%q) = %v, %v; want ErrInvalid", tt.rule, r, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.rule, err)
+			}
+			if got := r.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  string
+		start string
+		from  string
+		n     int
+		want  []time.Time
+	}{
+		{
+			name: "daily", rule: "FREQ=DAILY;INTERVAL=3",
+			start: "2024-01-30", from: "2024-01-30", n: 3,
+			want: dates("2024-01-30", "2024-02-02", "2024-02-05"),
+		},
+		{
+			name: "weekly on the start weekday", rule: "FREQ=WEEKLY;INTERVAL=2",
+			start: "2024-01-01", from: "2024-01-02", n: 2,
+			want: dates("2024-01-15", "2024-01-29"),
+		},
+		{
+			name: "weekly by day skips days before start", rule: "FREQ=WEEKLY;BYDAY=MO,WE",
+			start: "2024-01-03", from: "2024-01-01", n: 3,
+			want: dates("2024-01-03", "2024-01-08", "2024-01-10"),
+		},
+		{
+			name: "daily by day", rule: "FREQ=DAILY;BYDAY=SA,SU",
+			start: "2024-01-01", from: "2024-01-01", n: 3,
+			want: dates("2024-01-06", "2024-01-07", "2024-01-13"),
+		},
+		{
+			name: "second monday", rule: "FREQ=MONTHLY;BYDAY=2MO",
+			start: "2024-01-01", from: "2024-01-01", n: 3,
+			want: dates("2024-01-08", "2024-02-12", "2024-03-11"),
+		},
+		{
+			name: "last friday", rule: "FREQ=MONTHLY;BYDAY=-1FR",
+			start: "2024-01-01", from: "2024-01-01", n: 3,
+			want: dates("2024-01-26", "2024-02-23", "2024-03-29"),
+		},
+		{
+			name: "fifth monday skips short months", rule: "FREQ=MONTHLY;BYDAY=5MO",
+			start: "2024-01-01", from: "2024-01-01", n: 3,
+			want: dates("2024-01-29", "2024-04-29", "2024-07-29"),
+		},
+		{
+			name: "first and last weekday of a month", rule: "FREQ=MONTHLY;BYDAY=1MO,-1MO",
+			start: "2024-02-01", from: "2024-02-01", n: 4,
+			want: dates("2024-02-05", "2024-02-26", "2024-03-04", "2024-03-25"),
+		},
+		{
+			name: "last sunday of the year", rule: "FREQ=YEARLY;BYDAY=-1SU",
+			start: "2024-01-01", from: "2024-01-01", n: 2,
+			want: dates("2024-12-29", "2025-12-28"),
+		},
+		{
+			name: "month end skips shorter months", rule: "FREQ=MONTHLY",
+			start: "2024-01-31", from: "2024-01-01", n: 4,
+			want: dates("2024-01-31", "2024-03-31", "2024-05-31", "2024-07-31"),
+		},
+		{
+			name: "month end with an interval", rule: "FREQ=MONTHLY;INTERVAL=2",
+			start: "2024-08-31", from: "2024-08-31", n: 3,
+			want: dates("2024-08-31", "2024-10-31", "2024-12-31"),
+		},
+		{
+			name: "leap day", rule: "FREQ=YEARLY",
+			start: "2024-02-29", from: "2024-03-01", n: 2,
+			want: dates("2028-02-29", "2032-02-29"),
+		},
+		{
+			name: "rare leap day still ends", rule: "FREQ=YEARLY;INTERVAL=3",
+			start: "2024-02-29", from: "2024-02-29", n: 3,
+			want: dates("2024-02-29", "2036-02-29", "2048-02-29"),
+		},
+		{
+			name: "count", rule: "FREQ=DAILY;COUNT=3",
+			start: "2024-01-01", from: "2024-01-01", n: 10,
+			want: dates("2024-01-01", "2024-01-02", "2024-01-03"),
+		},
+		{
+			name: "count includes occurrences before from", rule: "FREQ=DAILY;COUNT=3",
+			start: "2024-01-01", from: "2024-01-03", n: 10,
+			want: dates("2024-01-03"),
+		},
+		{
+			name: "count with ordinals", rule: "FREQ=MONTHLY;BYDAY=-1FR;COUNT=2",
+			start: "2024-01-27", from: "2024-01-01", n: 10,
+			want: dates("2024-02-23", "2024-03-29"),
+		},
+		{
+			name: "until is inclusive", rule: "FREQ=WEEKLY;UNTIL=20240115",
+			start: "2024-01-01", from: "2024-01-01", n: 10,
+			want: dates("2024-01-01", "2024-01-08", "2024-01-15"),
+		},
+		{
+			name: "until with a time", rule: "FREQ=DAILY;UNTIL=20240102T235959Z",
+			start: "2024-01-01", from: "2024-01-01", n: 10,
+			want: dates("2024-01-01", "2024-01-02"),
+		},
+		{
+			name: "after until", rule: "FREQ=DAILY;UNTIL=20240102",
+			start: "2024-01-01", from: "2024-01-03", n: 10,
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := mustParse(t, tt.rule)
+			got := r.Next(date(tt.start), date(tt.from), tt.n)
+			if !slices.EqualFunc(got, tt.want, time.Time.Equal) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     string
+		start    string
+		from, to string
+		n        int
+		want     []time.Time
+	}{
+		{
+			name: "inclusive bounds", rule: "FREQ=WEEKLY",
+			start: "2024-01-01", from: "2024-01-08", to: "2024-01-22", n: 10,
+			want: dates("2024-01-08", "2024-01-15", "2024-01-22"),
+		},
+		{
+			name: "stops after n", rule: "FREQ=DAILY",
+			start: "2024-01-01", from: "2024-01-01", to: "2024-12-31", n: 2,
+			want: dates("2024-01-01", "2024-01-02"),
+		},
+		{
+			name: "count ends the range", rule: "FREQ=DAILY;COUNT=5",
+			start: "2024-01-01", from: "2024-01-03", to: "2024-01-10", n: 10,
+			want: dates("2024-01-03", "2024-01-04", "2024-01-05"),
+		},
+		{
+			name: "month end", rule: "FREQ=MONTHLY",
+			start: "2024-01-30", from: "2024-02-01", to: "2024-04-30", n: 10,
+			want: dates("2024-03-30", "2024-04-30"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := mustParse(t, tt.rule)
+			got := r.Between(date(tt.start), date(tt.from), date(tt.to), tt.n)
+			if !slices.EqualFunc(got, tt.want, time.Time.Equal) {
+				t.Errorf("Between() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOccurs(t *testing.T) {
+	tests := []struct {
+		rule  string
+		start string
+		d     string
+		want  bool
+	}{
+		{"FREQ=MONTHLY;BYDAY=-1FR", "2024-01-01", "2024-02-23", true},
+		{"FREQ=MONTHLY;BYDAY=-1FR", "2024-01-01", "2024-02-16", false},
+		{"FREQ=MONTHLY", "2024-01-31", "2024-02-29", false},
+		{"FREQ=MONTHLY", "2024-01-31", "2024-03-31", true},
+		{"FREQ=DAILY;COUNT=3", "2024-01-01", "2024-01-03", true},
+		{"FREQ=DAILY;COUNT=3", "2024-01-01", "2024-01-04", false},
+		{"FREQ=WEEKLY", "2024-01-08", "2024-01-01", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule+" "+tt.d, func(t *testing.T) {
+			r := mustParse(t, tt.rule)
+			if got := r.Occurs(date(tt.start), date(tt.d)); got != tt.want {
+				t.Errorf("Occurs(%s) = %v, want %v", tt.d, got, tt.want)
+			}
+		})
+	}
+}
+
+// Before gives the COUNT already used up when a series is split at an
+// occurrence for this-and-following edits.
+func TestBefore(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  string
+		start string
+		d     string
+		want  int
+	}{
+		{"at the start", "FREQ=DAILY", "2024-01-01", "2024-01-01", 0},
+		{"before the start", "FREQ=DAILY", "2024-01-05", "2024-01-01", 0},
+		{"daily", "FREQ=DAILY", "2024-01-01", "2024-01-04", 3},
+		{"ignores the time of day", "FREQ=DAILY", "2024-01-01", "2024-01-04T23:00:00Z", 3},
+		{"between occurrences", "FREQ=WEEKLY", "2024-01-01", "2024-01-10", 2},
+		{"capped by count", "FREQ=DAILY;COUNT=2", "2024-01-01", "2024-01-10", 2},
+		{"capped by until", "FREQ=DAILY;UNTIL=20240103", "2024-01-01", "2024-01-10", 3},
+		{"month end", "FREQ=MONTHLY", "2024-01-31", "2024-06-01", 3},
+		{"ordinals", "FREQ=MONTHLY;BYDAY=2MO", "2024-01-01", "2024-03-11", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := mustParse(t, tt.rule)
+			d, err := time.Parse(time.RFC3339, tt.d)
+			if err != nil {
+				d = date(tt.d)
+			}
+			if got := r.Before(date(tt.start), d); got != tt.want {
+				t.Errorf("Before(%s) = %d, want %d", tt.d, got, tt.want)
+			}
+		})
+	}
+}
+
+// Splitting a series with Before and giving the rest the remaining COUNT
+// keeps the occurrences of the original rule.
+func TestBeforeSplit(t *testing.T) {
+	r := mustParse(t, "FREQ=MONTHLY;BYDAY=-1FR;COUNT=6")
+	start, split := date("2024-01-01"), date("2024-03-29")
+
+	all := r.Next(start, start, 10)
+	used := r.Before(start, split)
+	rest := *r
+	rest.Count -= used
+	tail := rest.Next(split, split, 10)
+
+	if got := append(slices.Clone(all[:used]), tail...); !slices.EqualFunc(got, all, time.Time.Equal) {
+		t.Errorf("split series = %v, want %v", got, all)
+	}
+}
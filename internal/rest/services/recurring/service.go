@@ -0,0 +1,39 @@
+package recurring
+
+import (
+	"github.com/rsmrtk/mybox/internal/rest/services/recurring/create"
+	"github.com/rsmrtk/mybox/internal/rest/services/recurring/delete"
+	"github.com/rsmrtk/mybox/internal/rest/services/recurring/get"
+	"github.com/rsmrtk/mybox/internal/rest/services/recurring/list"
+	"github.com/rsmrtk/mybox/internal/rest/services/recurring/preview"
+	"github.com/rsmrtk/mybox/internal/rest/services/recurring/skip"
+	"github.com/rsmrtk/mybox/internal/rest/services/recurring/split"
+	"github.com/rsmrtk/mybox/internal/rest/services/recurring/update"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Service is the recurring template service facade
+type Service struct {
+	Get     *get.Facade
+	List    *list.Facade
+	Create  *create.Facade
+	Update  *update.Facade
+	Delete  *delete.Facade
+	Preview *preview.Facade
+	Skip    *skip.Facade
+	Split   *split.Facade
+}
+
+// New creates a new recurring template service
+func New(f *pkg.Facade) *Service {
+	return &Service{
+		Get:     get.New(f),
+		List:    list.New(f),
+		Create:  create.New(f),
+		Update:  update.New(f),
+		Delete:  delete.New(f),
+		Preview: preview.New(f),
+		Skip:    skip.New(f),
+		Split:   split.New(f),
+	}
+}
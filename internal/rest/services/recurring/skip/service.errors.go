@@ -0,0 +1,21 @@
+package skip
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	RecurringNotFound     *err.HTTPError
+	InvalidRecurringID    *err.HTTPError
+	NotAnOccurrence       *err.HTTPError
+	OccurrenceHandled     *err.HTTPError
+	FailedToSkipRecurring *err.HTTPError
+}{
+	RecurringNotFound:     err.NewHTTPError(http.StatusNotFound, "Recurring template not found."),
+	InvalidRecurringID:    err.NewHTTPError(http.StatusBadRequest, "Invalid recurring template ID format."),
+	NotAnOccurrence:       err.NewHTTPError(http.StatusBadRequest, "Date is not an occurrence of the rule."),
+	OccurrenceHandled:     err.NewHTTPError(http.StatusConflict, "Occurrence was already recorded or skipped."),
+	FailedToSkipRecurring: err.NewHTTPError(http.StatusInternalServerError, "Failed to skip occurrence."),
+}
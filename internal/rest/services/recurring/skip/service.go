@@ -0,0 +1,66 @@
+package skip
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/recurring"
+	"github.com/rsmrtk/mybox/internal/rest/services/recurring/form"
+	"github.com/rsmrtk/mybox/internal/rest/services/recurring/rrule"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_recurring"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx context.Context
+	req *recurring.SkipRequest
+	f   *Facade
+}
+
+func (s *service) skip() error {
+	id, err := uuid.Parse(s.req.RecurringID)
+	if err != nil {
+		return errs.InvalidRecurringID
+	}
+
+	data, err := s.f.pkg.M.Recurring.Lock(s.ctx, utils.AuthCtx(s.ctx), id.String())
+	if errors.Is(err, m_recurring.ErrNotFound) {
+		return errs.RecurringNotFound
+	}
+	if err != nil {
+		return errs.FailedToSkipRecurring
+	}
+
+	rule, err := rrule.Parse(data.Rule)
+	if err != nil {
+		return errs.FailedToSkipRecurring
+	}
+	day := form.Day(s.req.OccursOn.Time)
+	if !rule.Occurs(data.StartsOn, day) {
+		return errs.NotAnOccurrence
+	}
+
+	err = s.f.pkg.M.Recurring.AddOccurrence(s.ctx, &m_recurring.Occurrence{
+		RecurringID: data.RecurringID,
+		OccursOn:    day,
+		Status:      m_recurring.StatusSkipped,
+		CreatedAt:   time.Now().UTC(),
+	})
+	if errors.Is(err, m_recurring.ErrOccurrenceHandled) {
+		return errs.OccurrenceHandled
+	}
+	if err != nil {
+		return errs.FailedToSkipRecurring
+	}
+
+	return nil
+}
+
+func (s *service) reply() *recurring.SkipResponse {
+	return &recurring.SkipResponse{
+		Success: true,
+		Message: "Occurrence skipped successfully",
+	}
+}
@@ -0,0 +1,41 @@
+package skip
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/recurring"
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/dbtx"
+)
+
+// Facade is the skip recurring occurrence facade
+type Facade struct {
+	pkg *pkg.Facade
+}
+
+// New creates a new skip recurring occurrence facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg: pkg,
+	}
+}
+
+// Handle handles the skip recurring occurrence request
+func (f *Facade) Handle(ctx context.Context, req *recurring.SkipRequest) (*recurring.SkipResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	// The template is locked so that the scheduler cannot materialize the occurrence meanwhile
+	err := dbtx.InTx(ctx, f.pkg.M.DB, func(ctx context.Context) error {
+		s.ctx = ctx
+		return s.skip()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
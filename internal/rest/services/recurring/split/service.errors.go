@@ -0,0 +1,39 @@
+package split
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	RecurringNotFound      *err.HTTPError
+	InvalidRecurringID     *err.HTTPError
+	NotAnOccurrence        *err.HTTPError
+	AlreadyRecorded        *err.HTTPError
+	InvalidStart           *err.HTTPError
+	InvalidName            *err.HTTPError
+	InvalidAmount          *err.HTTPError
+	TypeRequired           *err.HTTPError
+	InvalidRule            *err.HTTPError
+	NoOccurrences          *err.HTTPError
+	UnknownCategory        *err.HTTPError
+	UnknownAccount         *err.HTTPError
+	InvalidTag             *err.HTTPError
+	FailedToSplitRecurring *err.HTTPError
+}{
+	RecurringNotFound:      err.NewHTTPError(http.StatusNotFound, "Recurring template not found."),
+	InvalidRecurringID:     err.NewHTTPError(http.StatusBadRequest, "Invalid recurring template ID format."),
+	NotAnOccurrence:        err.NewHTTPError(http.StatusBadRequest, "Date is not an occurrence of the rule."),
+	AlreadyRecorded:        err.NewHTTPError(http.StatusConflict, "Occurrences from this date on were already recorded."),
+	InvalidStart:           err.NewHTTPError(http.StatusBadRequest, "The new start date must not be before the occurrence."),
+	InvalidName:            err.NewHTTPError(http.StatusBadRequest, "Name must not be blank."),
+	InvalidAmount:          err.NewHTTPError(http.StatusBadRequest, "Amount must be positive."),
+	TypeRequired:           err.NewHTTPError(http.StatusBadRequest, "Either a type or a category is required."),
+	InvalidRule:            err.NewHTTPError(http.StatusBadRequest, "Rule must be an RRULE with FREQ=DAILY, WEEKLY, MONTHLY or YEARLY and optionally INTERVAL, BYDAY and one of UNTIL or COUNT."),
+	NoOccurrences:          err.NewHTTPError(http.StatusBadRequest, "Rule has no occurrences from the start date on."),
+	UnknownCategory:        err.NewHTTPError(http.StatusBadRequest, "Category not found."),
+	UnknownAccount:         err.NewHTTPError(http.StatusBadRequest, "Account not found."),
+	InvalidTag:             err.NewHTTPError(http.StatusBadRequest, "Tags must be 1 to 64 characters and must not contain commas."),
+	FailedToSplitRecurring: err.NewHTTPError(http.StatusInternalServerError, "Failed to edit recurring template."),
+}
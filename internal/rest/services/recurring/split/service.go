@@ -0,0 +1,158 @@
+package split
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/recurring"
+	"github.com/rsmrtk/mybox/internal/rest/services/recurring/form"
+	"github.com/rsmrtk/mybox/internal/rest/services/recurring/rrule"
+	"github.com/rsmrtk/mybox/internal/rest/services/tag/name"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_account"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_category"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_recurring"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx      context.Context
+	req      *recurring.SplitRequest
+	f        *Facade
+	previous *m_recurring.Data // nil when the template was edited in place
+	next     *m_recurring.Data
+}
+
+func (s *service) split() error {
+	id, err := uuid.Parse(s.req.RecurringID)
+	if err != nil {
+		return errs.InvalidRecurringID
+	}
+
+	old, err := s.f.pkg.M.Recurring.Lock(s.ctx, utils.AuthCtx(s.ctx), id.String())
+	if errors.Is(err, m_recurring.ErrNotFound) {
+		return errs.RecurringNotFound
+	}
+	if err != nil {
+		return errs.FailedToSplitRecurring
+	}
+
+	rule, err := rrule.Parse(old.Rule)
+	if err != nil {
+		return errs.FailedToSplitRecurring
+	}
+	day := form.Day(s.req.OccursOn.Time)
+	if !rule.Occurs(old.StartsOn, day) {
+		return errs.NotAnOccurrence
+	}
+
+	handled, err := s.f.pkg.M.Recurring.Occurrences(s.ctx, old.RecurringID, day)
+	if err != nil {
+		return errs.FailedToSplitRecurring
+	}
+	for _, o := range handled {
+		if o.Status == m_recurring.StatusMaterialized {
+			return errs.AlreadyRecorded
+		}
+	}
+
+	// The following occurrences get what is left of a COUNT
+	before := rule.Before(old.StartsOn, day)
+	following := *rule
+	if following.Count > 0 {
+		following.Count -= before
+	}
+
+	next := *old
+	next.RecurringID = uuid.New().String()
+	next.Rule = following.String()
+	next.StartsOn = day
+	next.CreatedAt = time.Now().UTC()
+	form.Edit{
+		Name:       s.req.Name,
+		Amount:     s.req.Amount,
+		Type:       s.req.Type,
+		CategoryID: s.req.CategoryID,
+		AccountID:  s.req.AccountID,
+		Tags:       s.req.Tags,
+		Rule:       s.req.Rule,
+		StartsOn:   s.req.StartsOn,
+	}.Apply(&next)
+	if form.Day(next.StartsOn).Before(day) {
+		return errs.InvalidStart
+	}
+	s.next = &next
+
+	if err := s.check(); err != nil {
+		return err
+	}
+	if err := form.Schedule(s.next, s.next.StartsOn); err != nil {
+		return errs.FailedToSplitRecurring
+	}
+	if s.next.NextOn == nil {
+		return errs.NoOccurrences
+	}
+
+	// Nothing happened before the occurrence, so the template itself changes
+	if before == 0 {
+		s.next.RecurringID, s.next.CreatedAt = old.RecurringID, old.CreatedAt
+		if err := s.f.pkg.M.Recurring.Update(s.ctx, s.next); err != nil {
+			return errs.FailedToSplitRecurring
+		}
+		return nil
+	}
+
+	until := day.AddDate(0, 0, -1)
+	rule.Count, rule.Until = 0, &until
+	old.Rule = rule.String()
+	if old.NextOn != nil && !old.NextOn.Before(day) {
+		old.NextOn = nil
+	}
+	if err := s.f.pkg.M.Recurring.Update(s.ctx, old); err != nil {
+		return errs.FailedToSplitRecurring
+	}
+	s.previous = old
+
+	if err := s.f.pkg.M.Recurring.Create(s.ctx, s.next); err != nil {
+		return errs.FailedToSplitRecurring
+	}
+	if err := s.f.pkg.M.Recurring.MoveOccurrences(s.ctx, old.RecurringID, s.next.RecurringID, day); err != nil {
+		return errs.FailedToSplitRecurring
+	}
+
+	return nil
+}
+
+// check validates the new template.
+func (s *service) check() error {
+	err := form.Check(s.ctx, s.f.pkg, s.next)
+	switch {
+	case errors.Is(err, form.ErrInvalidName):
+		return errs.InvalidName
+	case errors.Is(err, form.ErrInvalidAmount):
+		return errs.InvalidAmount
+	case errors.Is(err, form.ErrTypeRequired):
+		return errs.TypeRequired
+	case errors.Is(err, rrule.ErrInvalid):
+		return errs.InvalidRule
+	case errors.Is(err, m_category.ErrNotFound):
+		return errs.UnknownCategory
+	case errors.Is(err, m_account.ErrNotFound):
+		return errs.UnknownAccount
+	case errors.Is(err, name.ErrInvalid):
+		return errs.InvalidTag
+	case err != nil:
+		return errs.FailedToSplitRecurring
+	}
+	return nil
+}
+
+func (s *service) reply() *recurring.SplitResponse {
+	res := &recurring.SplitResponse{Next: form.Convert(s.next)}
+	if s.previous != nil {
+		previous := form.Convert(s.previous)
+		res.Previous = &previous
+	}
+	return res
+}
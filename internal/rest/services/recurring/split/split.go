@@ -0,0 +1,41 @@
+package split
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/recurring"
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/dbtx"
+)
+
+// Facade is the split recurring template facade
+type Facade struct {
+	pkg *pkg.Facade
+}
+
+// New creates a new split recurring template facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg: pkg,
+	}
+}
+
+// Handle handles the split recurring template request
+func (f *Facade) Handle(ctx context.Context, req *recurring.SplitRequest) (*recurring.SplitResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	// Both templates and the moved occurrences are written together, with the template locked
+	err := dbtx.InTx(ctx, f.pkg.M.DB, func(ctx context.Context) error {
+		s.ctx = ctx
+		return s.split()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
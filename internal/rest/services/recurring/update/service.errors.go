@@ -0,0 +1,31 @@
+package update
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	RecurringNotFound       *err.HTTPError
+	InvalidRecurringID      *err.HTTPError
+	InvalidName             *err.HTTPError
+	InvalidAmount           *err.HTTPError
+	TypeRequired            *err.HTTPError
+	InvalidRule             *err.HTTPError
+	UnknownCategory         *err.HTTPError
+	UnknownAccount          *err.HTTPError
+	InvalidTag              *err.HTTPError
+	FailedToUpdateRecurring *err.HTTPError
+}{
+	RecurringNotFound:       err.NewHTTPError(http.StatusNotFound, "Recurring template not found."),
+	InvalidRecurringID:      err.NewHTTPError(http.StatusBadRequest, "Invalid recurring template ID format."),
+	InvalidName:             err.NewHTTPError(http.StatusBadRequest, "Name must not be blank."),
+	InvalidAmount:           err.NewHTTPError(http.StatusBadRequest, "Amount must be positive."),
+	TypeRequired:            err.NewHTTPError(http.StatusBadRequest, "Either a type or a category is required."),
+	InvalidRule:             err.NewHTTPError(http.StatusBadRequest, "Rule must be an RRULE with FREQ=DAILY, WEEKLY, MONTHLY or YEARLY and optionally INTERVAL, BYDAY and one of UNTIL or COUNT."),
+	UnknownCategory:         err.NewHTTPError(http.StatusBadRequest, "Category not found."),
+	UnknownAccount:          err.NewHTTPError(http.StatusBadRequest, "Account not found."),
+	InvalidTag:              err.NewHTTPError(http.StatusBadRequest, "Tags must be 1 to 64 characters and must not contain commas."),
+	FailedToUpdateRecurring: err.NewHTTPError(http.StatusInternalServerError, "Failed to update recurring template."),
+}
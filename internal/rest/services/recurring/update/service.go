@@ -0,0 +1,96 @@
+package update
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/recurring"
+	"github.com/rsmrtk/mybox/internal/rest/services/recurring/form"
+	"github.com/rsmrtk/mybox/internal/rest/services/recurring/rrule"
+	"github.com/rsmrtk/mybox/internal/rest/services/tag/name"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_account"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_category"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_recurring"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx  context.Context
+	req  *recurring.UpdateRequest
+	f    *Facade
+	data *m_recurring.Data
+}
+
+func (s *service) update() error {
+	id, err := uuid.Parse(s.req.RecurringID)
+	if err != nil {
+		return errs.InvalidRecurringID
+	}
+
+	s.data, err = s.f.pkg.M.Recurring.Lock(s.ctx, utils.AuthCtx(s.ctx), id.String())
+	if errors.Is(err, m_recurring.ErrNotFound) {
+		return errs.RecurringNotFound
+	}
+	if err != nil {
+		return errs.FailedToUpdateRecurring
+	}
+
+	form.Edit{
+		Name:       s.req.Name,
+		Amount:     s.req.Amount,
+		Type:       s.req.Type,
+		CategoryID: s.req.CategoryID,
+		AccountID:  s.req.AccountID,
+		Tags:       s.req.Tags,
+		Rule:       s.req.Rule,
+		StartsOn:   s.req.StartsOn,
+	}.Apply(s.data)
+
+	err = form.Check(s.ctx, s.f.pkg, s.data)
+	switch {
+	case errors.Is(err, form.ErrInvalidName):
+		return errs.InvalidName
+	case errors.Is(err, form.ErrInvalidAmount):
+		return errs.InvalidAmount
+	case errors.Is(err, form.ErrTypeRequired):
+		return errs.TypeRequired
+	case errors.Is(err, rrule.ErrInvalid):
+		return errs.InvalidRule
+	case errors.Is(err, m_category.ErrNotFound):
+		return errs.UnknownCategory
+	case errors.Is(err, m_account.ErrNotFound):
+		return errs.UnknownAccount
+	case errors.Is(err, name.ErrInvalid):
+		return errs.InvalidTag
+	case err != nil:
+		return errs.FailedToUpdateRecurring
+	}
+
+	// The new rule picks up after the last occurrence that was materialized
+	from := s.data.StartsOn
+	last, err := s.f.pkg.M.Recurring.LastMaterialized(s.ctx, s.data.RecurringID)
+	if err != nil {
+		return errs.FailedToUpdateRecurring
+	}
+	if last != nil && !last.Before(from) {
+		from = form.Day(*last).AddDate(0, 0, 1)
+	}
+	if err := form.Schedule(s.data, from); err != nil {
+		return errs.FailedToUpdateRecurring
+	}
+
+	err = s.f.pkg.M.Recurring.Update(s.ctx, s.data)
+	if errors.Is(err, m_recurring.ErrNotFound) {
+		return errs.RecurringNotFound
+	}
+	if err != nil {
+		return errs.FailedToUpdateRecurring
+	}
+
+	return nil
+}
+
+func (s *service) reply() *recurring.UpdateResponse {
+	return &recurring.UpdateResponse{Recurring: form.Convert(s.data)}
+}
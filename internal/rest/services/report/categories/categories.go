@@ -0,0 +1,35 @@
+package categories
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/report"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Facade is the category report facade
+type Facade struct {
+	pkg *pkg.Facade
+}
+
+// New creates a new category report facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg: pkg,
+	}
+}
+
+// Handle handles the category report request
+func (f *Facade) Handle(ctx context.Context, req *report.CategoriesRequest) (*report.CategoriesResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	if err := s.report(); err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
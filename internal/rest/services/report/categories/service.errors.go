@@ -0,0 +1,17 @@
+package categories
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	InvalidDirection    *err.HTTPError
+	InvalidDateRange    *err.HTTPError
+	FailedToBuildReport *err.HTTPError
+}{
+	InvalidDirection:    err.NewHTTPError(http.StatusBadRequest, "Direction must be income or expense."),
+	InvalidDateRange:    err.NewHTTPError(http.StatusBadRequest, "From must not be after to."),
+	FailedToBuildReport: err.NewHTTPError(http.StatusInternalServerError, "Failed to build category report."),
+}
@@ -0,0 +1,152 @@
+package categories
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/report"
+	"github.com/rsmrtk/mybox/internal/rest/services/category/tree"
+	"github.com/rsmrtk/mybox/internal/rest/services/record"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_transaction"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+// sums holds the totals of one category or of the uncategorized records,
+// per currency.
+type sums struct {
+	income, expense record.Totals
+	count           int
+}
+
+func newSums() *sums {
+	return &sums{income: record.Totals{}, expense: record.Totals{}}
+}
+
+func (a *sums) add(b *sums) {
+	a.income.Merge(b.income)
+	a.expense.Merge(b.expense)
+	a.count += b.count
+}
+
+type service struct {
+	ctx  context.Context
+	req  *report.CategoriesRequest
+	f    *Facade
+	tree *tree.Tree
+
+	own           map[string]*sums
+	rollup        map[string]*sums
+	uncategorized *sums
+}
+
+func (s *service) report() error {
+	switch s.req.Direction {
+	case "", record.DirectionIncome, record.DirectionExpense:
+	default:
+		return errs.InvalidDirection
+	}
+	if s.req.From != nil && s.req.To != nil && s.req.From.After(s.req.To.Time) {
+		return errs.InvalidDateRange
+	}
+
+	items, err := s.f.pkg.M.Category.List(s.ctx, utils.AuthCtx(s.ctx))
+	if err != nil {
+		return errs.FailedToBuildReport
+	}
+	s.tree = tree.New(items)
+
+	filter := m_transaction.Filter{Direction: s.req.Direction}
+	if s.req.From != nil {
+		filter.From = &s.req.From.Time
+	}
+	if s.req.To != nil {
+		// To is inclusive, so the range ends at the start of the next day
+		to := s.req.To.Time.AddDate(0, 0, 1)
+		filter.To = &to
+	}
+	totals, err := s.f.pkg.M.Transaction.TotalsByCategory(s.ctx, filter)
+	if err != nil {
+		return errs.FailedToBuildReport
+	}
+
+	s.own = map[string]*sums{}
+	s.uncategorized = newSums()
+	for _, t := range totals {
+		sum := newSums()
+		if t.Direction == record.DirectionIncome {
+			sum.income.Add(t.Currency, t.Amount)
+		} else {
+			sum.expense.Add(t.Currency, t.Amount)
+		}
+		sum.count = t.Count
+
+		if t.CategoryID == nil {
+			s.uncategorized.add(sum)
+			continue
+		}
+		// Records filed under another workspace's categories are not ours to report
+		if s.tree.Get(*t.CategoryID) == nil {
+			continue
+		}
+		if s.own[*t.CategoryID] == nil {
+			s.own[*t.CategoryID] = newSums()
+		}
+		s.own[*t.CategoryID].add(sum)
+	}
+
+	// Every category adds its own totals to itself and all its ancestors
+	s.rollup = map[string]*sums{}
+	for id, sum := range s.own {
+		for _, a := range s.tree.Ancestors(id) {
+			if s.rollup[a] == nil {
+				s.rollup[a] = newSums()
+			}
+			s.rollup[a].add(sum)
+		}
+	}
+
+	return nil
+}
+
+func (s *service) reply() *report.CategoriesResponse {
+	var items []*report.CategoryTotals
+	for _, d := range s.tree.Walk() {
+		if s.req.TopLevel && d.ParentID != nil {
+			continue
+		}
+		own, rollup := s.own[d.CategoryID], s.rollup[d.CategoryID]
+		if own == nil {
+			own = newSums()
+		}
+		if rollup == nil {
+			rollup = newSums()
+		}
+
+		c := s.tree.Convert(d)
+		items = append(items, &report.CategoryTotals{
+			CategoryID:    c.CategoryID,
+			ParentID:      c.ParentID,
+			Name:          c.Name,
+			Path:          c.Path,
+			Income:        own.income.Amounts(),
+			Expense:       own.expense.Amounts(),
+			Count:         own.count,
+			RollupIncome:  rollup.income.Amounts(),
+			RollupExpense: rollup.expense.Amounts(),
+			RollupCount:   rollup.count,
+		})
+	}
+	if items == nil {
+		items = []*report.CategoryTotals{}
+	}
+
+	return &report.CategoriesResponse{
+		From:  s.req.From,
+		To:    s.req.To,
+		Items: items,
+		Uncategorized: &report.UncategorizedTotals{
+			Income:  s.uncategorized.income.Amounts(),
+			Expense: s.uncategorized.expense.Amounts(),
+			Count:   s.uncategorized.count,
+		},
+	}
+}
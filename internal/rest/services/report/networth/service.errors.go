@@ -0,0 +1,13 @@
+package networth
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	FailedToBuildReport *err.HTTPError
+}{
+	FailedToBuildReport: err.NewHTTPError(http.StatusInternalServerError, "Failed to build net worth report."),
+}
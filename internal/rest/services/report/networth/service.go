@@ -0,0 +1,82 @@
+package networth
+
+import (
+	"context"
+	"slices"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+	"github.com/rsmrtk/mybox/internal/rest/domain/report"
+	"github.com/rsmrtk/mybox/internal/rest/services/record"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_account"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx      context.Context
+	req      *report.NetWorthRequest
+	f        *Facade
+	accounts []*m_account.Data
+}
+
+func (s *service) report() error {
+	workspaceID := utils.AuthCtx(s.ctx)
+
+	var err error
+	s.accounts, err = s.f.pkg.M.Account.List(s.ctx, workspaceID)
+	if err != nil {
+		return errs.FailedToBuildReport
+	}
+
+	// List gives the current balances; an as-of date replaces them
+	if s.req.AsOf != nil {
+		balances, err := s.f.pkg.M.Account.Balances(s.ctx, workspaceID, s.req.AsOf.Time.AddDate(0, 0, 1))
+		if err != nil {
+			return errs.FailedToBuildReport
+		}
+		for _, d := range s.accounts {
+			d.Balance = balances[d.AccountID]
+		}
+	}
+
+	return nil
+}
+
+func (s *service) reply() *report.NetWorthResponse {
+	accounts := make([]*report.AccountBalance, 0, len(s.accounts))
+	assets, liabilities := map[string]float64{}, map[string]float64{}
+	for _, d := range s.accounts {
+		accounts = append(accounts, &report.AccountBalance{
+			AccountID: d.AccountID,
+			Name:      d.Name,
+			Kind:      d.Kind,
+			Balance:   record.AmountsIn(d.Balance, d.Currency),
+		})
+		if d.Balance >= 0 {
+			assets[d.Currency] += d.Balance
+		} else {
+			liabilities[d.Currency] -= d.Balance
+		}
+	}
+
+	var currencies []string
+	for _, d := range s.accounts {
+		if !slices.Contains(currencies, d.Currency) {
+			currencies = append(currencies, d.Currency)
+		}
+	}
+	slices.Sort(currencies)
+
+	res := &report.NetWorthResponse{
+		AsOf:        s.req.AsOf,
+		Accounts:    accounts,
+		Assets:      []*models.Amount{},
+		Liabilities: []*models.Amount{},
+		NetWorth:    []*models.Amount{},
+	}
+	for _, c := range currencies {
+		res.Assets = append(res.Assets, record.AmountsIn(assets[c], c)...)
+		res.Liabilities = append(res.Liabilities, record.AmountsIn(liabilities[c], c)...)
+		res.NetWorth = append(res.NetWorth, record.AmountsIn(assets[c]-liabilities[c], c)...)
+	}
+	return res
+}
@@ -0,0 +1,24 @@
+package report
+
+import (
+	"github.com/rsmrtk/mybox/internal/rest/services/report/categories"
+	"github.com/rsmrtk/mybox/internal/rest/services/report/networth"
+	"github.com/rsmrtk/mybox/internal/rest/services/report/tags"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Service is the report service facade
+type Service struct {
+	Categories *categories.Facade
+	Tags       *tags.Facade
+	NetWorth   *networth.Facade
+}
+
+// New creates a new report service
+func New(f *pkg.Facade) *Service {
+	return &Service{
+		Categories: categories.New(f),
+		Tags:       tags.New(f),
+		NetWorth:   networth.New(f),
+	}
+}
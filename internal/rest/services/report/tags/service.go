@@ -0,0 +1,101 @@
+package tags
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/report"
+	"github.com/rsmrtk/mybox/internal/rest/services/record"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_tag"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_transaction"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+// sums holds the totals of one tag, per currency.
+type sums struct {
+	income, expense record.Totals
+	count           int
+}
+
+type service struct {
+	ctx  context.Context
+	req  *report.TagsRequest
+	f    *Facade
+	tags []*m_tag.Data
+	sums map[string]*sums
+}
+
+func (s *service) report() error {
+	switch s.req.Direction {
+	case "", record.DirectionIncome, record.DirectionExpense:
+	default:
+		return errs.InvalidDirection
+	}
+	if s.req.From != nil && s.req.To != nil && s.req.From.After(s.req.To.Time) {
+		return errs.InvalidDateRange
+	}
+
+	var err error
+	s.tags, err = s.f.pkg.M.Tag.List(s.ctx, utils.AuthCtx(s.ctx))
+	if err != nil {
+		return errs.FailedToBuildReport
+	}
+	ids := make([]string, 0, len(s.tags))
+	for _, t := range s.tags {
+		ids = append(ids, t.TagID)
+	}
+
+	filter := m_transaction.Filter{Direction: s.req.Direction}
+	if s.req.From != nil {
+		filter.From = &s.req.From.Time
+	}
+	if s.req.To != nil {
+		// To is inclusive, so the range ends at the start of the next day
+		to := s.req.To.Time.AddDate(0, 0, 1)
+		filter.To = &to
+	}
+	totals, err := s.f.pkg.M.Transaction.TotalsByTag(s.ctx, filter, ids)
+	if err != nil {
+		return errs.FailedToBuildReport
+	}
+
+	s.sums = map[string]*sums{}
+	for _, t := range totals {
+		sum := s.sums[t.TagID]
+		if sum == nil {
+			sum = &sums{income: record.Totals{}, expense: record.Totals{}}
+			s.sums[t.TagID] = sum
+		}
+		if t.Direction == record.DirectionIncome {
+			sum.income.Add(t.Currency, t.Amount)
+		} else {
+			sum.expense.Add(t.Currency, t.Amount)
+		}
+		sum.count += t.Count
+	}
+
+	return nil
+}
+
+func (s *service) reply() *report.TagsResponse {
+	items := make([]*report.TagTotals, 0, len(s.tags))
+
+	for _, t := range s.tags {
+		sum := s.sums[t.TagID]
+		if sum == nil {
+			sum = &sums{}
+		}
+		items = append(items, &report.TagTotals{
+			TagID:   t.TagID,
+			Name:    t.Name,
+			Income:  sum.income.Amounts(),
+			Expense: sum.expense.Amounts(),
+			Count:   sum.count,
+		})
+	}
+
+	return &report.TagsResponse{
+		From:  s.req.From,
+		To:    s.req.To,
+		Items: items,
+	}
+}
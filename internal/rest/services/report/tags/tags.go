@@ -0,0 +1,35 @@
+package tags
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/report"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Facade is the tag report facade
+type Facade struct {
+	pkg *pkg.Facade
+}
+
+// New creates a new tag report facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg: pkg,
+	}
+}
+
+// Handle handles the tag report request
+func (f *Facade) Handle(ctx context.Context, req *report.TagsRequest) (*report.TagsResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	if err := s.report(); err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
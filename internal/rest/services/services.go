@@ -1,8 +1,21 @@
 package services
 
 import (
+	"github.com/rsmrtk/mybox/internal/rest/services/account"
+	"github.com/rsmrtk/mybox/internal/rest/services/audit"
+	"github.com/rsmrtk/mybox/internal/rest/services/budget"
+	"github.com/rsmrtk/mybox/internal/rest/services/category"
+	"github.com/rsmrtk/mybox/internal/rest/services/duplicate"
 	"github.com/rsmrtk/mybox/internal/rest/services/expense"
+	"github.com/rsmrtk/mybox/internal/rest/services/goal"
+	"github.com/rsmrtk/mybox/internal/rest/services/imports"
 	"github.com/rsmrtk/mybox/internal/rest/services/income"
+	"github.com/rsmrtk/mybox/internal/rest/services/recurring"
+	"github.com/rsmrtk/mybox/internal/rest/services/report"
+	"github.com/rsmrtk/mybox/internal/rest/services/tag"
+	"github.com/rsmrtk/mybox/internal/rest/services/transaction"
+	"github.com/rsmrtk/mybox/internal/rest/services/transfer"
+	"github.com/rsmrtk/mybox/internal/rest/services/trash"
 	"github.com/rsmrtk/mybox/pkg"
 )
 
@@ -11,13 +24,39 @@ type Options struct {
 }
 
 type Services struct {
-	Income  *income.Service
-	Expense *expense.Service
+	Income      *income.Service
+	Expense     *expense.Service
+	Audit       *audit.Service
+	Trash       *trash.Service
+	Transaction *transaction.Service
+	Category    *category.Service
+	Report      *report.Service
+	Tag         *tag.Service
+	Account     *account.Service
+	Transfer    *transfer.Service
+	Recurring   *recurring.Service
+	Budget      *budget.Service
+	Goal        *goal.Service
+	Import      *imports.Service
+	Duplicate   *duplicate.Service
 }
 
 func NewService(opts Options) *Services {
 	return &Services{
-		Income:  income.NewService(opts.Pkg),
-		Expense: expense.New(opts.Pkg),
+		Income:      income.NewService(opts.Pkg),
+		Expense:     expense.New(opts.Pkg),
+		Audit:       audit.New(opts.Pkg),
+		Trash:       trash.New(opts.Pkg),
+		Transaction: transaction.New(opts.Pkg),
+		Category:    category.New(opts.Pkg),
+		Report:      report.New(opts.Pkg),
+		Tag:         tag.New(opts.Pkg),
+		Account:     account.New(opts.Pkg),
+		Transfer:    transfer.New(opts.Pkg),
+		Recurring:   recurring.New(opts.Pkg),
+		Budget:      budget.New(opts.Pkg),
+		Goal:        goal.New(opts.Pkg),
+		Import:      imports.New(opts.Pkg),
+		Duplicate:   duplicate.New(opts.Pkg),
 	}
 }
@@ -0,0 +1,96 @@
+// Package split validates and converts the split lines of expenses. The
+// expense create and update services share it so that both enforce the same
+// rules.
+package split
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/expense"
+	"github.com/rsmrtk/mybox/internal/rest/services/category"
+	"github.com/rsmrtk/mybox/internal/rest/services/record"
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_split"
+)
+
+var (
+	// ErrInvalidLine is returned for a line without a positive amount or
+	// with a note over 255 characters.
+	ErrInvalidLine = errors.New("invalid split line")
+	// ErrTotalMismatch is returned when the lines do not add up to the expense amount.
+	ErrTotalMismatch = errors.New("split lines do not add up to the amount")
+)
+
+// Lines validates split lines against the expense amount and resolves their
+// categories. Category errors are those of category.Resolve.
+func Lines(ctx context.Context, f *pkg.Facade, splits []*expense.Split, total float64) ([]*m_split.Data, error) {
+	lines := make([]*m_split.Data, 0, len(splits))
+	for _, sp := range splits {
+		if sp == nil || len(sp.Amount) == 0 || sp.Amount[0] == nil || sp.Amount[0].Amount <= 0 || len(sp.Note) > 255 {
+			return nil, ErrInvalidLine
+		}
+		d := &m_split.Data{
+			SplitID: uuid.New().String(),
+			Amount:  sp.Amount[0].Amount,
+		}
+		if sp.CategoryID != "" {
+			c, err := category.Resolve(ctx, f, sp.CategoryID)
+			if err != nil {
+				return nil, err
+			}
+			d.CategoryID = &c.CategoryID
+		}
+		if sp.Note != "" {
+			note := sp.Note
+			d.Note = &note
+		}
+		lines = append(lines, d)
+	}
+	if err := Check(lines, total); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// Check reports ErrTotalMismatch unless lines is empty or adds up to total
+// to the cent.
+func Check(lines []*m_split.Data, total float64) error {
+	if len(lines) == 0 {
+		return nil
+	}
+	var sum int64
+	for _, d := range lines {
+		sum += cents(d.Amount)
+	}
+	if sum != cents(total) {
+		return ErrTotalMismatch
+	}
+	return nil
+}
+
+// Convert returns the API form of split lines, whose amounts are in the
+// currency of their expense.
+func Convert(lines []*m_split.Data, currency string) []*expense.Split {
+	if len(lines) == 0 {
+		return nil
+	}
+	splits := make([]*expense.Split, 0, len(lines))
+	for _, d := range lines {
+		sp := &expense.Split{Amount: record.AmountsIn(d.Amount, currency)}
+		if d.CategoryID != nil {
+			sp.CategoryID = *d.CategoryID
+		}
+		if d.Note != nil {
+			sp.Note = *d.Note
+		}
+		splits = append(splits, sp)
+	}
+	return splits
+}
+
+func cents(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
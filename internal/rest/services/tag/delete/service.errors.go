@@ -0,0 +1,19 @@
+package delete
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	TagNotFound       *err.HTTPError
+	InvalidTagID      *err.HTTPError
+	TagInUse          *err.HTTPError
+	FailedToDeleteTag *err.HTTPError
+}{
+	TagNotFound:       err.NewHTTPError(http.StatusNotFound, "Tag not found."),
+	InvalidTagID:      err.NewHTTPError(http.StatusBadRequest, "Invalid tag ID format."),
+	TagInUse:          err.NewHTTPError(http.StatusConflict, "Tag is followed by savings goals; delete them or merge the tag into another first."),
+	FailedToDeleteTag: err.NewHTTPError(http.StatusInternalServerError, "Failed to delete tag."),
+}
@@ -0,0 +1,53 @@
+package delete
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/tag"
+	"github.com/rsmrtk/mybox/internal/rest/services/tag/retag"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_tag"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx context.Context
+	req *tag.DeleteRequest
+	f   *Facade
+}
+
+func (s *service) delete() error {
+	id, err := uuid.Parse(s.req.TagID)
+	if err != nil {
+		return errs.InvalidTagID
+	}
+
+	tracked, err := retag.Track(s.ctx, s.f.pkg, id.String())
+	if err != nil {
+		return errs.FailedToDeleteTag
+	}
+
+	// The join rows cascade, so the tag disappears from every record
+	err = s.f.pkg.M.Tag.Delete(s.ctx, utils.AuthCtx(s.ctx), id.String())
+	switch {
+	case errors.Is(err, m_tag.ErrNotFound):
+		return errs.TagNotFound
+	case errors.Is(err, m_tag.ErrInUse):
+		return errs.TagInUse
+	case err != nil:
+		return errs.FailedToDeleteTag
+	}
+
+	if err := tracked.Audit(s.ctx); err != nil {
+		return errs.FailedToDeleteTag
+	}
+	return nil
+}
+
+func (s *service) reply() *tag.DeleteResponse {
+	return &tag.DeleteResponse{
+		Success: true,
+		Message: "Tag deleted successfully",
+	}
+}
@@ -0,0 +1,35 @@
+package list
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/tag"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Facade is the list tags facade
+type Facade struct {
+	pkg *pkg.Facade
+}
+
+// New creates a new list tags facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg: pkg,
+	}
+}
+
+// Handle handles the list tags request
+func (f *Facade) Handle(ctx context.Context, req *tag.ListRequest) (*tag.ListResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	if err := s.list(); err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
@@ -0,0 +1,13 @@
+package list
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	FailedToListTags *err.HTTPError
+}{
+	FailedToListTags: err.NewHTTPError(http.StatusInternalServerError, "Failed to list tags."),
+}
@@ -0,0 +1,41 @@
+package list
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/tag"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_tag"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx   context.Context
+	req   *tag.ListRequest
+	f     *Facade
+	items []*m_tag.Data
+}
+
+func (s *service) list() error {
+	var err error
+	s.items, err = s.f.pkg.M.Tag.List(s.ctx, utils.AuthCtx(s.ctx))
+	if err != nil {
+		return errs.FailedToListTags
+	}
+
+	return nil
+}
+
+func (s *service) reply() *tag.ListResponse {
+	items := make([]*tag.Tag, 0, len(s.items))
+
+	for _, d := range s.items {
+		items = append(items, &tag.Tag{
+			TagID:     d.TagID,
+			Name:      d.Name,
+			Uses:      d.Uses,
+			CreatedAt: d.CreatedAt,
+		})
+	}
+
+	return &tag.ListResponse{Items: items}
+}
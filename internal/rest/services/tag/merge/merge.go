@@ -0,0 +1,41 @@
+package merge
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/tag"
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/dbtx"
+)
+
+// Facade is the merge tag facade
+type Facade struct {
+	pkg *pkg.Facade
+}
+
+// New creates a new merge tag facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg: pkg,
+	}
+}
+
+// Handle handles the merge tag request
+func (f *Facade) Handle(ctx context.Context, req *tag.MergeRequest) (*tag.MergeResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	// Both tags are read and the records moved in one transaction
+	err := dbtx.InTx(ctx, f.pkg.M.DB, func(ctx context.Context) error {
+		s.ctx = ctx
+		return s.merge()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
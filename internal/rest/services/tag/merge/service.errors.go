@@ -0,0 +1,19 @@
+package merge
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	TagNotFound       *err.HTTPError
+	InvalidTagID      *err.HTTPError
+	SameTag           *err.HTTPError
+	FailedToMergeTags *err.HTTPError
+}{
+	TagNotFound:       err.NewHTTPError(http.StatusNotFound, "Tag not found."),
+	InvalidTagID:      err.NewHTTPError(http.StatusBadRequest, "Invalid tag ID format."),
+	SameTag:           err.NewHTTPError(http.StatusBadRequest, "Source and target must be different tags."),
+	FailedToMergeTags: err.NewHTTPError(http.StatusInternalServerError, "Failed to merge tags."),
+}
@@ -0,0 +1,79 @@
+package merge
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/tag"
+	"github.com/rsmrtk/mybox/internal/rest/services/tag/retag"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_tag"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx    context.Context
+	req    *tag.MergeRequest
+	f      *Facade
+	target *m_tag.Data
+	moved  int
+}
+
+func (s *service) merge() error {
+	sourceID, err := uuid.Parse(s.req.SourceID)
+	if err != nil {
+		return errs.InvalidTagID
+	}
+	targetID, err := uuid.Parse(s.req.TargetID)
+	if err != nil {
+		return errs.InvalidTagID
+	}
+	if sourceID == targetID {
+		return errs.SameTag
+	}
+
+	workspaceID := utils.AuthCtx(s.ctx)
+	for _, id := range []uuid.UUID{sourceID, targetID} {
+		_, err := s.f.pkg.M.Tag.Find(s.ctx, workspaceID, id.String())
+		if errors.Is(err, m_tag.ErrNotFound) {
+			return errs.TagNotFound
+		}
+		if err != nil {
+			return errs.FailedToMergeTags
+		}
+	}
+
+	tracked, err := retag.Track(s.ctx, s.f.pkg, sourceID.String())
+	if err != nil {
+		return errs.FailedToMergeTags
+	}
+
+	s.moved, err = s.f.pkg.M.Tag.Merge(s.ctx, workspaceID, sourceID.String(), targetID.String())
+	if err != nil {
+		return errs.FailedToMergeTags
+	}
+
+	if err := tracked.Audit(s.ctx); err != nil {
+		return errs.FailedToMergeTags
+	}
+
+	// Read the target again for its new number of uses
+	s.target, err = s.f.pkg.M.Tag.Find(s.ctx, workspaceID, targetID.String())
+	if err != nil {
+		return errs.FailedToMergeTags
+	}
+
+	return nil
+}
+
+func (s *service) reply() *tag.MergeResponse {
+	return &tag.MergeResponse{
+		Target: tag.Tag{
+			TagID:     s.target.TagID,
+			Name:      s.target.Name,
+			Uses:      s.target.Uses,
+			CreatedAt: s.target.CreatedAt,
+		},
+		MovedRecords: s.moved,
+	}
+}
@@ -0,0 +1,41 @@
+// Package name normalizes tag names. Tags are matched by their stored name,
+// so every path that accepts a tag name goes through Clean.
+package name
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalid is returned for blank names, names over 64 characters and
+// names with commas, which separate tags in query strings.
+var ErrInvalid = errors.New("invalid tag name")
+
+// Clean returns the stored form of a tag name: trimmed and lower case.
+func Clean(s string) (string, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	if name == "" || utf8.RuneCountInString(name) > 64 || strings.Contains(name, ",") {
+		return "", ErrInvalid
+	}
+	return name, nil
+}
+
+// CleanAll cleans names, drops duplicates and sorts them.
+func CleanAll(names []string) ([]string, error) {
+	seen := map[string]bool{}
+	out := make([]string, 0, len(names))
+	for _, n := range names {
+		name, err := Clean(n)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[name] {
+			seen[name] = true
+			out = append(out, name)
+		}
+	}
+	sort.Strings(out)
+	return out, nil
+}
@@ -0,0 +1,41 @@
+package rename
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/tag"
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/dbtx"
+)
+
+// Facade is the rename tag facade
+type Facade struct {
+	pkg *pkg.Facade
+}
+
+// New creates a new rename tag facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg: pkg,
+	}
+}
+
+// Handle handles the rename tag request
+func (f *Facade) Handle(ctx context.Context, req *tag.RenameRequest) (*tag.RenameResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	// The tag is renamed on its records and audited in one transaction
+	err := dbtx.InTx(ctx, f.pkg.M.DB, func(ctx context.Context) error {
+		s.ctx = ctx
+		return s.rename()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
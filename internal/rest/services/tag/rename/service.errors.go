@@ -0,0 +1,21 @@
+package rename
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	TagNotFound       *err.HTTPError
+	InvalidTagID      *err.HTTPError
+	InvalidName       *err.HTTPError
+	DuplicateName     *err.HTTPError
+	FailedToRenameTag *err.HTTPError
+}{
+	TagNotFound:       err.NewHTTPError(http.StatusNotFound, "Tag not found."),
+	InvalidTagID:      err.NewHTTPError(http.StatusBadRequest, "Invalid tag ID format."),
+	InvalidName:       err.NewHTTPError(http.StatusBadRequest, "Tags must be 1 to 64 characters and must not contain commas."),
+	DuplicateName:     err.NewHTTPError(http.StatusConflict, "Another tag has this name; merge the tags instead."),
+	FailedToRenameTag: err.NewHTTPError(http.StatusInternalServerError, "Failed to rename tag."),
+}
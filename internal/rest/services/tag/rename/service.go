@@ -0,0 +1,69 @@
+package rename
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/tag"
+	"github.com/rsmrtk/mybox/internal/rest/services/tag/name"
+	"github.com/rsmrtk/mybox/internal/rest/services/tag/retag"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_tag"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx  context.Context
+	req  *tag.RenameRequest
+	f    *Facade
+	data *m_tag.Data
+}
+
+func (s *service) rename() error {
+	id, err := uuid.Parse(s.req.TagID)
+	if err != nil {
+		return errs.InvalidTagID
+	}
+	newName, err := name.Clean(s.req.Name)
+	if err != nil {
+		return errs.InvalidName
+	}
+
+	s.data, err = s.f.pkg.M.Tag.Find(s.ctx, utils.AuthCtx(s.ctx), id.String())
+	if errors.Is(err, m_tag.ErrNotFound) {
+		return errs.TagNotFound
+	}
+	if err != nil {
+		return errs.FailedToRenameTag
+	}
+
+	tracked, err := retag.Track(s.ctx, s.f.pkg, s.data.TagID)
+	if err != nil {
+		return errs.FailedToRenameTag
+	}
+
+	s.data.Name = newName
+	err = s.f.pkg.M.Tag.Rename(s.ctx, s.data)
+	switch {
+	case errors.Is(err, m_tag.ErrDuplicateName):
+		return errs.DuplicateName
+	case errors.Is(err, m_tag.ErrNotFound):
+		return errs.TagNotFound
+	case err != nil:
+		return errs.FailedToRenameTag
+	}
+
+	if err := tracked.Audit(s.ctx); err != nil {
+		return errs.FailedToRenameTag
+	}
+	return nil
+}
+
+func (s *service) reply() *tag.RenameResponse {
+	return &tag.RenameResponse{Tag: tag.Tag{
+		TagID:     s.data.TagID,
+		Name:      s.data.Name,
+		Uses:      s.data.Uses,
+		CreatedAt: s.data.CreatedAt,
+	}}
+}
@@ -0,0 +1,59 @@
+// Package retag audits the records whose tags change because a tag was
+// renamed, merged or deleted.
+package retag
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/rsmrtk/mybox/internal/rest/services/audit"
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_audit"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_trash"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+// entities are the audit entity types of the tagged kinds.
+var entities = map[m_trash.Kind]string{m_trash.Income: audit.EntityIncome, m_trash.Expense: audit.EntityExpense}
+
+// Retag holds the tags records had before a change of their tags.
+type Retag struct {
+	f      *pkg.Facade
+	before map[m_trash.Kind]map[string][]string
+}
+
+// Track keeps the tags of every record carrying one of tagIDs.
+func Track(ctx context.Context, f *pkg.Facade, tagIDs ...string) (*Retag, error) {
+	r := &Retag{f: f, before: map[m_trash.Kind]map[string][]string{}}
+	for kind := range entities {
+		ids, err := f.M.Tag.Matching(ctx, kind, tagIDs, false)
+		if err != nil {
+			return nil, err
+		}
+		r.before[kind], err = f.M.Tag.Tagged(ctx, kind, utils.AuthCtx(ctx), slices.Sorted(maps.Keys(ids)))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Audit records the tags each tracked record has now as an update of it.
+func (r *Retag) Audit(ctx context.Context) error {
+	for kind, entity := range entities {
+		ids := slices.Sorted(maps.Keys(r.before[kind]))
+		after, err := r.f.M.Tag.Tagged(ctx, kind, utils.AuthCtx(ctx), ids)
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			err := audit.Record(ctx, r.f, entity, id, m_audit.ActionUpdate,
+				audit.Snapshot{}.WithTags(r.before[kind][id]), audit.Snapshot{}.WithTags(after[id]))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package tag
+
+import (
+	"github.com/rsmrtk/mybox/internal/rest/services/tag/delete"
+	"github.com/rsmrtk/mybox/internal/rest/services/tag/list"
+	"github.com/rsmrtk/mybox/internal/rest/services/tag/merge"
+	"github.com/rsmrtk/mybox/internal/rest/services/tag/rename"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Service is the tag service facade
+type Service struct {
+	List   *list.Facade
+	Rename *rename.Facade
+	Delete *delete.Facade
+	Merge  *merge.Facade
+}
+
+// New creates a new tag service
+func New(f *pkg.Facade) *Service {
+	return &Service{
+		List:   list.New(f),
+		Rename: rename.New(f),
+		Delete: delete.New(f),
+		Merge:  merge.New(f),
+	}
+}
@@ -0,0 +1,107 @@
+package tag
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/rsmrtk/mybox/internal/rest/services/tag/name"
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_trash"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+var (
+	// ErrInvalidName is returned for names that name.Clean rejects.
+	ErrInvalidName = name.ErrInvalid
+	// ErrNoWorkspace is returned when an unauthenticated request uses tags.
+	ErrNoWorkspace = errors.New("tags require a workspace")
+)
+
+// Apply replaces the tags of a record, creating tags that do not exist yet.
+// It returns the normalized names.
+func Apply(ctx context.Context, f *pkg.Facade, kind m_trash.Kind, id string, names []string) ([]string, error) {
+	names, err := name.CleanAll(names)
+	if err != nil {
+		return nil, err
+	}
+	workspaceID, ok := utils.AuthLookup(ctx)
+	if !ok {
+		return nil, ErrNoWorkspace
+	}
+
+	tags, err := f.M.Tag.Ensure(ctx, workspaceID, names)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(tags))
+	for _, t := range tags {
+		ids = append(ids, t.TagID)
+	}
+	if err := f.M.Tag.Set(ctx, kind, id, workspaceID, ids); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// Of returns the tag names of the given records keyed by record ID. Without
+// a workspace there are no tags to show.
+func Of(ctx context.Context, f *pkg.Facade, kind m_trash.Kind, ids []string) (map[string][]string, error) {
+	workspaceID, ok := utils.AuthLookup(ctx)
+	if !ok {
+		return map[string][]string{}, nil
+	}
+	return f.M.Tag.Tagged(ctx, kind, workspaceID, ids)
+}
+
+// Resolve turns the tag names of a list filter into tag IDs. The result is
+// never nil: a filter that cannot match anything yields an empty slice, so
+// that callers filter everything out rather than nothing.
+func Resolve(ctx context.Context, f *pkg.Facade, names []string, all bool) ([]string, error) {
+	names, err := name.CleanAll(names)
+	if err != nil {
+		return nil, err
+	}
+	workspaceID, ok := utils.AuthLookup(ctx)
+	if !ok {
+		return []string{}, nil
+	}
+
+	tags, err := f.M.Tag.Lookup(ctx, workspaceID, names)
+	if err != nil {
+		return nil, err
+	}
+	if all && len(tags) < len(names) {
+		return []string{}, nil
+	}
+	ids := make([]string, 0, len(tags))
+	for _, t := range tags {
+		ids = append(ids, t.TagID)
+	}
+	return ids, nil
+}
+
+// Split parses a comma separated tags query parameter.
+func Split(param string) []string {
+	if param == "" {
+		return nil
+	}
+	return strings.Split(param, ",")
+}
+
+// Values of the tag_mode list parameter.
+const (
+	ModeAny = "any"
+	ModeAll = "all"
+)
+
+// MatchAll parses a tag_mode parameter; an empty mode means ModeAny.
+func MatchAll(mode string) (all bool, ok bool) {
+	switch mode {
+	case "", ModeAny:
+		return false, true
+	case ModeAll:
+		return true, true
+	}
+	return false, false
+}
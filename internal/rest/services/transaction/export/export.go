@@ -0,0 +1,35 @@
+package export
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/transaction"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Facade is the export transactions facade
+type Facade struct {
+	pkg *pkg.Facade
+}
+
+// New creates a new export transactions facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg: pkg,
+	}
+}
+
+// Handle handles the export transactions request
+func (f *Facade) Handle(ctx context.Context, req *transaction.ExportRequest) (*transaction.ExportResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	if err := s.export(); err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
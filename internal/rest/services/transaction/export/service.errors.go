@@ -0,0 +1,25 @@
+package export
+
+import (
+	"fmt"
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	InvalidFormat  *err.HTTPError
+	InvalidSortBy  *err.HTTPError
+	InvalidOrder   *err.HTTPError
+	FailedToExport *err.HTTPError
+}{
+	InvalidFormat:  err.NewHTTPError(http.StatusBadRequest, "Format must be csv, jsonl, xlsx, beancount or ledger."),
+	InvalidSortBy:  err.NewHTTPError(http.StatusBadRequest, "Sort by must be date, amount, name, type or created_at."),
+	InvalidOrder:   err.NewHTTPError(http.StatusBadRequest, "Order must be asc or desc."),
+	FailedToExport: err.NewHTTPError(http.StatusInternalServerError, "Failed to export transactions."),
+}
+
+// invalidAccount reports a mapped account the journal format cannot hold.
+func invalidAccount(account, format string) *err.HTTPError {
+	return err.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Account %q is not a valid %s account name.", account, format))
+}
@@ -0,0 +1,461 @@
+package export
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+	"github.com/rsmrtk/mybox/internal/rest/domain/transaction"
+	"github.com/rsmrtk/mybox/internal/rest/services/record"
+	"github.com/rsmrtk/mybox/internal/rest/services/tag"
+	"github.com/rsmrtk/mybox/internal/rest/services/transaction/filter"
+	"github.com/rsmrtk/mybox/internal/rest/services/transaction/journalfile"
+	"github.com/rsmrtk/mybox/internal/rest/services/transaction/xlsxfile"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_account"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_category"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_split"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_transaction"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_trash"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+// chunk is how many rows are held at a time to look up their tags and, for
+// journals, their split lines.
+const chunk = 500
+
+var contentTypes = map[string]string{
+	transaction.FormatCSV:   "text/csv; charset=utf-8",
+	transaction.FormatJSONL: "application/x-ndjson",
+	transaction.FormatXLSX:  xlsxfile.ContentType,
+
+	transaction.FormatBeancount: "text/plain; charset=utf-8",
+	transaction.FormatLedger:    "text/plain; charset=utf-8",
+}
+
+var csvHeader = []string{
+	"direction", "transaction_id", "date", "name", "type", "amount", "currency",
+	"category_id", "account_id", "tags", "created_at", "version",
+}
+
+var sheetHeader = []string{
+	"Date", "Name", "Type", "Amount", "Currency", "Category ID", "Account ID", "Tags", "ID",
+}
+
+type service struct {
+	ctx        context.Context
+	req        *transaction.ExportRequest
+	f          *Facade
+	query      m_transaction.Filter
+	accounts   map[string]*m_account.Data
+	categories map[string]*m_category.Data
+	mapping    transaction.LedgerMapping
+}
+
+func (s *service) export() error {
+	if s.req.Format == "" {
+		s.req.Format = transaction.FormatCSV
+	}
+	if s.req.SortBy == "" {
+		s.req.SortBy = "date"
+	}
+	if s.req.Order == "" {
+		s.req.Order = "desc" // Newest first, as the list
+	}
+
+	if _, ok := contentTypes[s.req.Format]; !ok {
+		return errs.InvalidFormat
+	}
+	if journal(s.req.Format) {
+		// Journals read best oldest first
+		s.req.SortBy, s.req.Order = "date", "asc"
+		if err := s.checkMapping(); err != nil {
+			return err
+		}
+	}
+	if !slices.Contains(m_transaction.SortColumns, s.req.SortBy) {
+		return errs.InvalidSortBy
+	}
+	if s.req.Order != "asc" && s.req.Order != "desc" {
+		return errs.InvalidOrder
+	}
+
+	var err error
+	s.query, err = filter.Build(s.ctx, s.f.pkg, &s.req.Filter)
+	if err != nil {
+		return filter.Error(err, errs.FailedToExport)
+	}
+	s.query.SortBy, s.query.Desc = s.req.SortBy, s.req.Order == "desc"
+
+	// Journals name the accounts records were paid into or from, and the
+	// categories of split lines
+	s.accounts = map[string]*m_account.Data{}
+	s.categories = map[string]*m_category.Data{}
+	if workspaceID, ok := utils.AuthLookup(s.ctx); ok && journal(s.req.Format) {
+		accounts, err := s.f.pkg.M.Account.List(s.ctx, workspaceID)
+		if err != nil {
+			return errs.FailedToExport
+		}
+		for _, a := range accounts {
+			s.accounts[a.AccountID] = a
+		}
+		categories, err := s.f.pkg.M.Category.List(s.ctx, workspaceID)
+		if err != nil {
+			return errs.FailedToExport
+		}
+		for _, c := range categories {
+			s.categories[c.CategoryID] = c
+		}
+	}
+
+	return nil
+}
+
+func journal(format string) bool {
+	return format == transaction.FormatBeancount || format == transaction.FormatLedger
+}
+
+// checkMapping rejects mapped accounts the journal format cannot hold.
+func (s *service) checkMapping() error {
+	if s.req.Mapping != nil {
+		s.mapping = *s.req.Mapping
+	}
+	accounts := slices.Concat(
+		slices.Collect(maps.Values(s.mapping.Categories)),
+		slices.Collect(maps.Values(s.mapping.Types)),
+		slices.Collect(maps.Values(s.mapping.Accounts)),
+	)
+	if s.mapping.Cash != "" {
+		accounts = append(accounts, s.mapping.Cash)
+	}
+	for _, a := range accounts {
+		if !journalfile.Valid(s.req.Format, a) {
+			return invalidAccount(a, s.req.Format)
+		}
+	}
+	return nil
+}
+
+func (s *service) reply() *transaction.ExportResponse {
+	return &transaction.ExportResponse{
+		ContentType: contentTypes[s.req.Format],
+		FileName:    fmt.Sprintf("transactions-%s.%s", time.Now().UTC().Format(models.DateLayout), s.req.Format),
+		Write: func(w io.Writer) error {
+			var err error
+			switch s.req.Format {
+			case transaction.FormatJSONL:
+				err = s.writeJSONL(w)
+			case transaction.FormatXLSX:
+				err = s.writeXLSX(w)
+			case transaction.FormatBeancount, transaction.FormatLedger:
+				err = s.writeJournal(w)
+			default:
+				err = s.writeCSV(w)
+			}
+			if err != nil {
+				return errs.FailedToExport
+			}
+			return nil
+		},
+	}
+}
+
+// row is a record as exported, with its amount cut to cents and its
+// currency, which is DefaultCurrency for records outside an account.
+type row struct {
+	*record.Record
+	Amount   *models.Amount
+	Currency string
+	Tags     []string
+	Splits   []*m_split.Data // only read for journals
+}
+
+// each calls fn for every record matching query. Records are held in chunks
+// so that their tags are looked up together.
+func (s *service) each(query m_transaction.Filter, fn func(r *row) error) error {
+	buf := make([]*record.Record, 0, chunk)
+	flush := func() error {
+		// Tags are looked up per record type; IDs do not collide across the two
+		byKind := map[m_trash.Kind][]string{}
+		for _, r := range buf {
+			kind := m_trash.Kind(r.Direction)
+			byKind[kind] = append(byKind[kind], r.ID)
+		}
+		tags := map[string][]string{}
+		for kind, ids := range byKind {
+			t, err := tag.Of(s.ctx, s.f.pkg, kind, ids)
+			if err != nil {
+				return err
+			}
+			maps.Copy(tags, t)
+		}
+		splits := map[string][]*m_split.Data{}
+		if journal(s.req.Format) {
+			var err error
+			if splits, err = s.f.pkg.M.Split.List(s.ctx, byKind[m_trash.Expense]); err != nil {
+				return err
+			}
+		}
+
+		for _, r := range buf {
+			row := &row{Record: r, Amount: r.Amounts()[0], Currency: r.CurrencyCode(), Tags: tags[r.ID], Splits: splits[r.ID]}
+			if err := fn(row); err != nil {
+				return err
+			}
+		}
+		buf = buf[:0]
+		return nil
+	}
+
+	err := s.f.pkg.M.Transaction.Each(s.ctx, query, func(d *m_transaction.Data) error {
+		buf = append(buf, record.FromTransaction(d))
+		if len(buf) == chunk {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return flush()
+}
+
+func (s *service) writeCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	err := s.each(s.query, func(r *row) error {
+		return cw.Write([]string{
+			r.Direction,
+			r.ID,
+			r.Date.Format(models.DateLayout),
+			csvText(r.Name),
+			csvText(r.Type),
+			strconv.FormatFloat(r.Amount.Amount, 'f', 2, 64),
+			r.Currency,
+			r.CategoryID,
+			r.AccountID,
+			csvText(strings.Join(r.Tags, ",")),
+			r.CreatedAt.Format(models.DateLayout),
+			strconv.FormatInt(r.Version, 10),
+		})
+	})
+	if err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvText keeps spreadsheets from running user text as a formula: a
+// value starting like one gets an apostrophe in front, which spreadsheets
+// take as a mark for text and do not show.
+func csvText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// writeJSONL writes a line per record in the form of the list items.
+func (s *service) writeJSONL(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	return s.each(s.query, func(r *row) error {
+		return enc.Encode(&transaction.ListItem{
+			TransactionID: r.ID,
+			Direction:     r.Direction,
+			Name:          r.Name,
+			Amount:        []*models.Amount{r.Amount},
+			Type:          r.Type,
+			Date:          models.NewDate(r.Date),
+			CreatedAt:     models.NewDate(r.CreatedAt),
+			CategoryID:    r.CategoryID,
+			AccountID:     r.AccountID,
+			Tags:          r.Tags,
+			Version:       r.Version,
+		})
+	})
+}
+
+// total is the count and sum of one direction in one currency.
+type total struct {
+	count int
+	sum   float64
+}
+
+// writeXLSX writes incomes and expenses to sheets of their own, followed by
+// a summary of both per currency. A direction the filter leaves out keeps
+// an empty sheet.
+func (s *service) writeXLSX(w io.Writer) error {
+	x, err := xlsxfile.New(w, "Incomes", "Expenses", "Summary")
+	if err != nil {
+		return err
+	}
+
+	totals := map[string]map[string]*total{}
+	for _, direction := range []string{record.DirectionIncome, record.DirectionExpense} {
+		if err := x.Next(sheetHeader...); err != nil {
+			return err
+		}
+		if s.query.Direction != "" && s.query.Direction != direction {
+			continue
+		}
+		query := s.query
+		query.Direction = direction
+		totals[direction] = map[string]*total{}
+		err := s.each(query, func(r *row) error {
+			t := totals[direction][r.Currency]
+			if t == nil {
+				t = &total{}
+				totals[direction][r.Currency] = t
+			}
+			t.count++
+			t.sum += r.Amount.Amount
+			return x.Row(
+				xlsxfile.Date(r.Date),
+				xlsxfile.String(r.Name),
+				xlsxfile.String(r.Type),
+				xlsxfile.Amount(r.Amount.Amount),
+				xlsxfile.String(r.Currency),
+				xlsxfile.String(r.CategoryID),
+				xlsxfile.String(r.AccountID),
+				xlsxfile.String(strings.Join(r.Tags, ", ")),
+				xlsxfile.String(r.ID),
+			)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := x.Next("Currency", "Incomes", "Income total", "Expenses", "Expense total", "Net"); err != nil {
+		return err
+	}
+	currencies := map[string]bool{}
+	for _, byCurrency := range totals {
+		for currency := range byCurrency {
+			currencies[currency] = true
+		}
+	}
+	for _, currency := range slices.Sorted(maps.Keys(currencies)) {
+		income, expense := &total{}, &total{}
+		if t := totals[record.DirectionIncome][currency]; t != nil {
+			income = t
+		}
+		if t := totals[record.DirectionExpense][currency]; t != nil {
+			expense = t
+		}
+		err := x.Row(
+			xlsxfile.String(currency),
+			xlsxfile.Number(float64(income.count)),
+			xlsxfile.Amount(cents(income.sum)),
+			xlsxfile.Number(float64(expense.count)),
+			xlsxfile.Amount(cents(expense.sum)),
+			xlsxfile.Amount(cents(income.sum-expense.sum)),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return x.Close()
+}
+
+// writeJournal writes a transaction per record, moving its amount between
+// its income or expense account and the account it was paid into or from.
+// A split expense takes a posting per split line instead, each into the
+// account of the line's category. The record's ID and type go along as
+// metadata.
+func (s *service) writeJournal(w io.Writer) error {
+	j, err := journalfile.New(w, s.req.Format)
+	if err != nil {
+		return err
+	}
+	err = s.each(s.query, func(r *row) error {
+		// Money comes out of an income account and goes into an expense account
+		amount := r.Amount.Amount
+		if r.Direction == record.DirectionIncome {
+			amount = -amount
+		}
+		var postings []journalfile.Posting
+		for _, line := range r.Splits {
+			postings = append(postings, journalfile.Posting{
+				Account: s.splitAccount(r.Record, line), Amount: cents(line.Amount), Currency: r.Currency,
+			})
+		}
+		if len(postings) == 0 {
+			postings = append(postings, journalfile.Posting{Account: s.categoryAccount(r.Record), Amount: amount, Currency: r.Currency})
+		}
+		return j.Write(&journalfile.Transaction{
+			Date:     r.Date,
+			Name:     r.Name,
+			Tags:     r.Tags,
+			Meta:     []journalfile.Meta{{Key: "id", Value: r.ID}, {Key: "type", Value: r.Type}},
+			Postings: append(postings, journalfile.Posting{Account: s.fundsAccount(r.Record), Amount: -amount, Currency: r.Currency}),
+		})
+	})
+	if err != nil {
+		return err
+	}
+	return j.Flush()
+}
+
+// categoryAccount returns the income or expense account of a record.
+func (s *service) categoryAccount(r *record.Record) string {
+	if a, ok := s.mapping.Categories[r.CategoryID]; ok && r.CategoryID != "" {
+		return a
+	}
+	if a, ok := s.mapping.Types[r.Type]; ok {
+		return a
+	}
+	if r.Direction == record.DirectionIncome {
+		return journalfile.Account("Income", r.Type)
+	}
+	return journalfile.Account("Expenses", r.Type)
+}
+
+// splitAccount returns the expense account of a split line: that of its
+// category or, for a line without one, that of the expense.
+func (s *service) splitAccount(r *record.Record, line *m_split.Data) string {
+	if line.CategoryID == nil {
+		return s.categoryAccount(r)
+	}
+	if a, ok := s.mapping.Categories[*line.CategoryID]; ok {
+		return a
+	}
+	if c := s.categories[*line.CategoryID]; c != nil {
+		return journalfile.Account("Expenses", c.Name)
+	}
+	return s.categoryAccount(r)
+}
+
+// fundsAccount returns the asset or liability account a record was paid
+// into or from.
+func (s *service) fundsAccount(r *record.Record) string {
+	if a, ok := s.mapping.Accounts[r.AccountID]; ok && r.AccountID != "" {
+		return a
+	}
+	if a := s.accounts[r.AccountID]; a != nil {
+		if a.Kind == m_account.KindCreditCard {
+			return journalfile.Account("Liabilities", a.Name)
+		}
+		return journalfile.Account("Assets", a.Name)
+	}
+	if s.mapping.Cash != "" {
+		return s.mapping.Cash
+	}
+	return "Assets:Cash"
+}
+
+// cents rounds away the float error a sum of amounts collects.
+func cents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
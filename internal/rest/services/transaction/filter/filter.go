@@ -0,0 +1,127 @@
+// Package filter turns the filters shared by the transaction list and the
+// export into a model filter.
+package filter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	er "github.com/rsmrtk/fd-er"
+	"github.com/rsmrtk/mybox/internal/rest/domain/transaction"
+	"github.com/rsmrtk/mybox/internal/rest/services/account"
+	"github.com/rsmrtk/mybox/internal/rest/services/category"
+	"github.com/rsmrtk/mybox/internal/rest/services/record"
+	"github.com/rsmrtk/mybox/internal/rest/services/tag"
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_account"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_category"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_transaction"
+)
+
+var errs = struct {
+	InvalidDirection   *er.HTTPError
+	InvalidDateRange   *er.HTTPError
+	InvalidAmountRange *er.HTTPError
+	UnknownCategory    *er.HTTPError
+	UnknownAccount     *er.HTTPError
+	InvalidTag         *er.HTTPError
+	InvalidTagMode     *er.HTTPError
+}{
+	InvalidDirection:   er.NewHTTPError(http.StatusBadRequest, "Direction must be income or expense."),
+	InvalidDateRange:   er.NewHTTPError(http.StatusBadRequest, "From must not be after to."),
+	InvalidAmountRange: er.NewHTTPError(http.StatusBadRequest, "Min amount must not be greater than max amount."),
+	UnknownCategory:    er.NewHTTPError(http.StatusBadRequest, "Category not found."),
+	UnknownAccount:     er.NewHTTPError(http.StatusBadRequest, "Account not found."),
+	InvalidTag:         er.NewHTTPError(http.StatusBadRequest, "Tags must be 1 to 64 characters and must not contain commas."),
+	InvalidTagMode:     er.NewHTTPError(http.StatusBadRequest, "Tag mode must be any or all."),
+}
+
+// Build validates a filter and resolves its category, account and tags.
+// Rejected filters are reported as HTTP errors, lookups that fail as plain
+// errors; see Error. Sorting and paging are left to the caller.
+func Build(ctx context.Context, f *pkg.Facade, req *transaction.Filter) (m_transaction.Filter, error) {
+	var filter m_transaction.Filter
+
+	switch req.Direction {
+	case "", record.DirectionIncome, record.DirectionExpense:
+	default:
+		return filter, errs.InvalidDirection
+	}
+	if req.From != nil && req.To != nil && req.From.After(req.To.Time) {
+		return filter, errs.InvalidDateRange
+	}
+	if req.MinAmount != nil && req.MaxAmount != nil && *req.MinAmount > *req.MaxAmount {
+		return filter, errs.InvalidAmountRange
+	}
+
+	filter = m_transaction.Filter{
+		Direction: req.Direction,
+		Type:      req.Type,
+		Search:    req.Search,
+		MinAmount: req.MinAmount,
+		MaxAmount: req.MaxAmount,
+	}
+	if req.From != nil {
+		filter.From = &req.From.Time
+	}
+	if req.To != nil {
+		// To is inclusive, so the range ends at the start of the next day
+		to := req.To.Time.AddDate(0, 0, 1)
+		filter.To = &to
+	}
+
+	if req.Category != "" {
+		c, err := category.Resolve(ctx, f, req.Category)
+		if errors.Is(err, m_category.ErrNotFound) {
+			return filter, errs.UnknownCategory
+		}
+		if err != nil {
+			return filter, err
+		}
+		// A category filter takes in the subcategories
+		filter.CategoryIDs, err = f.M.Category.Descendants(ctx, c.WorkspaceID, c.CategoryID)
+		if err != nil {
+			return filter, err
+		}
+	}
+
+	if req.Account != "" {
+		a, err := account.Resolve(ctx, f, req.Account)
+		if errors.Is(err, m_account.ErrNotFound) {
+			return filter, errs.UnknownAccount
+		}
+		if err != nil {
+			return filter, err
+		}
+		filter.AccountID = a.AccountID
+	}
+
+	if req.Tags != nil {
+		all, ok := tag.MatchAll(req.TagMode)
+		if !ok {
+			return filter, errs.InvalidTagMode
+		}
+		ids, err := tag.Resolve(ctx, f, req.Tags, all)
+		if errors.Is(err, tag.ErrInvalidName) {
+			return filter, errs.InvalidTag
+		}
+		if err != nil {
+			return filter, fmt.Errorf("failed to resolve tags: %w", err)
+		}
+		filter.TagIDs, filter.AllTags = ids, all
+	}
+
+	return filter, nil
+}
+
+// Error returns err when Build rejected the filter, and failed when a lookup
+// went wrong.
+func Error(err error, failed *er.HTTPError) error {
+	var herr *er.HTTPError
+	if errors.As(err, &herr) {
+		return herr
+	}
+	return failed
+}
@@ -0,0 +1,227 @@
+// Package journalfile writes incomes and expenses as the balanced
+// transactions of a plain text accounting journal, in the syntax of
+// Beancount or of Ledger, which hledger reads as well. Transactions are
+// written as they come, so a journal of any length is written in constant
+// memory.
+package journalfile
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Formats of a journal.
+const (
+	Beancount = "beancount"
+	Ledger    = "ledger"
+)
+
+// Roots are the top-level accounts Beancount accepts.
+var Roots = []string{"Assets", "Liabilities", "Equity", "Income", "Expenses"}
+
+// Posting moves an amount into or, when negative, out of an account.
+type Posting struct {
+	Account  string
+	Amount   float64
+	Currency string
+}
+
+// Meta is a key and value attached to a transaction.
+type Meta struct {
+	Key   string
+	Value string
+}
+
+// Transaction is one entry of the journal. Its postings should add up to
+// zero in each currency.
+type Transaction struct {
+	Date     time.Time
+	Name     string
+	Tags     []string
+	Meta     []Meta
+	Postings []Posting
+}
+
+// Writer writes the transactions of a journal one after the other.
+type Writer struct {
+	w      *bufio.Writer
+	format string
+}
+
+// New starts a journal in format. A Beancount journal opens its accounts
+// through the auto_accounts plugin, as they are only known once every
+// transaction was written.
+func New(w io.Writer, format string) (*Writer, error) {
+	j := &Writer{w: bufio.NewWriter(w), format: format}
+	switch format {
+	case Beancount:
+		j.w.WriteString("plugin \"beancount.plugins.auto_accounts\"\n\n")
+	case Ledger:
+	default:
+		return nil, fmt.Errorf("unknown journal format %q", format)
+	}
+	return j, nil
+}
+
+// Write appends a transaction. Writes are buffered; an error of the
+// underlying writer is returned by the next Write or by Flush.
+func (j *Writer) Write(t *Transaction) error {
+	j.w.WriteString(t.Date.Format(time.DateOnly))
+	j.w.WriteString(" * ")
+	if j.format == Beancount {
+		j.beancount(t)
+	} else {
+		j.ledger(t)
+	}
+	// Transactions are separated by a blank line
+	_, err := j.w.WriteString("\n")
+	return err
+}
+
+func (j *Writer) beancount(t *Transaction) {
+	w := j.w
+
+	w.WriteString(quote(t.Name))
+	for _, tag := range t.Tags {
+		if tag = Tag(tag); tag != "" {
+			w.WriteString(" #" + tag)
+		}
+	}
+	w.WriteString("\n")
+	for _, m := range t.Meta {
+		fmt.Fprintf(w, "  %s: %s\n", m.Key, quote(m.Value))
+	}
+	for _, p := range t.Postings {
+		fmt.Fprintf(w, "  %s  %s %s\n", p.Account, amount(p.Amount), p.Currency)
+	}
+}
+
+func (j *Writer) ledger(t *Transaction) {
+	w := j.w
+
+	w.WriteString(line(t.Name))
+	w.WriteString("\n")
+	var tags []string
+	for _, tag := range t.Tags {
+		if tag = Tag(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	if len(tags) > 0 {
+		fmt.Fprintf(w, "    ; :%s:\n", strings.Join(tags, ":"))
+	}
+	for _, m := range t.Meta {
+		fmt.Fprintf(w, "    ; %s: %s\n", m.Key, line(m.Value))
+	}
+	for _, p := range t.Postings {
+		fmt.Fprintf(w, "    %s  %s %s\n", p.Account, amount(p.Amount), p.Currency)
+	}
+}
+
+// Flush writes out what is buffered.
+func (j *Writer) Flush() error {
+	return j.w.Flush()
+}
+
+func amount(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+// quote returns s as a Beancount string.
+func quote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(line(s)) + `"`
+}
+
+// line folds s onto one line, as Ledger ends a payee or a note at the line
+// break.
+func line(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// Account joins root and a component made of name, such as Expenses and
+// "eating out" into Expenses:Eating-Out. The component follows the rules
+// of Beancount, which are stricter than Ledger's: words start with a
+// capital or a digit and are joined by dashes. A name without letters or
+// digits gives Uncategorized.
+func Account(root, name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, w := range words {
+		rs := []rune(w)
+		rs[0] = unicode.ToUpper(rs[0])
+		words[i] = string(rs)
+	}
+	component := strings.Join(words, "-")
+	if component == "" {
+		component = "Uncategorized"
+	}
+	if first := []rune(component)[0]; !unicode.IsUpper(first) && !unicode.IsDigit(first) {
+		// Letters without case, such as CJK, cannot open a component
+		component = "X-" + component
+	}
+	return root + ":" + component
+}
+
+// Valid reports whether account can be written in format. Beancount wants
+// one of Roots followed by components as made by Account; Ledger takes any
+// name without a tab, two spaces in a row or a leading or trailing space,
+// which would end it.
+func Valid(format, account string) bool {
+	if account == "" || strings.ContainsAny(account, "\t\n;") || strings.Contains(account, "  ") ||
+		strings.TrimSpace(account) != account {
+		return false
+	}
+	if format != Beancount {
+		return true
+	}
+
+	parts := strings.Split(account, ":")
+	root := false
+	for _, r := range Roots {
+		root = root || parts[0] == r
+	}
+	if !root || len(parts) < 2 {
+		return false
+	}
+	for _, part := range parts[1:] {
+		for i, r := range part {
+			switch {
+			case i == 0 && (unicode.IsUpper(r) || unicode.IsDigit(r)):
+			case i > 0 && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-'):
+			default:
+				return false
+			}
+		}
+		if part == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// Tag returns a tag name as both formats accept it, with anything but ASCII
+// letters and digits, dashes, underscores, dots and slashes turned into
+// dashes. A name without ASCII letters or digits gives "".
+func Tag(name string) string {
+	keep := false
+	tag := strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			keep = true
+			return r
+		}
+		if strings.ContainsRune("-_./", r) {
+			return r
+		}
+		return '-'
+	}, strings.TrimSpace(name))
+	if !keep {
+		return ""
+	}
+	return tag
+}
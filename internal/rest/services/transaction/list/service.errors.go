@@ -0,0 +1,17 @@
+package list
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	InvalidSortBy            *err.HTTPError
+	InvalidOrder             *err.HTTPError
+	FailedToListTransactions *err.HTTPError
+}{
+	InvalidSortBy:            err.NewHTTPError(http.StatusBadRequest, "Sort by must be date, amount, name, type or created_at."),
+	InvalidOrder:             err.NewHTTPError(http.StatusBadRequest, "Order must be asc or desc."),
+	FailedToListTransactions: err.NewHTTPError(http.StatusInternalServerError, "Failed to list transactions."),
+}
@@ -0,0 +1,105 @@
+package list
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+	"github.com/rsmrtk/mybox/internal/rest/domain/transaction"
+	"github.com/rsmrtk/mybox/internal/rest/services/record"
+	"github.com/rsmrtk/mybox/internal/rest/services/tag"
+	"github.com/rsmrtk/mybox/internal/rest/services/transaction/filter"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_transaction"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_trash"
+)
+
+type service struct {
+	ctx   context.Context
+	req   *transaction.ListRequest
+	f     *Facade
+	items []*m_transaction.Data
+	total int
+	tags  map[string][]string
+}
+
+func (s *service) list() error {
+	// Set default values if not provided
+	if s.req.Limit <= 0 || s.req.Limit > 500 {
+		s.req.Limit = 100 // Default limit
+	}
+	if s.req.Offset < 0 {
+		s.req.Offset = 0
+	}
+	if s.req.SortBy == "" {
+		s.req.SortBy = "date"
+	}
+	if s.req.Order == "" {
+		s.req.Order = "desc" // Newest first
+	}
+
+	if !slices.Contains(m_transaction.SortColumns, s.req.SortBy) {
+		return errs.InvalidSortBy
+	}
+	if s.req.Order != "asc" && s.req.Order != "desc" {
+		return errs.InvalidOrder
+	}
+
+	query, err := filter.Build(s.ctx, s.f.pkg, &s.req.Filter)
+	if err != nil {
+		return filter.Error(err, errs.FailedToListTransactions)
+	}
+	query.SortBy, query.Desc = s.req.SortBy, s.req.Order == "desc"
+	query.Limit, query.Offset = s.req.Limit, s.req.Offset
+
+	s.items, s.total, err = s.f.pkg.M.Transaction.List(s.ctx, query)
+	if err != nil {
+		return errs.FailedToListTransactions
+	}
+
+	// Tags are looked up per record type; IDs do not collide across the two
+	byKind := map[m_trash.Kind][]string{}
+	for _, item := range s.items {
+		kind := m_trash.Kind(item.Direction)
+		byKind[kind] = append(byKind[kind], item.ID)
+	}
+	s.tags = map[string][]string{}
+	for kind, ids := range byKind {
+		tags, err := tag.Of(s.ctx, s.f.pkg, kind, ids)
+		if err != nil {
+			return errs.FailedToListTransactions
+		}
+		maps.Copy(s.tags, tags)
+	}
+
+	return nil
+}
+
+func (s *service) reply() *transaction.ListResponse {
+	items := make([]*transaction.ListItem, 0, len(s.items))
+
+	for _, data := range s.items {
+		r := record.FromTransaction(data)
+
+		items = append(items, &transaction.ListItem{
+			TransactionID: r.ID,
+			Direction:     r.Direction,
+			Name:          r.Name,
+			Amount:        r.Amounts(),
+			Type:          r.Type,
+			Date:          models.NewDate(r.Date),
+			CreatedAt:     models.NewDate(r.CreatedAt),
+			CategoryID:    r.CategoryID,
+			AccountID:     r.AccountID,
+			Tags:          s.tags[r.ID],
+			Version:       r.Version,
+		})
+	}
+
+	return &transaction.ListResponse{
+		Items:      items,
+		TotalCount: s.total,
+		Limit:      s.req.Limit,
+		Offset:     s.req.Offset,
+	}
+}
@@ -0,0 +1,21 @@
+package transaction
+
+import (
+	"github.com/rsmrtk/mybox/internal/rest/services/transaction/export"
+	"github.com/rsmrtk/mybox/internal/rest/services/transaction/list"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Service is the transaction service facade
+type Service struct {
+	List   *list.Facade
+	Export *export.Facade
+}
+
+// New creates a new transaction service
+func New(f *pkg.Facade) *Service {
+	return &Service{
+		List:   list.New(f),
+		Export: export.New(f),
+	}
+}
@@ -0,0 +1,284 @@
+// Package xlsxfile writes Office Open XML workbooks as a stream. The parts
+// describing the workbook are written first and the rows of each sheet go
+// straight into the zip archive, so a sheet of any length is written in
+// constant memory. Strings are stored inline; there is no shared string
+// table.
+package xlsxfile
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ContentType is the media type of a workbook.
+const ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// maxName is the longest sheet name Excel accepts.
+const maxName = 31
+
+// Styles of cells; their order matches cellXfs in styles.
+const (
+	styleNone = iota
+	styleHeader
+	styleDate
+	styleAmount
+)
+
+// Cell is one value of a row. The zero Cell is empty.
+type Cell struct {
+	kind  byte // 0, 's'tring, 'n'umber, 'a'mount or 'd'ate
+	text  string
+	value float64
+}
+
+// String returns a text cell. It is stored as an inline string, which
+// spreadsheets never evaluate, so text starting with = needs no escaping.
+func String(s string) Cell { return Cell{kind: 's', text: s} }
+
+// Number returns a number cell.
+func Number(v float64) Cell { return Cell{kind: 'n', value: v} }
+
+// Amount returns a number cell shown with two decimals.
+func Amount(v float64) Cell { return Cell{kind: 'a', value: v} }
+
+// Date returns a cell holding the day of t, shown as a date.
+func Date(t time.Time) Cell {
+	if t.IsZero() {
+		return Cell{}
+	}
+	return Cell{kind: 'd', value: serial(t)}
+}
+
+// epoch is day 0 of the 1900 date system, as Excel counts since the leap
+// day it assumes in 1900.
+var epoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+func serial(t time.Time) float64 {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return day.Sub(epoch).Hours() / 24
+}
+
+// Writer writes a workbook whose sheets are named up front and filled one
+// after the other.
+type Writer struct {
+	zw     *zip.Writer
+	names  []string
+	next   int
+	sheet  *bufio.Writer
+	row    int
+	closed bool
+}
+
+// New starts a workbook with the given sheets.
+func New(w io.Writer, sheets ...string) (*Writer, error) {
+	if len(sheets) == 0 {
+		return nil, errors.New("a workbook needs a sheet")
+	}
+	for _, name := range sheets {
+		if name == "" || len(name) > maxName || strings.ContainsAny(name, `[]:*?/\`) {
+			return nil, fmt.Errorf("invalid sheet name %q", name)
+		}
+	}
+
+	x := &Writer{zw: zip.NewWriter(w), names: sheets}
+	for _, part := range []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", x.contentTypes()},
+		{"_rels/.rels", rels},
+		{"xl/workbook.xml", x.workbook()},
+		{"xl/_rels/workbook.xml.rels", x.workbookRels()},
+		{"xl/styles.xml", styles},
+	} {
+		f, err := x.zw.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return nil, err
+		}
+	}
+	return x, nil
+}
+
+// Next finishes the current sheet and starts the next one, writing header
+// as its first row in bold. The header row stays in view while scrolling.
+func (x *Writer) Next(header ...string) error {
+	if err := x.finish(); err != nil {
+		return err
+	}
+	if x.next == len(x.names) {
+		return errors.New("no sheet left")
+	}
+	x.next++
+	f, err := x.zw.Create(fmt.Sprintf("xl/worksheets/sheet%d.xml", x.next))
+	if err != nil {
+		return err
+	}
+	x.sheet, x.row = bufio.NewWriter(f), 0
+
+	x.sheet.WriteString(xml.Header)
+	x.sheet.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	if len(header) > 0 {
+		x.sheet.WriteString(`<sheetViews><sheetView workbookViewId="0">` +
+			`<pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/>` +
+			`</sheetView></sheetViews>`)
+	}
+	x.sheet.WriteString(`<sheetData>`)
+	if len(header) == 0 {
+		return nil
+	}
+	cells := make([]Cell, len(header))
+	for i, h := range header {
+		cells[i] = String(h)
+	}
+	return x.write(cells, styleHeader)
+}
+
+// Row appends a row to the current sheet.
+func (x *Writer) Row(cells ...Cell) error {
+	if x.sheet == nil {
+		return errors.New("no sheet started")
+	}
+	return x.write(cells, styleNone)
+}
+
+func (x *Writer) write(cells []Cell, style int) error {
+	x.row++
+	w := x.sheet
+	fmt.Fprintf(w, `<row r="%d">`, x.row)
+	for i, c := range cells {
+		if c.kind == 0 {
+			continue
+		}
+		ref := column(i) + strconv.Itoa(x.row)
+		s := style
+		switch c.kind {
+		case 'd':
+			s = styleDate
+		case 'a':
+			s = styleAmount
+		}
+		fmt.Fprintf(w, `<c r="%s"`, ref)
+		if s != styleNone {
+			fmt.Fprintf(w, ` s="%d"`, s)
+		}
+		if c.kind == 's' {
+			w.WriteString(` t="inlineStr"><is><t xml:space="preserve">`)
+			xml.EscapeText(w, []byte(c.text))
+			w.WriteString(`</t></is></c>`)
+			continue
+		}
+		fmt.Fprintf(w, `><v>%s</v></c>`, strconv.FormatFloat(c.value, 'f', -1, 64))
+	}
+	_, err := w.WriteString(`</row>`)
+	return err
+}
+
+// column returns the letters of the i-th column, counting from 0.
+func column(i int) string {
+	var b []byte
+	for i++; i > 0; i = (i - 1) / 26 {
+		b = append([]byte{byte('A' + (i-1)%26)}, b...)
+	}
+	return string(b)
+}
+
+func (x *Writer) finish() error {
+	if x.sheet == nil {
+		return nil
+	}
+	x.sheet.WriteString(`</sheetData></worksheet>`)
+	err := x.sheet.Flush()
+	x.sheet = nil
+	return err
+}
+
+// Close finishes the current sheet, writes the sheets that were never
+// started as empty ones and completes the archive.
+func (x *Writer) Close() error {
+	if x.closed {
+		return nil
+	}
+	x.closed = true
+	for x.sheet != nil || x.next < len(x.names) {
+		if x.sheet == nil {
+			if err := x.Next(); err != nil {
+				return err
+			}
+		}
+		if err := x.finish(); err != nil {
+			return err
+		}
+	}
+	return x.zw.Close()
+}
+
+func (x *Writer) contentTypes() string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	for i := range x.names {
+		fmt.Fprintf(&b, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i+1)
+	}
+	b.WriteString(`</Types>`)
+	return b.String()
+}
+
+func (x *Writer) workbook() string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	for i, name := range x.names {
+		b.WriteString(`<sheet name="`)
+		xml.EscapeText(&b, []byte(name))
+		fmt.Fprintf(&b, `" sheetId="%d" r:id="rId%d"/>`, i+1, i+1)
+	}
+	b.WriteString(`</sheets></workbook>`)
+	return b.String()
+}
+
+func (x *Writer) workbookRels() string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i := range x.names {
+		fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i+1, i+1)
+	}
+	fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, len(x.names)+1)
+	b.WriteString(`</Relationships>`)
+	return b.String()
+}
+
+const rels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+// styles holds a bold font for headers, the built-in date format 14 and
+// the built-in amount format 4 (#,##0.00).
+const styles = xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="4">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`<xf numFmtId="14" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="4" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`</cellXfs>` +
+	`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>` +
+	`</styleSheet>`
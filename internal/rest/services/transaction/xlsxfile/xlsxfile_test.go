@@ -0,0 +1,47 @@
+package xlsxfile
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestStringIsInline(t *testing.T) {
+	var buf bytes.Buffer
+	x, err := New(&buf, "Records")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := x.Next("Name", "Amount"); err != nil {
+		t.Fatal(err)
+	}
+	if err := x.Row(String("=1+1"), Amount(2)); err != nil {
+		t.Fatal(err)
+	}
+	if err := x.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := zr.Open("xl/worksheets/sheet1.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	b, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sheet := string(b)
+	if !strings.Contains(sheet, `<c r="A2" t="inlineStr"><is><t xml:space="preserve">=1+1</t></is></c>`) {
+		t.Errorf("text is not an inline string: %s", sheet)
+	}
+	if strings.Contains(sheet, "<f>") {
+		t.Errorf("sheet has a formula: %s", sheet)
+	}
+}
@@ -0,0 +1,41 @@
+package create
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/transfer"
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/dbtx"
+)
+
+// Facade is the create transfer facade
+type Facade struct {
+	pkg *pkg.Facade
+}
+
+// New creates a new create transfer facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg: pkg,
+	}
+}
+
+// Handle handles the create transfer request
+func (f *Facade) Handle(ctx context.Context, req *transfer.CreateRequest) (*transfer.CreateResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	// Both accounts are read in the same transaction that writes the transfer
+	err := dbtx.InTx(ctx, f.pkg.M.DB, func(ctx context.Context) error {
+		s.ctx = ctx
+		return s.create()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
@@ -0,0 +1,25 @@
+package create
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	UnknownAccount         *err.HTTPError
+	SameAccount            *err.HTTPError
+	InvalidAmount          *err.HTTPError
+	InvalidFee             *err.HTTPError
+	RateRequired           *err.HTTPError
+	InvalidRate            *err.HTTPError
+	FailedToCreateTransfer *err.HTTPError
+}{
+	UnknownAccount:         err.NewHTTPError(http.StatusBadRequest, "Account not found."),
+	SameAccount:            err.NewHTTPError(http.StatusBadRequest, "Source and destination account must differ."),
+	InvalidAmount:          err.NewHTTPError(http.StatusBadRequest, "Amount must be positive."),
+	InvalidFee:             err.NewHTTPError(http.StatusBadRequest, "Fee must not be negative."),
+	RateRequired:           err.NewHTTPError(http.StatusBadRequest, "Rate is required between accounts of different currencies."),
+	InvalidRate:            err.NewHTTPError(http.StatusBadRequest, "Rate must be positive, and 1 between accounts of the same currency."),
+	FailedToCreateTransfer: err.NewHTTPError(http.StatusInternalServerError, "Failed to create transfer."),
+}
@@ -0,0 +1,79 @@
+package create
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/transfer"
+	"github.com/rsmrtk/mybox/internal/rest/services/audit"
+	"github.com/rsmrtk/mybox/internal/rest/services/transfer/form"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_account"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_audit"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_transfer"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx        context.Context
+	req        *transfer.CreateRequest
+	f          *Facade
+	data       *m_transfer.Data
+	currencies map[string]string
+}
+
+func (s *service) create() error {
+	from, to, err := form.Accounts(s.ctx, s.f.pkg, s.req.FromAccountID, s.req.ToAccountID)
+	if errors.Is(err, m_account.ErrNotFound) {
+		return errs.UnknownAccount
+	}
+	if err != nil {
+		return errs.FailedToCreateTransfer
+	}
+	s.currencies = form.Currencies(from, to)
+
+	s.data = &m_transfer.Data{
+		TransferID:   uuid.New().String(),
+		WorkspaceID:  utils.AuthCtx(s.ctx),
+		Amount:       s.req.Amount,
+		Rate:         s.req.Rate,
+		Fee:          s.req.Fee,
+		TransferDate: s.req.TransferDate.Time,
+		CreatedAt:    time.Now().UTC(),
+	}
+	if s.req.Note != "" {
+		s.data.Note = &s.req.Note
+	}
+
+	err = form.Check(s.data, from, to)
+	switch {
+	case errors.Is(err, form.ErrSameAccount):
+		return errs.SameAccount
+	case errors.Is(err, form.ErrInvalidAmount):
+		return errs.InvalidAmount
+	case errors.Is(err, form.ErrInvalidFee):
+		return errs.InvalidFee
+	case errors.Is(err, form.ErrRateRequired):
+		return errs.RateRequired
+	case errors.Is(err, form.ErrInvalidRate):
+		return errs.InvalidRate
+	case err != nil:
+		return errs.FailedToCreateTransfer
+	}
+
+	if err := s.f.pkg.M.Transfer.Create(s.ctx, s.data); err != nil {
+		return errs.FailedToCreateTransfer
+	}
+
+	if err := audit.Record(s.ctx, s.f.pkg, audit.EntityTransfer, s.data.TransferID, m_audit.ActionCreate, nil,
+		audit.TransferSnapshot(s.data)); err != nil {
+		return errs.FailedToCreateTransfer
+	}
+
+	return nil
+}
+
+func (s *service) reply() *transfer.CreateResponse {
+	return &transfer.CreateResponse{Transfer: form.Convert(s.data, s.currencies)}
+}
@@ -0,0 +1,41 @@
+package delete
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/transfer"
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/dbtx"
+)
+
+// Facade is the delete transfer facade
+type Facade struct {
+	pkg *pkg.Facade
+}
+
+// New creates a new delete transfer facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg: pkg,
+	}
+}
+
+// Handle handles the delete transfer request
+func (f *Facade) Handle(ctx context.Context, req *transfer.DeleteRequest) (*transfer.DeleteResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	// The audit entry is written in the same transaction as the delete
+	err := dbtx.InTx(ctx, f.pkg.M.DB, func(ctx context.Context) error {
+		s.ctx = ctx
+		return s.delete()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
@@ -0,0 +1,17 @@
+package delete
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	TransferNotFound       *err.HTTPError
+	InvalidTransferID      *err.HTTPError
+	FailedToDeleteTransfer *err.HTTPError
+}{
+	TransferNotFound:       err.NewHTTPError(http.StatusNotFound, "Transfer not found."),
+	InvalidTransferID:      err.NewHTTPError(http.StatusBadRequest, "Invalid transfer ID format."),
+	FailedToDeleteTransfer: err.NewHTTPError(http.StatusInternalServerError, "Failed to delete transfer."),
+}
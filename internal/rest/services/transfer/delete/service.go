@@ -0,0 +1,57 @@
+package delete
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/transfer"
+	"github.com/rsmrtk/mybox/internal/rest/services/audit"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_audit"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_transfer"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx context.Context
+	req *transfer.DeleteRequest
+	f   *Facade
+}
+
+func (s *service) delete() error {
+	id, err := uuid.Parse(s.req.TransferID)
+	if err != nil {
+		return errs.InvalidTransferID
+	}
+
+	workspaceID := utils.AuthCtx(s.ctx)
+	data, err := s.f.pkg.M.Transfer.Find(s.ctx, workspaceID, id.String())
+	if errors.Is(err, m_transfer.ErrNotFound) {
+		return errs.TransferNotFound
+	}
+	if err != nil {
+		return errs.FailedToDeleteTransfer
+	}
+
+	err = s.f.pkg.M.Transfer.Delete(s.ctx, workspaceID, data.TransferID)
+	if errors.Is(err, m_transfer.ErrNotFound) {
+		return errs.TransferNotFound
+	}
+	if err != nil {
+		return errs.FailedToDeleteTransfer
+	}
+
+	if err := audit.Record(s.ctx, s.f.pkg, audit.EntityTransfer, data.TransferID, m_audit.ActionDelete,
+		audit.TransferSnapshot(data), nil); err != nil {
+		return errs.FailedToDeleteTransfer
+	}
+
+	return nil
+}
+
+func (s *service) reply() *transfer.DeleteResponse {
+	return &transfer.DeleteResponse{
+		Success: true,
+		Message: "Transfer deleted successfully",
+	}
+}
@@ -0,0 +1,104 @@
+// Package form validates transfers and converts them for the API. The
+// transfer create and update services share it so that both price a
+// transfer the same way.
+package form
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+	dt "github.com/rsmrtk/mybox/internal/rest/domain/transfer"
+	"github.com/rsmrtk/mybox/internal/rest/services/account"
+	"github.com/rsmrtk/mybox/internal/rest/services/record"
+	"github.com/rsmrtk/mybox/pkg"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_account"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_transfer"
+)
+
+var (
+	// ErrSameAccount is returned when both sides are the same account.
+	ErrSameAccount = errors.New("transfer to the source account")
+	// ErrInvalidAmount is returned for an amount that is not positive.
+	ErrInvalidAmount = errors.New("invalid transfer amount")
+	// ErrInvalidFee is returned for a negative fee.
+	ErrInvalidFee = errors.New("invalid transfer fee")
+	// ErrRateRequired is returned when the currencies differ and no rate is set.
+	ErrRateRequired = errors.New("exchange rate required")
+	// ErrInvalidRate is returned for a negative rate, or a rate other than 1
+	// between accounts of the same currency.
+	ErrInvalidRate = errors.New("invalid exchange rate")
+)
+
+// Accounts resolves both sides of a transfer with account.Resolve.
+func Accounts(ctx context.Context, f *pkg.Facade, fromID, toID string) (from, to *m_account.Data, err error) {
+	if from, err = account.Resolve(ctx, f, fromID); err != nil {
+		return nil, nil, err
+	}
+	if to, err = account.Resolve(ctx, f, toID); err != nil {
+		return nil, nil, err
+	}
+	return from, to, nil
+}
+
+// Check validates a transfer between from and to and sets its received
+// amount. A zero rate defaults to 1 between accounts of the same currency.
+func Check(d *m_transfer.Data, from, to *m_account.Data) error {
+	if from.AccountID == to.AccountID {
+		return ErrSameAccount
+	}
+	if d.Amount <= 0 {
+		return ErrInvalidAmount
+	}
+	if d.Fee < 0 {
+		return ErrInvalidFee
+	}
+	switch {
+	case d.Rate < 0:
+		return ErrInvalidRate
+	case from.Currency == to.Currency && d.Rate == 0:
+		d.Rate = 1
+	case from.Currency == to.Currency && d.Rate != 1:
+		return ErrInvalidRate
+	case d.Rate == 0:
+		return ErrRateRequired
+	}
+
+	d.FromAccountID, d.ToAccountID = from.AccountID, to.AccountID
+	d.Received = math.Round(d.Amount*d.Rate*100) / 100
+	if d.Received <= 0 {
+		return ErrInvalidAmount
+	}
+	return nil
+}
+
+// Convert returns the API form of a transfer. currencies maps account IDs to
+// their currency.
+func Convert(d *m_transfer.Data, currencies map[string]string) dt.Transfer {
+	t := dt.Transfer{
+		TransferID:    d.TransferID,
+		FromAccountID: d.FromAccountID,
+		ToAccountID:   d.ToAccountID,
+		Amount:        record.AmountsIn(d.Amount, currencies[d.FromAccountID]),
+		Rate:          d.Rate,
+		Received:      record.AmountsIn(d.Received, currencies[d.ToAccountID]),
+		Fee:           record.AmountsIn(d.Fee, currencies[d.FromAccountID]),
+		TransferDate:  models.NewDate(d.TransferDate),
+		CreatedAt:     d.CreatedAt,
+		UpdatedAt:     d.UpdatedAt,
+	}
+	if d.Note != nil {
+		t.Note = *d.Note
+	}
+	return t
+}
+
+// Currencies maps the IDs of the given accounts to their currency.
+func Currencies(accounts ...*m_account.Data) map[string]string {
+	currencies := make(map[string]string, len(accounts))
+	for _, a := range accounts {
+		currencies[a.AccountID] = a.Currency
+	}
+	return currencies
+}
@@ -0,0 +1,35 @@
+package get
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/transfer"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Facade is the get transfer facade
+type Facade struct {
+	pkg *pkg.Facade
+}
+
+// New creates a new get transfer facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg: pkg,
+	}
+}
+
+// Handle handles the get transfer request
+func (f *Facade) Handle(ctx context.Context, req *transfer.GetRequest) (*transfer.GetResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	if err := s.find(); err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
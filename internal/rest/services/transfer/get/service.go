@@ -0,0 +1,48 @@
+package get
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/transfer"
+	"github.com/rsmrtk/mybox/internal/rest/services/transfer/form"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_transfer"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx        context.Context
+	req        *transfer.GetRequest
+	f          *Facade
+	data       *m_transfer.Data
+	currencies map[string]string
+}
+
+func (s *service) find() error {
+	id, err := uuid.Parse(s.req.TransferID)
+	if err != nil {
+		return errs.InvalidTransferID
+	}
+
+	workspaceID := utils.AuthCtx(s.ctx)
+	s.data, err = s.f.pkg.M.Transfer.Find(s.ctx, workspaceID, id.String())
+	if errors.Is(err, m_transfer.ErrNotFound) {
+		return errs.TransferNotFound
+	}
+	if err != nil {
+		return errs.FailedToGetTransfer
+	}
+
+	accounts, err := s.f.pkg.M.Account.List(s.ctx, workspaceID)
+	if err != nil {
+		return errs.FailedToGetTransfer
+	}
+	s.currencies = form.Currencies(accounts...)
+
+	return nil
+}
+
+func (s *service) reply() *transfer.GetResponse {
+	return &transfer.GetResponse{Transfer: form.Convert(s.data, s.currencies)}
+}
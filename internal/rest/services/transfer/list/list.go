@@ -0,0 +1,35 @@
+package list
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/transfer"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Facade is the list transfers facade
+type Facade struct {
+	pkg *pkg.Facade
+}
+
+// New creates a new list transfers facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg: pkg,
+	}
+}
+
+// Handle handles the list transfers request
+func (f *Facade) Handle(ctx context.Context, req *transfer.ListRequest) (*transfer.ListResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	if err := s.list(); err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
@@ -0,0 +1,17 @@
+package list
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	UnknownAccount        *err.HTTPError
+	InvalidDateRange      *err.HTTPError
+	FailedToListTransfers *err.HTTPError
+}{
+	UnknownAccount:        err.NewHTTPError(http.StatusBadRequest, "Account not found."),
+	InvalidDateRange:      err.NewHTTPError(http.StatusBadRequest, "From must not be after to."),
+	FailedToListTransfers: err.NewHTTPError(http.StatusInternalServerError, "Failed to list transfers."),
+}
@@ -0,0 +1,88 @@
+package list
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/transfer"
+	"github.com/rsmrtk/mybox/internal/rest/services/account"
+	"github.com/rsmrtk/mybox/internal/rest/services/transfer/form"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_account"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_transfer"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx        context.Context
+	req        *transfer.ListRequest
+	f          *Facade
+	items      []*m_transfer.Data
+	total      int
+	currencies map[string]string
+}
+
+func (s *service) list() error {
+	// Set default values if not provided
+	if s.req.Limit <= 0 || s.req.Limit > 500 {
+		s.req.Limit = 100 // Default limit
+	}
+	if s.req.Offset < 0 {
+		s.req.Offset = 0
+	}
+	if s.req.From != nil && s.req.To != nil && s.req.From.After(s.req.To.Time) {
+		return errs.InvalidDateRange
+	}
+
+	filter := m_transfer.Filter{
+		Limit:  s.req.Limit,
+		Offset: s.req.Offset,
+	}
+	if s.req.AccountID != "" {
+		a, err := account.Resolve(s.ctx, s.f.pkg, s.req.AccountID)
+		if errors.Is(err, m_account.ErrNotFound) {
+			return errs.UnknownAccount
+		}
+		if err != nil {
+			return errs.FailedToListTransfers
+		}
+		filter.AccountID = a.AccountID
+	}
+	if s.req.From != nil {
+		filter.From = &s.req.From.Time
+	}
+	if s.req.To != nil {
+		// To is inclusive, so the range ends at the start of the next day
+		to := s.req.To.Time.AddDate(0, 0, 1)
+		filter.To = &to
+	}
+
+	workspaceID := utils.AuthCtx(s.ctx)
+	var err error
+	s.items, s.total, err = s.f.pkg.M.Transfer.List(s.ctx, workspaceID, filter)
+	if err != nil {
+		return errs.FailedToListTransfers
+	}
+
+	accounts, err := s.f.pkg.M.Account.List(s.ctx, workspaceID)
+	if err != nil {
+		return errs.FailedToListTransfers
+	}
+	s.currencies = form.Currencies(accounts...)
+
+	return nil
+}
+
+func (s *service) reply() *transfer.ListResponse {
+	items := make([]*transfer.Transfer, 0, len(s.items))
+	for _, d := range s.items {
+		t := form.Convert(d, s.currencies)
+		items = append(items, &t)
+	}
+
+	return &transfer.ListResponse{
+		Items:      items,
+		TotalCount: s.total,
+		Limit:      s.req.Limit,
+		Offset:     s.req.Offset,
+	}
+}
@@ -0,0 +1,30 @@
+package transfer
+
+import (
+	"github.com/rsmrtk/mybox/internal/rest/services/transfer/create"
+	"github.com/rsmrtk/mybox/internal/rest/services/transfer/delete"
+	"github.com/rsmrtk/mybox/internal/rest/services/transfer/get"
+	"github.com/rsmrtk/mybox/internal/rest/services/transfer/list"
+	"github.com/rsmrtk/mybox/internal/rest/services/transfer/update"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Service is the transfer service facade
+type Service struct {
+	Get    *get.Facade
+	List   *list.Facade
+	Create *create.Facade
+	Update *update.Facade
+	Delete *delete.Facade
+}
+
+// New creates a new transfer service
+func New(f *pkg.Facade) *Service {
+	return &Service{
+		Get:    get.New(f),
+		List:   list.New(f),
+		Create: create.New(f),
+		Update: update.New(f),
+		Delete: delete.New(f),
+	}
+}
@@ -0,0 +1,29 @@
+package update
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	TransferNotFound       *err.HTTPError
+	InvalidTransferID      *err.HTTPError
+	UnknownAccount         *err.HTTPError
+	SameAccount            *err.HTTPError
+	InvalidAmount          *err.HTTPError
+	InvalidFee             *err.HTTPError
+	RateRequired           *err.HTTPError
+	InvalidRate            *err.HTTPError
+	FailedToUpdateTransfer *err.HTTPError
+}{
+	TransferNotFound:       err.NewHTTPError(http.StatusNotFound, "Transfer not found."),
+	InvalidTransferID:      err.NewHTTPError(http.StatusBadRequest, "Invalid transfer ID format."),
+	UnknownAccount:         err.NewHTTPError(http.StatusBadRequest, "Account not found."),
+	SameAccount:            err.NewHTTPError(http.StatusBadRequest, "Source and destination account must differ."),
+	InvalidAmount:          err.NewHTTPError(http.StatusBadRequest, "Amount must be positive."),
+	InvalidFee:             err.NewHTTPError(http.StatusBadRequest, "Fee must not be negative."),
+	RateRequired:           err.NewHTTPError(http.StatusBadRequest, "Rate is required between accounts of different currencies."),
+	InvalidRate:            err.NewHTTPError(http.StatusBadRequest, "Rate must be positive, and 1 between accounts of the same currency."),
+	FailedToUpdateTransfer: err.NewHTTPError(http.StatusInternalServerError, "Failed to update transfer."),
+}
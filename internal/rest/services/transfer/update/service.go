@@ -0,0 +1,113 @@
+package update
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/mybox/internal/rest/domain/transfer"
+	"github.com/rsmrtk/mybox/internal/rest/services/audit"
+	"github.com/rsmrtk/mybox/internal/rest/services/transfer/form"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_account"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_audit"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_transfer"
+	"github.com/rsmrtk/mybox/pkg/utils"
+)
+
+type service struct {
+	ctx        context.Context
+	req        *transfer.UpdateRequest
+	f          *Facade
+	data       *m_transfer.Data
+	currencies map[string]string
+}
+
+func (s *service) update() error {
+	id, err := uuid.Parse(s.req.TransferID)
+	if err != nil {
+		return errs.InvalidTransferID
+	}
+
+	s.data, err = s.f.pkg.M.Transfer.Find(s.ctx, utils.AuthCtx(s.ctx), id.String())
+	if errors.Is(err, m_transfer.ErrNotFound) {
+		return errs.TransferNotFound
+	}
+	if err != nil {
+		return errs.FailedToUpdateTransfer
+	}
+	before := audit.TransferSnapshot(s.data)
+
+	fromID, toID := s.data.FromAccountID, s.data.ToAccountID
+	if s.req.FromAccountID != "" {
+		fromID = s.req.FromAccountID
+	}
+	if s.req.ToAccountID != "" {
+		toID = s.req.ToAccountID
+	}
+	from, to, err := form.Accounts(s.ctx, s.f.pkg, fromID, toID)
+	if errors.Is(err, m_account.ErrNotFound) {
+		return errs.UnknownAccount
+	}
+	if err != nil {
+		return errs.FailedToUpdateTransfer
+	}
+	s.currencies = form.Currencies(from, to)
+
+	// The old rate does not carry over to a different pair of currencies
+	if from.AccountID != s.data.FromAccountID || to.AccountID != s.data.ToAccountID {
+		s.data.Rate = 0
+	}
+	if s.req.Amount != nil {
+		s.data.Amount = *s.req.Amount
+	}
+	if s.req.Rate != nil {
+		s.data.Rate = *s.req.Rate
+	}
+	if s.req.Fee != nil {
+		s.data.Fee = *s.req.Fee
+	}
+	if s.req.TransferDate != nil && !s.req.TransferDate.IsZero() {
+		s.data.TransferDate = s.req.TransferDate.Time
+	}
+	if s.req.Note != nil {
+		s.data.Note = nil
+		if *s.req.Note != "" {
+			s.data.Note = s.req.Note
+		}
+	}
+
+	err = form.Check(s.data, from, to)
+	switch {
+	case errors.Is(err, form.ErrSameAccount):
+		return errs.SameAccount
+	case errors.Is(err, form.ErrInvalidAmount):
+		return errs.InvalidAmount
+	case errors.Is(err, form.ErrInvalidFee):
+		return errs.InvalidFee
+	case errors.Is(err, form.ErrRateRequired):
+		return errs.RateRequired
+	case errors.Is(err, form.ErrInvalidRate):
+		return errs.InvalidRate
+	case err != nil:
+		return errs.FailedToUpdateTransfer
+	}
+
+	err = s.f.pkg.M.Transfer.Update(s.ctx, s.data)
+	if errors.Is(err, m_transfer.ErrNotFound) {
+		return errs.TransferNotFound
+	}
+	if err != nil {
+		return errs.FailedToUpdateTransfer
+	}
+
+	if err := audit.Record(s.ctx, s.f.pkg, audit.EntityTransfer, s.data.TransferID, m_audit.ActionUpdate, before,
+		audit.TransferSnapshot(s.data)); err != nil {
+		return errs.FailedToUpdateTransfer
+	}
+
+	return nil
+}
+
+func (s *service) reply() *transfer.UpdateResponse {
+	return &transfer.UpdateResponse{Transfer: form.Convert(s.data, s.currencies)}
+}
@@ -0,0 +1,35 @@
+package list
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/trash"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Facade is the list trash facade
+type Facade struct {
+	pkg *pkg.Facade
+}
+
+// New creates a new list trash facade
+func New(pkg *pkg.Facade) *Facade {
+	return &Facade{
+		pkg: pkg,
+	}
+}
+
+// Handle handles the list trash request
+func (f *Facade) Handle(ctx context.Context, req *trash.ListRequest) (*trash.ListResponse, error) {
+	s := &service{
+		ctx: ctx,
+		req: req,
+		f:   f,
+	}
+
+	if err := s.list(); err != nil {
+		return nil, err
+	}
+
+	return s.reply(), nil
+}
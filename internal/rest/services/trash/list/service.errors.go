@@ -0,0 +1,15 @@
+package list
+
+import (
+	"net/http"
+
+	err "github.com/rsmrtk/fd-er"
+)
+
+var errs = struct {
+	InvalidEntityType *err.HTTPError
+	FailedToListTrash *err.HTTPError
+}{
+	InvalidEntityType: err.NewHTTPError(http.StatusBadRequest, "Entity type must be income or expense."),
+	FailedToListTrash: err.NewHTTPError(http.StatusInternalServerError, "Failed to list trash."),
+}
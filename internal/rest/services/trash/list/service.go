@@ -0,0 +1,85 @@
+package list
+
+import (
+	"context"
+
+	"github.com/rsmrtk/mybox/internal/rest/domain/models"
+	"github.com/rsmrtk/mybox/internal/rest/domain/trash"
+	"github.com/rsmrtk/mybox/internal/rest/services/record"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_trash"
+)
+
+type service struct {
+	ctx   context.Context
+	req   *trash.ListRequest
+	f     *Facade
+	items []*m_trash.Data
+	total int
+}
+
+func (s *service) list() error {
+	// Set default values if not provided
+	if s.req.Limit <= 0 {
+		s.req.Limit = 100 // Default limit
+	}
+	if s.req.Offset < 0 {
+		s.req.Offset = 0
+	}
+
+	kind := m_trash.Kind(s.req.EntityType)
+	switch kind {
+	case "", m_trash.Income, m_trash.Expense:
+	default:
+		return errs.InvalidEntityType
+	}
+
+	var err error
+	s.items, s.total, err = s.f.pkg.M.Trash.List(s.ctx, kind, s.req.Limit, s.req.Offset)
+	if err != nil {
+		return errs.FailedToListTrash
+	}
+
+	return nil
+}
+
+func (s *service) reply() *trash.ListResponse {
+	retention := s.f.pkg.Config.Trash.Retention
+	items := make([]*trash.ListItem, 0, len(s.items))
+
+	for _, data := range s.items {
+		item := &trash.ListItem{
+			EntityType: string(data.Kind),
+			EntityID:   data.ID,
+			DeletedAt:  data.DeletedAt,
+			PurgeAt:    data.DeletedAt.Add(retention),
+		}
+		if data.Name != nil {
+			item.Name = *data.Name
+		}
+		if data.Type != nil {
+			item.Type = *data.Type
+		}
+		if data.Date != nil {
+			item.Date = models.NewDate(*data.Date)
+		}
+
+		var amount float64
+		if data.Amount != nil {
+			amount = *data.Amount
+		}
+		var currency string
+		if data.Currency != nil {
+			currency = *data.Currency
+		}
+		item.Amount = record.AmountsIn(amount, record.Currency(currency))
+
+		items = append(items, item)
+	}
+
+	return &trash.ListResponse{
+		Items:      items,
+		TotalCount: s.total,
+		Limit:      s.req.Limit,
+		Offset:     s.req.Offset,
+	}
+}
@@ -0,0 +1,18 @@
+package trash
+
+import (
+	"github.com/rsmrtk/mybox/internal/rest/services/trash/list"
+	"github.com/rsmrtk/mybox/pkg"
+)
+
+// Service is the trash service facade
+type Service struct {
+	List *list.Facade
+}
+
+// New creates a new trash service
+func New(f *pkg.Facade) *Service {
+	return &Service{
+		List: list.New(f),
+	}
+}
@@ -0,0 +1,74 @@
+// Package dbtx lets the in-repository models share a transaction that is
+// carried by the context, so that a service can be run inside or outside a
+// transaction without changes.
+package dbtx
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Conn is the part of *sql.DB and *sql.Tx used by the models.
+type Conn interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type txKey struct{}
+
+// From returns the transaction carried by ctx, or db when there is none.
+func From(ctx context.Context, db *sql.DB) Conn {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// InTx runs fn in a transaction that is committed if fn returns nil and
+// rolled back otherwise. When ctx already carries a transaction fn joins it.
+func InTx(ctx context.Context, db *sql.DB, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// Savepoint runs fn inside a savepoint of the transaction carried by ctx.
+// If fn fails only its own changes are undone and the transaction can go on.
+func Savepoint(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	tx, ok := ctx.Value(txKey{}).(*sql.Tx)
+	if !ok {
+		return fmt.Errorf("savepoint %s: no transaction in context", name)
+	}
+
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return fmt.Errorf("failed to create savepoint %s: %w", name, err)
+	}
+	err := fn(ctx)
+	if err == nil {
+		// Fails if a statement in fn failed without fn noticing, which
+		// leaves the transaction aborted until the rollback below.
+		if _, err = tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name); err == nil {
+			return nil
+		}
+		err = fmt.Errorf("failed to release savepoint %s: %w", name, err)
+	}
+	if _, rbErr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); rbErr != nil {
+		return fmt.Errorf("failed to roll back to savepoint %s: %w", name, rbErr)
+	}
+	return err
+}
@@ -0,0 +1,342 @@
+// Package m_account stores the accounts money is kept in. Accounts belong to
+// a workspace; incomes and expenses point at one through their account_id
+// column and, like transfers, move its balance.
+package m_account
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/dbtx"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_trash"
+)
+
+var (
+	// ErrNotFound is returned when the account does not exist in the workspace.
+	ErrNotFound = errors.New("account not found")
+	// ErrDuplicateName is returned when another account of the workspace has the name.
+	ErrDuplicateName = errors.New("account name already used")
+	// ErrInUse is returned by Delete while records, transfers, recurring templates or goals still point at the account.
+	ErrInUse = errors.New("account in use")
+)
+
+// Kinds of account.
+const (
+	KindCash       = "cash"
+	KindChecking   = "checking"
+	KindCreditCard = "credit_card"
+	KindSavings    = "savings"
+)
+
+// Kinds are the values accepted for Data.Kind.
+var Kinds = []string{KindCash, KindChecking, KindCreditCard, KindSavings}
+
+// Intervals are the periods accepted by Movements.
+var Intervals = []string{"day", "week", "month", "year"}
+
+type Data struct {
+	AccountID      string
+	WorkspaceID    string
+	Name           string
+	Kind           string
+	Currency       string
+	OpeningBalance float64
+	OpenedOn       time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+
+	// Balance is the opening balance plus every live record and transfer; Find and List set it.
+	Balance float64
+}
+
+type Model struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *Model {
+	return &Model{db: db}
+}
+
+// movements lists the live records and the transfers of every account as
+// signed amounts. A transfer leaves its source with its fee and arrives at
+// its destination converted.
+const movements = `
+	SELECT account_id, income_date AS date, COALESCE(income_amount, 0) AS amount
+	FROM income WHERE deleted_at IS NULL AND account_id IS NOT NULL
+	UNION ALL
+	SELECT account_id, expense_date, -COALESCE(expense_amount, 0)
+	FROM expense WHERE deleted_at IS NULL AND account_id IS NOT NULL
+	UNION ALL
+	SELECT from_account_id, transfer_date, -(amount + fee) FROM transfer
+	UNION ALL
+	SELECT to_account_id, transfer_date, received FROM transfer`
+
+// columns selects an account a with its current balance.
+const columns = `a.account_id::text, a.workspace_id::text, a.name, a.kind, a.currency,
+	a.opening_balance, a.opened_on, a.created_at, a.updated_at,
+	a.opening_balance + COALESCE((SELECT SUM(m.amount) FROM (` + movements + `) m
+		WHERE m.account_id = a.account_id), 0)`
+
+func (m *Model) Create(ctx context.Context, d *Data) error {
+	_, err := dbtx.From(ctx, m.db).ExecContext(ctx,
+		`INSERT INTO account (account_id, workspace_id, name, kind, currency, opening_balance, opened_on, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8)`,
+		d.AccountID, d.WorkspaceID, d.Name, d.Kind, d.Currency, d.OpeningBalance, d.OpenedOn, d.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert account: %w", constraint(err))
+	}
+	d.UpdatedAt = d.CreatedAt
+	d.Balance = d.OpeningBalance
+	return nil
+}
+
+func (m *Model) Find(ctx context.Context, workspaceID, id string) (*Data, error) {
+	d, err := scan(dbtx.From(ctx, m.db).QueryRowContext(ctx,
+		`SELECT `+columns+` FROM account a WHERE a.workspace_id = $1 AND a.account_id::text = $2`, workspaceID, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find account: %w", err)
+	}
+	return d, nil
+}
+
+// List returns the accounts of the workspace ordered by name.
+func (m *Model) List(ctx context.Context, workspaceID string) ([]*Data, error) {
+	rows, err := dbtx.From(ctx, m.db).QueryContext(ctx,
+		`SELECT `+columns+` FROM account a WHERE a.workspace_id = $1 ORDER BY lower(a.name), a.account_id`, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accounts: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*Data
+	for rows.Next() {
+		d, err := scan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan account: %w", err)
+		}
+		items = append(items, d)
+	}
+	return items, rows.Err()
+}
+
+// Update overwrites the editable columns of an account.
+func (m *Model) Update(ctx context.Context, d *Data) error {
+	err := dbtx.From(ctx, m.db).QueryRowContext(ctx,
+		`UPDATE account SET name = $3, kind = $4, currency = $5, opening_balance = $6, opened_on = $7
+		WHERE workspace_id = $1 AND account_id = $2
+		RETURNING updated_at`,
+		d.WorkspaceID, d.AccountID, d.Name, d.Kind, d.Currency, d.OpeningBalance, d.OpenedOn,
+	).Scan(&d.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update account: %w", constraint(err))
+	}
+	return nil
+}
+
+// Delete removes an account. Records in the trash count as uses too, since
+// restoring them must not leave a dangling reference.
+func (m *Model) Delete(ctx context.Context, workspaceID, id string) error {
+	res, err := dbtx.From(ctx, m.db).ExecContext(ctx,
+		`DELETE FROM account WHERE workspace_id = $1 AND account_id = $2`, workspaceID, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete account: %w", constraint(err))
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Moved reports whether any record, in the trash or not, or any transfer
+// moved money into or out of an account.
+func (m *Model) Moved(ctx context.Context, id string) (bool, error) {
+	var moved bool
+	err := dbtx.From(ctx, m.db).QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM income WHERE account_id = $1)
+			OR EXISTS (SELECT 1 FROM expense WHERE account_id = $1)
+			OR EXISTS (SELECT 1 FROM transfer WHERE from_account_id = $1 OR to_account_id = $1)`, id).Scan(&moved)
+	if err != nil {
+		return false, fmt.Errorf("failed to check account movements: %w", err)
+	}
+	return moved, nil
+}
+
+// Currencies returns the currencies of the given accounts keyed by account ID.
+func (m *Model) Currencies(ctx context.Context, ids []string) (map[string]string, error) {
+	currencies := make(map[string]string, len(ids))
+	if len(ids) == 0 {
+		return currencies, nil
+	}
+	rows, err := dbtx.From(ctx, m.db).QueryContext(ctx,
+		`SELECT account_id::text, currency FROM account WHERE account_id::text = ANY($1)`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account currencies: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, currency string
+		if err := rows.Scan(&id, &currency); err != nil {
+			return nil, fmt.Errorf("failed to scan account currency: %w", err)
+		}
+		currencies[id] = currency
+	}
+	return currencies, rows.Err()
+}
+
+// Assign points a record at an account, or clears it when accountID is nil.
+func (m *Model) Assign(ctx context.Context, kind m_trash.Kind, id string, accountID *string) error {
+	t, err := table(kind)
+	if err != nil {
+		return err
+	}
+	_, err = dbtx.From(ctx, m.db).ExecContext(ctx, fmt.Sprintf(
+		`UPDATE %[1]s SET account_id = $2 WHERE %[1]s_id = $1`, t), id, accountID)
+	if err != nil {
+		return fmt.Errorf("failed to assign %s account: %w", t, err)
+	}
+	return nil
+}
+
+// Assigned returns the account IDs of the given records keyed by record ID.
+// Records without an account are left out.
+func (m *Model) Assigned(ctx context.Context, kind m_trash.Kind, ids []string) (map[string]string, error) {
+	assigned := make(map[string]string, len(ids))
+	if len(ids) == 0 {
+		return assigned, nil
+	}
+	t, err := table(kind)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := dbtx.From(ctx, m.db).QueryContext(ctx, fmt.Sprintf(
+		`SELECT %[1]s_id::text, account_id::text FROM %[1]s
+		WHERE %[1]s_id::text = ANY($1) AND account_id IS NOT NULL`, t), ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s accounts: %w", t, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, accountID string
+		if err := rows.Scan(&id, &accountID); err != nil {
+			return nil, fmt.Errorf("failed to scan %s account: %w", t, err)
+		}
+		assigned[id] = accountID
+	}
+	return assigned, rows.Err()
+}
+
+// Balances returns the balance of every account of the workspace at the
+// start of before, keyed by account ID. The opening balance always counts.
+func (m *Model) Balances(ctx context.Context, workspaceID string, before time.Time) (map[string]float64, error) {
+	rows, err := dbtx.From(ctx, m.db).QueryContext(ctx,
+		`SELECT a.account_id::text, a.opening_balance + COALESCE((SELECT SUM(m.amount) FROM (`+movements+`) m
+			WHERE m.account_id = a.account_id AND m.date < $2), 0)
+		FROM account a WHERE a.workspace_id = $1`, workspaceID, before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account balances: %w", err)
+	}
+	defer rows.Close()
+
+	balances := map[string]float64{}
+	for rows.Next() {
+		var id string
+		var balance float64
+		if err := rows.Scan(&id, &balance); err != nil {
+			return nil, fmt.Errorf("failed to scan account balance: %w", err)
+		}
+		balances[id] = balance
+	}
+	return balances, rows.Err()
+}
+
+// Movement is the money that went in and out of an account in one period.
+type Movement struct {
+	Period  time.Time // start of the period
+	Inflow  float64
+	Outflow float64 // positive
+	Count   int
+}
+
+// Movements returns the live movements of an account summed per interval,
+// oldest first. Periods without movements are left out.
+func (m *Model) Movements(ctx context.Context, accountID, interval string) ([]*Movement, error) {
+	valid := false
+	for _, i := range Intervals {
+		valid = valid || i == interval
+	}
+	if !valid {
+		return nil, fmt.Errorf("unknown interval %q", interval)
+	}
+
+	rows, err := dbtx.From(ctx, m.db).QueryContext(ctx,
+		`SELECT date_trunc($2, m.date) AS period,
+			COALESCE(SUM(m.amount) FILTER (WHERE m.amount > 0), 0),
+			COALESCE(-SUM(m.amount) FILTER (WHERE m.amount < 0), 0),
+			COUNT(*)
+		FROM (`+movements+`) m
+		WHERE m.account_id::text = $1 AND m.date IS NOT NULL
+		GROUP BY period ORDER BY period`, accountID, interval)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account movements: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*Movement
+	for rows.Next() {
+		mv := &Movement{}
+		if err := rows.Scan(&mv.Period, &mv.Inflow, &mv.Outflow, &mv.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan account movement: %w", err)
+		}
+		items = append(items, mv)
+	}
+	return items, rows.Err()
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scan(row scanner) (*Data, error) {
+	var d Data
+	err := row.Scan(&d.AccountID, &d.WorkspaceID, &d.Name, &d.Kind, &d.Currency,
+		&d.OpeningBalance, &d.OpenedOn, &d.CreatedAt, &d.UpdatedAt, &d.Balance)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// constraint maps unique and foreign key violations to the package errors.
+func constraint(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23505":
+			return ErrDuplicateName
+		case "23503":
+			return ErrInUse
+		}
+	}
+	return err
+}
+
+func table(kind m_trash.Kind) (string, error) {
+	switch kind {
+	case m_trash.Income, m_trash.Expense:
+		return string(kind), nil
+	}
+	return "", fmt.Errorf("unknown kind %q", kind)
+}
@@ -0,0 +1,27 @@
+package m_api_key
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+const keyPrefix = "mbx_"
+
+// Generate returns a new random key together with the prefix shown in
+// listings and the hash stored in the database.
+func Generate() (key, prefix, hash string, err error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	key = keyPrefix + hex.EncodeToString(b)
+	return key, key[:len(keyPrefix)+8], Hash(key), nil
+}
+
+// Hash returns the hex encoded SHA-256 of key.
+func Hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
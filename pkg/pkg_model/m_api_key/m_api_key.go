@@ -0,0 +1,112 @@
+package m_api_key
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNotFound is returned when no api_key row matches.
+var ErrNotFound = errors.New("api key not found")
+
+type Data struct {
+	KeyID      string
+	KeyName    string
+	KeyPrefix  string
+	KeyHash    string
+	CustomerID string
+	CreatedAt  time.Time
+	RevokedAt  *time.Time
+}
+
+type Model struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *Model {
+	return &Model{db: db}
+}
+
+const columns = `key_id, key_name, key_prefix, key_hash, customer_id, created_at, revoked_at`
+
+func (m *Model) Create(ctx context.Context, d *Data) error {
+	_, err := m.db.ExecContext(ctx,
+		`INSERT INTO api_key (`+columns+`) VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		d.KeyID, d.KeyName, d.KeyPrefix, d.KeyHash, d.CustomerID, d.CreatedAt, d.RevokedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert api key: %w", err)
+	}
+	return nil
+}
+
+// FindActiveByHash returns the non-revoked key with the given hash.
+func (m *Model) FindActiveByHash(ctx context.Context, hash string) (*Data, error) {
+	row := m.db.QueryRowContext(ctx,
+		`SELECT `+columns+` FROM api_key WHERE key_hash = $1 AND revoked_at IS NULL`, hash)
+
+	d, err := scan(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find api key: %w", err)
+	}
+	return d, nil
+}
+
+// List returns keys ordered by creation time, newest first.
+func (m *Model) List(ctx context.Context, includeRevoked bool) ([]*Data, error) {
+	query := `SELECT ` + columns + ` FROM api_key`
+	if !includeRevoked {
+		query += ` WHERE revoked_at IS NULL`
+	}
+	query += ` ORDER BY created_at DESC`
+
+	rows, err := m.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*Data
+	for rows.Next() {
+		d, err := scan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan api key: %w", err)
+		}
+		items = append(items, d)
+	}
+	return items, rows.Err()
+}
+
+// Revoke marks the key as revoked. Revoking an already revoked key is a no-op.
+func (m *Model) Revoke(ctx context.Context, keyID string) error {
+	res, err := m.db.ExecContext(ctx,
+		`UPDATE api_key SET revoked_at = COALESCE(revoked_at, CURRENT_TIMESTAMP) WHERE key_id = $1`, keyID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scan(row scanner) (*Data, error) {
+	d := &Data{}
+	var revokedAt sql.NullTime
+	if err := row.Scan(&d.KeyID, &d.KeyName, &d.KeyPrefix, &d.KeyHash, &d.CustomerID, &d.CreatedAt, &revokedAt); err != nil {
+		return nil, err
+	}
+	if revokedAt.Valid {
+		d.RevokedAt = &revokedAt.Time
+	}
+	return d, nil
+}
@@ -0,0 +1,137 @@
+package m_audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rsmrtk/mybox/pkg/pkg_model/dbtx"
+)
+
+// Actions recorded in the log.
+const (
+	ActionCreate  = "create"
+	ActionUpdate  = "update"
+	ActionDelete  = "delete"
+	ActionRestore = "restore"
+	ActionPurge   = "purge"
+)
+
+type Data struct {
+	AuditID         string
+	OccurredAt      time.Time
+	ActorCustomerID *string
+	ActorAPIKeyID   *string
+	RequestID       *string
+	EntityType      string
+	EntityID        string
+	Action          string
+	Diff            json.RawMessage
+}
+
+// Filter narrows List. Zero values are ignored.
+type Filter struct {
+	EntityType string
+	EntityID   string
+	ActorID    string // matches either the customer or the API key
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+}
+
+type Model struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *Model {
+	return &Model{db: db}
+}
+
+const columns = `audit_id, occurred_at, actor_customer_id, actor_api_key_id, request_id, entity_type, entity_id, action, diff`
+
+func (m *Model) Create(ctx context.Context, d *Data) error {
+	_, err := dbtx.From(ctx, m.db).ExecContext(ctx,
+		`INSERT INTO audit_log (`+columns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		d.AuditID, d.OccurredAt, d.ActorCustomerID, d.ActorAPIKeyID, d.RequestID,
+		d.EntityType, d.EntityID, d.Action, []byte(d.Diff),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert audit log: %w", err)
+	}
+	return nil
+}
+
+// List returns matching entries, newest first, and the total number of
+// matches ignoring Limit and Offset.
+func (m *Model) List(ctx context.Context, f Filter) ([]*Data, int, error) {
+	var where []string
+	var args []any
+	add := func(cond string, v any) {
+		args = append(args, v)
+		where = append(where, strings.ReplaceAll(cond, "?", fmt.Sprintf("$%d", len(args))))
+	}
+
+	if f.EntityType != "" {
+		add("entity_type = ?", f.EntityType)
+	}
+	if f.EntityID != "" {
+		add("entity_id = ?", f.EntityID)
+	}
+	if f.ActorID != "" {
+		add("(actor_customer_id = ? OR actor_api_key_id = ?)", f.ActorID)
+	}
+	if f.From != nil {
+		add("occurred_at >= ?", *f.From)
+	}
+	if f.To != nil {
+		add("occurred_at < ?", *f.To)
+	}
+
+	cond := ""
+	if len(where) > 0 {
+		cond = " WHERE " + strings.Join(where, " AND ")
+	}
+
+	var total int
+	if err := dbtx.From(ctx, m.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM audit_log`+cond, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit log: %w", err)
+	}
+
+	args = append(args, f.Limit, f.Offset)
+	rows, err := dbtx.From(ctx, m.db).QueryContext(ctx,
+		fmt.Sprintf(`SELECT %s FROM audit_log%s ORDER BY occurred_at DESC, audit_id LIMIT $%d OFFSET $%d`,
+			columns, cond, len(args)-1, len(args)),
+		args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list audit log: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*Data
+	for rows.Next() {
+		d := &Data{}
+		var customerID, apiKeyID, requestID sql.NullString
+		var diff []byte
+		if err := rows.Scan(&d.AuditID, &d.OccurredAt, &customerID, &apiKeyID, &requestID,
+			&d.EntityType, &d.EntityID, &d.Action, &diff); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan audit log: %w", err)
+		}
+		d.ActorCustomerID = nullString(customerID)
+		d.ActorAPIKeyID = nullString(apiKeyID)
+		d.RequestID = nullString(requestID)
+		d.Diff = diff
+		items = append(items, d)
+	}
+	return items, total, rows.Err()
+}
+
+func nullString(s sql.NullString) *string {
+	if !s.Valid {
+		return nil
+	}
+	return &s.String
+}
@@ -0,0 +1,165 @@
+// Package m_budget stores spending limits. Budgets belong to a workspace and
+// cover either a category, with its subcategories, or an expense type; what
+// was actually spent is read from the expenses (see m_transaction).
+package m_budget
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/dbtx"
+)
+
+var (
+	// ErrNotFound is returned when the budget does not exist in the workspace.
+	ErrNotFound = errors.New("budget not found")
+	// ErrDuplicate is returned when the workspace already budgets the same
+	// category or expense type for the period.
+	ErrDuplicate = errors.New("budget already exists")
+)
+
+// Periods a budget can cover.
+const (
+	PeriodMonth = "month"
+	PeriodYear  = "year"
+)
+
+// Periods are the values accepted for Data.Period.
+var Periods = []string{PeriodMonth, PeriodYear}
+
+type Data struct {
+	BudgetID    string
+	WorkspaceID string
+	CategoryID  *string // either CategoryID or ExpenseType is set
+	ExpenseType *string
+	Period      string
+	Amount      float64
+	Currency    string    // ISO 4217; only expenses in it count against the budget
+	Rollover    bool      // carry what is left or overspent over to the next period
+	StartsOn    time.Time // first day of the first budgeted period
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type Model struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *Model {
+	return &Model{db: db}
+}
+
+const columns = `budget_id::text, workspace_id::text, category_id::text, expense_type, period,
+	amount, currency, rollover, starts_on, created_at, updated_at`
+
+func (m *Model) Create(ctx context.Context, d *Data) error {
+	_, err := dbtx.From(ctx, m.db).ExecContext(ctx,
+		`INSERT INTO budget (budget_id, workspace_id, category_id, expense_type, period, amount, currency, rollover, starts_on, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $10)`,
+		d.BudgetID, d.WorkspaceID, d.CategoryID, d.ExpenseType, d.Period, d.Amount, d.Currency, d.Rollover, d.StartsOn, d.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert budget: %w", constraint(err))
+	}
+	d.UpdatedAt = d.CreatedAt
+	return nil
+}
+
+func (m *Model) Find(ctx context.Context, workspaceID, id string) (*Data, error) {
+	d, err := scan(dbtx.From(ctx, m.db).QueryRowContext(ctx,
+		`SELECT `+columns+` FROM budget WHERE workspace_id = $1 AND budget_id::text = $2`, workspaceID, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find budget: %w", err)
+	}
+	return d, nil
+}
+
+// List returns the budgets of the workspace, category budgets first.
+func (m *Model) List(ctx context.Context, workspaceID string) ([]*Data, error) {
+	rows, err := dbtx.From(ctx, m.db).QueryContext(ctx,
+		`SELECT `+columns+` FROM budget WHERE workspace_id = $1
+		ORDER BY category_id IS NULL, lower(expense_type), period, budget_id`, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list budgets: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*Data
+	for rows.Next() {
+		d, err := scan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan budget: %w", err)
+		}
+		items = append(items, d)
+	}
+	return items, rows.Err()
+}
+
+// Update overwrites the editable columns of a budget. What it covers, its
+// period and its currency are fixed.
+func (m *Model) Update(ctx context.Context, d *Data) error {
+	err := dbtx.From(ctx, m.db).QueryRowContext(ctx,
+		`UPDATE budget SET amount = $3, rollover = $4, starts_on = $5
+		WHERE workspace_id = $1 AND budget_id = $2
+		RETURNING updated_at`,
+		d.WorkspaceID, d.BudgetID, d.Amount, d.Rollover, d.StartsOn,
+	).Scan(&d.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update budget: %w", err)
+	}
+	return nil
+}
+
+func (m *Model) Delete(ctx context.Context, workspaceID, id string) error {
+	res, err := dbtx.From(ctx, m.db).ExecContext(ctx,
+		`DELETE FROM budget WHERE workspace_id = $1 AND budget_id = $2`, workspaceID, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete budget: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scan(row scanner) (*Data, error) {
+	var (
+		d                 Data
+		category, expType sql.NullString
+	)
+	err := row.Scan(&d.BudgetID, &d.WorkspaceID, &category, &expType, &d.Period,
+		&d.Amount, &d.Currency, &d.Rollover, &d.StartsOn, &d.CreatedAt, &d.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if category.Valid {
+		d.CategoryID = &category.String
+	}
+	if expType.Valid {
+		d.ExpenseType = &expType.String
+	}
+	return &d, nil
+}
+
+// constraint maps unique violations to ErrDuplicate.
+func constraint(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return ErrDuplicate
+	}
+	return err
+}
@@ -0,0 +1,330 @@
+// Package m_category stores the category catalogue. Categories belong to a
+// workspace and form a tree; incomes and expenses point at one through their
+// category_id column.
+package m_category
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/dbtx"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_trash"
+)
+
+var (
+	// ErrNotFound is returned when the category does not exist in the workspace.
+	ErrNotFound = errors.New("category not found")
+	// ErrDuplicateName is returned when a sibling already has the name.
+	ErrDuplicateName = errors.New("category name already used")
+	// ErrInUse is returned by Delete while records or children still point at the category.
+	ErrInUse = errors.New("category in use")
+)
+
+type Data struct {
+	CategoryID  string
+	WorkspaceID string
+	ParentID    *string
+	Name        string
+	Icon        *string
+	Color       *string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type Model struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *Model {
+	return &Model{db: db}
+}
+
+const columns = `category_id::text, workspace_id::text, parent_id::text, name, icon, color, created_at, updated_at`
+
+func (m *Model) Create(ctx context.Context, d *Data) error {
+	_, err := dbtx.From(ctx, m.db).ExecContext(ctx,
+		`INSERT INTO category (category_id, workspace_id, parent_id, name, icon, color, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $7)`,
+		d.CategoryID, d.WorkspaceID, d.ParentID, d.Name, d.Icon, d.Color, d.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert category: %w", constraint(err))
+	}
+	d.UpdatedAt = d.CreatedAt
+	return nil
+}
+
+func (m *Model) Find(ctx context.Context, workspaceID, id string) (*Data, error) {
+	d, err := scan(dbtx.From(ctx, m.db).QueryRowContext(ctx,
+		`SELECT `+columns+` FROM category WHERE workspace_id = $1 AND category_id::text = $2`, workspaceID, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find category: %w", err)
+	}
+	return d, nil
+}
+
+// List returns every category of the workspace ordered by name.
+func (m *Model) List(ctx context.Context, workspaceID string) ([]*Data, error) {
+	rows, err := dbtx.From(ctx, m.db).QueryContext(ctx,
+		`SELECT `+columns+` FROM category WHERE workspace_id = $1 ORDER BY lower(name), category_id`, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*Data
+	for rows.Next() {
+		d, err := scan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan category: %w", err)
+		}
+		items = append(items, d)
+	}
+	return items, rows.Err()
+}
+
+// Update overwrites the editable columns of a category.
+func (m *Model) Update(ctx context.Context, d *Data) error {
+	err := dbtx.From(ctx, m.db).QueryRowContext(ctx,
+		`UPDATE category SET parent_id = $3, name = $4, icon = $5, color = $6
+		WHERE workspace_id = $1 AND category_id = $2
+		RETURNING updated_at`,
+		d.WorkspaceID, d.CategoryID, d.ParentID, d.Name, d.Icon, d.Color,
+	).Scan(&d.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update category: %w", constraint(err))
+	}
+	return nil
+}
+
+// Delete removes a category. Records in the trash count as uses too, since
+// restoring them must not leave a dangling reference.
+func (m *Model) Delete(ctx context.Context, workspaceID, id string) error {
+	res, err := dbtx.From(ctx, m.db).ExecContext(ctx,
+		`DELETE FROM category WHERE workspace_id = $1 AND category_id = $2`, workspaceID, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete category: %w", constraint(err))
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Descendants returns the IDs of id and every category below it.
+func (m *Model) Descendants(ctx context.Context, workspaceID, id string) ([]string, error) {
+	rows, err := dbtx.From(ctx, m.db).QueryContext(ctx,
+		`WITH RECURSIVE tree AS (
+			SELECT category_id FROM category WHERE workspace_id = $1 AND category_id::text = $2
+			UNION ALL
+			SELECT c.category_id FROM category c JOIN tree t ON c.parent_id = t.category_id
+		)
+		SELECT category_id::text FROM tree`, workspaceID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk categories: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan category: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// Assign points a record at a category, or clears it when categoryID is nil.
+func (m *Model) Assign(ctx context.Context, kind m_trash.Kind, id string, categoryID *string) error {
+	t, err := table(kind)
+	if err != nil {
+		return err
+	}
+	_, err = dbtx.From(ctx, m.db).ExecContext(ctx, fmt.Sprintf(
+		`UPDATE %[1]s SET category_id = $2 WHERE %[1]s_id = $1`, t), id, categoryID)
+	if err != nil {
+		return fmt.Errorf("failed to assign %s category: %w", t, err)
+	}
+	return nil
+}
+
+// Assigned returns the category IDs of the given records keyed by record ID.
+// Records without a category are left out.
+func (m *Model) Assigned(ctx context.Context, kind m_trash.Kind, ids []string) (map[string]string, error) {
+	assigned := make(map[string]string, len(ids))
+	if len(ids) == 0 {
+		return assigned, nil
+	}
+	t, err := table(kind)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := dbtx.From(ctx, m.db).QueryContext(ctx, fmt.Sprintf(
+		`SELECT %[1]s_id::text, category_id::text FROM %[1]s
+		WHERE %[1]s_id::text = ANY($1) AND category_id IS NOT NULL`, t), ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s categories: %w", t, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, categoryID string
+		if err := rows.Scan(&id, &categoryID); err != nil {
+			return nil, fmt.Errorf("failed to scan %s category: %w", t, err)
+		}
+		assigned[id] = categoryID
+	}
+	return assigned, rows.Err()
+}
+
+// Merged lists the records moved by Merge or renamed by Rename.
+type Merged struct {
+	Incomes  []string
+	Expenses []string
+}
+
+// Merge moves every record, expense split line, recurring template, budget
+// and child category of source to target and then deletes source. Moved records take
+// the target's name as their type and get a new version, so that stale
+// If-Match headers are rejected.
+func (m *Model) Merge(ctx context.Context, workspaceID string, source, target *Data) (*Merged, error) {
+	conn := dbtx.From(ctx, m.db)
+	merged, err := m.retype(ctx, source.CategoryID, target.CategoryID, target.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = conn.ExecContext(ctx,
+		`UPDATE expense_split SET category_id = $2 WHERE category_id = $1`, source.CategoryID, target.CategoryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to move expense splits: %w", err)
+	}
+
+	_, err = conn.ExecContext(ctx,
+		`UPDATE recurring SET category_id = $2 WHERE category_id = $1`, source.CategoryID, target.CategoryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to move recurring templates: %w", err)
+	}
+
+	// Where target has a budget for the period already, source's goes with it
+	_, err = conn.ExecContext(ctx,
+		`UPDATE budget b SET category_id = $2 WHERE category_id = $1
+		AND NOT EXISTS (SELECT 1 FROM budget t WHERE t.category_id = $2 AND t.period = b.period)`,
+		source.CategoryID, target.CategoryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to move budgets: %w", err)
+	}
+
+	_, err = conn.ExecContext(ctx,
+		`UPDATE category SET parent_id = $3 WHERE workspace_id = $1 AND parent_id = $2`,
+		workspaceID, source.CategoryID, target.CategoryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to move child categories: %w", constraint(err))
+	}
+
+	if err := m.Delete(ctx, workspaceID, source.CategoryID); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scan(row scanner) (*Data, error) {
+	var (
+		d           Data
+		parent      sql.NullString
+		icon, color sql.NullString
+	)
+	err := row.Scan(&d.CategoryID, &d.WorkspaceID, &parent, &d.Name, &icon, &color, &d.CreatedAt, &d.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	d.ParentID = nullString(parent)
+	d.Icon = nullString(icon)
+	d.Color = nullString(color)
+	return &d, nil
+}
+
+// constraint maps unique and foreign key violations to the package errors.
+func constraint(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23505":
+			return ErrDuplicateName
+		case "23503":
+			return ErrInUse
+		}
+	}
+	return err
+}
+
+func nullString(s sql.NullString) *string {
+	if !s.Valid {
+		return nil
+	}
+	return &s.String
+}
+
+func table(kind m_trash.Kind) (string, error) {
+	switch kind {
+	case m_trash.Income, m_trash.Expense:
+		return string(kind), nil
+	}
+	return "", fmt.Errorf("unknown kind %q", kind)
+}
+
+// Rename gives the records of a category its new name as their type, as a
+// record keeps the name of its category in its type. Renamed records get a
+// new version, so that stale If-Match headers are rejected.
+func (m *Model) Rename(ctx context.Context, d *Data) (*Merged, error) {
+	return m.retype(ctx, d.CategoryID, d.CategoryID, d.Name)
+}
+
+// retype points the records of category at target, naming their type after
+// it, and bumps their version.
+func (m *Model) retype(ctx context.Context, category, target, name string) (*Merged, error) {
+	conn := dbtx.From(ctx, m.db)
+	merged := &Merged{}
+
+	for _, t := range []struct {
+		table string
+		ids   *[]string
+	}{{"income", &merged.Incomes}, {"expense", &merged.Expenses}} {
+		rows, err := conn.QueryContext(ctx, fmt.Sprintf(
+			`UPDATE %[1]s SET category_id = $2, %[1]s_type = $3, version = version + 1
+			WHERE category_id = $1
+			RETURNING %[1]s_id::text`, t.table), category, target, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update %s rows: %w", t.table, err)
+		}
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("failed to scan %s id: %w", t.table, err)
+			}
+			*t.ids = append(*t.ids, id)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("failed to update %s rows: %w", t.table, err)
+		}
+	}
+	return merged, nil
+}
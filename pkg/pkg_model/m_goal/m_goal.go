@@ -0,0 +1,191 @@
+// Package m_goal stores savings goals. Goals belong to a workspace and follow
+// either an account or a tag; what was saved towards them is read from the
+// incomes and transfers (see Contributed).
+package m_goal
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/dbtx"
+)
+
+var (
+	// ErrNotFound is returned when the goal does not exist in the workspace.
+	ErrNotFound = errors.New("goal not found")
+	// ErrDuplicateName is returned when another goal of the workspace has the name.
+	ErrDuplicateName = errors.New("goal name already used")
+)
+
+type Data struct {
+	GoalID        string
+	WorkspaceID   string
+	Name          string
+	TargetAmount  float64
+	TargetDate    *time.Time
+	AccountID     *string // either AccountID or TagID is set
+	TagID         *string
+	InitialAmount float64   // saved before StartsOn
+	StartsOn      time.Time // first day money counts towards the goal
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type Model struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *Model {
+	return &Model{db: db}
+}
+
+const columns = `goal_id::text, workspace_id::text, name, target_amount, target_date,
+	account_id::text, tag_id::text, initial_amount, starts_on, created_at, updated_at`
+
+func (m *Model) Create(ctx context.Context, d *Data) error {
+	_, err := dbtx.From(ctx, m.db).ExecContext(ctx,
+		`INSERT INTO goal (goal_id, workspace_id, name, target_amount, target_date, account_id, tag_id,
+			initial_amount, starts_on, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $10)`,
+		d.GoalID, d.WorkspaceID, d.Name, d.TargetAmount, d.TargetDate, d.AccountID, d.TagID,
+		d.InitialAmount, d.StartsOn, d.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert goal: %w", constraint(err))
+	}
+	d.UpdatedAt = d.CreatedAt
+	return nil
+}
+
+func (m *Model) Find(ctx context.Context, workspaceID, id string) (*Data, error) {
+	d, err := scan(dbtx.From(ctx, m.db).QueryRowContext(ctx,
+		`SELECT `+columns+` FROM goal WHERE workspace_id = $1 AND goal_id::text = $2`, workspaceID, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find goal: %w", err)
+	}
+	return d, nil
+}
+
+// List returns the goals of the workspace, those with the nearest target
+// date first.
+func (m *Model) List(ctx context.Context, workspaceID string) ([]*Data, error) {
+	rows, err := dbtx.From(ctx, m.db).QueryContext(ctx,
+		`SELECT `+columns+` FROM goal WHERE workspace_id = $1
+		ORDER BY target_date NULLS LAST, lower(name), goal_id`, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list goals: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*Data
+	for rows.Next() {
+		d, err := scan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan goal: %w", err)
+		}
+		items = append(items, d)
+	}
+	return items, rows.Err()
+}
+
+func (m *Model) Update(ctx context.Context, d *Data) error {
+	err := dbtx.From(ctx, m.db).QueryRowContext(ctx,
+		`UPDATE goal SET name = $3, target_amount = $4, target_date = $5, account_id = $6, tag_id = $7,
+			initial_amount = $8, starts_on = $9
+		WHERE workspace_id = $1 AND goal_id = $2
+		RETURNING updated_at`,
+		d.WorkspaceID, d.GoalID, d.Name, d.TargetAmount, d.TargetDate, d.AccountID, d.TagID,
+		d.InitialAmount, d.StartsOn,
+	).Scan(&d.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update goal: %w", constraint(err))
+	}
+	return nil
+}
+
+func (m *Model) Delete(ctx context.Context, workspaceID, id string) error {
+	res, err := dbtx.From(ctx, m.db).ExecContext(ctx,
+		`DELETE FROM goal WHERE workspace_id = $1 AND goal_id = $2`, workspaceID, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete goal: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// contributions lists, as signed amounts, the money that moved towards or
+// away from a goal: $1 is the followed account, $2 the followed tag.
+const contributions = `
+	SELECT income_date AS date, COALESCE(income_amount, 0) AS amount
+	FROM income WHERE deleted_at IS NULL AND account_id::text = $1
+	UNION ALL
+	SELECT transfer_date, received FROM transfer WHERE to_account_id::text = $1
+	UNION ALL
+	SELECT transfer_date, -(amount + fee) FROM transfer WHERE from_account_id::text = $1
+	UNION ALL
+	SELECT i.income_date, COALESCE(i.income_amount, 0)
+	FROM income i JOIN income_tag g ON g.income_id = i.income_id
+	WHERE i.deleted_at IS NULL AND g.tag_id::text = $2`
+
+// Contributed returns what was saved towards the goal in [from, to): the
+// incomes and transfers in, less the transfers out, of its account, or the
+// incomes carrying its tag.
+func (m *Model) Contributed(ctx context.Context, d *Data, from, to time.Time) (float64, error) {
+	var sum float64
+	err := dbtx.From(ctx, m.db).QueryRowContext(ctx,
+		`SELECT COALESCE(SUM(c.amount), 0) FROM (`+contributions+`) c
+		WHERE c.date >= $3 AND c.date < $4`,
+		d.AccountID, d.TagID, from, to).Scan(&sum)
+	if err != nil {
+		return 0, fmt.Errorf("failed to sum goal contributions: %w", err)
+	}
+	return sum, nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scan(row scanner) (*Data, error) {
+	var (
+		d            Data
+		target       sql.NullTime
+		account, tag sql.NullString
+	)
+	err := row.Scan(&d.GoalID, &d.WorkspaceID, &d.Name, &d.TargetAmount, &target,
+		&account, &tag, &d.InitialAmount, &d.StartsOn, &d.CreatedAt, &d.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if target.Valid {
+		d.TargetDate = &target.Time
+	}
+	if account.Valid {
+		d.AccountID = &account.String
+	}
+	if tag.Valid {
+		d.TagID = &tag.String
+	}
+	return &d, nil
+}
+
+// constraint maps unique violations to ErrDuplicateName.
+func constraint(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return ErrDuplicateName
+	}
+	return err
+}
@@ -0,0 +1,121 @@
+package m_idempotency
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Data is a request made with an Idempotency-Key. StatusCode is 0 until the
+// first request completes.
+type Data struct {
+	Scope       string
+	Key         string
+	Fingerprint string
+	StatusCode  int
+	ContentType string
+	Response    []byte
+	Headers     map[string]string // replayed along with the response
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+type Model struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *Model {
+	return &Model{db: db}
+}
+
+// Reserve claims d.Key within d.Scope for a new request. When the key is
+// already held by an unexpired entry, that entry is returned instead and
+// nothing is written; a nil result means the caller owns the key. An entry
+// still in progress after lease is taken over, as its request is gone.
+func (m *Model) Reserve(ctx context.Context, d *Data, lease time.Duration) (*Data, error) {
+	res, err := m.db.ExecContext(ctx,
+		`INSERT INTO idempotency_key (scope, idem_key, fingerprint, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (scope, idem_key) DO UPDATE SET
+			fingerprint = EXCLUDED.fingerprint,
+			status_code = 0,
+			content_type = NULL,
+			response = NULL,
+			headers = NULL,
+			created_at = EXCLUDED.created_at,
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_key.expires_at <= EXCLUDED.created_at
+			OR (idempotency_key.status_code = 0 AND idempotency_key.created_at <= $6)`,
+		d.Scope, d.Key, d.Fingerprint, d.CreatedAt, d.ExpiresAt, d.CreatedAt.Add(-lease),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n > 0 {
+		return nil, nil
+	}
+
+	existing := &Data{}
+	var contentType sql.NullString
+	var headers []byte
+	err = m.db.QueryRowContext(ctx,
+		`SELECT scope, idem_key, fingerprint, status_code, content_type, response, headers, created_at, expires_at
+		FROM idempotency_key WHERE scope = $1 AND idem_key = $2`, d.Scope, d.Key,
+	).Scan(&existing.Scope, &existing.Key, &existing.Fingerprint, &existing.StatusCode,
+		&contentType, &existing.Response, &headers, &existing.CreatedAt, &existing.ExpiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Expired and purged between the two statements; try again.
+		return m.Reserve(ctx, d, lease)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find idempotency key: %w", err)
+	}
+	existing.ContentType = contentType.String
+	if headers != nil {
+		if err := json.Unmarshal(headers, &existing.Headers); err != nil {
+			return nil, fmt.Errorf("failed to read idempotency key headers: %w", err)
+		}
+	}
+	return existing, nil
+}
+
+// Complete stores the response of the request holding the key.
+func (m *Model) Complete(ctx context.Context, scope, key string, statusCode int, contentType string, response []byte, headers map[string]string) error {
+	encoded, err := json.Marshal(headers)
+	if err != nil {
+		return fmt.Errorf("failed to encode idempotency key headers: %w", err)
+	}
+	_, err = m.db.ExecContext(ctx,
+		`UPDATE idempotency_key SET status_code = $3, content_type = $4, response = $5, headers = $6
+		WHERE scope = $1 AND idem_key = $2`,
+		scope, key, statusCode, contentType, response, encoded,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+	return nil
+}
+
+// Release frees a key whose request did not complete, so that it can be
+// retried with the same key.
+func (m *Model) Release(ctx context.Context, scope, key string) error {
+	_, err := m.db.ExecContext(ctx,
+		`DELETE FROM idempotency_key WHERE scope = $1 AND idem_key = $2 AND status_code = 0`, scope, key)
+	if err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired removes entries that expired before now.
+func (m *Model) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	res, err := m.db.ExecContext(ctx, `DELETE FROM idempotency_key WHERE expires_at < $1`, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	return n, nil
+}
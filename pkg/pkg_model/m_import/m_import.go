@@ -0,0 +1,318 @@
+// Package m_import stores import profiles and batches. Profiles keep the
+// column mapping of a bank's files as JSON; the services own its shape.
+// Batches belong to a workspace and list the incomes and expenses they
+// created, so that an import can be rolled back as a whole.
+package m_import
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/dbtx"
+)
+
+var (
+	// ErrNotFound is returned when the profile or batch does not exist in the workspace.
+	ErrNotFound = errors.New("import not found")
+	// ErrDuplicateName is returned when another profile of the workspace has the name.
+	ErrDuplicateName = errors.New("import profile name already used")
+)
+
+// Directions of imported records.
+const (
+	DirectionIncome  = "income"
+	DirectionExpense = "expense"
+)
+
+type Profile struct {
+	ProfileID   string
+	WorkspaceID string
+	Name        string
+	Mapping     []byte // JSON
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type Batch struct {
+	BatchID      string
+	WorkspaceID  string
+	Source       string // file format, such as csv
+	FileName     *string
+	ProfileID    *string
+	TotalRows    int
+	CreatedRows  int
+	CreatedAt    time.Time
+	RolledBackAt *time.Time
+}
+
+// Record is an income or expense created by a batch from the given line of
+// the file.
+type Record struct {
+	BatchID    string
+	Direction  string
+	RecordID   string
+	Line       int
+	ExternalID *string // the bank's ID of the transaction, when the file has one
+}
+
+type Model struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *Model {
+	return &Model{db: db}
+}
+
+const profileColumns = `profile_id::text, workspace_id::text, name, mapping, created_at, updated_at`
+
+func (m *Model) CreateProfile(ctx context.Context, p *Profile) error {
+	_, err := dbtx.From(ctx, m.db).ExecContext(ctx,
+		`INSERT INTO import_profile (profile_id, workspace_id, name, mapping, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $5)`,
+		p.ProfileID, p.WorkspaceID, p.Name, p.Mapping, p.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert import profile: %w", constraint(err))
+	}
+	p.UpdatedAt = p.CreatedAt
+	return nil
+}
+
+func (m *Model) FindProfile(ctx context.Context, workspaceID, id string) (*Profile, error) {
+	return m.findProfile(ctx, `SELECT `+profileColumns+` FROM import_profile
+		WHERE workspace_id = $1 AND profile_id::text = $2`, workspaceID, id)
+}
+
+// FindProfileByName looks a profile up by its name, ignoring case.
+func (m *Model) FindProfileByName(ctx context.Context, workspaceID, name string) (*Profile, error) {
+	return m.findProfile(ctx, `SELECT `+profileColumns+` FROM import_profile
+		WHERE workspace_id = $1 AND lower(name) = lower($2)`, workspaceID, name)
+}
+
+func (m *Model) findProfile(ctx context.Context, query string, args ...any) (*Profile, error) {
+	p, err := scanProfile(dbtx.From(ctx, m.db).QueryRowContext(ctx, query, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find import profile: %w", err)
+	}
+	return p, nil
+}
+
+// ListProfiles returns the profiles of the workspace ordered by name.
+func (m *Model) ListProfiles(ctx context.Context, workspaceID string) ([]*Profile, error) {
+	rows, err := dbtx.From(ctx, m.db).QueryContext(ctx,
+		`SELECT `+profileColumns+` FROM import_profile WHERE workspace_id = $1
+		ORDER BY lower(name), profile_id`, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list import profiles: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*Profile
+	for rows.Next() {
+		p, err := scanProfile(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan import profile: %w", err)
+		}
+		items = append(items, p)
+	}
+	return items, rows.Err()
+}
+
+func (m *Model) UpdateProfile(ctx context.Context, p *Profile) error {
+	err := dbtx.From(ctx, m.db).QueryRowContext(ctx,
+		`UPDATE import_profile SET name = $3, mapping = $4
+		WHERE workspace_id = $1 AND profile_id = $2
+		RETURNING updated_at`,
+		p.WorkspaceID, p.ProfileID, p.Name, p.Mapping,
+	).Scan(&p.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update import profile: %w", constraint(err))
+	}
+	return nil
+}
+
+// DeleteProfile removes a profile. Batches imported with it are kept.
+func (m *Model) DeleteProfile(ctx context.Context, workspaceID, id string) error {
+	res, err := dbtx.From(ctx, m.db).ExecContext(ctx,
+		`DELETE FROM import_profile WHERE workspace_id = $1 AND profile_id = $2`, workspaceID, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete import profile: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+const batchColumns = `batch_id::text, workspace_id::text, source, file_name, profile_id::text,
+	total_rows, created_rows, created_at, rolled_back_at`
+
+// CreateBatch inserts a batch together with the records it created.
+func (m *Model) CreateBatch(ctx context.Context, b *Batch, records []*Record) error {
+	conn := dbtx.From(ctx, m.db)
+	_, err := conn.ExecContext(ctx,
+		`INSERT INTO import_batch (batch_id, workspace_id, source, file_name, profile_id, total_rows, created_rows, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		b.BatchID, b.WorkspaceID, b.Source, b.FileName, b.ProfileID, b.TotalRows, b.CreatedRows, b.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert import batch: %w", err)
+	}
+	for _, r := range records {
+		_, err := conn.ExecContext(ctx,
+			`INSERT INTO import_record (batch_id, direction, record_id, line, external_id) VALUES ($1, $2, $3, $4, $5)`,
+			b.BatchID, r.Direction, r.RecordID, r.Line, r.ExternalID)
+		if err != nil {
+			return fmt.Errorf("failed to insert import record: %w", err)
+		}
+	}
+	return nil
+}
+
+// LockBatch finds a batch and locks it until the end of the transaction
+// carried by ctx, so that it is rolled back once.
+func (m *Model) LockBatch(ctx context.Context, workspaceID, id string) (*Batch, error) {
+	b, err := scanBatch(dbtx.From(ctx, m.db).QueryRowContext(ctx,
+		`SELECT `+batchColumns+` FROM import_batch WHERE workspace_id = $1 AND batch_id::text = $2 FOR UPDATE`,
+		workspaceID, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find import batch: %w", err)
+	}
+	return b, nil
+}
+
+// ListBatches returns the batches of the workspace, newest first.
+func (m *Model) ListBatches(ctx context.Context, workspaceID string) ([]*Batch, error) {
+	rows, err := dbtx.From(ctx, m.db).QueryContext(ctx,
+		`SELECT `+batchColumns+` FROM import_batch WHERE workspace_id = $1
+		ORDER BY created_at DESC, batch_id`, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list import batches: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*Batch
+	for rows.Next() {
+		b, err := scanBatch(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan import batch: %w", err)
+		}
+		items = append(items, b)
+	}
+	return items, rows.Err()
+}
+
+// Records returns the records created by a batch in file order.
+func (m *Model) Records(ctx context.Context, batchID string) ([]*Record, error) {
+	rows, err := dbtx.From(ctx, m.db).QueryContext(ctx,
+		`SELECT batch_id::text, direction, record_id::text, line, external_id FROM import_record
+		WHERE batch_id::text = $1 ORDER BY line, record_id`, batchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list import records: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*Record
+	for rows.Next() {
+		r := &Record{}
+		if err := rows.Scan(&r.BatchID, &r.Direction, &r.RecordID, &r.Line, &r.ExternalID); err != nil {
+			return nil, fmt.Errorf("failed to scan import record: %w", err)
+		}
+		items = append(items, r)
+	}
+	return items, rows.Err()
+}
+
+// LockWorkspace serializes the imports of a workspace until the end of the
+// transaction carried by ctx.
+func (m *Model) LockWorkspace(ctx context.Context, workspaceID string) error {
+	_, err := dbtx.From(ctx, m.db).ExecContext(ctx,
+		`SELECT pg_advisory_xact_lock(hashtext('import:' || $1))`, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to lock imports: %w", err)
+	}
+	return nil
+}
+
+// Imported reports whether a batch of the workspace that was not rolled back
+// created a record with the external ID.
+func (m *Model) Imported(ctx context.Context, workspaceID, externalID string) (bool, error) {
+	var found bool
+	err := dbtx.From(ctx, m.db).QueryRowContext(ctx,
+		`SELECT EXISTS (
+			SELECT 1 FROM import_record r JOIN import_batch b ON b.batch_id = r.batch_id
+			WHERE b.workspace_id = $1 AND b.rolled_back_at IS NULL AND r.external_id = $2
+		)`, workspaceID, externalID).Scan(&found)
+	if err != nil {
+		return false, fmt.Errorf("failed to look up imported record: %w", err)
+	}
+	return found, nil
+}
+
+// MarkRolledBack records when a batch was rolled back.
+func (m *Model) MarkRolledBack(ctx context.Context, id string, at time.Time) error {
+	_, err := dbtx.From(ctx, m.db).ExecContext(ctx,
+		`UPDATE import_batch SET rolled_back_at = $2 WHERE batch_id = $1`, id, at)
+	if err != nil {
+		return fmt.Errorf("failed to mark import batch rolled back: %w", err)
+	}
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanProfile(row scanner) (*Profile, error) {
+	var p Profile
+	err := row.Scan(&p.ProfileID, &p.WorkspaceID, &p.Name, &p.Mapping, &p.CreatedAt, &p.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func scanBatch(row scanner) (*Batch, error) {
+	var (
+		b                 Batch
+		fileName, profile sql.NullString
+		rolledBack        sql.NullTime
+	)
+	err := row.Scan(&b.BatchID, &b.WorkspaceID, &b.Source, &fileName, &profile,
+		&b.TotalRows, &b.CreatedRows, &b.CreatedAt, &rolledBack)
+	if err != nil {
+		return nil, err
+	}
+	if fileName.Valid {
+		b.FileName = &fileName.String
+	}
+	if profile.Valid {
+		b.ProfileID = &profile.String
+	}
+	if rolledBack.Valid {
+		b.RolledBackAt = &rolledBack.Time
+	}
+	return &b, nil
+}
+
+// constraint maps unique violations to ErrDuplicateName.
+func constraint(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return ErrDuplicateName
+	}
+	return err
+}
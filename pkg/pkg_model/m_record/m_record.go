@@ -0,0 +1,155 @@
+// Package m_record reads and writes income and expense rows through the
+// in-repository connection, so that writes can take part in a transaction
+// (see package dbtx). Rows use the db-fd-model types so that services can
+// switch between the two models freely.
+package m_record
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rsmrtk/db-fd-model/m_expense"
+	"github.com/rsmrtk/db-fd-model/m_income"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/dbtx"
+)
+
+// ErrNotFound is returned when no row matches. Trashed rows are found.
+var ErrNotFound = errors.New("record not found")
+
+type Model struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *Model {
+	return &Model{db: db}
+}
+
+func (m *Model) CreateExpense(ctx context.Context, d *m_expense.Data) error {
+	_, err := dbtx.From(ctx, m.db).ExecContext(ctx,
+		`INSERT INTO expense (expense_id, expense_name, expense_amount, expense_type, expense_date, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		d.ExpenseID, d.ExpenseName, d.ExpenseAmount, d.ExpenseType, d.ExpenseDate, d.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert expense: %w", err)
+	}
+	return nil
+}
+
+func (m *Model) FindExpense(ctx context.Context, id string) (*m_expense.Data, error) {
+	var (
+		d          m_expense.Data
+		expenseID  string
+		name, kind sql.NullString
+	)
+	err := dbtx.From(ctx, m.db).QueryRowContext(ctx,
+		`SELECT expense_id::text, expense_name, expense_amount, expense_type, expense_date, created_at
+		FROM expense WHERE expense_id = $1`, id,
+	).Scan(&expenseID, &name, &d.ExpenseAmount, &kind, &d.ExpenseDate, &d.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find expense: %w", err)
+	}
+
+	// Match what db-fd-model returns for the untyped columns
+	if parsed, err := uuid.Parse(expenseID); err == nil {
+		d.ExpenseID = parsed
+	}
+	if name.Valid {
+		d.ExpenseName = name.String
+	}
+	if kind.Valid {
+		d.ExpenseType = kind.String
+	}
+	return &d, nil
+}
+
+// UpdateExpense overwrites the editable columns of an expense.
+func (m *Model) UpdateExpense(ctx context.Context, d *m_expense.Data) error {
+	res, err := dbtx.From(ctx, m.db).ExecContext(ctx,
+		`UPDATE expense SET expense_name = $2, expense_amount = $3, expense_type = $4, expense_date = $5
+		WHERE expense_id = $1`,
+		d.ExpenseID, d.ExpenseName, d.ExpenseAmount, d.ExpenseType, d.ExpenseDate,
+	)
+	return updated(res, err, "expense")
+}
+
+func (m *Model) CreateIncome(ctx context.Context, d *m_income.Data) error {
+	_, err := dbtx.From(ctx, m.db).ExecContext(ctx,
+		`INSERT INTO income (income_id, income_name, income_amount, income_type, income_date, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		d.IncomeID, d.IncomeName, d.IncomeAmount, d.IncomeType, d.IncomeDate, d.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert income: %w", err)
+	}
+	return nil
+}
+
+func (m *Model) FindIncome(ctx context.Context, id string) (*m_income.Data, error) {
+	var (
+		d          m_income.Data
+		name, kind sql.NullString
+		amount     sql.NullFloat64
+		date, at   sql.NullTime
+	)
+	err := dbtx.From(ctx, m.db).QueryRowContext(ctx,
+		`SELECT income_id::text, income_name, income_amount, income_type, income_date, created_at
+		FROM income WHERE income_id = $1`, id,
+	).Scan(&d.IncomeID, &name, &amount, &kind, &date, &at)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find income: %w", err)
+	}
+
+	d.IncomeName = nullString(name)
+	d.IncomeType = nullString(kind)
+	if amount.Valid {
+		d.IncomeAmount = &amount.Float64
+	}
+	d.IncomeDate = nullTime(date)
+	d.CreatedAt = nullTime(at)
+	return &d, nil
+}
+
+// UpdateIncome overwrites the editable columns of an income.
+func (m *Model) UpdateIncome(ctx context.Context, d *m_income.Data) error {
+	res, err := dbtx.From(ctx, m.db).ExecContext(ctx,
+		`UPDATE income SET income_name = $2, income_amount = $3, income_type = $4, income_date = $5
+		WHERE income_id = $1`,
+		d.IncomeID, d.IncomeName, d.IncomeAmount, d.IncomeType, d.IncomeDate,
+	)
+	return updated(res, err, "income")
+}
+
+func updated(res sql.Result, err error, table string) error {
+	if err != nil {
+		return fmt.Errorf("failed to update %s: %w", table, err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func nullString(s sql.NullString) *string {
+	if !s.Valid {
+		return nil
+	}
+	return &s.String
+}
+
+func nullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
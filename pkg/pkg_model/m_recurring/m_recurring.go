@@ -0,0 +1,332 @@
+// Package m_recurring stores recurring templates and the occurrences that
+// were materialized from them or skipped. Templates belong to a workspace;
+// the (recurring_id, occurs_on) key of recurring_occurrence makes sure each
+// occurrence is handled once, whichever process gets to it first.
+package m_recurring
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rsmrtk/mybox/pkg/pkg_model/dbtx"
+)
+
+var (
+	// ErrNotFound is returned when the template does not exist in the workspace.
+	ErrNotFound = errors.New("recurring template not found")
+	// ErrOccurrenceHandled is returned by AddOccurrence when the occurrence
+	// was already materialized or skipped.
+	ErrOccurrenceHandled = errors.New("occurrence already handled")
+)
+
+// Directions of the records a template creates.
+const (
+	DirectionIncome  = "income"
+	DirectionExpense = "expense"
+)
+
+// Occurrence statuses.
+const (
+	StatusMaterialized = "materialized"
+	StatusSkipped      = "skipped"
+)
+
+type Data struct {
+	RecurringID string
+	WorkspaceID string
+	Direction   string
+	Name        string
+	Amount      float64
+	Type        *string // nil when the category names the records
+	CategoryID  *string
+	AccountID   *string
+	Tags        []string
+	Rule        string
+	StartsOn    time.Time
+	NextOn      *time.Time // first occurrence still to materialize, nil once the rule has ended
+	RetryAt     *time.Time // set after a failed run; the template is not due before
+	LastError   *string    // of the failed run
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type Occurrence struct {
+	RecurringID string
+	OccursOn    time.Time
+	Status      string
+	RecordID    *string
+	CreatedAt   time.Time
+}
+
+type Model struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *Model {
+	return &Model{db: db}
+}
+
+const columns = `recurring_id::text, workspace_id::text, direction, name, amount, type,
+	category_id::text, account_id::text, tags, rrule, starts_on, next_on, retry_at, last_error, created_at, updated_at`
+
+func (m *Model) Create(ctx context.Context, d *Data) error {
+	tags, err := json.Marshal(tagList(d.Tags))
+	if err != nil {
+		return fmt.Errorf("failed to encode recurring tags: %w", err)
+	}
+	_, err = dbtx.From(ctx, m.db).ExecContext(ctx,
+		`INSERT INTO recurring (recurring_id, workspace_id, direction, name, amount, type,
+			category_id, account_id, tags, rrule, starts_on, next_on, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $13)`,
+		d.RecurringID, d.WorkspaceID, d.Direction, d.Name, d.Amount, d.Type,
+		d.CategoryID, d.AccountID, tags, d.Rule, d.StartsOn, d.NextOn, d.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert recurring template: %w", err)
+	}
+	d.UpdatedAt = d.CreatedAt
+	return nil
+}
+
+func (m *Model) Find(ctx context.Context, workspaceID, id string) (*Data, error) {
+	return m.find(ctx, `SELECT `+columns+` FROM recurring WHERE workspace_id = $1 AND recurring_id::text = $2`, workspaceID, id)
+}
+
+// Lock is Find that also locks the template until the end of the
+// transaction carried by ctx, so that the scheduler and edits take turns.
+func (m *Model) Lock(ctx context.Context, workspaceID, id string) (*Data, error) {
+	return m.find(ctx, `SELECT `+columns+` FROM recurring WHERE workspace_id = $1 AND recurring_id::text = $2 FOR UPDATE`, workspaceID, id)
+}
+
+func (m *Model) find(ctx context.Context, query string, args ...any) (*Data, error) {
+	d, err := scan(dbtx.From(ctx, m.db).QueryRowContext(ctx, query, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find recurring template: %w", err)
+	}
+	return d, nil
+}
+
+// List returns the templates of the workspace ordered by name.
+func (m *Model) List(ctx context.Context, workspaceID string) ([]*Data, error) {
+	return m.list(ctx, `SELECT `+columns+` FROM recurring WHERE workspace_id = $1
+		ORDER BY lower(name), recurring_id`, workspaceID)
+}
+
+// Due locks and returns up to limit templates of any workspace with an
+// occurrence on or before day, leaving out those that failed and are not to
+// be retried before now. Templates locked by another transaction are
+// skipped, so that several schedulers can run side by side. It must be
+// called inside a transaction.
+func (m *Model) Due(ctx context.Context, day, now time.Time, limit int) ([]*Data, error) {
+	return m.list(ctx, `SELECT `+columns+` FROM recurring
+		WHERE next_on <= $1 AND (retry_at IS NULL OR retry_at <= $2)
+		ORDER BY next_on, recurring_id LIMIT $3 FOR UPDATE SKIP LOCKED`, day, now, limit)
+}
+
+func (m *Model) list(ctx context.Context, query string, args ...any) ([]*Data, error) {
+	rows, err := dbtx.From(ctx, m.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recurring templates: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*Data
+	for rows.Next() {
+		d, err := scan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan recurring template: %w", err)
+		}
+		items = append(items, d)
+	}
+	return items, rows.Err()
+}
+
+// Update overwrites the editable columns of a template, next_on included.
+// An edit may fix what made the last run fail, so the template is due again
+// right away.
+func (m *Model) Update(ctx context.Context, d *Data) error {
+	tags, err := json.Marshal(tagList(d.Tags))
+	if err != nil {
+		return fmt.Errorf("failed to encode recurring tags: %w", err)
+	}
+	err = dbtx.From(ctx, m.db).QueryRowContext(ctx,
+		`UPDATE recurring SET name = $3, amount = $4, type = $5, category_id = $6, account_id = $7,
+			tags = $8, rrule = $9, starts_on = $10, next_on = $11, retry_at = NULL, last_error = NULL
+		WHERE workspace_id = $1 AND recurring_id = $2
+		RETURNING updated_at`,
+		d.WorkspaceID, d.RecurringID, d.Name, d.Amount, d.Type, d.CategoryID, d.AccountID,
+		tags, d.Rule, d.StartsOn, d.NextOn,
+	).Scan(&d.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update recurring template: %w", err)
+	}
+	return nil
+}
+
+// Advance moves next_on of a template, or clears it when the rule has ended,
+// and forgets a failed run.
+func (m *Model) Advance(ctx context.Context, id string, next *time.Time) error {
+	_, err := dbtx.From(ctx, m.db).ExecContext(ctx,
+		`UPDATE recurring SET next_on = $2, retry_at = NULL, last_error = NULL WHERE recurring_id = $1`, id, next)
+	if err != nil {
+		return fmt.Errorf("failed to advance recurring template: %w", err)
+	}
+	return nil
+}
+
+// Fail records why a template could not be materialized and leaves it out
+// of Due until retryAt.
+func (m *Model) Fail(ctx context.Context, id, message string, retryAt time.Time) error {
+	_, err := dbtx.From(ctx, m.db).ExecContext(ctx,
+		`UPDATE recurring SET retry_at = $2, last_error = $3 WHERE recurring_id = $1`, id, retryAt, message)
+	if err != nil {
+		return fmt.Errorf("failed to record recurring template failure: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a template and its occurrences. Records already created
+// from it are kept.
+func (m *Model) Delete(ctx context.Context, workspaceID, id string) error {
+	res, err := dbtx.From(ctx, m.db).ExecContext(ctx,
+		`DELETE FROM recurring WHERE workspace_id = $1 AND recurring_id = $2`, workspaceID, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete recurring template: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// AddOccurrence records that an occurrence was materialized or skipped. It
+// returns ErrOccurrenceHandled when the occurrence already has a row.
+func (m *Model) AddOccurrence(ctx context.Context, o *Occurrence) error {
+	res, err := dbtx.From(ctx, m.db).ExecContext(ctx,
+		`INSERT INTO recurring_occurrence (recurring_id, occurs_on, status, record_id, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (recurring_id, occurs_on) DO NOTHING`,
+		o.RecurringID, o.OccursOn, o.Status, o.RecordID, o.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert recurring occurrence: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrOccurrenceHandled
+	}
+	return nil
+}
+
+// Occurrences returns the handled occurrences of a template on or after
+// from, keyed by day in YYYY-MM-DD form.
+func (m *Model) Occurrences(ctx context.Context, id string, from time.Time) (map[string]*Occurrence, error) {
+	rows, err := dbtx.From(ctx, m.db).QueryContext(ctx,
+		`SELECT recurring_id::text, occurs_on, status, record_id::text, created_at
+		FROM recurring_occurrence WHERE recurring_id::text = $1 AND occurs_on >= $2`, id, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recurring occurrences: %w", err)
+	}
+	defer rows.Close()
+
+	items := map[string]*Occurrence{}
+	for rows.Next() {
+		var (
+			o        Occurrence
+			recordID sql.NullString
+		)
+		if err := rows.Scan(&o.RecurringID, &o.OccursOn, &o.Status, &recordID, &o.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan recurring occurrence: %w", err)
+		}
+		if recordID.Valid {
+			o.RecordID = &recordID.String
+		}
+		items[o.OccursOn.Format(time.DateOnly)] = &o
+	}
+	return items, rows.Err()
+}
+
+// LastMaterialized returns the day of the latest materialized occurrence of
+// a template, or nil when none was materialized.
+func (m *Model) LastMaterialized(ctx context.Context, id string) (*time.Time, error) {
+	var last sql.NullTime
+	err := dbtx.From(ctx, m.db).QueryRowContext(ctx,
+		`SELECT MAX(occurs_on) FROM recurring_occurrence WHERE recurring_id::text = $1 AND status = $2`,
+		id, StatusMaterialized).Scan(&last)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last recurring occurrence: %w", err)
+	}
+	if !last.Valid {
+		return nil, nil
+	}
+	return &last.Time, nil
+}
+
+// MoveOccurrences hands the occurrences of a template on or after from over
+// to another template, as when a series is split.
+func (m *Model) MoveOccurrences(ctx context.Context, fromID, toID string, from time.Time) error {
+	_, err := dbtx.From(ctx, m.db).ExecContext(ctx,
+		`UPDATE recurring_occurrence SET recurring_id = $2 WHERE recurring_id = $1 AND occurs_on >= $3`,
+		fromID, toID, from)
+	if err != nil {
+		return fmt.Errorf("failed to move recurring occurrences: %w", err)
+	}
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scan(row scanner) (*Data, error) {
+	var (
+		d                      Data
+		typ, category, account sql.NullString
+		tags                   []byte
+		next, retry            sql.NullTime
+		lastError              sql.NullString
+	)
+	err := row.Scan(&d.RecurringID, &d.WorkspaceID, &d.Direction, &d.Name, &d.Amount, &typ,
+		&category, &account, &tags, &d.Rule, &d.StartsOn, &next, &retry, &lastError, &d.CreatedAt, &d.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(tags, &d.Tags); err != nil {
+		return nil, fmt.Errorf("failed to decode recurring tags: %w", err)
+	}
+	d.Type = nullString(typ)
+	d.CategoryID = nullString(category)
+	d.AccountID = nullString(account)
+	if next.Valid {
+		d.NextOn = &next.Time
+	}
+	if retry.Valid {
+		d.RetryAt = &retry.Time
+	}
+	d.LastError = nullString(lastError)
+	return &d, nil
+}
+
+func nullString(s sql.NullString) *string {
+	if !s.Valid {
+		return nil
+	}
+	return &s.String
+}
+
+// tagList keeps a nil slice from being stored as JSON null.
+func tagList(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
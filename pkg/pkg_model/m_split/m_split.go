@@ -0,0 +1,74 @@
+// Package m_split stores the split lines of expenses.
+package m_split
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/rsmrtk/mybox/pkg/pkg_model/dbtx"
+)
+
+type Data struct {
+	SplitID    string
+	ExpenseID  string
+	Position   int
+	Amount     float64
+	CategoryID *string
+	Note       *string
+}
+
+type Model struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *Model {
+	return &Model{db: db}
+}
+
+// Replace swaps every split line of an expense for lines, numbering them in
+// order. An empty lines removes the split.
+func (m *Model) Replace(ctx context.Context, expenseID string, lines []*Data) error {
+	conn := dbtx.From(ctx, m.db)
+	if _, err := conn.ExecContext(ctx, `DELETE FROM expense_split WHERE expense_id = $1`, expenseID); err != nil {
+		return fmt.Errorf("failed to clear expense splits: %w", err)
+	}
+	for i, d := range lines {
+		d.ExpenseID, d.Position = expenseID, i
+		_, err := conn.ExecContext(ctx,
+			`INSERT INTO expense_split (split_id, expense_id, position, amount, category_id, note)
+			VALUES ($1, $2, $3, $4, $5, $6)`,
+			d.SplitID, d.ExpenseID, d.Position, d.Amount, d.CategoryID, d.Note,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to insert expense split: %w", err)
+		}
+	}
+	return nil
+}
+
+// List returns the split lines of the given expenses in order, keyed by
+// expense ID. Expenses without a split are left out.
+func (m *Model) List(ctx context.Context, expenseIDs []string) (map[string][]*Data, error) {
+	lines := make(map[string][]*Data, len(expenseIDs))
+	if len(expenseIDs) == 0 {
+		return lines, nil
+	}
+	rows, err := dbtx.From(ctx, m.db).QueryContext(ctx,
+		`SELECT split_id::text, expense_id::text, position, amount, category_id::text, note
+		FROM expense_split WHERE expense_id::text = ANY($1)
+		ORDER BY expense_id, position`, expenseIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expense splits: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		d := &Data{}
+		if err := rows.Scan(&d.SplitID, &d.ExpenseID, &d.Position, &d.Amount, &d.CategoryID, &d.Note); err != nil {
+			return nil, fmt.Errorf("failed to scan expense split: %w", err)
+		}
+		lines[d.ExpenseID] = append(lines[d.ExpenseID], d)
+	}
+	return lines, rows.Err()
+}
@@ -0,0 +1,313 @@
+// Package m_tag stores free-form tags. Tags belong to a workspace and are
+// attached to incomes and expenses through the income_tag and expense_tag
+// join tables.
+package m_tag
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/dbtx"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_trash"
+)
+
+var (
+	// ErrNotFound is returned when the tag does not exist in the workspace.
+	ErrNotFound = errors.New("tag not found")
+	// ErrDuplicateName is returned when another tag of the workspace has the name.
+	ErrDuplicateName = errors.New("tag name already used")
+	// ErrInUse is returned by Delete while savings goals follow the tag.
+	ErrInUse = errors.New("tag in use")
+)
+
+type Data struct {
+	TagID       string
+	WorkspaceID string
+	Name        string
+	CreatedAt   time.Time
+
+	// Uses is the number of live records carrying the tag; Find and List set it.
+	Uses int
+}
+
+type Model struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *Model {
+	return &Model{db: db}
+}
+
+// tagged joins every record to its tags.
+const tagged = `
+	SELECT 'income' AS direction, income_id AS id, tag_id FROM income_tag
+	UNION ALL
+	SELECT 'expense', expense_id, tag_id FROM expense_tag`
+
+// columns selects a tag t with its number of live uses.
+const columns = `t.tag_id::text, t.workspace_id::text, t.name, t.created_at,
+	(SELECT COUNT(*) FROM (` + tagged + `) g
+		LEFT JOIN income i ON g.direction = 'income' AND i.income_id = g.id
+		LEFT JOIN expense e ON g.direction = 'expense' AND e.expense_id = g.id
+		WHERE g.tag_id = t.tag_id AND COALESCE(i.deleted_at, e.deleted_at) IS NULL)`
+
+// List returns the tags of the workspace ordered by name.
+func (m *Model) List(ctx context.Context, workspaceID string) ([]*Data, error) {
+	rows, err := dbtx.From(ctx, m.db).QueryContext(ctx,
+		`SELECT `+columns+` FROM tag t WHERE t.workspace_id = $1 ORDER BY t.name`, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*Data
+	for rows.Next() {
+		d := &Data{}
+		if err := rows.Scan(&d.TagID, &d.WorkspaceID, &d.Name, &d.CreatedAt, &d.Uses); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		items = append(items, d)
+	}
+	return items, rows.Err()
+}
+
+func (m *Model) Find(ctx context.Context, workspaceID, id string) (*Data, error) {
+	d := &Data{}
+	err := dbtx.From(ctx, m.db).QueryRowContext(ctx,
+		`SELECT `+columns+` FROM tag t WHERE t.workspace_id = $1 AND t.tag_id::text = $2`, workspaceID, id,
+	).Scan(&d.TagID, &d.WorkspaceID, &d.Name, &d.CreatedAt, &d.Uses)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find tag: %w", err)
+	}
+	return d, nil
+}
+
+// Lookup returns the tags of the workspace with the given names. Unknown
+// names are left out.
+func (m *Model) Lookup(ctx context.Context, workspaceID string, names []string) ([]*Data, error) {
+	rows, err := dbtx.From(ctx, m.db).QueryContext(ctx,
+		`SELECT tag_id::text, workspace_id::text, name, created_at FROM tag
+		WHERE workspace_id = $1 AND name = ANY($2) ORDER BY name`, workspaceID, names)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up tags: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*Data
+	for rows.Next() {
+		d := &Data{}
+		if err := rows.Scan(&d.TagID, &d.WorkspaceID, &d.Name, &d.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		items = append(items, d)
+	}
+	return items, rows.Err()
+}
+
+// Ensure creates the tags of the workspace that do not exist yet and returns
+// all of them.
+func (m *Model) Ensure(ctx context.Context, workspaceID string, names []string) ([]*Data, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	_, err := dbtx.From(ctx, m.db).ExecContext(ctx,
+		`INSERT INTO tag (workspace_id, name, created_at)
+		SELECT $1, name, $3 FROM unnest($2::text[]) AS name
+		ON CONFLICT (workspace_id, name) DO NOTHING`, workspaceID, names, time.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tags: %w", err)
+	}
+	return m.Lookup(ctx, workspaceID, names)
+}
+
+// Set replaces the workspace's tags on a record with tagIDs.
+func (m *Model) Set(ctx context.Context, kind m_trash.Kind, id, workspaceID string, tagIDs []string) error {
+	t, err := table(kind)
+	if err != nil {
+		return err
+	}
+	conn := dbtx.From(ctx, m.db)
+	_, err = conn.ExecContext(ctx, fmt.Sprintf(
+		`DELETE FROM %[1]s_tag WHERE %[1]s_id = $1
+		AND tag_id IN (SELECT tag_id FROM tag WHERE workspace_id = $2)`, t), id, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to clear %s tags: %w", t, err)
+	}
+	if len(tagIDs) == 0 {
+		return nil
+	}
+	_, err = conn.ExecContext(ctx, fmt.Sprintf(
+		`INSERT INTO %[1]s_tag (%[1]s_id, tag_id)
+		SELECT $1, tag_id FROM tag WHERE workspace_id = $2 AND tag_id::text = ANY($3)
+		ON CONFLICT DO NOTHING`, t), id, workspaceID, tagIDs)
+	if err != nil {
+		return fmt.Errorf("failed to tag %s: %w", t, err)
+	}
+	return nil
+}
+
+// Tagged returns the names of the workspace's tags on the given records,
+// keyed by record ID and ordered by name. Untagged records are left out.
+func (m *Model) Tagged(ctx context.Context, kind m_trash.Kind, workspaceID string, ids []string) (map[string][]string, error) {
+	tags := make(map[string][]string, len(ids))
+	if len(ids) == 0 {
+		return tags, nil
+	}
+	t, err := table(kind)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := dbtx.From(ctx, m.db).QueryContext(ctx, fmt.Sprintf(
+		`SELECT r.%[1]s_id::text, t.name FROM %[1]s_tag r JOIN tag t ON t.tag_id = r.tag_id
+		WHERE t.workspace_id = $1 AND r.%[1]s_id::text = ANY($2)
+		ORDER BY t.name`, t), workspaceID, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s tags: %w", t, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return nil, fmt.Errorf("failed to scan %s tag: %w", t, err)
+		}
+		tags[id] = append(tags[id], name)
+	}
+	return tags, rows.Err()
+}
+
+// Matching returns the IDs of the records carrying any of tagIDs, or all of
+// them when all is set.
+func (m *Model) Matching(ctx context.Context, kind m_trash.Kind, tagIDs []string, all bool) (map[string]bool, error) {
+	t, err := table(kind)
+	if err != nil {
+		return nil, err
+	}
+	need := 1
+	if all {
+		need = len(tagIDs)
+	}
+	rows, err := dbtx.From(ctx, m.db).QueryContext(ctx, fmt.Sprintf(
+		`SELECT %[1]s_id::text FROM %[1]s_tag WHERE tag_id::text = ANY($1)
+		GROUP BY %[1]s_id HAVING COUNT(*) >= $2`, t), tagIDs, need)
+	if err != nil {
+		return nil, fmt.Errorf("failed to match %s tags: %w", t, err)
+	}
+	defer rows.Close()
+
+	ids := map[string]bool{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan %s id: %w", t, err)
+		}
+		ids[id] = true
+	}
+	return ids, rows.Err()
+}
+
+// Rename renames a tag. The records carrying it get a new version, so that
+// stale If-Match headers are rejected.
+func (m *Model) Rename(ctx context.Context, d *Data) error {
+	if err := m.touch(ctx, d.TagID); err != nil {
+		return err
+	}
+	res, err := dbtx.From(ctx, m.db).ExecContext(ctx,
+		`UPDATE tag SET name = $3 WHERE workspace_id = $1 AND tag_id = $2`, d.WorkspaceID, d.TagID, d.Name)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return ErrDuplicateName
+		}
+		return fmt.Errorf("failed to rename tag: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Delete removes a tag from every record, which gets a new version, and then
+// the tag itself.
+func (m *Model) Delete(ctx context.Context, workspaceID, id string) error {
+	if err := m.touch(ctx, id); err != nil {
+		return err
+	}
+	res, err := dbtx.From(ctx, m.db).ExecContext(ctx,
+		`DELETE FROM tag WHERE workspace_id = $1 AND tag_id = $2`, workspaceID, id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return ErrInUse
+		}
+		return fmt.Errorf("failed to delete tag: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Merge puts target on every record carrying source, hands the goals
+// following source over to target, deletes source and returns the number of
+// records that were tagged with source. Deleting source gives those records
+// a new version.
+func (m *Model) Merge(ctx context.Context, workspaceID, source, target string) (int, error) {
+	conn := dbtx.From(ctx, m.db)
+
+	var moved int
+	err := conn.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM (`+tagged+`) g WHERE g.tag_id = $1`, source).Scan(&moved)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count tagged records: %w", err)
+	}
+
+	for _, t := range []string{"income", "expense"} {
+		_, err := conn.ExecContext(ctx, fmt.Sprintf(
+			`INSERT INTO %[1]s_tag (%[1]s_id, tag_id)
+			SELECT %[1]s_id, $2 FROM %[1]s_tag WHERE tag_id = $1
+			ON CONFLICT DO NOTHING`, t), source, target)
+		if err != nil {
+			return 0, fmt.Errorf("failed to move %s tags: %w", t, err)
+		}
+	}
+
+	_, err = conn.ExecContext(ctx, `UPDATE goal SET tag_id = $2 WHERE tag_id = $1`, source, target)
+	if err != nil {
+		return 0, fmt.Errorf("failed to move goals: %w", err)
+	}
+
+	if err := m.Delete(ctx, workspaceID, source); err != nil {
+		return 0, err
+	}
+	return moved, nil
+}
+
+// touch bumps the version of the records carrying a tag, whose tags are
+// about to change.
+func (m *Model) touch(ctx context.Context, tagID string) error {
+	for _, t := range []string{"income", "expense"} {
+		_, err := dbtx.From(ctx, m.db).ExecContext(ctx, fmt.Sprintf(
+			`UPDATE %[1]s SET version = version + 1
+			WHERE %[1]s_id IN (SELECT %[1]s_id FROM %[1]s_tag WHERE tag_id = $1)`, t), tagID)
+		if err != nil {
+			return fmt.Errorf("failed to bump %s versions: %w", t, err)
+		}
+	}
+	return nil
+}
+
+func table(kind m_trash.Kind) (string, error) {
+	switch kind {
+	case m_trash.Income, m_trash.Expense:
+		return string(kind), nil
+	}
+	return "", fmt.Errorf("unknown kind %q", kind)
+}
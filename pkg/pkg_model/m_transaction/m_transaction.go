@@ -0,0 +1,374 @@
+// Package m_transaction reads incomes and expenses as a single list of money
+// movements. Trashed rows are left out, and so are transfers between
+// accounts, which are neither income nor expense.
+package m_transaction
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rsmrtk/mybox/pkg/pkg_model/dbtx"
+)
+
+// Directions of a transaction; they match the source table names.
+const (
+	DirectionIncome  = "income"
+	DirectionExpense = "expense"
+)
+
+type Data struct {
+	Direction string
+	ID        string
+	Name      *string
+	Amount    *float64
+	Type      *string
+	Date      *time.Time
+	CreatedAt *time.Time
+	Version   int64
+
+	CategoryID *string
+	AccountID  *string
+	Currency   *string // of the account; nil for records outside one
+}
+
+// Filter narrows List. Zero values are ignored.
+type Filter struct {
+	Direction string
+	From      *time.Time // inclusive
+	To        *time.Time // exclusive
+	Type      string
+	Search    string // case-insensitive substring of the name
+	MinAmount *float64
+	MaxAmount *float64
+	// CategoryIDs keeps records in any of the categories. Callers expand
+	// parents to their descendants (see m_category.Descendants).
+	CategoryIDs []string
+	AccountID   string // keeps records of one account
+	// TagIDs keeps records carrying any of the tags, or all of them with
+	// AllTags. An empty non-nil slice matches nothing.
+	TagIDs  []string
+	AllTags bool
+	SortBy  string // one of SortColumns; defaults to date
+	Desc    bool
+	Limit   int
+	Offset  int
+}
+
+// SortColumns are the values accepted by Filter.SortBy.
+var SortColumns = []string{"date", "amount", "name", "type", "created_at"}
+
+type Model struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *Model {
+	return &Model{db: db}
+}
+
+const union = `
+	SELECT 'income' AS direction, r.income_id::text AS id, r.income_name AS name, r.income_amount AS amount,
+		r.income_type AS type, r.income_date AS date, r.created_at, r.version, r.category_id::text AS category_id,
+		r.account_id::text AS account_id, a.currency
+	FROM income r LEFT JOIN account a ON a.account_id = r.account_id WHERE r.deleted_at IS NULL
+	UNION ALL
+	SELECT 'expense', r.expense_id::text, r.expense_name, r.expense_amount,
+		r.expense_type, r.expense_date, r.created_at, r.version, r.category_id::text, r.account_id::text, a.currency
+	FROM expense r LEFT JOIN account a ON a.account_id = r.account_id WHERE r.deleted_at IS NULL`
+
+// tagged joins every record to its tags.
+const tagged = `
+	SELECT 'income' AS direction, income_id::text AS id, tag_id::text AS tag_id FROM income_tag
+	UNION ALL
+	SELECT 'expense', expense_id::text, tag_id::text FROM expense_tag`
+
+// List returns one page of matching transactions and the total number of
+// matches ignoring Limit and Offset.
+func (m *Model) List(ctx context.Context, f Filter) ([]*Data, int, error) {
+	cond, args := f.where()
+
+	conn := dbtx.From(ctx, m.db)
+
+	var total int
+	if err := conn.QueryRowContext(ctx, `SELECT COUNT(*) FROM (`+union+`) t`+cond, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count transactions: %w", err)
+	}
+
+	args = append(args, f.Limit, f.Offset)
+	rows, err := conn.QueryContext(ctx, fmt.Sprintf(
+		`SELECT direction, id, name, amount, type, date, created_at, version, category_id, account_id, currency FROM (%s) t%s
+		ORDER BY %s LIMIT $%d OFFSET $%d`,
+		union, cond, f.orderBy(), len(args)-1, len(args)), args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list transactions: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*Data
+	for rows.Next() {
+		d := &Data{}
+		if err := rows.Scan(&d.Direction, &d.ID, &d.Name, &d.Amount, &d.Type, &d.Date, &d.CreatedAt, &d.Version, &d.CategoryID, &d.AccountID, &d.Currency); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan transaction: %w", err)
+		}
+		items = append(items, d)
+	}
+	return items, total, rows.Err()
+}
+
+// Each calls fn for every matching transaction in the order of the filter,
+// reading the rows one at a time so that any number of them can be walked.
+// Filter.Limit and Offset are ignored. Each stops at the first error of fn
+// and returns it.
+func (m *Model) Each(ctx context.Context, f Filter, fn func(d *Data) error) error {
+	cond, args := f.where()
+	rows, err := dbtx.From(ctx, m.db).QueryContext(ctx, fmt.Sprintf(
+		`SELECT direction, id, name, amount, type, date, created_at, version, category_id, account_id, currency FROM (%s) t%s
+		ORDER BY %s`, union, cond, f.orderBy()), args...)
+	if err != nil {
+		return fmt.Errorf("failed to read transactions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		d := &Data{}
+		if err := rows.Scan(&d.Direction, &d.ID, &d.Name, &d.Amount, &d.Type, &d.Date, &d.CreatedAt, &d.Version, &d.CategoryID, &d.AccountID, &d.Currency); err != nil {
+			return fmt.Errorf("failed to scan transaction: %w", err)
+		}
+		if err := fn(d); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// Total is the sum of one direction within one category in one currency.
+// CategoryID is nil for records outside the catalogue.
+type Total struct {
+	CategoryID *string
+	Direction  string
+	Currency   string // of the account; empty for records outside one
+	Amount     float64
+	Count      int
+}
+
+// TotalsByCategory sums the matching transactions per category, direction
+// and currency. Split expenses count line by line under the category of each
+// line, falling back to the expense's own category, and once in Count.
+// Filter.SortBy, Desc, Limit and Offset are ignored.
+func (m *Model) TotalsByCategory(ctx context.Context, f Filter) ([]*Total, error) {
+	cond, args := f.where()
+	rows, err := dbtx.From(ctx, m.db).QueryContext(ctx, fmt.Sprintf(
+		`SELECT COALESCE(s.category_id::text, t.category_id), t.direction, COALESCE(t.currency, ''),
+			COALESCE(SUM(COALESCE(s.amount, t.amount)), 0), COUNT(DISTINCT t.id)
+		FROM (SELECT * FROM (%s) t%s) t
+		LEFT JOIN expense_split s ON t.direction = 'expense' AND s.expense_id::text = t.id
+		GROUP BY 1, t.direction, 3`, union, cond), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to total transactions: %w", err)
+	}
+	defer rows.Close()
+
+	var totals []*Total
+	for rows.Next() {
+		t := &Total{}
+		if err := rows.Scan(&t.CategoryID, &t.Direction, &t.Currency, &t.Amount, &t.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan total: %w", err)
+		}
+		totals = append(totals, t)
+	}
+	return totals, rows.Err()
+}
+
+// PeriodTotal is what was spent in one period within one category on one
+// expense type in one currency. CategoryID is nil for expenses outside the
+// catalogue; Type is lower-cased.
+type PeriodTotal struct {
+	Period     time.Time // start of the period
+	CategoryID *string
+	Type       string
+	Currency   string // of the account; empty for expenses outside one
+	Amount     float64
+}
+
+// ExpensesByPeriod sums the matching expenses per interval ("month" or
+// "year"), category, type and currency. Split expenses count as in
+// TotalsByCategory.
+// Filter.Direction, SortBy, Desc, Limit and Offset are ignored.
+func (m *Model) ExpensesByPeriod(ctx context.Context, f Filter, interval string) ([]*PeriodTotal, error) {
+	f.Direction = DirectionExpense
+	cond, args := f.where()
+	args = append(args, interval)
+	rows, err := dbtx.From(ctx, m.db).QueryContext(ctx, fmt.Sprintf(
+		`SELECT date_trunc($%d, t.date), COALESCE(s.category_id::text, t.category_id),
+			lower(COALESCE(t.type, '')), COALESCE(t.currency, ''), COALESCE(SUM(COALESCE(s.amount, t.amount)), 0)
+		FROM (SELECT * FROM (%s) t%s) t
+		LEFT JOIN expense_split s ON s.expense_id::text = t.id
+		WHERE t.date IS NOT NULL
+		GROUP BY 1, 2, 3, 4`, len(args), union, cond), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to total expenses by period: %w", err)
+	}
+	defer rows.Close()
+
+	var totals []*PeriodTotal
+	for rows.Next() {
+		t := &PeriodTotal{}
+		if err := rows.Scan(&t.Period, &t.CategoryID, &t.Type, &t.Currency, &t.Amount); err != nil {
+			return nil, fmt.Errorf("failed to scan total: %w", err)
+		}
+		totals = append(totals, t)
+	}
+	return totals, rows.Err()
+}
+
+// TagTotal is the sum of one direction carrying one tag in one currency.
+type TagTotal struct {
+	TagID     string
+	Direction string
+	Currency  string // of the account; empty for records outside one
+	Amount    float64
+	Count     int
+}
+
+// TotalsByTag sums the matching transactions per tag of tagIDs, direction
+// and currency. A transaction with several tags counts towards each of them.
+func (m *Model) TotalsByTag(ctx context.Context, f Filter, tagIDs []string) ([]*TagTotal, error) {
+	cond, args := f.where()
+	args = append(args, tagIDs)
+	rows, err := dbtx.From(ctx, m.db).QueryContext(ctx, fmt.Sprintf(
+		`SELECT g.tag_id, t.direction, COALESCE(t.currency, ''), COALESCE(SUM(t.amount), 0), COUNT(*)
+		FROM (SELECT * FROM (%s) t%s) t
+		JOIN (%s) g ON g.direction = t.direction AND g.id = t.id
+		WHERE g.tag_id = ANY($%d)
+		GROUP BY g.tag_id, t.direction, 3`, union, cond, tagged, len(args)), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to total transactions by tag: %w", err)
+	}
+	defer rows.Close()
+
+	var totals []*TagTotal
+	for rows.Next() {
+		t := &TagTotal{}
+		if err := rows.Scan(&t.TagID, &t.Direction, &t.Currency, &t.Amount, &t.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan total: %w", err)
+		}
+		totals = append(totals, t)
+	}
+	return totals, rows.Err()
+}
+
+// Pair is two records of one direction whose amounts and dates are close.
+// A is the later of the two.
+type Pair struct {
+	A, B *Data
+}
+
+// Pairs returns the matching records that may have been entered twice:
+// records of one direction at most days apart whose amounts differ by at
+// most tolerance, a fraction of the larger amount, or by a cent. Records of
+// two different accounts are never paired. Newer pairs come first;
+// Filter.SortBy, Desc and Offset are ignored and Limit caps the pairs.
+func (m *Model) Pairs(ctx context.Context, f Filter, days int, tolerance float64) ([]*Pair, error) {
+	cond, args := f.where()
+	args = append(args, days, tolerance, f.Limit)
+	n := len(args)
+	rows, err := dbtx.From(ctx, m.db).QueryContext(ctx, fmt.Sprintf(
+		`WITH t AS (SELECT * FROM (%s) t%s)
+		SELECT a.direction, a.id, a.name, a.amount, a.type, a.date, a.created_at, a.version, a.category_id, a.account_id, a.currency,
+			b.direction, b.id, b.name, b.amount, b.type, b.date, b.created_at, b.version, b.category_id, b.account_id, b.currency
+		FROM t a
+		JOIN t b ON b.direction = a.direction
+			AND (b.date < a.date OR b.date = a.date AND b.id < a.id)
+			AND b.date >= a.date - make_interval(days => $%d)
+			AND abs(a.amount - b.amount) <= GREATEST(0.01, $%d * GREATEST(abs(a.amount), abs(b.amount)))
+			AND (a.account_id IS NULL OR b.account_id IS NULL OR a.account_id = b.account_id)
+		ORDER BY a.date DESC, a.id, b.date DESC, b.id
+		LIMIT $%d`, union, cond, n-2, n-1, n), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pair transactions: %w", err)
+	}
+	defer rows.Close()
+
+	var pairs []*Pair
+	for rows.Next() {
+		a, b := &Data{}, &Data{}
+		if err := rows.Scan(
+			&a.Direction, &a.ID, &a.Name, &a.Amount, &a.Type, &a.Date, &a.CreatedAt, &a.Version, &a.CategoryID, &a.AccountID, &a.Currency,
+			&b.Direction, &b.ID, &b.Name, &b.Amount, &b.Type, &b.Date, &b.CreatedAt, &b.Version, &b.CategoryID, &b.AccountID, &b.Currency,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan pair: %w", err)
+		}
+		pairs = append(pairs, &Pair{A: a, B: b})
+	}
+	return pairs, rows.Err()
+}
+
+// orderBy renders the ORDER BY clause of List and Each.
+func (f Filter) orderBy() string {
+	sortBy := "date"
+	for _, c := range SortColumns {
+		if f.SortBy == c {
+			sortBy = c
+		}
+	}
+	order := "ASC"
+	if f.Desc {
+		order = "DESC"
+	}
+	return sortBy + " " + order + " NULLS LAST, id"
+}
+
+// where renders the filter conditions shared by List, the totals and Pairs.
+func (f Filter) where() (string, []any) {
+	var where []string
+	var args []any
+	add := func(cond string, v any) {
+		args = append(args, v)
+		where = append(where, strings.ReplaceAll(cond, "?", fmt.Sprintf("$%d", len(args))))
+	}
+
+	if f.Direction != "" {
+		add("direction = ?", f.Direction)
+	}
+	if f.From != nil {
+		add("date >= ?", *f.From)
+	}
+	if f.To != nil {
+		add("date < ?", *f.To)
+	}
+	if f.Type != "" {
+		add("type = ?", f.Type)
+	}
+	if f.Search != "" {
+		add("name ILIKE '%' || ? || '%'", f.Search)
+	}
+	if f.MinAmount != nil {
+		add("amount >= ?", *f.MinAmount)
+	}
+	if f.MaxAmount != nil {
+		add("amount <= ?", *f.MaxAmount)
+	}
+	if f.CategoryIDs != nil {
+		add("category_id = ANY(?)", f.CategoryIDs)
+	}
+	if f.AccountID != "" {
+		add("account_id = ?", f.AccountID)
+	}
+	if f.TagIDs != nil {
+		matches := `(SELECT COUNT(*) FROM (` + tagged + `) g
+			WHERE g.direction = t.direction AND g.id = t.id AND g.tag_id = ANY(?))`
+		if f.AllTags {
+			add(matches+" = cardinality(?::text[])", f.TagIDs)
+		} else {
+			add(matches+" > 0", f.TagIDs)
+		}
+	}
+
+	cond := ""
+	if len(where) > 0 {
+		cond = " WHERE " + strings.Join(where, " AND ")
+	}
+	return cond, args
+}
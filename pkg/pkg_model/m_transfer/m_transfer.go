@@ -0,0 +1,178 @@
+// Package m_transfer stores transfers between two accounts of a workspace.
+// Transfers move account balances (see m_account) but are neither incomes
+// nor expenses, so they stay out of m_transaction and every total built on it.
+package m_transfer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rsmrtk/mybox/pkg/pkg_model/dbtx"
+)
+
+// ErrNotFound is returned when the transfer does not exist in the workspace.
+var ErrNotFound = errors.New("transfer not found")
+
+type Data struct {
+	TransferID    string
+	WorkspaceID   string
+	FromAccountID string
+	ToAccountID   string
+	Amount        float64 // leaves the source account, in its currency
+	Rate          float64 // destination units per source unit
+	Received      float64 // arrives at the destination account, in its currency
+	Fee           float64 // charged to the source account on top of Amount
+	TransferDate  time.Time
+	Note          *string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// Filter narrows List. Zero values are ignored.
+type Filter struct {
+	AccountID string     // either side of the transfer
+	From      *time.Time // inclusive
+	To        *time.Time // exclusive
+	Limit     int
+	Offset    int
+}
+
+type Model struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *Model {
+	return &Model{db: db}
+}
+
+const columns = `transfer_id::text, workspace_id::text, from_account_id::text, to_account_id::text,
+	amount, rate, received, fee, transfer_date, note, created_at, updated_at`
+
+func (m *Model) Create(ctx context.Context, d *Data) error {
+	_, err := dbtx.From(ctx, m.db).ExecContext(ctx,
+		`INSERT INTO transfer (transfer_id, workspace_id, from_account_id, to_account_id,
+			amount, rate, received, fee, transfer_date, note, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $11)`,
+		d.TransferID, d.WorkspaceID, d.FromAccountID, d.ToAccountID,
+		d.Amount, d.Rate, d.Received, d.Fee, d.TransferDate, d.Note, d.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert transfer: %w", err)
+	}
+	d.UpdatedAt = d.CreatedAt
+	return nil
+}
+
+func (m *Model) Find(ctx context.Context, workspaceID, id string) (*Data, error) {
+	d, err := scan(dbtx.From(ctx, m.db).QueryRowContext(ctx,
+		`SELECT `+columns+` FROM transfer WHERE workspace_id = $1 AND transfer_id::text = $2`, workspaceID, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find transfer: %w", err)
+	}
+	return d, nil
+}
+
+// List returns one page of the workspace's matching transfers, newest first,
+// and the total number of matches ignoring Limit and Offset.
+func (m *Model) List(ctx context.Context, workspaceID string, f Filter) ([]*Data, int, error) {
+	where := []string{"workspace_id = $1"}
+	args := []any{workspaceID}
+	add := func(cond string, v any) {
+		args = append(args, v)
+		where = append(where, strings.ReplaceAll(cond, "?", fmt.Sprintf("$%d", len(args))))
+	}
+	if f.AccountID != "" {
+		add("(from_account_id::text = ? OR to_account_id::text = ?)", f.AccountID)
+	}
+	if f.From != nil {
+		add("transfer_date >= ?", *f.From)
+	}
+	if f.To != nil {
+		add("transfer_date < ?", *f.To)
+	}
+	cond := " WHERE " + strings.Join(where, " AND ")
+
+	conn := dbtx.From(ctx, m.db)
+
+	var total int
+	if err := conn.QueryRowContext(ctx, `SELECT COUNT(*) FROM transfer`+cond, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count transfers: %w", err)
+	}
+
+	args = append(args, f.Limit, f.Offset)
+	rows, err := conn.QueryContext(ctx, fmt.Sprintf(
+		`SELECT %s FROM transfer%s ORDER BY transfer_date DESC, transfer_id LIMIT $%d OFFSET $%d`,
+		columns, cond, len(args)-1, len(args)), args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list transfers: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*Data
+	for rows.Next() {
+		d, err := scan(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan transfer: %w", err)
+		}
+		items = append(items, d)
+	}
+	return items, total, rows.Err()
+}
+
+// Update overwrites the editable columns of a transfer.
+func (m *Model) Update(ctx context.Context, d *Data) error {
+	err := dbtx.From(ctx, m.db).QueryRowContext(ctx,
+		`UPDATE transfer SET from_account_id = $3, to_account_id = $4, amount = $5, rate = $6,
+			received = $7, fee = $8, transfer_date = $9, note = $10
+		WHERE workspace_id = $1 AND transfer_id = $2
+		RETURNING updated_at`,
+		d.WorkspaceID, d.TransferID, d.FromAccountID, d.ToAccountID, d.Amount, d.Rate,
+		d.Received, d.Fee, d.TransferDate, d.Note,
+	).Scan(&d.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update transfer: %w", err)
+	}
+	return nil
+}
+
+func (m *Model) Delete(ctx context.Context, workspaceID, id string) error {
+	res, err := dbtx.From(ctx, m.db).ExecContext(ctx,
+		`DELETE FROM transfer WHERE workspace_id = $1 AND transfer_id = $2`, workspaceID, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete transfer: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scan(row scanner) (*Data, error) {
+	var (
+		d    Data
+		note sql.NullString
+	)
+	err := row.Scan(&d.TransferID, &d.WorkspaceID, &d.FromAccountID, &d.ToAccountID,
+		&d.Amount, &d.Rate, &d.Received, &d.Fee, &d.TransferDate, &note, &d.CreatedAt, &d.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if note.Valid {
+		d.Note = &note.String
+	}
+	return &d, nil
+}
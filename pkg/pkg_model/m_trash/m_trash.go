@@ -0,0 +1,207 @@
+package m_trash
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rsmrtk/mybox/pkg/pkg_model/dbtx"
+)
+
+// ErrNotFound is returned when no row in the expected state matches.
+var ErrNotFound = errors.New("record not found")
+
+// Kind selects the income or the expense table.
+type Kind string
+
+const (
+	Income  Kind = "income"
+	Expense Kind = "expense"
+)
+
+// table returns the table name, which is also the column prefix.
+func (k Kind) table() (string, error) {
+	switch k {
+	case Income, Expense:
+		return string(k), nil
+	}
+	return "", fmt.Errorf("unknown kind %q", k)
+}
+
+// Data is a trashed income or expense.
+type Data struct {
+	Kind      Kind
+	ID        string
+	Name      *string
+	Amount    *float64
+	Type      *string
+	Date      *time.Time
+	Currency  *string // of the account; nil for records outside one
+	DeletedAt time.Time
+}
+
+// Model manages the deleted_at column of the income and expense tables.
+type Model struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *Model {
+	return &Model{db: db}
+}
+
+// SoftDelete moves a live row to the trash.
+func (m *Model) SoftDelete(ctx context.Context, kind Kind, id string) error {
+	t, err := kind.table()
+	if err != nil {
+		return err
+	}
+	return m.exec(ctx, fmt.Sprintf(
+		`UPDATE %[1]s SET deleted_at = CURRENT_TIMESTAMP WHERE %[1]s_id = $1 AND deleted_at IS NULL`, t), id)
+}
+
+// Restore moves a trashed row back.
+func (m *Model) Restore(ctx context.Context, kind Kind, id string) error {
+	t, err := kind.table()
+	if err != nil {
+		return err
+	}
+	return m.exec(ctx, fmt.Sprintf(
+		`UPDATE %[1]s SET deleted_at = NULL WHERE %[1]s_id = $1 AND deleted_at IS NOT NULL`, t), id)
+}
+
+// IsDeleted reports whether the row is in the trash. Missing rows are not.
+func (m *Model) IsDeleted(ctx context.Context, kind Kind, id string) (bool, error) {
+	t, err := kind.table()
+	if err != nil {
+		return false, err
+	}
+	var deleted bool
+	err = dbtx.From(ctx, m.db).QueryRowContext(ctx, fmt.Sprintf(
+		`SELECT deleted_at IS NOT NULL FROM %[1]s WHERE %[1]s_id = $1`, t), id).Scan(&deleted)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check %s: %w", t, err)
+	}
+	return deleted, nil
+}
+
+// DeletedIDs returns the IDs of every trashed row of kind.
+func (m *Model) DeletedIDs(ctx context.Context, kind Kind) (map[string]bool, error) {
+	t, err := kind.table()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := dbtx.From(ctx, m.db).QueryContext(ctx, fmt.Sprintf(
+		`SELECT %[1]s_id FROM %[1]s WHERE deleted_at IS NOT NULL`, t))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted %s: %w", t, err)
+	}
+	defer rows.Close()
+
+	ids := map[string]bool{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan deleted %s: %w", t, err)
+		}
+		ids[id] = true
+	}
+	return ids, rows.Err()
+}
+
+// List returns trashed rows, most recently deleted first, and the total
+// number of trashed rows. An empty kind lists both tables.
+func (m *Model) List(ctx context.Context, kind Kind, limit, offset int) ([]*Data, int, error) {
+	var parts []string
+	for _, k := range []Kind{Income, Expense} {
+		if kind != "" && kind != k {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf(
+			`SELECT '%[1]s' AS kind, r.%[1]s_id::text AS id, r.%[1]s_name AS name, r.%[1]s_amount::float8 AS amount,
+				r.%[1]s_type AS type, r.%[1]s_date AS date, a.currency, r.deleted_at
+			FROM %[1]s r LEFT JOIN account a ON a.account_id = r.account_id WHERE r.deleted_at IS NOT NULL`, k))
+	}
+	if len(parts) == 0 {
+		return nil, 0, fmt.Errorf("unknown kind %q", kind)
+	}
+	union := strings.Join(parts, " UNION ALL ")
+
+	var total int
+	if err := dbtx.From(ctx, m.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM (`+union+`) t`).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count trash: %w", err)
+	}
+
+	rows, err := dbtx.From(ctx, m.db).QueryContext(ctx,
+		`SELECT kind, id, name, amount, type, date, currency, deleted_at FROM (`+union+`) t
+		ORDER BY deleted_at DESC, id LIMIT $1 OFFSET $2`, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list trash: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*Data
+	for rows.Next() {
+		d := &Data{}
+		var name, typ sql.NullString
+		var amount sql.NullFloat64
+		var date sql.NullTime
+		if err := rows.Scan(&d.Kind, &d.ID, &name, &amount, &typ, &date, &d.Currency, &d.DeletedAt); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan trash: %w", err)
+		}
+		if name.Valid {
+			d.Name = &name.String
+		}
+		if amount.Valid {
+			d.Amount = &amount.Float64
+		}
+		if typ.Valid {
+			d.Type = &typ.String
+		}
+		if date.Valid {
+			d.Date = &date.Time
+		}
+		items = append(items, d)
+	}
+	return items, total, rows.Err()
+}
+
+// Purge permanently removes rows trashed before cutoff and returns their IDs.
+func (m *Model) Purge(ctx context.Context, kind Kind, cutoff time.Time) ([]string, error) {
+	t, err := kind.table()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := dbtx.From(ctx, m.db).QueryContext(ctx, fmt.Sprintf(
+		`DELETE FROM %[1]s WHERE deleted_at IS NOT NULL AND deleted_at < $1 RETURNING %[1]s_id::text`, t), cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge %s: %w", t, err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan purged %s: %w", t, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func (m *Model) exec(ctx context.Context, query string, args ...any) error {
+	res, err := dbtx.From(ctx, m.db).ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update trash: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
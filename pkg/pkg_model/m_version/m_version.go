@@ -0,0 +1,126 @@
+package m_version
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/rsmrtk/mybox/pkg/pkg_model/dbtx"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_trash"
+)
+
+var (
+	// ErrNotFound is returned when the row does not exist or is in the trash.
+	ErrNotFound = errors.New("record not found")
+	// ErrConflict is returned by Bump when the row moved past the expected version.
+	ErrConflict = errors.New("version conflict")
+)
+
+// Model manages the version column of the income and expense tables.
+type Model struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *Model {
+	return &Model{db: db}
+}
+
+// Get returns the current version of a live row.
+func (m *Model) Get(ctx context.Context, kind m_trash.Kind, id string) (int64, error) {
+	t, err := table(kind)
+	if err != nil {
+		return 0, err
+	}
+	var version int64
+	err = dbtx.From(ctx, m.db).QueryRowContext(ctx, fmt.Sprintf(
+		`SELECT version FROM %[1]s WHERE %[1]s_id = $1 AND deleted_at IS NULL`, t), id).Scan(&version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get %s version: %w", t, err)
+	}
+	return version, nil
+}
+
+// GetMany returns the versions of the given live rows keyed by ID.
+func (m *Model) GetMany(ctx context.Context, kind m_trash.Kind, ids []string) (map[string]int64, error) {
+	versions := make(map[string]int64, len(ids))
+	if len(ids) == 0 {
+		return versions, nil
+	}
+	t, err := table(kind)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := dbtx.From(ctx, m.db).QueryContext(ctx, fmt.Sprintf(
+		`SELECT %[1]s_id::text, version FROM %[1]s WHERE %[1]s_id::text = ANY($1) AND deleted_at IS NULL`, t), ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s versions: %w", t, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		var version int64
+		if err := rows.Scan(&id, &version); err != nil {
+			return nil, fmt.Errorf("failed to scan %s version: %w", t, err)
+		}
+		versions[id] = version
+	}
+	return versions, rows.Err()
+}
+
+// Bump increments the version of a live row and returns the new value. A
+// non-zero expected version makes the bump conditional: concurrent writers
+// that read the same version race here and all but one get ErrConflict.
+func (m *Model) Bump(ctx context.Context, kind m_trash.Kind, id string, expected int64) (int64, error) {
+	t, err := table(kind)
+	if err != nil {
+		return 0, err
+	}
+	var version int64
+	err = dbtx.From(ctx, m.db).QueryRowContext(ctx, fmt.Sprintf(
+		`UPDATE %[1]s SET version = version + 1
+		WHERE %[1]s_id = $1 AND deleted_at IS NULL AND ($2 = 0 OR version = $2)
+		RETURNING version`, t), id, expected).Scan(&version)
+	if errors.Is(err, sql.ErrNoRows) {
+		if _, err := m.Get(ctx, kind, id); err != nil {
+			return 0, err
+		}
+		return 0, ErrConflict
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to bump %s version: %w", t, err)
+	}
+	return version, nil
+}
+
+// Claim moves a live row to its next version before it is written, so that
+// two edits based on the same version cannot both win. With match set, the
+// current version has to satisfy it or ErrConflict is returned. In a
+// transaction the row stays locked until it ends, so callers read the row
+// after claiming it rather than before.
+func (m *Model) Claim(ctx context.Context, kind m_trash.Kind, id string, match func(version int64) bool) (int64, error) {
+	var expected int64
+	if match != nil {
+		current, err := m.Get(ctx, kind, id)
+		if err != nil {
+			return 0, err
+		}
+		if !match(current) {
+			return 0, ErrConflict
+		}
+		expected = current
+	}
+	return m.Bump(ctx, kind, id, expected)
+}
+
+func table(kind m_trash.Kind) (string, error) {
+	switch kind {
+	case m_trash.Income, m_trash.Expense:
+		return string(kind), nil
+	}
+	return "", fmt.Errorf("unknown kind %q", kind)
+}
@@ -0,0 +1,45 @@
+-- Baseline schema: income and expense tables.
+
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE IF NOT EXISTS income (
+    income_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    income_name VARCHAR(255),
+    income_amount DECIMAL(15, 2),
+    income_type VARCHAR(100),
+    income_date TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS expense (
+    expense_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    expense_name VARCHAR(255),
+    expense_amount DECIMAL(15, 2),
+    expense_type VARCHAR(100),
+    expense_date TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_income_date ON income(income_date);
+CREATE INDEX IF NOT EXISTS idx_income_type ON income(income_type);
+CREATE INDEX IF NOT EXISTS idx_income_created_at ON income(created_at);
+
+CREATE INDEX IF NOT EXISTS idx_expense_date ON expense(expense_date);
+CREATE INDEX IF NOT EXISTS idx_expense_type ON expense(expense_type);
+CREATE INDEX IF NOT EXISTS idx_expense_created_at ON expense(created_at);
+
+CREATE OR REPLACE FUNCTION update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE OR REPLACE TRIGGER update_income_updated_at BEFORE UPDATE ON income
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE OR REPLACE TRIGGER update_expense_updated_at BEFORE UPDATE ON expense
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
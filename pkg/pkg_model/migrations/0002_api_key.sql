@@ -0,0 +1,13 @@
+-- API keys used by AuthMiddleware; only the SHA-256 hash of a key is stored.
+
+CREATE TABLE IF NOT EXISTS api_key (
+    key_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    key_name VARCHAR(255) NOT NULL,
+    key_prefix VARCHAR(16) NOT NULL,
+    key_hash CHAR(64) NOT NULL UNIQUE,
+    customer_id UUID NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_key_customer_id ON api_key(customer_id);
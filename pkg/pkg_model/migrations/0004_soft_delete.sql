@@ -0,0 +1,7 @@
+-- Soft delete: rows with deleted_at set are in the trash until purged.
+
+ALTER TABLE income ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+ALTER TABLE expense ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_income_deleted_at ON income(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_expense_deleted_at ON expense(deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,4 @@
+-- Row versions for optimistic concurrency; exposed to clients as the ETag.
+
+ALTER TABLE income ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE expense ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,16 @@
+-- Stored responses for requests sent with an Idempotency-Key header.
+-- A status_code of 0 marks a request that is still being processed.
+
+CREATE TABLE IF NOT EXISTS idempotency_key (
+    scope VARCHAR(255) NOT NULL,
+    idem_key VARCHAR(255) NOT NULL,
+    fingerprint CHAR(64) NOT NULL,
+    status_code INTEGER NOT NULL DEFAULT 0,
+    content_type VARCHAR(255),
+    response BYTEA,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (scope, idem_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_key_expires_at ON idempotency_key(expires_at);
@@ -0,0 +1,30 @@
+-- Category catalogue. Categories belong to a workspace (the customer of the
+-- API key) and form a tree through parent_id.
+
+CREATE TABLE IF NOT EXISTS category (
+    category_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    workspace_id UUID NOT NULL,
+    parent_id UUID REFERENCES category(category_id),
+    name VARCHAR(100) NOT NULL,
+    icon VARCHAR(64),
+    color CHAR(7),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Sibling names are unique regardless of case and surrounding spaces
+CREATE UNIQUE INDEX IF NOT EXISTS idx_category_sibling_name ON category(
+    workspace_id,
+    COALESCE(parent_id, '00000000-0000-0000-0000-000000000000'::uuid),
+    lower(btrim(name))
+);
+CREATE INDEX IF NOT EXISTS idx_category_parent_id ON category(parent_id);
+
+CREATE OR REPLACE TRIGGER update_category_updated_at BEFORE UPDATE ON category
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+ALTER TABLE income ADD COLUMN IF NOT EXISTS category_id UUID REFERENCES category(category_id);
+ALTER TABLE expense ADD COLUMN IF NOT EXISTS category_id UUID REFERENCES category(category_id);
+
+CREATE INDEX IF NOT EXISTS idx_income_category_id ON income(category_id);
+CREATE INDEX IF NOT EXISTS idx_expense_category_id ON expense(category_id);
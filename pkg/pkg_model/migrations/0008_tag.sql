@@ -0,0 +1,27 @@
+-- Free-form tags. Tags belong to a workspace like categories do, and are
+-- attached to incomes and expenses through one join table per record type
+-- so that purging a record drops its tags.
+
+CREATE TABLE IF NOT EXISTS tag (
+    tag_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    workspace_id UUID NOT NULL,
+    name VARCHAR(64) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tag_workspace_name ON tag(workspace_id, name);
+
+CREATE TABLE IF NOT EXISTS income_tag (
+    income_id UUID NOT NULL REFERENCES income(income_id) ON DELETE CASCADE,
+    tag_id UUID NOT NULL REFERENCES tag(tag_id) ON DELETE CASCADE,
+    PRIMARY KEY (income_id, tag_id)
+);
+
+CREATE TABLE IF NOT EXISTS expense_tag (
+    expense_id UUID NOT NULL REFERENCES expense(expense_id) ON DELETE CASCADE,
+    tag_id UUID NOT NULL REFERENCES tag(tag_id) ON DELETE CASCADE,
+    PRIMARY KEY (expense_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_income_tag_tag_id ON income_tag(tag_id);
+CREATE INDEX IF NOT EXISTS idx_expense_tag_tag_id ON expense_tag(tag_id);
@@ -0,0 +1,14 @@
+-- Split lines of an expense. Each line carries part of the expense amount
+-- under its own category; the lines of an expense add up to its amount.
+
+CREATE TABLE IF NOT EXISTS expense_split (
+    split_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    expense_id UUID NOT NULL REFERENCES expense(expense_id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    amount DECIMAL(15, 2) NOT NULL,
+    category_id UUID REFERENCES category(category_id),
+    note VARCHAR(255),
+    UNIQUE (expense_id, position)
+);
+
+CREATE INDEX IF NOT EXISTS idx_expense_split_category_id ON expense_split(category_id);
@@ -0,0 +1,27 @@
+-- Accounts: where money is kept (cash, checking, credit card, savings).
+-- Accounts belong to a workspace; incomes and expenses point at one through
+-- their account_id column, and an account's balance is its opening balance
+-- plus its live incomes minus its live expenses.
+
+CREATE TABLE IF NOT EXISTS account (
+    account_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    workspace_id UUID NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    kind VARCHAR(16) NOT NULL CHECK (kind IN ('cash', 'checking', 'credit_card', 'savings')),
+    currency CHAR(3) NOT NULL,
+    opening_balance DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    opened_on DATE NOT NULL DEFAULT CURRENT_DATE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_account_workspace_name ON account(workspace_id, lower(btrim(name)));
+
+CREATE OR REPLACE TRIGGER update_account_updated_at BEFORE UPDATE ON account
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+ALTER TABLE income ADD COLUMN IF NOT EXISTS account_id UUID REFERENCES account(account_id);
+ALTER TABLE expense ADD COLUMN IF NOT EXISTS account_id UUID REFERENCES account(account_id);
+
+CREATE INDEX IF NOT EXISTS idx_income_account_id ON income(account_id);
+CREATE INDEX IF NOT EXISTS idx_expense_account_id ON expense(account_id);
@@ -0,0 +1,27 @@
+-- Transfers move money between two accounts of a workspace. They change the
+-- balances of both accounts but are neither an income nor an expense.
+-- amount and fee are in the currency of the source account; received is
+-- amount * rate in the currency of the destination account.
+
+CREATE TABLE IF NOT EXISTS transfer (
+    transfer_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    workspace_id UUID NOT NULL,
+    from_account_id UUID NOT NULL REFERENCES account(account_id),
+    to_account_id UUID NOT NULL REFERENCES account(account_id),
+    amount DECIMAL(15, 2) NOT NULL CHECK (amount > 0),
+    rate DECIMAL(18, 8) NOT NULL DEFAULT 1 CHECK (rate > 0),
+    received DECIMAL(15, 2) NOT NULL CHECK (received > 0),
+    fee DECIMAL(15, 2) NOT NULL DEFAULT 0 CHECK (fee >= 0),
+    transfer_date TIMESTAMP NOT NULL,
+    note VARCHAR(255),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (from_account_id <> to_account_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_transfer_workspace_date ON transfer(workspace_id, transfer_date);
+CREATE INDEX IF NOT EXISTS idx_transfer_from_account_id ON transfer(from_account_id);
+CREATE INDEX IF NOT EXISTS idx_transfer_to_account_id ON transfer(to_account_id);
+
+CREATE OR REPLACE TRIGGER update_transfer_updated_at BEFORE UPDATE ON transfer
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,40 @@
+-- Recurring templates create an income or an expense on every occurrence of
+-- an RFC 5545 recurrence rule. next_on is the first occurrence that has not
+-- been materialized yet, or NULL once the rule has ended.
+-- recurring_occurrence records each occurrence that was materialized or
+-- skipped; its primary key makes sure an occurrence is handled only once.
+
+CREATE TABLE IF NOT EXISTS recurring (
+    recurring_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    workspace_id UUID NOT NULL,
+    direction VARCHAR(7) NOT NULL CHECK (direction IN ('income', 'expense')),
+    name VARCHAR(255) NOT NULL,
+    amount DECIMAL(15, 2) NOT NULL CHECK (amount > 0),
+    type VARCHAR(255),
+    category_id UUID REFERENCES category(category_id),
+    account_id UUID REFERENCES account(account_id),
+    tags JSONB NOT NULL DEFAULT '[]',
+    rrule TEXT NOT NULL,
+    starts_on DATE NOT NULL,
+    next_on DATE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (type IS NOT NULL OR category_id IS NOT NULL)
+);
+
+CREATE TABLE IF NOT EXISTS recurring_occurrence (
+    recurring_id UUID NOT NULL REFERENCES recurring(recurring_id) ON DELETE CASCADE,
+    occurs_on DATE NOT NULL,
+    status VARCHAR(12) NOT NULL CHECK (status IN ('materialized', 'skipped')),
+    record_id UUID,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (recurring_id, occurs_on)
+);
+
+CREATE INDEX IF NOT EXISTS idx_recurring_workspace_id ON recurring(workspace_id);
+CREATE INDEX IF NOT EXISTS idx_recurring_next_on ON recurring(next_on) WHERE next_on IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_recurring_category_id ON recurring(category_id);
+CREATE INDEX IF NOT EXISTS idx_recurring_account_id ON recurring(account_id);
+
+CREATE OR REPLACE TRIGGER update_recurring_updated_at BEFORE UPDATE ON recurring
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
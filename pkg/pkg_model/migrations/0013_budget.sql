@@ -0,0 +1,27 @@
+-- Budgets limit what a workspace spends per month or year, either in a
+-- category (with its subcategories) or on an expense type outside the
+-- catalogue. starts_on is the first day of the first budgeted period; with
+-- rollover, what is left of or overspent in a period carries over to the next.
+-- Budgets go with their category when it is deleted.
+
+CREATE TABLE IF NOT EXISTS budget (
+    budget_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    workspace_id UUID NOT NULL,
+    category_id UUID REFERENCES category(category_id) ON DELETE CASCADE,
+    expense_type VARCHAR(255),
+    period VARCHAR(5) NOT NULL CHECK (period IN ('month', 'year')),
+    amount DECIMAL(15, 2) NOT NULL CHECK (amount > 0),
+    rollover BOOLEAN NOT NULL DEFAULT FALSE,
+    starts_on DATE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((category_id IS NULL) <> (expense_type IS NULL))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_budget_workspace_target ON budget(
+    workspace_id, COALESCE(category_id::text, lower(btrim(expense_type))), period
+);
+CREATE INDEX IF NOT EXISTS idx_budget_category_id ON budget(category_id);
+
+CREATE OR REPLACE TRIGGER update_budget_updated_at BEFORE UPDATE ON budget
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
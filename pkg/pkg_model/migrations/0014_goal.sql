@@ -0,0 +1,27 @@
+-- Savings goals track money put aside for a target. A goal follows either an
+-- account, where incomes and transfers in count towards it and transfers out
+-- against it, or a tag, where the incomes carrying it count. Only money that
+-- arrives on or after starts_on counts; initial_amount is what was already
+-- saved. Accounts and tags cannot be deleted while a goal follows them.
+
+CREATE TABLE IF NOT EXISTS goal (
+    goal_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    workspace_id UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    target_amount DECIMAL(15, 2) NOT NULL CHECK (target_amount > 0),
+    target_date DATE,
+    account_id UUID REFERENCES account(account_id),
+    tag_id UUID REFERENCES tag(tag_id),
+    initial_amount DECIMAL(15, 2) NOT NULL DEFAULT 0 CHECK (initial_amount >= 0),
+    starts_on DATE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((account_id IS NULL) <> (tag_id IS NULL))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_goal_workspace_name ON goal(workspace_id, lower(name));
+CREATE INDEX IF NOT EXISTS idx_goal_account_id ON goal(account_id);
+CREATE INDEX IF NOT EXISTS idx_goal_tag_id ON goal(tag_id);
+
+CREATE OR REPLACE TRIGGER update_goal_updated_at BEFORE UPDATE ON goal
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
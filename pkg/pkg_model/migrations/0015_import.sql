@@ -0,0 +1,41 @@
+-- Imports turn bank statements into incomes and expenses. A profile keeps
+-- how a bank's CSV files map onto records; each committed import is a batch
+-- that remembers the records it created so that it can be rolled back.
+
+CREATE TABLE IF NOT EXISTS import_profile (
+    profile_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    workspace_id UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    mapping JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_import_profile_workspace_name ON import_profile(workspace_id, lower(name));
+
+CREATE TABLE IF NOT EXISTS import_batch (
+    batch_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    workspace_id UUID NOT NULL,
+    source VARCHAR(16) NOT NULL,
+    file_name VARCHAR(255),
+    profile_id UUID REFERENCES import_profile(profile_id) ON DELETE SET NULL,
+    total_rows INTEGER NOT NULL,
+    created_rows INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    rolled_back_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_import_batch_workspace_created ON import_batch(workspace_id, created_at);
+
+-- Records are incomes or expenses, so record_id has no foreign key; rows of
+-- purged records are skipped on rollback
+CREATE TABLE IF NOT EXISTS import_record (
+    batch_id UUID NOT NULL REFERENCES import_batch(batch_id) ON DELETE CASCADE,
+    direction VARCHAR(7) NOT NULL CHECK (direction IN ('income', 'expense')),
+    record_id UUID NOT NULL,
+    line INTEGER NOT NULL,
+    PRIMARY KEY (batch_id, direction, record_id)
+);
+
+CREATE OR REPLACE TRIGGER update_import_profile_updated_at BEFORE UPDATE ON import_profile
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
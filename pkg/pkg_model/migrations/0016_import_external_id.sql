@@ -0,0 +1,7 @@
+-- Bank statements such as OFX give every transaction an ID (the FITID).
+-- Imported records keep it so that importing an overlapping statement again
+-- skips the transactions already imported.
+
+ALTER TABLE import_record ADD COLUMN IF NOT EXISTS external_id VARCHAR(255);
+
+CREATE INDEX IF NOT EXISTS idx_import_record_external_id ON import_record(external_id) WHERE external_id IS NOT NULL;
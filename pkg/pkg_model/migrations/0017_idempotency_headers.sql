@@ -0,0 +1,4 @@
+-- Replayed responses carry the ETag and Location of the original response,
+-- so that a retried create can go on with a conditional update.
+
+ALTER TABLE idempotency_key ADD COLUMN IF NOT EXISTS headers JSONB;
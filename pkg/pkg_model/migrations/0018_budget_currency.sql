@@ -0,0 +1,4 @@
+-- Expenses are totalled per currency, so a budget says which currency it
+-- limits; spending in other currencies does not count against it.
+
+ALTER TABLE budget ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'USD';
@@ -0,0 +1,6 @@
+-- A template whose occurrence cannot be materialized, for example because
+-- its account was closed, is retried later instead of on every run, so it
+-- does not hold up the other due templates. The error is kept for display.
+
+ALTER TABLE recurring ADD COLUMN IF NOT EXISTS retry_at TIMESTAMP;
+ALTER TABLE recurring ADD COLUMN IF NOT EXISTS last_error TEXT;
@@ -2,13 +2,52 @@ package pkg_model
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 
+	_ "github.com/jackc/pgx/v5/stdlib"
 	dbModelFinDash "github.com/rsmrtk/db-fd-model"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_account"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_api_key"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_audit"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_budget"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_category"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_goal"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_idempotency"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_import"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_record"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_recurring"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_split"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_tag"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_transaction"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_transfer"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_trash"
+	"github.com/rsmrtk/mybox/pkg/pkg_model/m_version"
 	"github.com/rsmrtk/smartlg/logger"
 )
 
 type Models struct {
 	FinDash *dbModelFinDash.Model
+
+	// DB is used by the tables that live in this repository rather than in
+	// db-fd-model (see the m_* packages next to this file).
+	DB          *sql.DB
+	Record      *m_record.Model
+	APIKey      *m_api_key.Model
+	Audit       *m_audit.Model
+	Trash       *m_trash.Model
+	Version     *m_version.Model
+	Idempotency *m_idempotency.Model
+	Transaction *m_transaction.Model
+	Category    *m_category.Model
+	Tag         *m_tag.Model
+	Split       *m_split.Model
+	Account     *m_account.Model
+	Transfer    *m_transfer.Model
+	Recurring   *m_recurring.Model
+	Budget      *m_budget.Model
+	Goal        *m_goal.Model
+	Import      *m_import.Model
 }
 
 func New(ctx context.Context, postgresURL string, lg *logger.Logger) (*Models, error) {
@@ -20,7 +59,29 @@ func New(ctx context.Context, postgresURL string, lg *logger.Logger) (*Models, e
 		return nil, err
 	}
 
+	db, err := sql.Open("pgx", postgresURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
 	return &Models{
-		FinDash: finDashInstance,
+		FinDash:     finDashInstance,
+		DB:          db,
+		Record:      m_record.New(db),
+		APIKey:      m_api_key.New(db),
+		Audit:       m_audit.New(db),
+		Trash:       m_trash.New(db),
+		Version:     m_version.New(db),
+		Idempotency: m_idempotency.New(db),
+		Transaction: m_transaction.New(db),
+		Category:    m_category.New(db),
+		Tag:         m_tag.New(db),
+		Split:       m_split.New(db),
+		Account:     m_account.New(db),
+		Transfer:    m_transfer.New(db),
+		Recurring:   m_recurring.New(db),
+		Budget:      m_budget.New(db),
+		Goal:        m_goal.New(db),
+		Import:      m_import.New(db),
 	}, nil
 }
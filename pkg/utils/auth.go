@@ -8,6 +8,8 @@ import (
 
 type authCustomerID struct{}
 
+type authAPIKeyID struct{}
+
 func AuthCtx(ctx context.Context) string {
 	return ctx.Value(authCustomerID{}).(string)
 }
@@ -16,6 +18,22 @@ func AuthSetCtx(ctx context.Context, customerID string) context.Context {
 	return context.WithValue(ctx, authCustomerID{}, customerID)
 }
 
+// AuthLookup is AuthCtx for requests that may be unauthenticated.
+func AuthLookup(ctx context.Context) (string, bool) {
+	customerID, ok := ctx.Value(authCustomerID{}).(string)
+	return customerID, ok && customerID != ""
+}
+
+// AuthAPIKeyCtx returns the ID of the API key the request was made with, or "".
+func AuthAPIKeyCtx(ctx context.Context) string {
+	keyID, _ := ctx.Value(authAPIKeyID{}).(string)
+	return keyID
+}
+
+func AuthSetAPIKeyCtx(ctx context.Context, keyID string) context.Context {
+	return context.WithValue(ctx, authAPIKeyID{}, keyID)
+}
+
 const ginAuthCustomerID = "customerID"
 
 func GinAuthSetCtx(ctx *gin.Context, customerID string) {
@@ -25,3 +43,9 @@ func GinAuthSetCtx(ctx *gin.Context, customerID string) {
 func GinAuthCtx(ctx context.Context) string {
 	return ctx.Value(ginAuthCustomerID).(string)
 }
+
+// GinAuthLookup is GinAuthCtx for requests that may be unauthenticated.
+func GinAuthLookup(ctx context.Context) (string, bool) {
+	customerID, ok := ctx.Value(ginAuthCustomerID).(string)
+	return customerID, ok && customerID != ""
+}
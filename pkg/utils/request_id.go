@@ -0,0 +1,15 @@
+package utils
+
+import "context"
+
+type requestID struct{}
+
+// RequestIDCtx returns the request ID set by RequestIDMiddleware, or "".
+func RequestIDCtx(ctx context.Context) string {
+	id, _ := ctx.Value(requestID{}).(string)
+	return id
+}
+
+func RequestIDSetCtx(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestID{}, id)
+}
@@ -6,6 +6,31 @@
 -- UUID extension for generating UUIDs
 CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
 
+-- Category catalogue; categories belong to a workspace and form a tree
+CREATE TABLE IF NOT EXISTS category (
+    category_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    workspace_id UUID NOT NULL,
+    parent_id UUID REFERENCES category(category_id),
+    name VARCHAR(100) NOT NULL,
+    icon VARCHAR(64),
+    color CHAR(7),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Accounts (cash, checking, credit card, savings) of a workspace
+CREATE TABLE IF NOT EXISTS account (
+    account_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    workspace_id UUID NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    kind VARCHAR(16) NOT NULL CHECK (kind IN ('cash', 'checking', 'credit_card', 'savings')),
+    currency CHAR(3) NOT NULL,
+    opening_balance DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    opened_on DATE NOT NULL DEFAULT CURRENT_DATE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
 -- Income table
 CREATE TABLE IF NOT EXISTS income (
     income_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
@@ -14,7 +39,11 @@ CREATE TABLE IF NOT EXISTS income (
     income_type VARCHAR(100),
     income_date TIMESTAMP,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP,
+    version BIGINT NOT NULL DEFAULT 1,
+    category_id UUID REFERENCES category(category_id),
+    account_id UUID REFERENCES account(account_id)
 );
 
 -- Expense table
@@ -25,7 +54,193 @@ CREATE TABLE IF NOT EXISTS expense (
     expense_type VARCHAR(100),
     expense_date TIMESTAMP,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP,
+    version BIGINT NOT NULL DEFAULT 1,
+    category_id UUID REFERENCES category(category_id),
+    account_id UUID REFERENCES account(account_id)
+);
+
+-- Split lines of an expense; the lines add up to the expense amount
+CREATE TABLE IF NOT EXISTS expense_split (
+    split_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    expense_id UUID NOT NULL REFERENCES expense(expense_id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    amount DECIMAL(15, 2) NOT NULL,
+    category_id UUID REFERENCES category(category_id),
+    note VARCHAR(255),
+    UNIQUE (expense_id, position)
+);
+
+-- Transfers between two accounts; neither an income nor an expense
+CREATE TABLE IF NOT EXISTS transfer (
+    transfer_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    workspace_id UUID NOT NULL,
+    from_account_id UUID NOT NULL REFERENCES account(account_id),
+    to_account_id UUID NOT NULL REFERENCES account(account_id),
+    amount DECIMAL(15, 2) NOT NULL CHECK (amount > 0),
+    rate DECIMAL(18, 8) NOT NULL DEFAULT 1 CHECK (rate > 0),
+    received DECIMAL(15, 2) NOT NULL CHECK (received > 0),
+    fee DECIMAL(15, 2) NOT NULL DEFAULT 0 CHECK (fee >= 0),
+    transfer_date TIMESTAMP NOT NULL,
+    note VARCHAR(255),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (from_account_id <> to_account_id)
+);
+
+-- Recurring templates; each occurrence of the rule creates an income or expense
+CREATE TABLE IF NOT EXISTS recurring (
+    recurring_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    workspace_id UUID NOT NULL,
+    direction VARCHAR(7) NOT NULL CHECK (direction IN ('income', 'expense')),
+    name VARCHAR(255) NOT NULL,
+    amount DECIMAL(15, 2) NOT NULL CHECK (amount > 0),
+    type VARCHAR(255),
+    category_id UUID REFERENCES category(category_id),
+    account_id UUID REFERENCES account(account_id),
+    tags JSONB NOT NULL DEFAULT '[]',
+    rrule TEXT NOT NULL,
+    starts_on DATE NOT NULL,
+    next_on DATE,
+    retry_at TIMESTAMP, -- set after a failed run; the template is not due before
+    last_error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (type IS NOT NULL OR category_id IS NOT NULL)
+);
+
+-- Occurrences of a recurring template that were materialized or skipped
+CREATE TABLE IF NOT EXISTS recurring_occurrence (
+    recurring_id UUID NOT NULL REFERENCES recurring(recurring_id) ON DELETE CASCADE,
+    occurs_on DATE NOT NULL,
+    status VARCHAR(12) NOT NULL CHECK (status IN ('materialized', 'skipped')),
+    record_id UUID,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (recurring_id, occurs_on)
+);
+
+-- Monthly or yearly spending limits per category or expense type
+CREATE TABLE IF NOT EXISTS budget (
+    budget_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    workspace_id UUID NOT NULL,
+    category_id UUID REFERENCES category(category_id) ON DELETE CASCADE,
+    expense_type VARCHAR(255),
+    period VARCHAR(5) NOT NULL CHECK (period IN ('month', 'year')),
+    amount DECIMAL(15, 2) NOT NULL CHECK (amount > 0),
+    currency CHAR(3) NOT NULL DEFAULT 'USD',
+    rollover BOOLEAN NOT NULL DEFAULT FALSE,
+    starts_on DATE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((category_id IS NULL) <> (expense_type IS NULL))
+);
+
+-- Free-form tags, attached to incomes and expenses through join tables
+CREATE TABLE IF NOT EXISTS tag (
+    tag_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    workspace_id UUID NOT NULL,
+    name VARCHAR(64) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS income_tag (
+    income_id UUID NOT NULL REFERENCES income(income_id) ON DELETE CASCADE,
+    tag_id UUID NOT NULL REFERENCES tag(tag_id) ON DELETE CASCADE,
+    PRIMARY KEY (income_id, tag_id)
+);
+
+CREATE TABLE IF NOT EXISTS expense_tag (
+    expense_id UUID NOT NULL REFERENCES expense(expense_id) ON DELETE CASCADE,
+    tag_id UUID NOT NULL REFERENCES tag(tag_id) ON DELETE CASCADE,
+    PRIMARY KEY (expense_id, tag_id)
+);
+
+-- Savings goals, following an account or a tag
+CREATE TABLE IF NOT EXISTS goal (
+    goal_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    workspace_id UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    target_amount DECIMAL(15, 2) NOT NULL CHECK (target_amount > 0),
+    target_date DATE,
+    account_id UUID REFERENCES account(account_id),
+    tag_id UUID REFERENCES tag(tag_id),
+    initial_amount DECIMAL(15, 2) NOT NULL DEFAULT 0 CHECK (initial_amount >= 0),
+    starts_on DATE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((account_id IS NULL) <> (tag_id IS NULL))
+);
+
+-- Column mappings of bank CSV files
+CREATE TABLE IF NOT EXISTS import_profile (
+    profile_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    workspace_id UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    mapping JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Committed imports; each can be rolled back once
+CREATE TABLE IF NOT EXISTS import_batch (
+    batch_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    workspace_id UUID NOT NULL,
+    source VARCHAR(16) NOT NULL,
+    file_name VARCHAR(255),
+    profile_id UUID REFERENCES import_profile(profile_id) ON DELETE SET NULL,
+    total_rows INTEGER NOT NULL,
+    created_rows INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    rolled_back_at TIMESTAMP
+);
+
+-- Incomes and expenses created by an import batch
+CREATE TABLE IF NOT EXISTS import_record (
+    batch_id UUID NOT NULL REFERENCES import_batch(batch_id) ON DELETE CASCADE,
+    direction VARCHAR(7) NOT NULL CHECK (direction IN ('income', 'expense')),
+    record_id UUID NOT NULL,
+    line INTEGER NOT NULL,
+    external_id VARCHAR(255), -- the bank's ID of the transaction, such as an OFX FITID
+    PRIMARY KEY (batch_id, direction, record_id)
+);
+
+-- API keys (only the SHA-256 hash of a key is stored)
+CREATE TABLE IF NOT EXISTS api_key (
+    key_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    key_name VARCHAR(255) NOT NULL,
+    key_prefix VARCHAR(16) NOT NULL,
+    key_hash CHAR(64) NOT NULL UNIQUE,
+    customer_id UUID NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP
+);
+
+-- Append-only audit log of income and expense mutations
+CREATE TABLE IF NOT EXISTS audit_log (
+    audit_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    occurred_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    actor_customer_id UUID,
+    actor_api_key_id UUID,
+    request_id VARCHAR(128),
+    entity_type VARCHAR(32) NOT NULL,
+    entity_id UUID NOT NULL,
+    action VARCHAR(16) NOT NULL,
+    diff JSONB NOT NULL
+);
+
+-- Stored responses for requests sent with an Idempotency-Key header
+CREATE TABLE IF NOT EXISTS idempotency_key (
+    scope VARCHAR(255) NOT NULL,
+    idem_key VARCHAR(255) NOT NULL,
+    fingerprint CHAR(64) NOT NULL,
+    status_code INTEGER NOT NULL DEFAULT 0,
+    content_type VARCHAR(255),
+    response BYTEA,
+    headers JSONB, -- response headers sent back on replay, such as ETag and Location
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (scope, idem_key)
 );
 
 -- Indexes for better query performance
@@ -37,6 +252,50 @@ CREATE INDEX IF NOT EXISTS idx_expense_date ON expense(expense_date);
 CREATE INDEX IF NOT EXISTS idx_expense_type ON expense(expense_type);
 CREATE INDEX IF NOT EXISTS idx_expense_created_at ON expense(created_at);
 
+CREATE INDEX IF NOT EXISTS idx_income_deleted_at ON income(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_expense_deleted_at ON expense(deleted_at) WHERE deleted_at IS NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_category_sibling_name ON category(
+    workspace_id,
+    COALESCE(parent_id, '00000000-0000-0000-0000-000000000000'::uuid),
+    lower(btrim(name))
+);
+CREATE INDEX IF NOT EXISTS idx_category_parent_id ON category(parent_id);
+CREATE INDEX IF NOT EXISTS idx_income_category_id ON income(category_id);
+CREATE INDEX IF NOT EXISTS idx_expense_category_id ON expense(category_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tag_workspace_name ON tag(workspace_id, name);
+CREATE INDEX IF NOT EXISTS idx_income_tag_tag_id ON income_tag(tag_id);
+CREATE INDEX IF NOT EXISTS idx_expense_tag_tag_id ON expense_tag(tag_id);
+CREATE INDEX IF NOT EXISTS idx_expense_split_category_id ON expense_split(category_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_account_workspace_name ON account(workspace_id, lower(btrim(name)));
+CREATE INDEX IF NOT EXISTS idx_income_account_id ON income(account_id);
+CREATE INDEX IF NOT EXISTS idx_expense_account_id ON expense(account_id);
+CREATE INDEX IF NOT EXISTS idx_transfer_workspace_date ON transfer(workspace_id, transfer_date);
+CREATE INDEX IF NOT EXISTS idx_transfer_from_account_id ON transfer(from_account_id);
+CREATE INDEX IF NOT EXISTS idx_transfer_to_account_id ON transfer(to_account_id);
+CREATE INDEX IF NOT EXISTS idx_recurring_workspace_id ON recurring(workspace_id);
+CREATE INDEX IF NOT EXISTS idx_recurring_next_on ON recurring(next_on) WHERE next_on IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_recurring_category_id ON recurring(category_id);
+CREATE INDEX IF NOT EXISTS idx_recurring_account_id ON recurring(account_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_budget_workspace_target ON budget(
+    workspace_id, COALESCE(category_id::text, lower(btrim(expense_type))), period
+);
+CREATE INDEX IF NOT EXISTS idx_budget_category_id ON budget(category_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_goal_workspace_name ON goal(workspace_id, lower(name));
+CREATE INDEX IF NOT EXISTS idx_goal_account_id ON goal(account_id);
+CREATE INDEX IF NOT EXISTS idx_goal_tag_id ON goal(tag_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_import_profile_workspace_name ON import_profile(workspace_id, lower(name));
+CREATE INDEX IF NOT EXISTS idx_import_batch_workspace_created ON import_batch(workspace_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_import_record_external_id ON import_record(external_id) WHERE external_id IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_api_key_customer_id ON api_key(customer_id);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_entity ON audit_log(entity_type, entity_id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor_customer_id ON audit_log(actor_customer_id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_occurred_at ON audit_log(occurred_at);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_key_expires_at ON idempotency_key(expires_at);
+
 -- Trigger function to update updated_at timestamp
 CREATE OR REPLACE FUNCTION update_updated_at_column()
 RETURNS TRIGGER AS $$
@@ -53,6 +312,38 @@ CREATE TRIGGER update_income_updated_at BEFORE UPDATE ON income
 CREATE TRIGGER update_expense_updated_at BEFORE UPDATE ON expense
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+CREATE TRIGGER update_category_updated_at BEFORE UPDATE ON category
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_account_updated_at BEFORE UPDATE ON account
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_transfer_updated_at BEFORE UPDATE ON transfer
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_recurring_updated_at BEFORE UPDATE ON recurring
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_budget_updated_at BEFORE UPDATE ON budget
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_goal_updated_at BEFORE UPDATE ON goal
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_import_profile_updated_at BEFORE UPDATE ON import_profile
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Reject any change to audit_log rows
+CREATE OR REPLACE FUNCTION audit_log_append_only()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER audit_log_append_only BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
+
 -- Sample data (optional, uncomment to insert)
 /*
 -- Sample income records